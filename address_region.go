@@ -0,0 +1,35 @@
+package magento2
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+// AddressRegion is the region of an address. Customer addresses carry it as an object with code, name
+// and ID; quote and order addresses only as the region name, next to the flat region_code and region_id
+// fields of Address
+type AddressRegion struct {
+	RegionCode string `json:"region_code,omitempty"`
+	Region     string `json:"region,omitempty"`
+	RegionID   int    `json:"region_id,omitempty"`
+}
+
+// UnmarshalJSON accepts the object of customer addresses as well as the name of quote addresses
+func (r *AddressRegion) UnmarshalJSON(data []byte) error {
+	if bytes.HasPrefix(bytes.TrimSpace(data), []byte(`"`)) {
+		*r = AddressRegion{}
+		return json.Unmarshal(data, &r.Region)
+	}
+	type plain AddressRegion
+	return json.Unmarshal(data, (*plain)(r))
+}
+
+// MarshalJSON writes the object when a code or ID is known, a region known only by name is written
+// as the name, the way quote addresses take it
+func (r AddressRegion) MarshalJSON() ([]byte, error) {
+	if r.RegionCode == "" && r.RegionID == 0 {
+		return json.Marshal(r.Region)
+	}
+	type plain AddressRegion
+	return json.Marshal(plain(r))
+}
@@ -0,0 +1,70 @@
+package magento2
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var countryIDPattern = regexp.MustCompile(`^[A-Z]{2}$`)
+
+// Validate checks the fields every checkout and customer address endpoint requires. It does not know
+// the country's regions, use ValidateForCountry or ValidateAddress for that
+func (a *Address) Validate() error {
+	if !countryIDPattern.MatchString(a.CountryID) {
+		return &ValidationError{Entity: "address", Field: "country_id", Reason: "must be an ISO 3166-1 alpha-2 code in upper case"}
+	}
+	required := []struct {
+		field string
+		value string
+	}{
+		{"firstname", a.Firstname},
+		{"lastname", a.Lastname},
+		{"city", a.City},
+		{"telephone", a.Telephone},
+		{"postcode", a.Postcode},
+		{"street", strings.Join(a.Street, "")},
+	}
+	for _, r := range required {
+		if strings.TrimSpace(r.value) == "" {
+			return &ValidationError{Entity: "address", Field: r.field, Reason: "is required"}
+		}
+	}
+	return nil
+}
+
+// ValidateForCountry runs Validate and checks the region against the directory data of the country
+func (a *Address) ValidateForCountry(country *Country) error {
+	if err := a.Validate(); err != nil {
+		return err
+	}
+	if country.ID != a.CountryID {
+		return &ValidationError{Entity: "address", Field: "country_id", Reason: fmt.Sprintf("does not match country '%s'", country.ID)}
+	}
+	if !country.RequiresRegion() {
+		return nil
+	}
+	if a.RegionID == 0 {
+		return &ValidationError{Entity: "address", Field: "region_id", Reason: "is required for country " + a.CountryID}
+	}
+	for _, region := range country.AvailableRegions {
+		if region.ID == strconv.Itoa(a.RegionID) {
+			return nil
+		}
+	}
+	return &ValidationError{Entity: "address", Field: "region_id", Reason: fmt.Sprintf("%d is not a region of country %s", a.RegionID, a.CountryID)}
+}
+
+// ValidateAddress validates the address against the country metadata from the directory API
+func ValidateAddress(ctx context.Context, address *Address, apiClient *Client) error {
+	if err := address.Validate(); err != nil {
+		return err
+	}
+	country, err := GetCountry(ctx, address.CountryID, apiClient)
+	if err != nil {
+		return fmt.Errorf("error getting country for address validation: %w", err)
+	}
+	return address.ValidateForCountry(country)
+}
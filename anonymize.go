@@ -0,0 +1,170 @@
+package magento2
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"github.com/rs/zerolog"
+)
+
+type AnonymizeMode int
+
+const (
+	// AnonymizeStrip blanks personal data
+	AnonymizeStrip AnonymizeMode = iota
+	// AnonymizeHash replaces personal data with a salted hash, so records of the same person still correlate
+	AnonymizeHash
+)
+
+// DefaultPIIKeys are the JSON keys of orders, addresses and customers holding personal data
+var DefaultPIIKeys = []string{
+	"firstname", "lastname", "middlename", "prefix", "suffix", "dob", "gender", "taxvat", "vat_id",
+	"email", "telephone", "fax", "company", "street", "city", "postcode",
+	"customer_email", "customer_firstname", "customer_lastname", "customer_middlename",
+	"customer_prefix", "customer_suffix", "customer_dob", "customer_taxvat", "customer_note",
+	"remote_ip", "x_forwarded_for", "giftcard_sender_email", "giftcard_recipient_email",
+	"giftcard_sender_name", "giftcard_recipient_name", "gift_message",
+}
+
+// Anonymizer removes or hashes personal data of any JSON-serializable value by key name
+type Anonymizer struct {
+	Mode AnonymizeMode
+	Salt string
+	keys map[string]bool
+}
+
+// NewAnonymizer returns an anonymizer for DefaultPIIKeys plus the given additional keys
+func NewAnonymizer(mode AnonymizeMode, salt string, additionalKeys ...string) *Anonymizer {
+	a := &Anonymizer{Mode: mode, Salt: salt, keys: map[string]bool{}}
+	for _, key := range DefaultPIIKeys {
+		a.keys[key] = true
+	}
+	for _, key := range additionalKeys {
+		a.keys[key] = true
+	}
+	return a
+}
+
+// AnonymizeJSON returns the JSON document with personal data removed
+func (a *Anonymizer) AnonymizeJSON(data []byte) ([]byte, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var document any
+	if err := decoder.Decode(&document); err != nil {
+		return nil, fmt.Errorf("error decoding document for anonymization: %w", err)
+	}
+	return json.Marshal(a.walk(document))
+}
+
+func (a *Anonymizer) walk(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		for key, child := range v {
+			if a.keys[key] {
+				v[key] = a.replace(child)
+				continue
+			}
+			v[key] = a.walk(child)
+		}
+	case []any:
+		for i := range v {
+			v[i] = a.walk(v[i])
+		}
+	}
+	return value
+}
+
+func (a *Anonymizer) replace(value any) any {
+	switch v := value.(type) {
+	case string:
+		if a.Mode == AnonymizeHash && v != "" {
+			sum := sha256.Sum256([]byte(a.Salt + v))
+			return "sha256:" + hex.EncodeToString(sum[:8])
+		}
+		return ""
+	case []any:
+		for i := range v {
+			v[i] = a.replace(v[i])
+		}
+		return v
+	case map[string]any:
+		for key := range v {
+			v[key] = a.replace(v[key])
+		}
+		return v
+	}
+	// numbers like gender can't be hashed into the same JSON type, drop them in both modes
+	return nil
+}
+
+// Anonymize returns an anonymized copy of v, the original is left untouched
+func Anonymize[T any](v *T, a *Anonymizer) (*T, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("error encoding value for anonymization: %w", err)
+	}
+	data, err = a.AnonymizeJSON(data)
+	if err != nil {
+		return nil, err
+	}
+	anonymized := new(T)
+	if err = json.Unmarshal(data, anonymized); err != nil {
+		return nil, fmt.Errorf("error decoding anonymized value: %w", err)
+	}
+	return anonymized, nil
+}
+
+func AnonymizeOrder(o *Order, a *Anonymizer) (*Order, error) {
+	return Anonymize(o, a)
+}
+
+func AnonymizeCustomer(c *Customer, a *Anonymizer) (*Customer, error) {
+	return Anonymize(c, a)
+}
+
+var (
+	logAnonymizationMu     sync.Mutex
+	previousMarshalFunc    func(v any) ([]byte, error)
+	logAnonymizationActive bool
+)
+
+// EnableLogAnonymization anonymizes every value logged through zerolog's Interface, which is how this
+// package logs orders, carts and payloads. zerolog's marshal function is process-wide, so this also
+// applies to other zerolog users in the application
+func EnableLogAnonymization(a *Anonymizer) {
+	logAnonymizationMu.Lock()
+	defer logAnonymizationMu.Unlock()
+
+	if !logAnonymizationActive {
+		previousMarshalFunc = zerolog.InterfaceMarshalFunc
+		logAnonymizationActive = true
+	}
+	marshal := previousMarshalFunc
+	zerolog.InterfaceMarshalFunc = func(v any) ([]byte, error) {
+		data, err := marshal(v)
+		if err != nil {
+			return data, err
+		}
+		anonymized, err := a.AnonymizeJSON(data)
+		if err != nil {
+			// not a JSON document, e.g. a custom marshaller, log as is
+			return data, nil
+		}
+		return anonymized, nil
+	}
+}
+
+// DisableLogAnonymization restores zerolog's marshal function from before EnableLogAnonymization
+func DisableLogAnonymization() {
+	logAnonymizationMu.Lock()
+	defer logAnonymizationMu.Unlock()
+
+	if logAnonymizationActive {
+		zerolog.InterfaceMarshalFunc = previousMarshalFunc
+		logAnonymizationActive = false
+	}
+}
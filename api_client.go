@@ -1,8 +1,9 @@
 package magento2
 
 import (
-	"net/http"
 	"reflect"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"fmt"
@@ -13,9 +14,15 @@ import (
 )
 
 const (
-	RetryAttempts       = 3
-	RetryWaitSeconds    = 5
-	RetryMaxWaitSeconds = 20
+	DefaultUserAgent      = "go-m2rest"
+	RetryAttempts         = 3
+	RetryWaitSeconds      = 5
+	RetryMaxWaitSeconds   = 20
+	DefaultTimeoutSeconds = 60
+
+	// DefaultMaxResponseBytes is a sensible cap for WithMaxResponseSize, so a runaway endpoint fails with
+	// ErrResponseTooLarge instead of exhausting memory. Clients read responses of any size by default
+	DefaultMaxResponseBytes = 32 << 20
 )
 
 // SetLogger is deprecated. Use SetZeroLogger from logger.go instead
@@ -25,11 +32,33 @@ func SetLogger(l any) {
 
 type Client struct {
 	HTTPClient *resty.Client
+	// ValidatePayloads runs the local Validate() checks before create requests are sent
+	ValidatePayloads bool
+	// ConcurrencyCheck makes product writes fail with ErrConflict when the remote product changed since it was read
+	ConcurrencyCheck ConcurrencyCheckMode
+
+	storeConfig           *StoreConfig
+	idempotencyKeys       bool
+	maintenanceHook       MaintenanceHook
+	contentValidator      *ContentValidator
+	invoiceDocumentSource InvoiceDocumentSource
+	journal               atomic.Pointer[Journal]
+	journalHooks          sync.Once
+	slowRequestThreshold  time.Duration
+	runID                 string
+	stats                 *clientStats
+	reauth                *reauthenticator
+	extensionsMu          sync.RWMutex
+	extensions            map[string]*Extension
+	versionMu             sync.Mutex
+	version               *MagentoVersion
 }
 
 type StoreConfig struct {
-	Scheme    string
-	HostName  string
+	Scheme   string
+	HostName string
+	// BasePath is the path of an installation in a subdirectory, e.g. "/shop", empty at the root
+	BasePath  string
 	StoreCode string
 }
 
@@ -65,15 +94,23 @@ func (c *Client) PostRouteAndDecode(route string, body, target any, tryTo string
 	return mayReturnErrorForHTTPResponse(resp, tryTo)
 }
 
+// SetDefaultTimeout sets the time budget of a call. Calls taking a context get it as an overall deadline
+// shared by all retries; the remaining calls apply it to each attempt and start no retry once it is
+// used up
+func (c *Client) SetDefaultTimeout(timeout time.Duration) *Client {
+	c.HTTPClient.SetTimeout(timeout)
+	return c
+}
+
+// Deprecated: use NewClient(ctx, WithStore(storeConfig))
 func NewAPIClientWithoutAuthentication(storeConfig *StoreConfig) *Client {
 	httpClient := buildBasicHTTPClient(storeConfig)
 	log.Info().Interface("storeConfig", storeConfig).Msg("Created API client without authentication")
 
-	return &Client{
-		HTTPClient: httpClient,
-	}
+	return newClient(httpClient, storeConfig)
 }
 
+// Deprecated: use NewClient with WithAdminCredentials or WithCustomerCredentials, which also report failed logins
 func NewAPIClientFromAuthentication(storeConfig *StoreConfig, payload AuthenticationRequestPayload, authenticationType AuthenticationType) (*Client, error) {
 	client := buildBasicHTTPClient(storeConfig)
 
@@ -87,48 +124,57 @@ func NewAPIClientFromAuthentication(storeConfig *StoreConfig, payload Authentica
 	client.SetAuthToken(token)
 	log.Info().Str("authenticationType", authenticationType.Route()).Msg("API client authenticated successfully")
 
-	return &Client{
-		HTTPClient: client,
-	}, nil
+	return newClient(client, storeConfig), nil
 }
 
+// Deprecated: use NewClient(ctx, WithStore(storeConfig), WithBearerToken(bearer))
 func NewAPIClientFromIntegration(storeConfig *StoreConfig, bearer string) (*Client, error) {
 	client := buildBasicHTTPClient(storeConfig)
 
 	client.SetAuthToken(bearer)
 	log.Info().Interface("storeConfig", storeConfig).Msg("Created API client from integration")
 
-	return &Client{
-		HTTPClient: client,
-	}, nil
+	return newClient(client, storeConfig), nil
+}
+
+func newClient(httpClient *resty.Client, storeConfig *StoreConfig) *Client {
+	client := &Client{
+		HTTPClient:  httpClient,
+		storeConfig: storeConfig,
+		stats:       &clientStats{},
+	}
+	httpClient.OnBeforeRequest(client.stats.countRequest).
+		OnSuccess(client.stats.countResponse).
+		OnError(client.stats.countError)
+	return client
 }
 
 func buildBasicHTTPClient(storeConfig *StoreConfig) *resty.Client {
+	return configureHTTPClient(resty.New(), storeConfig)
+}
+
+func configureHTTPClient(client *resty.Client, storeConfig *StoreConfig) *resty.Client {
 	apiVersion := "/V1"
 	restPrefix := "/rest/" + storeConfig.StoreCode
-	fullRestRoute := storeConfig.Scheme + "://" + storeConfig.HostName + restPrefix + apiVersion
-	client := resty.New()
+	fullRestRoute := storeConfig.Scheme + "://" + storeConfig.HostName + storeConfig.BasePath + restPrefix + apiVersion
 	// SetRESTMode is not needed in resty v2
 	client.SetHostURL(fullRestRoute)
 	client.SetHeaders(map[string]string{
-		"User-Agent": "go-m2rest",
+		"User-Agent": DefaultUserAgent,
 	})
 	client.SetDebug(false) // Set to true for very verbose resty debugging
 
 	retryWait := time.Duration(RetryWaitSeconds)
 	retryMaxWait := time.Duration(RetryMaxWaitSeconds)
+	client.SetTimeout(DefaultTimeoutSeconds * time.Second)
 	client.SetRetryCount(RetryAttempts).
 		SetRetryWaitTime(retryWait * time.Second).
 		SetRetryMaxWaitTime(retryMaxWait * time.Second).
-		AddRetryCondition(
-			func(r *resty.Response, err error) bool {
-				if r != nil {
-					status := r.StatusCode()
-					return status == http.StatusServiceUnavailable || status == http.StatusInternalServerError
-				}
-				return false
-			},
-		)
+		AddRetryCondition(retryCondition(client))
+	client.OnBeforeRequest(markCallStart).
+		OnBeforeRequest(propagateRequestID).
+		OnAfterResponse(logResponseWithContext).
+		OnError(logErrorWithContext)
 	log.Debug().Str("route", fullRestRoute).Msg("Built basic HTTP client")
 	return client
 }
@@ -1,6 +1,7 @@
 package magento2
 
 import (
+	"context"
 	"fmt"
 	"strings"
 
@@ -21,6 +22,12 @@ func CreateAttribute(a *Attribute, apiClient *Client) (*MAttribute, error) {
 	endpoint := productsAttribute
 	httpClient := apiClient.HTTPClient
 
+	if apiClient.ValidatePayloads {
+		if err := a.Validate(); err != nil {
+			return mAttribute, err
+		}
+	}
+
 	payLoad := createAttributePayload{
 		Attribute: *a,
 	}
@@ -158,3 +165,56 @@ func (mas *MAttribute) AddOption(option Option) (string, error) {
 
 	return optionValue, nil
 }
+
+// GetAttributeTypes returns the frontend input types the store supports for product attributes
+func GetAttributeTypes(ctx context.Context, apiClient *Client) ([]AttributeType, error) {
+	attributeTypes := &[]AttributeType{}
+
+	log.Debug().Str("endpoint", productsAttributeTypes).Msg("Getting attribute types")
+
+	resp, err := apiClient.HTTPClient.R().SetContext(ctx).SetResult(attributeTypes).Get(productsAttributeTypes)
+	if err != nil {
+		return nil, fmt.Errorf("error getting attribute types: %w", err)
+	}
+
+	httpErr := mayReturnErrorForHTTPResponse(resp, "get attribute types from remote")
+	if httpErr != nil {
+		return nil, httpErr
+	}
+
+	return *attributeTypes, nil
+}
+
+// HasOptions reports whether the attribute's values are chosen from an option list
+func (a *Attribute) HasOptions() bool {
+	switch a.FrontendInput {
+	case FrontendInputSelect, FrontendInputMultiselect, FrontendInputBoolean, FrontendInputSwatchVisual, FrontendInputSwatchText:
+		return true
+	}
+	return false
+}
+
+// ExpectedBackendType returns the backend type Magento uses for the attribute's frontend input
+func (a *Attribute) ExpectedBackendType() (string, bool) {
+	backendType, ok := frontendInputBackendTypes[a.FrontendInput]
+	return backendType, ok
+}
+
+// SearchAttributes returns all product attributes matching the criteria, paging through the results
+func SearchAttributes(ctx context.Context, criteria *SearchCriteria, apiClient *Client) ([]Attribute, error) {
+	return searchAll[Attribute](ctx, productsAttribute, criteria, "search attributes", apiClient)
+}
+
+// DeleteAttribute deletes the product attribute together with its values on all products
+func DeleteAttribute(ctx context.Context, attributeCode string, apiClient *Client) error {
+	endpoint := productsAttribute + "/" + attributeCode
+
+	log.Debug().Str("attributeCode", attributeCode).Str("endpoint", endpoint).Msg("Deleting attribute")
+
+	resp, err := apiClient.HTTPClient.R().SetContext(ctx).Delete(endpoint)
+	if err != nil {
+		return fmt.Errorf("error deleting attribute: %w", err)
+	}
+
+	return mayReturnErrorForHTTPResponse(resp, "delete attribute")
+}
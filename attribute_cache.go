@@ -0,0 +1,84 @@
+package magento2
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/rs/zerolog/log"
+)
+
+// AttributeCache keeps attributes fetched by code, so resolving codes and option labels
+// to IDs does not cost a request per lookup. It is safe for concurrent use
+type AttributeCache struct {
+	APIClient  *Client
+	mu         sync.RWMutex
+	attributes map[string]*Attribute
+}
+
+func NewAttributeCache(apiClient *Client) *AttributeCache {
+	return &AttributeCache{
+		APIClient:  apiClient,
+		attributes: map[string]*Attribute{},
+	}
+}
+
+// Get returns the cached attribute, fetching it from remote on first use
+func (ac *AttributeCache) Get(ctx context.Context, attributeCode string) (*Attribute, error) {
+	ac.mu.RLock()
+	attribute, ok := ac.attributes[attributeCode]
+	ac.mu.RUnlock()
+	if ok {
+		return attribute, nil
+	}
+
+	endpoint := fmt.Sprintf("%s/%s", productsAttribute, attributeCode)
+	attribute = &Attribute{}
+
+	log.Debug().
+		Str("attributeCode", attributeCode).
+		Str("endpoint", endpoint).
+		Msg("Attribute cache miss, getting attribute from remote")
+
+	resp, err := ac.APIClient.HTTPClient.R().SetContext(ctx).SetResult(attribute).Get(endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("error getting attribute for cache: %w", err)
+	}
+
+	httpErr := mayReturnErrorForHTTPResponse(resp, "get attribute for cache from remote")
+	if httpErr != nil {
+		return nil, httpErr
+	}
+
+	ac.mu.Lock()
+	ac.attributes[attributeCode] = attribute
+	ac.mu.Unlock()
+	return attribute, nil
+}
+
+// Invalidate drops the given attributes from the cache, or all of them when no code is given
+func (ac *AttributeCache) Invalidate(attributeCodes ...string) {
+	ac.mu.Lock()
+	defer ac.mu.Unlock()
+	if len(attributeCodes) == 0 {
+		ac.attributes = map[string]*Attribute{}
+		return
+	}
+	for _, code := range attributeCodes {
+		delete(ac.attributes, code)
+	}
+}
+
+// OptionValue resolves an option label of the attribute to its value, e.g. "Red" -> "42"
+func (ac *AttributeCache) OptionValue(ctx context.Context, attributeCode, label string) (string, error) {
+	attribute, err := ac.Get(ctx, attributeCode)
+	if err != nil {
+		return "", err
+	}
+	for _, option := range attribute.Options {
+		if option.Label == label {
+			return option.Value, nil
+		}
+	}
+	return "", fmt.Errorf("%w: option '%s' of attribute '%s'", ErrNotFound, label, attributeCode)
+}
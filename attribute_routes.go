@@ -3,4 +3,5 @@ package magento2
 const (
 	productsAttribute        = "/products/attributes"
 	productsAttributeOptions = "options"
+	productsAttributeTypes   = "/products/attributes/types"
 )
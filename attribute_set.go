@@ -1,6 +1,7 @@
 package magento2
 
 import (
+	"context"
 	"fmt"
 	"strconv"
 
@@ -102,6 +103,88 @@ func GetAttributeSetByName(name string, apiClient *Client) (*MAttributeSet, erro
 	return mAttributeSet, nil
 }
 
+// GetAttributeSetByID fetches the attribute set with its groups and attributes
+func GetAttributeSetByID(attributeSetID int, apiClient *Client) (*MAttributeSet, error) {
+	mAttributeSet := &MAttributeSet{
+		Route:                  productsAttributeSet + "/" + strconv.Itoa(attributeSetID),
+		AttributeSet:           &AttributeSet{},
+		AttributeSetAttributes: &[]Attribute{},
+		APIClient:              apiClient,
+	}
+
+	log.Debug().Int("attributeSetID", attributeSetID).Msg("Getting attribute set by ID")
+
+	err := mAttributeSet.UpdateAttributeSetFromRemote()
+	if err != nil {
+		return nil, fmt.Errorf("error getting attribute set by id: %w", err)
+	}
+	return mAttributeSet, nil
+}
+
+// CloneAttributeSet creates a set named newName with the groups and attribute assignments of the source set.
+// The set is created with the source set as skeleton, then groups and attributes still missing in the copy
+// are replayed. The REST API does not report the group of an assignment, so replayed attributes go to the
+// first group of the copy
+func CloneAttributeSet(ctx context.Context, sourceSetID int, newName string, apiClient *Client) (*MAttributeSet, error) {
+	source, err := GetAttributeSetByID(sourceSetID, apiClient)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Debug().
+		Int("sourceSetID", sourceSetID).
+		Str("name", newName).
+		Int("groups", len(source.AttributeSetGroups)).
+		Int("attributes", len(*source.AttributeSetAttributes)).
+		Msg("Cloning attribute set")
+
+	clone, err := CreateAttributeSet(AttributeSet{
+		AttributeSetName: newName,
+		SortOrder:        source.AttributeSet.SortOrder,
+		EntityTypeID:     source.AttributeSet.EntityTypeID,
+	}, sourceSetID, apiClient)
+	if err != nil {
+		return clone, fmt.Errorf("error creating attribute set clone: %w", err)
+	}
+
+	for _, group := range source.AttributeSetGroups {
+		if err := ctx.Err(); err != nil {
+			return clone, err
+		}
+		if _, err := clone.FindGroupByName(group.AttributeGroupName); err == nil {
+			continue
+		}
+		if err := clone.CreateGroup(group.AttributeGroupName); err != nil {
+			return clone, fmt.Errorf("error replaying group %s: %w", group.AttributeGroupName, err)
+		}
+	}
+
+	assigned := make(map[string]bool, len(*clone.AttributeSetAttributes))
+	for _, attribute := range *clone.AttributeSetAttributes {
+		assigned[attribute.AttributeCode] = true
+	}
+	for sortOrder, attribute := range *source.AttributeSetAttributes {
+		if assigned[attribute.AttributeCode] {
+			continue
+		}
+		if err := ctx.Err(); err != nil {
+			return clone, err
+		}
+		if len(clone.AttributeSetGroups) == 0 {
+			return clone, fmt.Errorf("%w: no group to assign attribute %s to", ErrNotFound, attribute.AttributeCode)
+		}
+		groupID, err := strconv.Atoi(clone.AttributeSetGroups[0].AttributeGroupID)
+		if err != nil {
+			return clone, fmt.Errorf("error parsing group id of attribute set clone: %w", err)
+		}
+		if err := clone.AssignAttribute(groupID, sortOrder, attribute.AttributeCode); err != nil {
+			return clone, fmt.Errorf("error replaying attribute %s: %w", attribute.AttributeCode, err)
+		}
+	}
+
+	return clone, nil
+}
+
 func (mas *MAttributeSet) UpdateAttributeSetOnRemote() error {
 	log.Debug().
 		Str("route", mas.Route).
@@ -296,6 +379,150 @@ func (mas *MAttributeSet) CreateGroup(groupName string) error {
 	return nil
 }
 
+// ListAttributeSetSkeletons returns the attribute sets of an entity type that can be used as skeletonID
+// for CreateAttributeSet. Use EntityTypeProduct for catalog products
+func ListAttributeSetSkeletons(ctx context.Context, entityTypeID int, apiClient *Client) ([]AttributeSet, error) {
+	searchQuery := BuildSearchQuery("entity_type_id", strconv.Itoa(entityTypeID), "eq")
+	endpoint := productsAttributeSetList + "?" + searchQuery
+
+	response := &attributeSetSearchQueryResponse{}
+
+	log.Debug().
+		Int("entityTypeID", entityTypeID).
+		Str("endpoint", endpoint).
+		Msg("Listing attribute set skeletons")
+
+	resp, err := apiClient.HTTPClient.R().SetContext(ctx).SetResult(response).Get(endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("error listing attribute set skeletons: %w", err)
+	}
+
+	httpErr := mayReturnErrorForHTTPResponse(resp, "list attribute-set skeletons from remote")
+	if httpErr != nil {
+		return nil, httpErr
+	}
+
+	return response.AttributeSets, nil
+}
+
+// DefaultAttributeSetSkeletonID returns the ID of the "Default" attribute set of an entity type,
+// falling back to the lowest ID when no set carries that name
+func DefaultAttributeSetSkeletonID(ctx context.Context, entityTypeID int, apiClient *Client) (int, error) {
+	sets, err := ListAttributeSetSkeletons(ctx, entityTypeID, apiClient)
+	if err != nil {
+		return 0, err
+	}
+	if len(sets) == 0 {
+		return 0, ErrNotFound
+	}
+
+	skeleton := sets[0]
+	for _, set := range sets {
+		if set.AttributeSetName == defaultAttributeSetName {
+			return set.AttributeSetID, nil
+		}
+		if set.AttributeSetID < skeleton.AttributeSetID {
+			skeleton = set
+		}
+	}
+	return skeleton.AttributeSetID, nil
+}
+
+// FindGroupByName looks up a group of the attribute set, as last fetched from remote, by its name
+func (mas *MAttributeSet) FindGroupByName(groupName string) (*Group, error) {
+	for i := range mas.AttributeSetGroups {
+		if mas.AttributeSetGroups[i].AttributeGroupName == groupName {
+			return &mas.AttributeSetGroups[i], nil
+		}
+	}
+	log.Warn().Str("groupName", groupName).Int("attributeSetID", mas.AttributeSet.AttributeSetID).Msg("Attribute group not found by name")
+	return nil, ErrNotFound
+}
+
+func (mas *MAttributeSet) UpdateGroup(ctx context.Context, groupID, groupName string) error {
+	endpoint := fmt.Sprintf("%s/%d/%s", productsAttributeSet, mas.AttributeSet.AttributeSetID, productsAttributeSetGroupsRelative)
+	httpClient := mas.APIClient.HTTPClient
+
+	group := Group{
+		AttributeGroupID:   groupID,
+		AttributeGroupName: groupName,
+		AttributeSetID:     mas.AttributeSet.AttributeSetID,
+	}
+	payLoad := createGroupPayload{Group: group}
+
+	log.Debug().
+		Str("groupID", groupID).
+		Str("groupName", groupName).
+		Str("endpoint", endpoint).
+		Interface("payload", payLoad).
+		Msg("Updating attribute group of attribute set")
+
+	resp, err := httpClient.R().SetContext(ctx).SetBody(payLoad).Put(endpoint)
+	if err != nil {
+		log.Error().Err(err).Msg("Error updating attribute group of attribute set")
+		return fmt.Errorf("error updating group on attribute-set: %w", err)
+	}
+
+	httpErr := mayReturnErrorForHTTPResponse(resp, "update group on attribute-set")
+	if httpErr != nil {
+		return httpErr
+	}
+
+	log.Debug().Msg("Attribute group updated successfully, updating attribute set from remote")
+	err = mas.UpdateAttributeSetFromRemote()
+	if err != nil {
+		return fmt.Errorf("error updating attribute set from remote after updating group: %w", err)
+	}
+	return nil
+}
+
+func (mas *MAttributeSet) DeleteGroup(ctx context.Context, groupID string) error {
+	endpoint := productsAttributeSetGroups + "/" + groupID
+	httpClient := mas.APIClient.HTTPClient
+
+	log.Debug().
+		Str("groupID", groupID).
+		Str("endpoint", endpoint).
+		Msg("Deleting attribute group of attribute set")
+
+	resp, err := httpClient.R().SetContext(ctx).Delete(endpoint)
+	if err != nil {
+		log.Error().Err(err).Msg("Error deleting attribute group of attribute set")
+		return fmt.Errorf("error deleting group on attribute-set: %w", err)
+	}
+
+	httpErr := mayReturnErrorForHTTPResponse(resp, "delete group on attribute-set")
+	if httpErr != nil {
+		return httpErr
+	}
+
+	log.Debug().Msg("Attribute group deleted successfully, updating attribute set from remote")
+	err = mas.UpdateAttributeSetFromRemote()
+	if err != nil {
+		return fmt.Errorf("error updating attribute set from remote after deleting group: %w", err)
+	}
+	return nil
+}
+
+// SearchAttributeSets returns all attribute sets matching the criteria, paging through the results
+func SearchAttributeSets(ctx context.Context, criteria *SearchCriteria, apiClient *Client) ([]AttributeSet, error) {
+	return searchAll[AttributeSet](ctx, productsAttributeSetList, criteria, "search attribute-sets", apiClient)
+}
+
+// DeleteAttributeSet deletes the attribute set. Magento refuses to delete the default set
+func DeleteAttributeSet(ctx context.Context, attributeSetID int, apiClient *Client) error {
+	endpoint := productsAttributeSet + "/" + strconv.Itoa(attributeSetID)
+
+	log.Debug().Int("attributeSetID", attributeSetID).Str("endpoint", endpoint).Msg("Deleting attribute set")
+
+	resp, err := apiClient.HTTPClient.R().SetContext(ctx).Delete(endpoint)
+	if err != nil {
+		return fmt.Errorf("error deleting attribute set: %w", err)
+	}
+
+	return mayReturnErrorForHTTPResponse(resp, "delete attribute-set")
+}
+
 // --- Helper Functions (Potentially in a separate util file) ---
 
 // BuildSearchQuery is assumed to be defined elsewhere and is not modified as part of the logging refactor.
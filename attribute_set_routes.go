@@ -7,4 +7,5 @@ const (
 	productsAttributeSetGroupsList         = "/products/attribute-sets/groups/list"
 	productsAttributeSetAttributes         = "/products/attribute-sets/attributes"
 	productsAttributeSetAttributesRelative = "attributes"
+	productsAttributeSetGroupsRelative     = "groups"
 )
@@ -1,5 +1,14 @@
 package magento2
 
+const (
+	EntityTypeCustomer        = 1
+	EntityTypeCustomerAddress = 2
+	EntityTypeCategory        = 3
+	EntityTypeProduct         = 4
+)
+
+const defaultAttributeSetName = "Default"
+
 type AttributeSet struct {
 	AttributeSetID      int         `json:"attribute_set_id,omitempty"`
 	AttributeSetName    string      `json:"attribute_set_name"`
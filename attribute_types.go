@@ -1,5 +1,67 @@
 package magento2
 
+const (
+	FrontendInputText         = "text"
+	FrontendInputTextarea     = "textarea"
+	FrontendInputTextEditor   = "texteditor"
+	FrontendInputPageBuilder  = "pagebuilder"
+	FrontendInputDate         = "date"
+	FrontendInputDatetime     = "datetime"
+	FrontendInputBoolean      = "boolean"
+	FrontendInputMultiselect  = "multiselect"
+	FrontendInputSelect       = "select"
+	FrontendInputPrice        = "price"
+	FrontendInputMediaImage   = "media_image"
+	FrontendInputWeee         = "weee"
+	FrontendInputSwatchVisual = "swatch_visual"
+	FrontendInputSwatchText   = "swatch_text"
+)
+
+const (
+	FrontendClassNumber       = "validate-number"
+	FrontendClassDigits       = "validate-digits"
+	FrontendClassEmail        = "validate-email"
+	FrontendClassURL          = "validate-url"
+	FrontendClassAlpha        = "validate-alpha"
+	FrontendClassAlphanumeric = "validate-alphanum"
+)
+
+// frontendInputBackendTypes maps frontend inputs to the backend type Magento stores them with
+var frontendInputBackendTypes = map[string]string{
+	FrontendInputText:         "varchar",
+	FrontendInputTextarea:     "text",
+	FrontendInputTextEditor:   "text",
+	FrontendInputPageBuilder:  "text",
+	FrontendInputDate:         "datetime",
+	FrontendInputDatetime:     "datetime",
+	FrontendInputBoolean:      "int",
+	FrontendInputMultiselect:  "varchar",
+	FrontendInputSelect:       "int",
+	FrontendInputPrice:        "decimal",
+	FrontendInputMediaImage:   "varchar",
+	FrontendInputWeee:         "static",
+	FrontendInputSwatchVisual: "int",
+	FrontendInputSwatchText:   "int",
+}
+
+// AttributeType is an entry of /products/attributes/types
+type AttributeType struct {
+	Value string `json:"value"`
+	Label string `json:"label"`
+}
+
+// FrontendClasses lists the validation classes that can be set as an attribute's frontend_class
+func FrontendClasses() []string {
+	return []string{
+		FrontendClassNumber,
+		FrontendClassDigits,
+		FrontendClassEmail,
+		FrontendClassURL,
+		FrontendClassAlpha,
+		FrontendClassAlphanumeric,
+	}
+}
+
 type createAttributePayload struct {
 	Attribute Attribute `json:"attribute"`
 }
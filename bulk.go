@@ -0,0 +1,159 @@
+package magento2
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sync"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	DefaultBulkSignatureHeader = "X-Magento-Signature"
+	defaultBulkCallbackMaxBody = 10 << 20
+)
+
+var ErrInvalidSignature = errors.New("invalid callback signature")
+
+// GetBulkStatus returns the detailed status of the bulk operation started by an async request
+func GetBulkStatus(ctx context.Context, bulkUUID string, apiClient *Client) (*BulkStatus, error) {
+	endpoint := fmt.Sprintf("%s/%s/%s", bulk, url.PathEscape(bulkUUID), bulkDetailedStatusSuffix)
+	status := &BulkStatus{}
+
+	log.Debug().
+		Str("bulkUUID", bulkUUID).
+		Str("endpoint", endpoint).
+		Msg("Getting bulk status")
+
+	resp, err := apiClient.HTTPClient.R().SetContext(ctx).SetResult(status).Get(endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("error getting bulk status: %w", err)
+	}
+
+	httpErr := mayReturnErrorForHTTPResponse(resp, "get bulk status")
+	if httpErr != nil {
+		return nil, httpErr
+	}
+	return status, nil
+}
+
+func newBulkEvent(status BulkStatus) *BulkEvent {
+	event := &BulkEvent{BulkStatus: status, Completed: true}
+	for _, operation := range status.OperationsList {
+		switch operation.Status {
+		case BulkOperationStatusOpen:
+			event.Completed = false
+		case BulkOperationStatusFailedRetriable, BulkOperationStatusFailedNotRetriable, BulkOperationStatusRejected:
+			event.Failed = append(event.Failed, operation)
+		}
+	}
+	return event
+}
+
+type BulkEventHandlerFunc func(ctx context.Context, event *BulkEvent) error
+
+// BulkCallbackHandler is an http.Handler receiving bulk operation notifications, i.e. the detailed bulk
+// status posted by a notifier module or observer on the Magento side. When Secret is set the body must be
+// signed with hex encoded HMAC-SHA256 in SignatureHeader. Registered handlers run in order, the first
+// error answers the callback with 500 so the sender can retry
+type BulkCallbackHandler struct {
+	Secret          string
+	SignatureHeader string
+	MaxBodyBytes    int64
+
+	mu       sync.RWMutex
+	handlers []BulkEventHandlerFunc
+}
+
+func NewBulkCallbackHandler(secret string) *BulkCallbackHandler {
+	return &BulkCallbackHandler{
+		Secret:          secret,
+		SignatureHeader: DefaultBulkSignatureHeader,
+		MaxBodyBytes:    defaultBulkCallbackMaxBody,
+	}
+}
+
+// OnEvent registers a handler for every decoded bulk event
+func (h *BulkCallbackHandler) OnEvent(handler BulkEventHandlerFunc) *BulkCallbackHandler {
+	h.mu.Lock()
+	h.handlers = append(h.handlers, handler)
+	h.mu.Unlock()
+	return h
+}
+
+func (h *BulkCallbackHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, h.MaxBodyBytes))
+	if err != nil {
+		http.Error(w, "error reading body", http.StatusRequestEntityTooLarge)
+		return
+	}
+
+	if err = h.verifySignature(r.Header.Get(h.SignatureHeader), body); err != nil {
+		log.Warn().Err(err).Str("remoteAddr", r.RemoteAddr).Msg("Rejected bulk callback")
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	status := BulkStatus{}
+	if err = json.Unmarshal(body, &status); err != nil || status.BulkID == "" {
+		http.Error(w, "invalid bulk status payload", http.StatusBadRequest)
+		return
+	}
+	event := newBulkEvent(status)
+
+	log.Debug().
+		Str("bulkUUID", event.BulkID).
+		Bool("completed", event.Completed).
+		Int("failed", len(event.Failed)).
+		Msg("Bulk callback received")
+
+	h.mu.RLock()
+	handlers := h.handlers
+	h.mu.RUnlock()
+
+	for _, handler := range handlers {
+		if err = handler(r.Context(), event); err != nil {
+			log.Error().Err(err).Str("bulkUUID", event.BulkID).Msg("Bulk callback handler failed")
+			http.Error(w, "handler failed", http.StatusInternalServerError)
+			return
+		}
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *BulkCallbackHandler) verifySignature(signature string, body []byte) error {
+	if h.Secret == "" {
+		return nil
+	}
+	expected, err := hex.DecodeString(signature)
+	if err != nil || len(expected) == 0 {
+		return ErrInvalidSignature
+	}
+	mac := hmac.New(sha256.New, []byte(h.Secret))
+	mac.Write(body)
+	if !hmac.Equal(mac.Sum(nil), expected) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+// SignBulkCallback returns the signature header value for body, for senders and tests
+func SignBulkCallback(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,6 @@
+package magento2
+
+const (
+	bulk                     = "/bulk"
+	bulkDetailedStatusSuffix = "detailed-status"
+)
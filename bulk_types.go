@@ -0,0 +1,35 @@
+package magento2
+
+const (
+	BulkOperationStatusComplete           = 1
+	BulkOperationStatusFailedRetriable    = 2
+	BulkOperationStatusFailedNotRetriable = 3
+	BulkOperationStatusOpen               = 4
+	BulkOperationStatusRejected           = 5
+)
+
+type BulkOperation struct {
+	ID            int    `json:"id"`
+	Topic         string `json:"topic_name,omitempty"`
+	Status        int    `json:"status"`
+	ResultMessage string `json:"result_message,omitempty"`
+	ErrorCode     int    `json:"error_code,omitempty"`
+}
+
+type BulkStatus struct {
+	BulkID              string          `json:"bulk_id"`
+	Description         string          `json:"description,omitempty"`
+	StartTime           string          `json:"start_time,omitempty"`
+	UserID              int             `json:"user_id,omitempty"`
+	OperationCount      int             `json:"operation_count"`
+	OperationsList      []BulkOperation `json:"operations_list"`
+	ExtensionAttributes map[string]any  `json:"extension_attributes,omitempty"`
+}
+
+// BulkEvent is a decoded bulk operation callback
+type BulkEvent struct {
+	BulkStatus
+	// Completed is true when no operation is open anymore
+	Completed bool
+	Failed    []BulkOperation
+}
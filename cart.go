@@ -1,6 +1,7 @@
 package magento2
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"strconv"
@@ -130,6 +131,14 @@ func (cart *MCart) AddItems(items []CartItem) error {
 		CartItem CartItem `json:"cartItem"`
 	}
 
+	if cart.APIClient.ValidatePayloads {
+		for i := range items {
+			if err := items[i].Validate(); err != nil {
+				return err
+			}
+		}
+	}
+
 	for _, item := range items {
 		item.QuoteID = cart.QuoteID
 		payLoad := &PayLoad{
@@ -209,6 +218,19 @@ func (cart *MCart) AddShippingInformation(addrInfo *AddressInformation) error {
 		AddressInformation AddressInformation `json:"addressInformation"`
 	}
 
+	if cart.APIClient.ValidatePayloads {
+		if addrInfo.ShippingAddress != nil {
+			if err := addrInfo.ShippingAddress.Validate(); err != nil {
+				return err
+			}
+		}
+		if addrInfo.BillingAddress != nil {
+			if err := addrInfo.BillingAddress.Validate(); err != nil {
+				return err
+			}
+		}
+	}
+
 	payLoad := &PayLoad{
 		AddressInformation: *addrInfo,
 	}
@@ -288,13 +310,20 @@ func (cart *MCart) CreateOrder(paymentMethod PaymentMethod) (*MOrder, error) {
 	}
 
 	log.Debug().Int("orderID", orderIDInt).Msg("Order created successfully")
-	return &MOrder{
+	mOrder := &MOrder{
 		Route: Orders + "/" + orderIDString,
 		Order: &Order{
 			EntityID: orderIDInt,
 		},
 		APIClient: cart.APIClient,
-	}, nil
+	}
+	if runID := cart.APIClient.runID; runID != "" {
+		// the order exists at this point, so a failing stamp, e.g. with a customer token, doesn't fail the call
+		if err := mOrder.TagRun(context.Background(), runID); err != nil {
+			log.Warn().Err(err).Int("orderID", orderIDInt).Str("runID", runID).Msg("Could not tag order with run ID")
+		}
+	}
+	return mOrder, nil
 }
 
 func (cart *MCart) DeleteItem(itemID int) error {
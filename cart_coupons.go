@@ -0,0 +1,115 @@
+package magento2
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+)
+
+func (cart *MCart) GetTotals(ctx context.Context) (*CartTotals, error) {
+	endpoint := cart.Route + cartTotals
+	totals := &CartTotals{}
+
+	log.Debug().Str("endpoint", endpoint).Msg("Getting cart totals")
+
+	resp, err := cart.APIClient.HTTPClient.R().SetContext(ctx).SetResult(totals).Get(endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("error getting cart totals: %w", err)
+	}
+
+	httpErr := mayReturnErrorForHTTPResponse(resp, "get cart totals")
+	if httpErr != nil {
+		return nil, httpErr
+	}
+
+	return totals, nil
+}
+
+func (cart *MCart) ApplyCoupon(ctx context.Context, couponCode string) error {
+	endpoint := cart.Route + cartCoupons + "/" + url.PathEscape(couponCode)
+
+	log.Debug().Str("endpoint", endpoint).Str("couponCode", couponCode).Msg("Applying coupon to cart")
+
+	resp, err := cart.APIClient.HTTPClient.R().SetContext(ctx).Put(endpoint)
+	if err != nil {
+		return fmt.Errorf("error applying coupon to cart: %w", err)
+	}
+
+	return mayReturnErrorForHTTPResponse(resp, fmt.Sprintf("apply coupon '%s' to cart", couponCode))
+}
+
+func (cart *MCart) RemoveCoupon(ctx context.Context) error {
+	endpoint := cart.Route + cartCoupons
+
+	log.Debug().Str("endpoint", endpoint).Msg("Removing coupon from cart")
+
+	resp, err := cart.APIClient.HTTPClient.R().SetContext(ctx).Delete(endpoint)
+	if err != nil {
+		return fmt.Errorf("error removing coupon from cart: %w", err)
+	}
+
+	return mayReturnErrorForHTTPResponse(resp, "remove coupon from cart")
+}
+
+// SimulateCoupon copies the items of a guest cart into a temporary guest cart, applies the coupon
+// there and reports the change in totals. The real cart is never touched. Magento has no endpoint to
+// delete a guest cart, the temporary one is left behind and expires with the regular quote cleanup.
+// Customer carts are rejected with ErrNotGuestCart: a guest cart misses the rules of the customer's
+// group, and Magento keeps a single active cart per customer, leaving no other cart to simulate in
+func (cart *MCart) SimulateCoupon(ctx context.Context, couponCode string) (*CouponSimulation, error) {
+	if !strings.HasPrefix(cart.Route, guestCart+"/") {
+		return nil, fmt.Errorf("%w: route '%s'", ErrNotGuestCart, cart.Route)
+	}
+	if err := cart.UpdateFromRemote(); err != nil {
+		return nil, fmt.Errorf("error refreshing cart before coupon simulation: %w", err)
+	}
+
+	tempCart, err := NewGuestCartFromAPIClient(cart.APIClient)
+	if err != nil {
+		return nil, fmt.Errorf("error creating temporary cart for coupon simulation: %w", err)
+	}
+
+	items := make([]CartItem, 0, len(cart.Cart.Items))
+	for _, item := range cart.Cart.Items {
+		items = append(items, CartItem{
+			Sku:           item.Sku,
+			Qty:           item.Qty,
+			ProductOption: item.ProductOption,
+		})
+	}
+	if err = tempCart.AddItems(items); err != nil {
+		return nil, fmt.Errorf("error copying items to temporary cart: %w", err)
+	}
+
+	before, err := tempCart.GetTotals(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error getting totals before coupon simulation: %w", err)
+	}
+
+	if err = tempCart.ApplyCoupon(ctx, couponCode); err != nil {
+		return nil, err
+	}
+
+	after, err := tempCart.GetTotals(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error getting totals after coupon simulation: %w", err)
+	}
+
+	log.Debug().
+		Str("couponCode", couponCode).
+		Str("tempQuoteID", tempCart.QuoteID).
+		Float64("discountBefore", before.DiscountAmount).
+		Float64("discountAfter", after.DiscountAmount).
+		Msg("Coupon simulated on temporary cart")
+
+	return &CouponSimulation{
+		CouponCode:      couponCode,
+		Before:          before,
+		After:           after,
+		DiscountDelta:   after.DiscountAmount - before.DiscountAmount,
+		GrandTotalDelta: after.GrandTotal - before.GrandTotal,
+	}, nil
+}
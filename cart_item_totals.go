@@ -0,0 +1,27 @@
+package magento2
+
+import (
+	"context"
+	"fmt"
+)
+
+// ItemTotals fetches the cart totals and returns the per item row totals, discounts and taxes. The
+// totals are also set on the matching items of cart.Cart
+func (cart *MCart) ItemTotals(ctx context.Context) ([]CartTotalsItem, error) {
+	totals, err := cart.GetTotals(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error getting item totals: %w", err)
+	}
+
+	if cart.Cart != nil {
+		byItemID := make(map[int]*CartTotalsItem, len(totals.Items))
+		for i := range totals.Items {
+			byItemID[totals.Items[i].ItemID] = &totals.Items[i]
+		}
+		for i := range cart.Cart.Items {
+			cart.Cart.Items[i].Totals = byItemID[cart.Cart.Items[i].ItemID]
+		}
+	}
+
+	return totals.Items, nil
+}
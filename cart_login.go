@@ -0,0 +1,64 @@
+package magento2
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+)
+
+type assignCustomerPayload struct {
+	CustomerID int `json:"customerId"`
+	StoreID    int `json:"storeId"`
+}
+
+// LoginAsCustomer is the "login during checkout" sequence: it logs the customer in, assigns the guest
+// cart to them, which merges it into an existing customer cart, and returns the customer's cart. The
+// returned cart uses a clone of the guest cart's client holding the customer token
+func (cart *MCart) LoginAsCustomer(ctx context.Context, username, password string) (*MCart, error) {
+	if !strings.HasPrefix(cart.Route, guestCart+"/") {
+		return nil, fmt.Errorf("%w: route '%s'", ErrNotGuestCart, cart.Route)
+	}
+
+	customerClient, err := cart.APIClient.Clone(WithCustomerCredentials(username, password))
+	if err != nil {
+		return nil, fmt.Errorf("error logging in customer for guest cart: %w", err)
+	}
+	customer, err := GetCurrentCustomer(ctx, customerClient)
+	if err != nil {
+		return nil, fmt.Errorf("error logging in customer for guest cart: %w", err)
+	}
+
+	payLoad := &assignCustomerPayload{
+		CustomerID: customer.ID,
+		StoreID:    customer.StoreID,
+	}
+	log.Debug().
+		Str("route", cart.Route).
+		Int("customerID", customer.ID).
+		Msg("Assigning guest cart to customer")
+
+	resp, err := customerClient.HTTPClient.R().SetContext(ctx).SetBody(payLoad).Put(cart.Route)
+	if err != nil {
+		return nil, fmt.Errorf("error assigning guest cart to customer: %w", err)
+	}
+
+	httpErr := mayReturnErrorForHTTPResponse(resp, "assign guest cart to customer")
+	if httpErr != nil {
+		return nil, httpErr
+	}
+
+	mCart := &MCart{
+		Route:     customerCart,
+		Cart:      &Cart{},
+		APIClient: customerClient,
+	}
+	err = mCart.UpdateFromRemote()
+	if err != nil {
+		return nil, fmt.Errorf("error updating customer cart from remote after assigning guest cart: %w", err)
+	}
+	mCart.QuoteID = strconv.Itoa(mCart.Cart.ID)
+	return mCart, nil
+}
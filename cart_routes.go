@@ -3,9 +3,12 @@ package magento2
 const (
 	guestCart               = "/guest-carts"
 	customerCart            = "/carts/mine"
+	cartsSearch             = "/carts/search"
 	cartShippingCosts       = "/estimate-shipping-methods"
 	cartShippingInformation = "/shipping-information"
 	cartPaymentMethods      = "/payment-methods"
 	cartItems               = "/items"
 	cartPlaceOrder          = "/order"
+	cartTotals              = "/totals"
+	cartCoupons             = "/coupons"
 )
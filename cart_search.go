@@ -0,0 +1,29 @@
+package magento2
+
+import (
+	"context"
+	"time"
+)
+
+// SearchCarts returns one page of the quotes matching the criteria, e.g. filtered by is_active or
+// updated_at. It needs an admin or integration token
+func SearchCarts(ctx context.Context, criteria *SearchCriteria, apiClient *Client) (*CartSearchResult, error) {
+	result, err := searchPage[Cart](ctx, cartsSearch, criteria.Clone(), "search carts", apiClient)
+	if err != nil {
+		return nil, err
+	}
+	return &CartSearchResult{
+		Items:      result.Items,
+		TotalCount: result.TotalCount,
+	}, nil
+}
+
+// GetAbandonedCarts returns every active, non-empty quote not updated since inactiveSince, narrowed
+// down by the optional criteria (e.g. a store_id filter)
+func GetAbandonedCarts(ctx context.Context, inactiveSince time.Time, criteria *SearchCriteria, apiClient *Client) ([]Cart, error) {
+	sc := criteria.Clone().
+		And(SearchFilter{Field: "is_active", Value: "1", ConditionType: "eq"}).
+		And(SearchFilter{Field: "items_count", Value: "0", ConditionType: "gt"}).
+		And(SearchFilter{Field: "updated_at", Value: inactiveSince.UTC().Format(DateTimeFormat), ConditionType: "lt"})
+	return searchAll[Cart](ctx, cartsSearch, sc, "search abandoned carts", apiClient)
+}
@@ -0,0 +1,146 @@
+package magento2
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+const (
+	totalSegmentTax               = "tax"
+	extensionTaxGrandtotalDetails = "tax_grandtotal_details"
+)
+
+// AppliedTaxRate is a tax rate applied to the cart. Title is the code of the rate, by convention
+// "<country>-<region>-<postcode>-<name>" e.g. "US-CA-*-Rate 1"
+type AppliedTaxRate struct {
+	Title   string  `json:"title"`
+	Percent Decimal `json:"percent"`
+}
+
+// AppliedTax is an entry of the tax_grandtotal_details of the tax segment: the amount charged by the
+// rates of the tax rules sharing a priority. Rates of one priority are summed up, not compounded
+type AppliedTax struct {
+	Amount  Decimal          `json:"amount"`
+	Rates   []AppliedTaxRate `json:"rates"`
+	GroupID int              `json:"group_id"`
+}
+
+// TaxJurisdiction is the tax charged under one rate code. When rates share an AppliedTax its amount is
+// split by their percent
+type TaxJurisdiction struct {
+	Code    string
+	Percent Decimal
+	Amount  Decimal
+}
+
+// ItemTax is the tax of a cart item
+type ItemTax struct {
+	ItemID     int
+	Name       string
+	TaxPercent Decimal
+	TaxAmount  Decimal
+}
+
+// CartTaxBreakdown splits the tax of a cart by applied rule, jurisdiction and item. TaxAmount is the tax
+// total of the cart
+type CartTaxBreakdown struct {
+	TaxAmount     Decimal
+	AppliedTaxes  []AppliedTax
+	Jurisdictions []TaxJurisdiction
+	Items         []ItemTax
+}
+
+// TaxBreakdown reads the totals of the cart and breaks its tax down
+func (cart *MCart) TaxBreakdown(ctx context.Context) (*CartTaxBreakdown, error) {
+	totals, err := cart.GetTotals(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return totals.TaxBreakdown()
+}
+
+// TaxBreakdown decodes the applied taxes of the tax segment. Magento only sends the details when
+// "Display Full Tax Summary" is enabled in the tax config, without them only Items are filled
+func (t *CartTotals) TaxBreakdown() (*CartTaxBreakdown, error) {
+	breakdown := &CartTaxBreakdown{TaxAmount: NewDecimalFromFloat(t.TaxAmount)}
+
+	for _, segment := range t.TotalSegments {
+		if segment.Code != totalSegmentTax {
+			continue
+		}
+		raw, ok := segment.ExtensionAttributes[extensionTaxGrandtotalDetails]
+		if !ok || raw == nil {
+			break
+		}
+		data, err := json.Marshal(raw)
+		if err != nil {
+			return nil, fmt.Errorf("error encoding tax details: %w", err)
+		}
+		if err := json.Unmarshal(data, &breakdown.AppliedTaxes); err != nil {
+			return nil, fmt.Errorf("error decoding tax details: %w", err)
+		}
+	}
+
+	index := map[string]int{}
+	for _, applied := range breakdown.AppliedTaxes {
+		for _, share := range splitAppliedTax(applied) {
+			i, ok := index[share.Code]
+			if !ok {
+				index[share.Code] = len(breakdown.Jurisdictions)
+				breakdown.Jurisdictions = append(breakdown.Jurisdictions, share)
+				continue
+			}
+			breakdown.Jurisdictions[i].Amount = breakdown.Jurisdictions[i].Amount.Add(share.Amount)
+		}
+	}
+
+	for _, item := range t.Items {
+		breakdown.Items = append(breakdown.Items, ItemTax{
+			ItemID:     item.ItemID,
+			Name:       item.Name,
+			TaxPercent: NewDecimalFromFloat(item.TaxPercent),
+			TaxAmount:  NewDecimalFromFloat(item.TaxAmount),
+		})
+	}
+	return breakdown, nil
+}
+
+// splitAppliedTax splits the amount by the percent of the rates, the last rate takes the rounding rest
+func splitAppliedTax(applied AppliedTax) []TaxJurisdiction {
+	var totalPercent Decimal
+	for _, rate := range applied.Rates {
+		totalPercent = totalPercent.Add(rate.Percent)
+	}
+
+	shares := make([]TaxJurisdiction, 0, len(applied.Rates))
+	rest := applied.Amount
+	for i, rate := range applied.Rates {
+		amount := rest
+		if i < len(applied.Rates)-1 {
+			amount = applied.Amount.mulDiv(rate.Percent, totalPercent).Round(2)
+			rest = rest.Sub(amount)
+		}
+		shares = append(shares, TaxJurisdiction{Code: rate.Title, Percent: rate.Percent, Amount: amount})
+	}
+	return shares
+}
+
+// Jurisdiction returns the tax charged under the rate code
+func (b *CartTaxBreakdown) Jurisdiction(code string) (TaxJurisdiction, bool) {
+	for _, jurisdiction := range b.Jurisdictions {
+		if jurisdiction.Code == code {
+			return jurisdiction, true
+		}
+	}
+	return TaxJurisdiction{}, false
+}
+
+// Unexplained is the part of the tax total no applied tax accounts for, zero when the details add up
+func (b *CartTaxBreakdown) Unexplained() Decimal {
+	rest := b.TaxAmount
+	for _, applied := range b.AppliedTaxes {
+		rest = rest.Sub(applied.Amount)
+	}
+	return rest
+}
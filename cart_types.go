@@ -24,7 +24,7 @@ type Cart struct {
 	ItemsQty            int                    `json:"items_qty"`
 	Customer            Customer               `json:"customer"`
 	BillingAddress      *BillingAddress        `json:"billing_address"`
-	ReservedOrderID     int                    `json:"reserved_order_id"`
+	ReservedOrderID     string                 `json:"reserved_order_id"`
 	OrigOrderID         int                    `json:"orig_order_id"`
 	Currency            Currency               `json:"currency"`
 	CustomerIsGuest     bool                   `json:"customer_is_guest"`
@@ -45,6 +45,8 @@ type CartItem struct {
 	QuoteID             string                 `json:"quote_id"`
 	ProductOption       CartProductOption      `json:"product_option,omitempty"`
 	ExtensionAttributes map[string]any `json:"extension_attributes,omitempty"`
+	// Totals is filled by MCart.ItemTotals
+	Totals *CartTotalsItem `json:"-"`
 }
 
 type CartProductOption struct {
@@ -138,3 +140,72 @@ type Carrier struct {
 	PriceExclTax float64 `json:"price_excl_tax"`
 	PriceInclTax float64 `json:"price_incl_tax"`
 }
+
+type CartTotalSegment struct {
+	Code                string         `json:"code"`
+	Title               string         `json:"title,omitempty"`
+	Value               float64        `json:"value"`
+	Area                string         `json:"area,omitempty"`
+	ExtensionAttributes map[string]any `json:"extension_attributes,omitempty"`
+}
+
+type CartTotals struct {
+	GrandTotal               float64            `json:"grand_total"`
+	BaseGrandTotal           float64            `json:"base_grand_total"`
+	Subtotal                 float64            `json:"subtotal"`
+	BaseSubtotal             float64            `json:"base_subtotal"`
+	DiscountAmount           float64            `json:"discount_amount"`
+	BaseDiscountAmount       float64            `json:"base_discount_amount"`
+	SubtotalWithDiscount     float64            `json:"subtotal_with_discount"`
+	BaseSubtotalWithDiscount float64            `json:"base_subtotal_with_discount"`
+	ShippingAmount           float64            `json:"shipping_amount"`
+	BaseShippingAmount       float64            `json:"base_shipping_amount"`
+	TaxAmount                float64            `json:"tax_amount"`
+	BaseTaxAmount            float64            `json:"base_tax_amount"`
+	CouponCode               string             `json:"coupon_code,omitempty"`
+	ItemsQty                 float64            `json:"items_qty"`
+	BaseCurrencyCode         string             `json:"base_currency_code,omitempty"`
+	QuoteCurrencyCode        string             `json:"quote_currency_code,omitempty"`
+	TotalSegments            []CartTotalSegment `json:"total_segments,omitempty"`
+	Items                    []CartTotalsItem   `json:"items,omitempty"`
+	ExtensionAttributes      map[string]any     `json:"extension_attributes,omitempty"`
+}
+
+// CartTotalsItem holds the totals of a quote item. Unlike on CartTotals, DiscountAmount is positive here
+type CartTotalsItem struct {
+	ItemID               int            `json:"item_id"`
+	Name                 string         `json:"name,omitempty"`
+	Qty                  float64        `json:"qty"`
+	Price                float64        `json:"price"`
+	BasePrice            float64        `json:"base_price"`
+	PriceInclTax         float64        `json:"price_incl_tax"`
+	BasePriceInclTax     float64        `json:"base_price_incl_tax"`
+	RowTotal             float64        `json:"row_total"`
+	BaseRowTotal         float64        `json:"base_row_total"`
+	RowTotalWithDiscount float64        `json:"row_total_with_discount"`
+	RowTotalInclTax      float64        `json:"row_total_incl_tax"`
+	BaseRowTotalInclTax  float64        `json:"base_row_total_incl_tax"`
+	TaxAmount            float64        `json:"tax_amount"`
+	BaseTaxAmount        float64        `json:"base_tax_amount"`
+	TaxPercent           float64        `json:"tax_percent"`
+	DiscountAmount       float64        `json:"discount_amount"`
+	BaseDiscountAmount   float64        `json:"base_discount_amount"`
+	DiscountPercent      float64        `json:"discount_percent"`
+	Options              string         `json:"options,omitempty"`
+	ExtensionAttributes  map[string]any `json:"extension_attributes,omitempty"`
+}
+
+// CouponSimulation holds the totals of a cart copy before and after a coupon was applied.
+// Discount amounts are negative in Magento, so a working coupon yields a negative DiscountDelta
+type CouponSimulation struct {
+	CouponCode      string
+	Before          *CartTotals
+	After           *CartTotals
+	DiscountDelta   float64
+	GrandTotalDelta float64
+}
+
+type CartSearchResult struct {
+	Items      []Cart `json:"items"`
+	TotalCount int    `json:"total_count"`
+}
@@ -0,0 +1,159 @@
+package magento2
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/rs/zerolog/log"
+)
+
+// AuditIssueKind is the kind of problem an AuditIssue reports
+type AuditIssueKind string
+
+const (
+	AuditMissingChild             AuditIssueKind = "missing_child"
+	AuditNoWebsites               AuditIssueKind = "no_websites"
+	AuditMissingRequiredAttribute AuditIssueKind = "missing_required_attribute"
+	AuditNoSalableChildren        AuditIssueKind = "no_salable_children"
+)
+
+// AuditIssue is a problem of a product found by AuditCatalog
+type AuditIssue struct {
+	Kind      AuditIssueKind `json:"kind"`
+	ProductID int            `json:"product_id"`
+	Sku       string         `json:"sku"`
+	Detail    string         `json:"detail"`
+}
+
+// CatalogAuditReport lists the issues AuditCatalog found, ordered by SKU and kind
+type CatalogAuditReport struct {
+	ProductsScanned int          `json:"products_scanned"`
+	Issues          []AuditIssue `json:"issues"`
+}
+
+// ByKind returns the issues of one kind
+func (r *CatalogAuditReport) ByKind(kind AuditIssueKind) []AuditIssue {
+	var issues []AuditIssue
+	for _, issue := range r.Issues {
+		if issue.Kind == kind {
+			issues = append(issues, issue)
+		}
+	}
+	return issues
+}
+
+// auditedProduct is what the audit keeps of a product after its page was checked
+type auditedProduct struct {
+	sku       string
+	salable   bool
+	childIDs  []int
+	isParent  bool
+	productID int
+}
+
+// AuditCatalog scans the whole catalog for common data problems: configurables linking children that don't
+// exist, configurables without a salable child, enabled products without website and products missing
+// a value of a user-defined attribute their attribute set requires. System attributes are left out, Magento
+// enforces most of them on save. Products without stock item count as salable when enabled. Pages are
+// fetched with up to workers requests in flight, as with ForEachProductPage
+func AuditCatalog(ctx context.Context, workers int, apiClient *Client) (*CatalogAuditReport, error) {
+	report := &CatalogAuditReport{Issues: []AuditIssue{}}
+	products := map[int]*auditedProduct{}
+	requiredBySet := map[int][]string{}
+
+	requiredAttributes := func(attributeSetID int) ([]string, error) {
+		if required, ok := requiredBySet[attributeSetID]; ok {
+			return required, nil
+		}
+		mAttributeSet, err := GetAttributeSetByID(attributeSetID, apiClient)
+		if err != nil {
+			return nil, err
+		}
+		required := []string{}
+		for _, attribute := range *mAttributeSet.AttributeSetAttributes {
+			if attribute.IsRequired && attribute.IsUserDefined {
+				required = append(required, attribute.AttributeCode)
+			}
+		}
+		requiredBySet[attributeSetID] = required
+		return required, nil
+	}
+
+	err := ForEachProductPage(ctx, nil, workers, apiClient, func(page []Product) error {
+		for i := range page {
+			product := &page[i]
+			report.ProductsScanned++
+
+			enabled := product.Status == ProductStatusEnabled
+			audited := &auditedProduct{sku: product.Sku, salable: enabled, productID: product.ID}
+			if stockItem, ok := product.StockItem(); ok {
+				audited.salable = enabled && stockItem.IsInStock
+			}
+			if product.TypeID == ProductTypeConfigurable {
+				audited.isParent = true
+				audited.childIDs = product.ConfigurableProductLinks()
+			}
+			products[product.ID] = audited
+
+			if enabled && len(product.WebsiteIDs()) == 0 {
+				report.Issues = append(report.Issues, AuditIssue{Kind: AuditNoWebsites, ProductID: product.ID, Sku: product.Sku,
+					Detail: "enabled but not assigned to any website"})
+			}
+
+			required, err := requiredAttributes(product.AttributeSetID)
+			if err != nil {
+				return fmt.Errorf("error getting required attributes of attribute set %d: %w", product.AttributeSetID, err)
+			}
+			for _, code := range required {
+				if product.CustomAttributeString(code) == "" {
+					report.Issues = append(report.Issues, AuditIssue{Kind: AuditMissingRequiredAttribute, ProductID: product.ID, Sku: product.Sku,
+						Detail: fmt.Sprintf("no value for required attribute %s", code)})
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error auditing catalog: %w", err)
+	}
+
+	// children may come on any page, so links are checked once all products are known
+	for _, parent := range products {
+		if !parent.isParent {
+			continue
+		}
+		salableChildren := 0
+		for _, childID := range parent.childIDs {
+			child, ok := products[childID]
+			if !ok {
+				report.Issues = append(report.Issues, AuditIssue{Kind: AuditMissingChild, ProductID: parent.productID, Sku: parent.sku,
+					Detail: fmt.Sprintf("links child %d which does not exist", childID)})
+				continue
+			}
+			if child.salable {
+				salableChildren++
+			}
+		}
+		if salableChildren == 0 {
+			report.Issues = append(report.Issues, AuditIssue{Kind: AuditNoSalableChildren, ProductID: parent.productID, Sku: parent.sku,
+				Detail: fmt.Sprintf("none of %d children is salable", len(parent.childIDs))})
+		}
+	}
+
+	sort.SliceStable(report.Issues, func(i, j int) bool {
+		if report.Issues[i].Sku != report.Issues[j].Sku {
+			return report.Issues[i].Sku < report.Issues[j].Sku
+		}
+		if report.Issues[i].Kind != report.Issues[j].Kind {
+			return report.Issues[i].Kind < report.Issues[j].Kind
+		}
+		return report.Issues[i].Detail < report.Issues[j].Detail
+	})
+
+	log.Debug().
+		Int("products", report.ProductsScanned).
+		Int("issues", len(report.Issues)).
+		Msg("Catalog audit finished")
+	return report, nil
+}
@@ -0,0 +1,278 @@
+package magento2
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+)
+
+// CatalogDiffKind tells how an entity differs between the source and the target store
+type CatalogDiffKind string
+
+const (
+	// CatalogDiffMissing entities exist in the source but not in the target
+	CatalogDiffMissing CatalogDiffKind = "missing"
+	// CatalogDiffExtra entities exist in the target but not in the source
+	CatalogDiffExtra   CatalogDiffKind = "extra"
+	CatalogDiffChanged CatalogDiffKind = "changed"
+)
+
+// DefaultCatalogDiffIgnoredAttributes are left out of product comparisons when
+// CatalogDiffOptions.IgnoreAttributes is nil. Category IDs are assigned per installation
+var DefaultCatalogDiffIgnoredAttributes = []string{"category_ids"}
+
+// CatalogDiffOptions configures DiffCatalogs. Criteria limits the products compared on both sides, nil
+// compares the whole catalog. Attributes limits the compared custom attributes, nil compares all of
+// them except IgnoreAttributes. SourceCategoryRootID and TargetCategoryRootID are the roots of the
+// category subtrees compared, installations assign their own IDs; 0 as source root skips categories,
+// 0 as target root uses the source root's ID. Workers is the number of product pages fetched concurrently
+// per store
+type CatalogDiffOptions struct {
+	Criteria             *SearchCriteria
+	Attributes           []string
+	IgnoreAttributes     []string
+	SourceCategoryRootID int
+	TargetCategoryRootID int
+	Workers              int
+}
+
+// CatalogFieldDiff is a field with different values in source and target. A value missing on one side
+// is empty
+type CatalogFieldDiff struct {
+	Field  string `json:"field"`
+	Source string `json:"source"`
+	Target string `json:"target"`
+}
+
+// ProductDiff is a product that differs between the stores. Fields is only set for changed products
+type ProductDiff struct {
+	Sku    string             `json:"sku"`
+	Kind   CatalogDiffKind    `json:"kind"`
+	Fields []CatalogFieldDiff `json:"fields,omitempty"`
+}
+
+// CategoryDiff is a category that differs between the stores. Path is the chain of url keys below the
+// compared root, e.g. "men/shirts", as category IDs are assigned per installation
+type CategoryDiff struct {
+	Path   string             `json:"path"`
+	Kind   CatalogDiffKind    `json:"kind"`
+	Fields []CatalogFieldDiff `json:"fields,omitempty"`
+}
+
+// CatalogDiff is the result of DiffCatalogs, products ordered by SKU and categories by path
+type CatalogDiff struct {
+	SourceProducts int            `json:"source_products"`
+	TargetProducts int            `json:"target_products"`
+	Products       []ProductDiff  `json:"products"`
+	Categories     []CategoryDiff `json:"categories"`
+}
+
+// Empty reports whether the stores have the same catalog
+func (d *CatalogDiff) Empty() bool {
+	return len(d.Products) == 0 && len(d.Categories) == 0
+}
+
+// ProductsByKind returns the product differences of one kind, e.g. the SKUs missing in the target
+func (d *CatalogDiff) ProductsByKind(kind CatalogDiffKind) []ProductDiff {
+	var diffs []ProductDiff
+	for _, diff := range d.Products {
+		if diff.Kind == kind {
+			diffs = append(diffs, diff)
+		}
+	}
+	return diffs
+}
+
+// String lists the differences one per line, changed fields as "field: source -> target"
+func (d *CatalogDiff) String() string {
+	var b strings.Builder
+	write := func(kind CatalogDiffKind, entity string, fields []CatalogFieldDiff) {
+		fmt.Fprintf(&b, "%s %s\n", kind, entity)
+		for _, field := range fields {
+			fmt.Fprintf(&b, "  %s: %q -> %q\n", field.Field, field.Source, field.Target)
+		}
+	}
+	for _, diff := range d.Products {
+		write(diff.Kind, "product "+diff.Sku, diff.Fields)
+	}
+	for _, diff := range d.Categories {
+		write(diff.Kind, "category "+diff.Path, diff.Fields)
+	}
+	return b.String()
+}
+
+// DiffCatalogs compares the products, and the categories if opts.SourceCategoryRootID is set, of two stores,
+// e.g. staging and production before a go-live. Products are matched by SKU as Magento compares them,
+// categories by their url key path. Product fields, custom attributes and category attributes are
+// compared as the REST API returns them: select attributes compare option IDs, which only match between
+// installations sharing their attribute options
+func DiffCatalogs(ctx context.Context, source, target *Client, opts CatalogDiffOptions) (*CatalogDiff, error) {
+	log.Debug().
+		Int("sourceCategoryRootID", opts.SourceCategoryRootID).
+		Int("targetCategoryRootID", opts.TargetCategoryRootID).
+		Strs("attributes", opts.Attributes).
+		Msg("Diffing catalogs")
+
+	ignored := opts.IgnoreAttributes
+	if ignored == nil {
+		ignored = DefaultCatalogDiffIgnoredAttributes
+	}
+	compared := func(code string) bool {
+		if opts.Attributes != nil {
+			return slices.Contains(opts.Attributes, code)
+		}
+		return !slices.Contains(ignored, code)
+	}
+
+	sourceProducts, err := productSnapshot(ctx, opts, compared, source)
+	if err != nil {
+		return nil, fmt.Errorf("error loading source catalog: %w", err)
+	}
+	targetProducts, err := productSnapshot(ctx, opts, compared, target)
+	if err != nil {
+		return nil, fmt.Errorf("error loading target catalog: %w", err)
+	}
+
+	diff := &CatalogDiff{
+		SourceProducts: len(sourceProducts),
+		TargetProducts: len(targetProducts),
+		Products:       []ProductDiff{},
+		Categories:     []CategoryDiff{},
+	}
+	for _, key := range mergedKeys(sourceProducts, targetProducts) {
+		sourceProduct, inSource := sourceProducts[key]
+		targetProduct, inTarget := targetProducts[key]
+		switch {
+		case !inTarget:
+			diff.Products = append(diff.Products, ProductDiff{Sku: sourceProduct.key, Kind: CatalogDiffMissing})
+		case !inSource:
+			diff.Products = append(diff.Products, ProductDiff{Sku: targetProduct.key, Kind: CatalogDiffExtra})
+		default:
+			if fields := diffFields(sourceProduct.fields, targetProduct.fields); len(fields) > 0 {
+				diff.Products = append(diff.Products, ProductDiff{Sku: sourceProduct.key, Kind: CatalogDiffChanged, Fields: fields})
+			}
+		}
+	}
+
+	if opts.SourceCategoryRootID == 0 {
+		return diff, nil
+	}
+	targetRootID := opts.TargetCategoryRootID
+	if targetRootID == 0 {
+		targetRootID = opts.SourceCategoryRootID
+	}
+	sourceCategories, err := categorySnapshot(ctx, opts.SourceCategoryRootID, source)
+	if err != nil {
+		return nil, fmt.Errorf("error loading source categories: %w", err)
+	}
+	targetCategories, err := categorySnapshot(ctx, targetRootID, target)
+	if err != nil {
+		return nil, fmt.Errorf("error loading target categories: %w", err)
+	}
+	for _, path := range mergedKeys(sourceCategories, targetCategories) {
+		sourceCategory, inSource := sourceCategories[path]
+		targetCategory, inTarget := targetCategories[path]
+		switch {
+		case !inTarget:
+			diff.Categories = append(diff.Categories, CategoryDiff{Path: path, Kind: CatalogDiffMissing})
+		case !inSource:
+			diff.Categories = append(diff.Categories, CategoryDiff{Path: path, Kind: CatalogDiffExtra})
+		default:
+			if fields := diffFields(sourceCategory.fields, targetCategory.fields); len(fields) > 0 {
+				diff.Categories = append(diff.Categories, CategoryDiff{Path: path, Kind: CatalogDiffChanged, Fields: fields})
+			}
+		}
+	}
+	return diff, nil
+}
+
+// catalogEntry is what a snapshot keeps of an entity: its displayed key and the compared fields
+type catalogEntry struct {
+	key    string
+	fields map[string]string
+}
+
+// productSnapshot loads the compared fields of the products matching opts.Criteria, keyed by SkuKey
+func productSnapshot(ctx context.Context, opts CatalogDiffOptions, compared func(code string) bool, apiClient *Client) (map[string]catalogEntry, error) {
+	snapshot := map[string]catalogEntry{}
+	err := ForEachProductPage(ctx, opts.Criteria, opts.Workers, apiClient, func(products []Product) error {
+		for i := range products {
+			product := &products[i]
+			fields := map[string]string{
+				"name":       product.Name,
+				"type_id":    product.TypeID,
+				"price":      strconv.FormatFloat(product.Price, 'f', -1, 64),
+				"status":     strconv.Itoa(product.Status),
+				"visibility": strconv.Itoa(product.Visibility),
+				"weight":     strconv.FormatFloat(product.Weight, 'f', -1, 64),
+			}
+			for _, attribute := range product.CustomAttributes {
+				code, _ := attribute["attribute_code"].(string)
+				if code != "" && compared(code) {
+					fields[code] = product.CustomAttributeString(code)
+				}
+			}
+			snapshot[SkuKey(product.Sku)] = catalogEntry{key: product.Sku, fields: fields}
+		}
+		return nil
+	})
+	return snapshot, err
+}
+
+// categorySnapshot loads the subtree below rootID, keyed by url key path
+func categorySnapshot(ctx context.Context, rootID int, apiClient *Client) (map[string]catalogEntry, error) {
+	root, _, err := loadCategorySubtree(ctx, rootID, allStoresCode, apiClient)
+	if err != nil {
+		return nil, err
+	}
+	snapshot := map[string]catalogEntry{}
+	var walk func(node *categoryNode, parentPath string)
+	walk = func(node *categoryNode, parentPath string) {
+		for _, child := range node.children {
+			path := child.key()
+			if parentPath != "" {
+				path = parentPath + "/" + path
+			}
+			fields := map[string]string{
+				"name":            child.category.Name,
+				"is_active":       strconv.FormatBool(child.category.IsActive),
+				"include_in_menu": strconv.FormatBool(child.category.IncludeInMenu),
+				"position":        strconv.Itoa(child.category.Position),
+			}
+			for _, code := range CategorySpecAttributes {
+				fields[code] = child.attribute(code)
+			}
+			snapshot[path] = catalogEntry{key: path, fields: fields}
+			walk(child, path)
+		}
+	}
+	walk(root, "")
+	return snapshot, nil
+}
+
+// diffFields returns the fields whose values differ, ordered by field
+func diffFields(source, target map[string]string) []CatalogFieldDiff {
+	var diffs []CatalogFieldDiff
+	for _, field := range mergedKeys(source, target) {
+		if source[field] != target[field] {
+			diffs = append(diffs, CatalogFieldDiff{Field: field, Source: source[field], Target: target[field]})
+		}
+	}
+	return diffs
+}
+
+// mergedKeys returns the keys of both maps, sorted and without duplicates
+func mergedKeys[V any](a, b map[string]V) []string {
+	keys := sortedKeys(a)
+	for key := range b {
+		if _, ok := a[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	slices.Sort(keys)
+	return keys
+}
@@ -0,0 +1,252 @@
+package magento2
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+)
+
+// ReadCatalogSpec reads a spec from JSON
+func ReadCatalogSpec(r io.Reader) (*CatalogSpec, error) {
+	spec := &CatalogSpec{}
+	if err := json.NewDecoder(r).Decode(spec); err != nil {
+		return nil, fmt.Errorf("error reading catalog spec: %w", err)
+	}
+	return spec, nil
+}
+
+// PlanCatalogSpec diffs the spec against the remote and returns the changes needed: attributes to create,
+// labels to update and options to add, then attribute sets and groups to create and attributes to assign.
+// Nothing is changed, so the plan doubles as dry run. A spec changing the frontend input of an existing
+// attribute is rejected, Magento cannot convert stored values
+func PlanCatalogSpec(ctx context.Context, spec *CatalogSpec, apiClient *Client) (*CatalogPlan, error) {
+	plan := &CatalogPlan{Changes: []CatalogChange{}}
+	attributes := NewAttributeCache(apiClient)
+
+	log.Debug().
+		Int("attributes", len(spec.Attributes)).
+		Int("attributeSets", len(spec.AttributeSets)).
+		Msg("Planning catalog spec")
+
+	for i := range spec.Attributes {
+		attributeSpec := &spec.Attributes[i]
+		remote, err := attributes.Get(ctx, attributeSpec.Code)
+		if errors.Is(err, ErrNotFound) {
+			plan.Changes = append(plan.Changes, CatalogChange{Action: CatalogChangeCreateAttribute, AttributeCode: attributeSpec.Code, Attribute: attributeSpec})
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if attributeSpec.FrontendInput != "" && attributeSpec.FrontendInput != remote.FrontendInput {
+			return nil, &ValidationError{
+				Entity: "attribute spec " + attributeSpec.Code,
+				Field:  "frontend_input",
+				Reason: fmt.Sprintf("cannot change from %s to %s", remote.FrontendInput, attributeSpec.FrontendInput),
+			}
+		}
+		if attributeSpec.Label != "" && attributeSpec.Label != remote.DefaultFrontendLabel {
+			plan.Changes = append(plan.Changes, CatalogChange{Action: CatalogChangeUpdateAttribute, AttributeCode: attributeSpec.Code, Attribute: attributeSpec})
+		}
+		for _, label := range attributeSpec.Options {
+			if !hasOptionLabel(remote.Options, label) {
+				plan.Changes = append(plan.Changes, CatalogChange{Action: CatalogChangeAddOption, AttributeCode: attributeSpec.Code, Option: label})
+			}
+		}
+	}
+
+	for _, setSpec := range spec.AttributeSets {
+		mAttributeSet, err := GetAttributeSetByName(setSpec.Name, apiClient)
+		if err != nil && !errors.Is(err, ErrNotFound) {
+			return nil, err
+		}
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		groups := map[string]bool{}
+		assigned := map[string]bool{}
+		if mAttributeSet == nil {
+			plan.Changes = append(plan.Changes, CatalogChange{Action: CatalogChangeCreateAttributeSet, AttributeSet: setSpec.Name, Skeleton: setSpec.Skeleton})
+		} else {
+			for _, group := range mAttributeSet.AttributeSetGroups {
+				groups[strings.ToLower(group.AttributeGroupName)] = true
+			}
+			for _, attribute := range *mAttributeSet.AttributeSetAttributes {
+				assigned[attribute.AttributeCode] = true
+			}
+		}
+
+		for _, group := range setSpec.Groups {
+			if !groups[strings.ToLower(group.Name)] {
+				plan.Changes = append(plan.Changes, CatalogChange{Action: CatalogChangeCreateGroup, AttributeSet: setSpec.Name, Group: group.Name})
+			}
+			for sortOrder, code := range group.Attributes {
+				if !assigned[code] {
+					plan.Changes = append(plan.Changes, CatalogChange{Action: CatalogChangeAssignAttribute, AttributeSet: setSpec.Name, Group: group.Name, AttributeCode: code, SortOrder: sortOrder})
+				}
+			}
+		}
+	}
+	return plan, nil
+}
+
+func hasOptionLabel(options []Option, label string) bool {
+	for _, option := range options {
+		if strings.EqualFold(strings.TrimSpace(option.Label), strings.TrimSpace(label)) {
+			return true
+		}
+	}
+	return false
+}
+
+func (c CatalogChange) String() string {
+	switch c.Action {
+	case CatalogChangeCreateAttribute, CatalogChangeUpdateAttribute:
+		return fmt.Sprintf("%s %s (%s, %q)", c.Action, c.AttributeCode, c.Attribute.FrontendInput, c.Attribute.Label)
+	case CatalogChangeAddOption:
+		return fmt.Sprintf("%s %s %q", c.Action, c.AttributeCode, c.Option)
+	case CatalogChangeCreateAttributeSet:
+		return fmt.Sprintf("%s %q", c.Action, c.AttributeSet)
+	case CatalogChangeCreateGroup:
+		return fmt.Sprintf("%s %q / %q", c.Action, c.AttributeSet, c.Group)
+	case CatalogChangeAssignAttribute:
+		return fmt.Sprintf("%s %s -> %q / %q", c.Action, c.AttributeCode, c.AttributeSet, c.Group)
+	}
+	return string(c.Action)
+}
+
+// String lists the changes one per line, e.g. for the output of a dry run
+func (p *CatalogPlan) String() string {
+	var b strings.Builder
+	for _, change := range p.Changes {
+		b.WriteString(change.String())
+		b.WriteByte('\n')
+	}
+	return b.String()
+}
+
+// ApplyCatalogPlan runs the changes of the plan in order and stops at the first failure, returning the
+// number of changes applied. Planning and applying again picks up where a failed run stopped
+func ApplyCatalogPlan(ctx context.Context, plan *CatalogPlan, apiClient *Client) (int, error) {
+	sets := map[string]*MAttributeSet{}
+	attributeSet := func(name string) (*MAttributeSet, error) {
+		if mAttributeSet, ok := sets[name]; ok {
+			return mAttributeSet, nil
+		}
+		mAttributeSet, err := GetAttributeSetByName(name, apiClient)
+		if err != nil {
+			return nil, fmt.Errorf("error getting attribute set %s: %w", name, err)
+		}
+		sets[name] = mAttributeSet
+		return mAttributeSet, nil
+	}
+
+	for i, change := range plan.Changes {
+		if err := ctx.Err(); err != nil {
+			return i, err
+		}
+
+		log.Info().
+			Str("action", string(change.Action)).
+			Str("attributeCode", change.AttributeCode).
+			Str("attributeSet", change.AttributeSet).
+			Str("group", change.Group).
+			Msg("Applying catalog change")
+
+		var err error
+		switch change.Action {
+		case CatalogChangeCreateAttribute:
+			_, err = CreateAttribute(change.Attribute.attribute(), apiClient)
+		case CatalogChangeUpdateAttribute:
+			var mAttribute *MAttribute
+			if mAttribute, err = GetAttributeByAttributeCode(change.AttributeCode, apiClient); err == nil {
+				mAttribute.Attribute.DefaultFrontendLabel = change.Attribute.Label
+				err = mAttribute.UpdateAttributeOnRemote()
+			}
+		case CatalogChangeAddOption:
+			var mAttribute *MAttribute
+			if mAttribute, err = GetAttributeByAttributeCode(change.AttributeCode, apiClient); err == nil {
+				_, err = mAttribute.AddOption(Option{Label: change.Option})
+			}
+		case CatalogChangeCreateAttributeSet:
+			var mAttributeSet *MAttributeSet
+			if mAttributeSet, err = createAttributeSetFromSkeleton(ctx, change.AttributeSet, change.Skeleton, apiClient); err == nil {
+				sets[change.AttributeSet] = mAttributeSet
+			}
+		case CatalogChangeCreateGroup:
+			var mAttributeSet *MAttributeSet
+			if mAttributeSet, err = attributeSet(change.AttributeSet); err == nil {
+				// a set created from a skeleton may already have the group
+				if _, findErr := mAttributeSet.FindGroupByName(change.Group); findErr != nil {
+					err = mAttributeSet.CreateGroup(change.Group)
+				}
+			}
+		case CatalogChangeAssignAttribute:
+			var mAttributeSet *MAttributeSet
+			if mAttributeSet, err = attributeSet(change.AttributeSet); err == nil {
+				err = assignAttributeToGroup(mAttributeSet, change)
+			}
+		default:
+			err = fmt.Errorf("unknown catalog change action %q", change.Action)
+		}
+		if err != nil {
+			return i, fmt.Errorf("error applying %s: %w", change, err)
+		}
+	}
+	return len(plan.Changes), nil
+}
+
+func (s *AttributeSpec) attribute() *Attribute {
+	attribute := &Attribute{
+		AttributeCode:        s.Code,
+		FrontendInput:        s.FrontendInput,
+		DefaultFrontendLabel: s.Label,
+		Scope:                s.Scope,
+		IsRequired:           s.Required,
+		IsUserDefined:        true,
+	}
+	for i, label := range s.Options {
+		attribute.Options = append(attribute.Options, Option{Label: label, SortOrder: i})
+	}
+	return attribute
+}
+
+func createAttributeSetFromSkeleton(ctx context.Context, name, skeleton string, apiClient *Client) (*MAttributeSet, error) {
+	var skeletonID int
+	if skeleton == "" {
+		id, err := DefaultAttributeSetSkeletonID(ctx, EntityTypeProduct, apiClient)
+		if err != nil {
+			return nil, fmt.Errorf("error getting default attribute set: %w", err)
+		}
+		skeletonID = id
+	} else {
+		mSkeleton, err := GetAttributeSetByName(skeleton, apiClient)
+		if err != nil {
+			return nil, fmt.Errorf("error getting skeleton attribute set %s: %w", skeleton, err)
+		}
+		skeletonID = mSkeleton.AttributeSet.AttributeSetID
+	}
+	return CreateAttributeSet(AttributeSet{AttributeSetName: name, EntityTypeID: EntityTypeProduct}, skeletonID, apiClient)
+}
+
+func assignAttributeToGroup(mAttributeSet *MAttributeSet, change CatalogChange) error {
+	for _, group := range mAttributeSet.AttributeSetGroups {
+		if !strings.EqualFold(group.AttributeGroupName, change.Group) {
+			continue
+		}
+		groupID, err := strconv.Atoi(group.AttributeGroupID)
+		if err != nil {
+			return fmt.Errorf("error parsing group id %q: %w", group.AttributeGroupID, err)
+		}
+		return mAttributeSet.AssignAttribute(groupID, change.SortOrder, change.AttributeCode)
+	}
+	return fmt.Errorf("%w: group %s of attribute set %s", ErrNotFound, change.Group, change.AttributeSet)
+}
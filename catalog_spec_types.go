@@ -0,0 +1,62 @@
+package magento2
+
+// CatalogSpec declares the product attributes and attribute sets an environment needs. Like CategorySpec
+// it carries yaml tags next to the json tags
+type CatalogSpec struct {
+	Attributes    []AttributeSpec    `json:"attributes,omitempty" yaml:"attributes,omitempty"`
+	AttributeSets []AttributeSetSpec `json:"attribute_sets,omitempty" yaml:"attribute_sets,omitempty"`
+}
+
+// AttributeSpec declares a product attribute. Options lists the labels of select and multiselect options,
+// options present on remote but missing in the spec are kept
+type AttributeSpec struct {
+	Code          string   `json:"code" yaml:"code"`
+	Label         string   `json:"label" yaml:"label"`
+	FrontendInput string   `json:"frontend_input" yaml:"frontend_input"`
+	Scope         string   `json:"scope,omitempty" yaml:"scope,omitempty"`
+	Required      bool     `json:"required,omitempty" yaml:"required,omitempty"`
+	Options       []string `json:"options,omitempty" yaml:"options,omitempty"`
+}
+
+// AttributeSetSpec declares an attribute set by name. A missing set is created from the set named Skeleton,
+// or from the default set when it is empty
+type AttributeSetSpec struct {
+	Name     string               `json:"name" yaml:"name"`
+	Skeleton string               `json:"skeleton,omitempty" yaml:"skeleton,omitempty"`
+	Groups   []AttributeGroupSpec `json:"groups,omitempty" yaml:"groups,omitempty"`
+}
+
+// AttributeGroupSpec lists the attributes of a group of an attribute set in sort order
+type AttributeGroupSpec struct {
+	Name       string   `json:"name" yaml:"name"`
+	Attributes []string `json:"attributes,omitempty" yaml:"attributes,omitempty"`
+}
+
+// CatalogChangeAction is the kind of a planned catalog change
+type CatalogChangeAction string
+
+const (
+	CatalogChangeCreateAttribute    CatalogChangeAction = "create_attribute"
+	CatalogChangeUpdateAttribute    CatalogChangeAction = "update_attribute"
+	CatalogChangeAddOption          CatalogChangeAction = "add_option"
+	CatalogChangeCreateAttributeSet CatalogChangeAction = "create_attribute_set"
+	CatalogChangeCreateGroup        CatalogChangeAction = "create_group"
+	CatalogChangeAssignAttribute    CatalogChangeAction = "assign_attribute"
+)
+
+// CatalogChange is one step of a CatalogPlan. Only the fields the action needs are set
+type CatalogChange struct {
+	Action        CatalogChangeAction `json:"action"`
+	AttributeCode string              `json:"attribute_code,omitempty"`
+	AttributeSet  string              `json:"attribute_set,omitempty"`
+	Group         string              `json:"group,omitempty"`
+	Option        string              `json:"option,omitempty"`
+	SortOrder     int                 `json:"sort_order,omitempty"`
+	Attribute     *AttributeSpec      `json:"attribute,omitempty"`
+	Skeleton      string              `json:"skeleton,omitempty"`
+}
+
+// CatalogPlan lists the changes that make the remote match a CatalogSpec, in the order they are applied
+type CatalogPlan struct {
+	Changes []CatalogChange `json:"changes"`
+}
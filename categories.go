@@ -1,7 +1,9 @@
 package magento2
 
 import (
+	"context"
 	"fmt"
+	"strconv"
 
 	"github.com/rs/zerolog/log"
 )
@@ -13,21 +15,34 @@ type MCategory struct {
 	APIClient *Client
 }
 
-func CreateCategory(c *Category, apiClient *Client) (*MCategory, error) {
+// CreateCategory creates the category. Pass WithStoreCode to create it with the names and meta data of
+// a store view, which Magento then keeps as that store view's values
+func CreateCategory(c *Category, apiClient *Client, opts ...RequestOption) (*MCategory, error) {
 	mC := &MCategory{
 		Category:  &Category{},
 		Products:  &[]ProductLink{},
 		APIClient: apiClient,
 	}
-	endpoint := categories
+	o := newRequestOptions(opts)
+	endpoint := o.endpoint(apiClient, categories)
 	httpClient := apiClient.HTTPClient
 
+	if apiClient.ValidatePayloads {
+		if err := c.Validate(); err != nil {
+			return mC, err
+		}
+	}
+
 	payLoad := createCategoryPayload{
 		Category: *c,
 	}
+	if apiClient.runID != "" && c.ID == 0 {
+		payLoad.Category = c.stampRunID(apiClient.runID)
+	}
 
 	log.Debug().
 		Interface("payload", payLoad).
+		Str("storeCode", o.storeCode).
 		Str("endpoint", endpoint).
 		Msg("Creating category")
 
@@ -172,3 +187,174 @@ func (mC *MCategory) AssignProductByProductLink(pl *ProductLink) error {
 
 	return nil
 }
+
+func GetCategoryByID(ctx context.Context, id int, apiClient *Client, opts ...RequestOption) (*MCategory, error) {
+	mC := &MCategory{
+		Route:     fmt.Sprintf("%s/%d", categories, id),
+		Category:  &Category{},
+		Products:  &[]ProductLink{},
+		APIClient: apiClient,
+	}
+	o := newRequestOptions(opts)
+	endpoint := o.endpoint(apiClient, mC.Route)
+
+	log.Debug().
+		Int("categoryID", id).
+		Str("storeCode", o.storeCode).
+		Str("endpoint", endpoint).
+		Msg("Getting category by ID")
+
+	req, cancel := o.newRequest(ctx, apiClient)
+	defer cancel()
+
+	resp, err := req.SetResult(mC.Category).Get(endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("error getting category by ID: %w", err)
+	}
+
+	httpErr := mayReturnErrorForHTTPResponse(resp, "get category by id from remote")
+	if httpErr != nil {
+		return nil, httpErr
+	}
+
+	err = mC.UpdateCategoryProductsFromRemote()
+	if err != nil {
+		return mC, fmt.Errorf("error updating category products from remote after getting by ID: %w", err)
+	}
+
+	return mC, nil
+}
+
+// UpdateCategoryOnRemote saves the local category. Pass WithStoreCode to store localized
+// names and meta data on a store view instead of the default scope
+func (mC *MCategory) UpdateCategoryOnRemote(ctx context.Context, opts ...RequestOption) error {
+	o := newRequestOptions(opts)
+	endpoint := o.endpoint(mC.APIClient, mC.Route)
+
+	payLoad := updateCategoryPayload{
+		Category: *mC.Category,
+	}
+
+	log.Debug().
+		Int("categoryID", mC.Category.ID).
+		Str("storeCode", o.storeCode).
+		Str("endpoint", endpoint).
+		Interface("payload", payLoad).
+		Msg("Updating category on remote")
+
+	req, cancel := o.newRequest(ctx, mC.APIClient)
+	defer cancel()
+
+	resp, err := req.SetBody(payLoad).SetResult(mC.Category).Put(endpoint)
+	if err != nil {
+		log.Error().Err(err).Msg("Error updating category on remote")
+		return fmt.Errorf("error updating category on remote: %w", err)
+	}
+
+	httpErr := mayReturnErrorForHTTPResponse(resp, "update remote category from local")
+	if httpErr != nil {
+		return httpErr
+	}
+	return nil
+}
+
+func (mC *MCategory) Delete(ctx context.Context, opts ...RequestOption) error {
+	o := newRequestOptions(opts)
+	endpoint := o.endpoint(mC.APIClient, mC.Route)
+
+	log.Debug().
+		Int("categoryID", mC.Category.ID).
+		Str("endpoint", endpoint).
+		Msg("Deleting category")
+
+	req, cancel := o.newRequest(ctx, mC.APIClient)
+	defer cancel()
+
+	resp, err := req.Delete(endpoint)
+	if err != nil {
+		log.Error().Err(err).Msg("Error deleting category")
+		return fmt.Errorf("error deleting category: %w", err)
+	}
+
+	httpErr := mayReturnErrorForHTTPResponse(resp, "delete category")
+	if httpErr != nil {
+		return httpErr
+	}
+	return nil
+}
+
+// Move moves the category below parentID, after the sibling afterID. An afterID of 0 places it first
+func (mC *MCategory) Move(ctx context.Context, parentID, afterID int, opts ...RequestOption) error {
+	o := newRequestOptions(opts)
+	endpoint := o.endpoint(mC.APIClient, mC.Route+"/"+categoriesMoveRelative)
+
+	payLoad := moveCategoryPayload{
+		ParentID: parentID,
+		AfterID:  afterID,
+	}
+
+	log.Debug().
+		Int("categoryID", mC.Category.ID).
+		Int("parentID", parentID).
+		Int("afterID", afterID).
+		Str("endpoint", endpoint).
+		Msg("Moving category")
+
+	req, cancel := o.newRequest(ctx, mC.APIClient)
+	defer cancel()
+
+	resp, err := req.SetBody(payLoad).Put(endpoint)
+	if err != nil {
+		return fmt.Errorf("error moving category: %w", err)
+	}
+
+	httpErr := mayReturnErrorForHTTPResponse(resp, "move category")
+	if httpErr != nil {
+		return httpErr
+	}
+	mC.Category.ParentID = parentID
+	return nil
+}
+
+// MaxCategoryTreeDepth bounds GetCategoryTree. Magento returns the whole tree when no depth is given,
+// which on large catalogs is easily a response of hundreds of megabytes
+const MaxCategoryTreeDepth = 10
+
+// GetCategoryTree returns the category tree below rootID, depth levels deep
+func GetCategoryTree(ctx context.Context, rootID, depth int, apiClient *Client, opts ...RequestOption) (*CategoryTree, error) {
+	if depth < 1 || depth > MaxCategoryTreeDepth {
+		return nil, &ValidationError{Entity: "category tree", Field: "depth", Reason: fmt.Sprintf("must be between 1 and %d", MaxCategoryTreeDepth)}
+	}
+	o := newRequestOptions(opts)
+	endpoint := o.endpoint(apiClient, categories)
+
+	log.Debug().
+		Int("rootID", rootID).
+		Int("depth", depth).
+		Str("endpoint", endpoint).
+		Msg("Getting category tree")
+
+	req, cancel := o.newRequest(ctx, apiClient)
+	defer cancel()
+
+	tree := &CategoryTree{}
+	resp, err := req.
+		SetQueryParam("rootCategoryId", strconv.Itoa(rootID)).
+		SetQueryParam("depth", strconv.Itoa(depth)).
+		SetResult(tree).
+		Get(endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("error getting category tree: %w", err)
+	}
+
+	httpErr := mayReturnErrorForHTTPResponse(resp, "get category tree")
+	if httpErr != nil {
+		return nil, httpErr
+	}
+	return tree, nil
+}
+
+// SearchCategories returns all categories matching the criteria, paging through the results
+func SearchCategories(ctx context.Context, criteria *SearchCriteria, apiClient *Client) ([]Category, error) {
+	return searchAll[Category](ctx, categoriesList, criteria, "search categories", apiClient)
+}
@@ -4,4 +4,5 @@ const (
 	categories                 = "/categories"
 	categoriesList             = "/categories/list"
 	categoriesProductsRelative = "products"
+	categoriesMoveRelative     = "move"
 )
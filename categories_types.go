@@ -17,6 +17,18 @@ type Category struct {
 	CustomAttributes    []CustomAttributes `json:"custom_attributes,omitempty"`
 }
 
+// CategoryTree is a node of the tree returned by GetCategoryTree
+type CategoryTree struct {
+	ID           int            `json:"id"`
+	ParentID     int            `json:"parent_id"`
+	Name         string         `json:"name"`
+	IsActive     bool           `json:"is_active"`
+	Position     int            `json:"position"`
+	Level        int            `json:"level"`
+	ProductCount int            `json:"product_count"`
+	ChildrenData []CategoryTree `json:"children_data"`
+}
+
 type ProductLink struct {
 	Sku                 string                 `json:"sku"`
 	Position            int                    `json:"position"`
@@ -28,6 +40,10 @@ type createCategoryPayload struct {
 	Category Category `json:"category"`
 }
 
+type updateCategoryPayload struct {
+	Category Category `json:"category"`
+}
+
 type assignProductPayload struct {
 	ProductLink ProductLink `json:"productLink"`
 }
@@ -44,3 +60,13 @@ type categorySearchQueryResponse struct {
 		} `json:"filter_groups"`
 	} `json:"search_criteria"`
 }
+
+type moveCategoryPayload struct {
+	ParentID int `json:"parentId"`
+	AfterID  int `json:"afterId,omitempty"`
+}
+
+// partialCategoryPayload sends only the given fields, so false and zero values are saved too
+type partialCategoryPayload struct {
+	Category map[string]any `json:"category"`
+}
@@ -0,0 +1,566 @@
+package magento2
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+)
+
+const categoryURLKeyAttributeCode = "url_key"
+
+// CategorySpecAttributes are the custom attributes ExportCategoryTree puts into a spec
+var CategorySpecAttributes = []string{"description", "meta_title", "meta_keywords", "meta_description", "display_mode", "is_anchor"}
+
+// categoryNode is a category of a loaded subtree
+type categoryNode struct {
+	category Category
+	parent   *categoryNode
+	children []*categoryNode
+	matched  bool
+}
+
+func (n *categoryNode) attribute(code string) string {
+	for _, attribute := range n.category.CustomAttributes {
+		if attribute.AttributeCode == code {
+			return attribute.Value
+		}
+	}
+	return ""
+}
+
+func (n *categoryNode) isAncestorOf(other *categoryNode) bool {
+	for ; other != nil; other = other.parent {
+		if other == n {
+			return true
+		}
+	}
+	return false
+}
+
+func (n *categoryNode) key() string {
+	return categorySpecKey(n.attribute(categoryURLKeyAttributeCode), n.category.Name)
+}
+
+// categorySpecKey identifies a category among its siblings: by url key, or by name if it has none
+func categorySpecKey(urlKey, name string) string {
+	if urlKey != "" {
+		return urlKey
+	}
+	return "name:" + strings.ToLower(strings.TrimSpace(name))
+}
+
+// loadCategorySubtree loads the root and all categories below it as seen by the store view
+func loadCategorySubtree(ctx context.Context, rootID int, storeCode string, apiClient *Client) (*categoryNode, map[int]*categoryNode, error) {
+	route := newRequestOptions([]RequestOption{WithStoreCode(storeCode)}).endpoint(apiClient, categoriesList)
+	tryTo := "load category subtree of store " + storeCode
+
+	roots, err := searchAll[Category](ctx, route, NewSearchCriteria(SearchFilter{Field: "entity_id", Value: strconv.Itoa(rootID), ConditionType: "eq"}), tryTo, apiClient)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(roots) == 0 {
+		return nil, nil, fmt.Errorf("%w: category %d", ErrNotFound, rootID)
+	}
+	root := &categoryNode{category: roots[0]}
+
+	subtree, err := searchAll[Category](ctx, route, NewSearchCriteria(SearchFilter{Field: "path", Value: root.category.Path + "/%", ConditionType: "like"}), tryTo, apiClient)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	nodes := make(map[int]*categoryNode, len(subtree)+1)
+	nodes[rootID] = root
+	for _, category := range subtree {
+		nodes[category.ID] = &categoryNode{category: category}
+	}
+	for _, category := range subtree {
+		node := nodes[category.ID]
+		parent, ok := nodes[category.ParentID]
+		if !ok {
+			continue
+		}
+		node.parent = parent
+		parent.children = append(parent.children, node)
+	}
+	for _, node := range nodes {
+		sort.SliceStable(node.children, func(i, j int) bool {
+			return node.children[i].category.Position < node.children[j].category.Position
+		})
+	}
+	return root, nodes, nil
+}
+
+// ExportCategoryTree dumps the category rootID and its subtree into a spec, with the values the given
+// store views override
+func ExportCategoryTree(ctx context.Context, rootID int, storeCodes []string, apiClient *Client) (*CategorySpec, error) {
+	log.Debug().Int("rootID", rootID).Strs("storeCodes", storeCodes).Msg("Exporting category tree")
+
+	root, _, err := loadCategorySubtree(ctx, rootID, allStoresCode, apiClient)
+	if err != nil {
+		return nil, err
+	}
+	spec := specFromNode(root)
+
+	for _, storeCode := range storeCodes {
+		_, scoped, err := loadCategorySubtree(ctx, rootID, storeCode, apiClient)
+		if err != nil {
+			return nil, err
+		}
+		addStoreViewSpecs(spec, root, scoped, storeCode)
+	}
+	return spec, nil
+}
+
+func specFromNode(node *categoryNode) *CategorySpec {
+	spec := &CategorySpec{
+		Name:          node.category.Name,
+		URLKey:        node.attribute(categoryURLKeyAttributeCode),
+		IsActive:      node.category.IsActive,
+		IncludeInMenu: node.category.IncludeInMenu,
+		Position:      node.category.Position,
+		Attributes:    specAttributes(node),
+	}
+	for _, child := range node.children {
+		spec.Children = append(spec.Children, *specFromNode(child))
+	}
+	return spec
+}
+
+func specAttributes(node *categoryNode) map[string]string {
+	attributes := map[string]string{}
+	for _, code := range CategorySpecAttributes {
+		if value := node.attribute(code); value != "" {
+			attributes[code] = value
+		}
+	}
+	if len(attributes) == 0 {
+		return nil
+	}
+	return attributes
+}
+
+// addStoreViewSpecs records the values of the store view that differ from the default scope
+func addStoreViewSpecs(spec *CategorySpec, node *categoryNode, scoped map[int]*categoryNode, storeCode string) {
+	if scopedNode, ok := scoped[node.category.ID]; ok {
+		storeSpec := CategoryStoreSpec{}
+		if scopedNode.category.Name != node.category.Name {
+			storeSpec.Name = scopedNode.category.Name
+		}
+		if urlKey := scopedNode.attribute(categoryURLKeyAttributeCode); urlKey != node.attribute(categoryURLKeyAttributeCode) {
+			storeSpec.URLKey = urlKey
+		}
+		for _, code := range CategorySpecAttributes {
+			if value := scopedNode.attribute(code); value != node.attribute(code) {
+				if storeSpec.Attributes == nil {
+					storeSpec.Attributes = map[string]string{}
+				}
+				storeSpec.Attributes[code] = value
+			}
+		}
+		if storeSpec.Name != "" || storeSpec.URLKey != "" || len(storeSpec.Attributes) > 0 {
+			if spec.StoreViews == nil {
+				spec.StoreViews = map[string]CategoryStoreSpec{}
+			}
+			spec.StoreViews[storeCode] = storeSpec
+		}
+	}
+	for i, child := range node.children {
+		addStoreViewSpecs(&spec.Children[i], child, scoped, storeCode)
+	}
+}
+
+// WriteCategorySpec writes the spec as indented JSON
+func WriteCategorySpec(w io.Writer, spec *CategorySpec) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(spec); err != nil {
+		return fmt.Errorf("error writing category spec: %w", err)
+	}
+	return nil
+}
+
+// ReadCategorySpec reads a spec written by WriteCategorySpec
+func ReadCategorySpec(r io.Reader) (*CategorySpec, error) {
+	spec := &CategorySpec{}
+	if err := json.NewDecoder(r).Decode(spec); err != nil {
+		return nil, fmt.Errorf("error reading category spec: %w", err)
+	}
+	return spec, nil
+}
+
+// WriteCategorySpecYAML writes the spec as YAML, e.g. to keep the tree under version control
+func WriteCategorySpecYAML(w io.Writer, spec *CategorySpec) error {
+	if err := writeYAML(w, spec); err != nil {
+		return fmt.Errorf("error writing category spec: %w", err)
+	}
+	return nil
+}
+
+// ReadCategorySpecYAML reads a spec written by WriteCategorySpecYAML or by hand
+func ReadCategorySpecYAML(r io.Reader) (*CategorySpec, error) {
+	spec := &CategorySpec{}
+	if err := readYAML(r, spec); err != nil {
+		return nil, fmt.Errorf("error reading category spec: %w", err)
+	}
+	return spec, nil
+}
+
+// String lists the changes one per line, e.g. for the output of a dry run
+func (p *CategoryPlan) String() string {
+	var b strings.Builder
+	for _, change := range p.Changes {
+		fmt.Fprintf(&b, "%-6s %s", change.Action, change.Path)
+		if change.StoreCode != "" {
+			fmt.Fprintf(&b, " [%s]", change.StoreCode)
+		}
+		if len(change.Fields) > 0 {
+			fmt.Fprintf(&b, " (%s)", strings.Join(change.Fields, ", "))
+		}
+		b.WriteByte('\n')
+	}
+	return b.String()
+}
+
+// ApplyCategoryTree makes the subtree below targetRootID match the children of the spec: categories are
+// matched among siblings by url key, or by name without one. Categories found elsewhere in the subtree are
+// moved, missing ones created and changed ones updated, per store view too. The root itself is left
+// unchanged. The returned plan lists the changes made, or those a dry run would make
+func ApplyCategoryTree(ctx context.Context, spec *CategorySpec, targetRootID int, opts ApplyCategoryTreeOptions, apiClient *Client) (*CategoryPlan, error) {
+	root, nodes, err := loadCategorySubtree(ctx, targetRootID, allStoresCode, apiClient)
+	if err != nil {
+		return nil, err
+	}
+	root.matched = true
+
+	a := &categoryApplier{
+		apiClient: apiClient,
+		opts:      opts,
+		plan:      &CategoryPlan{Changes: []CategoryChange{}},
+		byKey:     map[string][]*categoryNode{},
+		scoped:    map[string]map[int]*categoryNode{},
+	}
+	ids := sortedKeys(nodes)
+	for _, id := range ids {
+		if node := nodes[id]; node != root {
+			a.byKey[node.key()] = append(a.byKey[node.key()], node)
+		}
+	}
+	for _, storeCode := range specStoreCodes(spec) {
+		_, scoped, err := loadCategorySubtree(ctx, targetRootID, storeCode, apiClient)
+		if err != nil {
+			return a.plan, err
+		}
+		a.scoped[storeCode] = scoped
+	}
+
+	log.Debug().
+		Int("targetRootID", targetRootID).
+		Int("existing", len(nodes)-1).
+		Bool("dryRun", opts.DryRun).
+		Msg("Applying category tree")
+
+	if err := a.applyChildren(ctx, spec.Children, root, ""); err != nil {
+		return a.plan, err
+	}
+
+	if opts.Delete {
+		for _, id := range ids {
+			node := nodes[id]
+			// deleting the topmost unmatched category deletes its subtree
+			if node.matched || node.parent == nil || !node.parent.matched {
+				continue
+			}
+			if err := a.delete(ctx, node); err != nil {
+				return a.plan, err
+			}
+		}
+	}
+	return a.plan, nil
+}
+
+func specStoreCodes(spec *CategorySpec) []string {
+	codes := map[string]bool{}
+	var collect func(s *CategorySpec)
+	collect = func(s *CategorySpec) {
+		for code := range s.StoreViews {
+			codes[code] = true
+		}
+		for i := range s.Children {
+			collect(&s.Children[i])
+		}
+	}
+	for i := range spec.Children {
+		collect(&spec.Children[i])
+	}
+	return sortedKeys(codes)
+}
+
+type categoryApplier struct {
+	apiClient *Client
+	opts      ApplyCategoryTreeOptions
+	plan      *CategoryPlan
+	byKey     map[string][]*categoryNode
+	scoped    map[string]map[int]*categoryNode
+}
+
+func (a *categoryApplier) applyChildren(ctx context.Context, specs []CategorySpec, parent *categoryNode, parentPath string) error {
+	for i := range specs {
+		spec := &specs[i]
+		path := strings.TrimPrefix(parentPath+"/"+spec.Name, "/")
+		key := categorySpecKey(spec.URLKey, spec.Name)
+
+		node := findUnmatched(parent.children, key)
+		if node == nil {
+			if node = findUnmatched(a.byKey[key], key); node != nil && !node.isAncestorOf(parent) {
+				if err := a.move(ctx, node, parent, path); err != nil {
+					return err
+				}
+			} else {
+				node = nil
+			}
+		}
+
+		if node == nil {
+			created, err := a.create(ctx, spec, parent, path)
+			if err != nil {
+				return err
+			}
+			node = created
+		} else {
+			node.matched = true
+			if err := a.update(ctx, spec, node, path); err != nil {
+				return err
+			}
+		}
+
+		for _, storeCode := range sortedKeys(spec.StoreViews) {
+			if err := a.updateStoreView(ctx, spec.StoreViews[storeCode], node, storeCode, path); err != nil {
+				return err
+			}
+		}
+
+		if err := a.applyChildren(ctx, spec.Children, node, path); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func findUnmatched(nodes []*categoryNode, key string) *categoryNode {
+	for _, node := range nodes {
+		if !node.matched && node.key() == key {
+			return node
+		}
+	}
+	return nil
+}
+
+func (a *categoryApplier) record(change CategoryChange) {
+	log.Info().
+		Str("action", string(change.Action)).
+		Str("path", change.Path).
+		Int("categoryID", change.CategoryID).
+		Str("storeCode", change.StoreCode).
+		Strs("fields", change.Fields).
+		Bool("dryRun", a.opts.DryRun).
+		Msg("Category change")
+	a.plan.Changes = append(a.plan.Changes, change)
+}
+
+func (a *categoryApplier) create(ctx context.Context, spec *CategorySpec, parent *categoryNode, path string) (*categoryNode, error) {
+	category := Category{
+		ParentID:      parent.category.ID,
+		Name:          spec.Name,
+		IsActive:      spec.IsActive,
+		IncludeInMenu: spec.IncludeInMenu,
+		Position:      spec.Position,
+	}
+	for _, code := range sortedKeys(spec.Attributes) {
+		category.CustomAttributes = append(category.CustomAttributes, CustomAttributes{AttributeCode: code, Value: spec.Attributes[code]})
+	}
+	if spec.URLKey != "" {
+		category.CustomAttributes = append(category.CustomAttributes, CustomAttributes{AttributeCode: categoryURLKeyAttributeCode, Value: spec.URLKey})
+	}
+	if a.apiClient.runID != "" {
+		category = category.stampRunID(a.apiClient.runID)
+	}
+
+	node := &categoryNode{category: category, parent: parent, matched: true}
+	parent.children = append(parent.children, node)
+	if !a.opts.DryRun {
+		payload := categoryPayloadFields(&category)
+		saved, err := a.save(ctx, http.MethodPost, categories, allStoresCode, payload)
+		if err != nil {
+			return nil, fmt.Errorf("error creating category %s: %w", path, err)
+		}
+		node.category.ID = saved.ID
+	}
+	a.record(CategoryChange{Action: CategoryChangeCreate, Path: path, CategoryID: node.category.ID})
+	return node, nil
+}
+
+func (a *categoryApplier) update(ctx context.Context, spec *CategorySpec, node *categoryNode, path string) error {
+	fields := map[string]any{}
+	if spec.Name != node.category.Name {
+		fields["name"] = spec.Name
+	}
+	if spec.IsActive != node.category.IsActive {
+		fields["is_active"] = spec.IsActive
+	}
+	if spec.IncludeInMenu != node.category.IncludeInMenu {
+		fields["include_in_menu"] = spec.IncludeInMenu
+	}
+	if spec.Position != node.category.Position {
+		fields["position"] = spec.Position
+	}
+	attributes := changedAttributes(node, spec.URLKey, spec.Attributes)
+	return a.saveChanges(ctx, node, allStoresCode, path, fields, attributes)
+}
+
+func (a *categoryApplier) updateStoreView(ctx context.Context, storeSpec CategoryStoreSpec, node *categoryNode, storeCode, path string) error {
+	scopedNode := node
+	if scoped, ok := a.scoped[storeCode][node.category.ID]; ok {
+		scopedNode = scoped
+	}
+	fields := map[string]any{}
+	if storeSpec.Name != "" && storeSpec.Name != scopedNode.category.Name {
+		fields["name"] = storeSpec.Name
+	}
+	attributes := changedAttributes(scopedNode, storeSpec.URLKey, storeSpec.Attributes)
+	return a.saveChanges(ctx, node, storeCode, path, fields, attributes)
+}
+
+// changedAttributes returns the attributes whose value differs, an empty url key counts as unchanged
+func changedAttributes(node *categoryNode, urlKey string, attributes map[string]string) map[string]string {
+	changed := map[string]string{}
+	for code, value := range attributes {
+		if node.attribute(code) != value {
+			changed[code] = value
+		}
+	}
+	if urlKey != "" && node.attribute(categoryURLKeyAttributeCode) != urlKey {
+		changed[categoryURLKeyAttributeCode] = urlKey
+	}
+	return changed
+}
+
+func (a *categoryApplier) saveChanges(ctx context.Context, node *categoryNode, storeCode, path string, fields map[string]any, attributes map[string]string) error {
+	if len(fields) == 0 && len(attributes) == 0 {
+		return nil
+	}
+	changed := sortedKeys(fields)
+	customAttributes := []CustomAttributes{}
+	for _, code := range sortedKeys(attributes) {
+		changed = append(changed, code)
+		customAttributes = append(customAttributes, CustomAttributes{AttributeCode: code, Value: attributes[code]})
+	}
+
+	change := CategoryChange{Action: CategoryChangeUpdate, Path: path, CategoryID: node.category.ID, Fields: changed}
+	if storeCode != allStoresCode {
+		change.StoreCode = storeCode
+	}
+	if !a.opts.DryRun && node.category.ID != 0 {
+		fields["id"] = node.category.ID
+		if len(customAttributes) > 0 {
+			fields["custom_attributes"] = customAttributes
+		}
+		if _, err := a.save(ctx, http.MethodPut, fmt.Sprintf("%s/%d", categories, node.category.ID), storeCode, fields); err != nil {
+			return fmt.Errorf("error updating category %s: %w", path, err)
+		}
+	}
+	a.record(change)
+	return nil
+}
+
+func (a *categoryApplier) move(ctx context.Context, node, parent *categoryNode, path string) error {
+	if node.parent != nil {
+		node.parent.children = slices.DeleteFunc(node.parent.children, func(child *categoryNode) bool { return child == node })
+	}
+	node.parent = parent
+	parent.children = append(parent.children, node)
+
+	if !a.opts.DryRun && parent.category.ID != 0 {
+		mC := &MCategory{
+			Route:     fmt.Sprintf("%s/%d", categories, node.category.ID),
+			Category:  &node.category,
+			APIClient: a.apiClient,
+		}
+		if err := mC.Move(ctx, parent.category.ID, 0, WithStoreCode(allStoresCode)); err != nil {
+			return fmt.Errorf("error moving category %s: %w", path, err)
+		}
+	}
+	node.category.ParentID = parent.category.ID
+	a.record(CategoryChange{Action: CategoryChangeMove, Path: path, CategoryID: node.category.ID})
+	return nil
+}
+
+func (a *categoryApplier) delete(ctx context.Context, node *categoryNode) error {
+	names := []string{}
+	for n := node; n.parent != nil; n = n.parent {
+		names = append([]string{n.category.Name}, names...)
+	}
+	path := strings.Join(names, "/")
+
+	if !a.opts.DryRun {
+		mC := &MCategory{
+			Route:     fmt.Sprintf("%s/%d", categories, node.category.ID),
+			Category:  &node.category,
+			APIClient: a.apiClient,
+		}
+		if err := mC.Delete(ctx, WithStoreCode(allStoresCode)); err != nil {
+			return fmt.Errorf("error deleting category %s: %w", path, err)
+		}
+	}
+	a.record(CategoryChange{Action: CategoryChangeDelete, Path: path, CategoryID: node.category.ID})
+	return nil
+}
+
+// save sends the given fields only, so false and zero values are saved too
+func (a *categoryApplier) save(ctx context.Context, method, route, storeCode string, fields map[string]any) (*Category, error) {
+	o := newRequestOptions([]RequestOption{WithStoreCode(storeCode)})
+	endpoint := o.endpoint(a.apiClient, route)
+
+	req, cancel := o.newRequest(ctx, a.apiClient)
+	defer cancel()
+
+	saved := &Category{}
+	resp, err := req.SetBody(partialCategoryPayload{Category: fields}).SetResult(saved).Execute(method, endpoint)
+	if err != nil {
+		return nil, err
+	}
+	httpErr := mayReturnErrorForHTTPResponse(resp, "save category")
+	if httpErr != nil {
+		return nil, httpErr
+	}
+	return saved, nil
+}
+
+// categoryPayloadFields lists the fields of a new category, including false flags
+func categoryPayloadFields(category *Category) map[string]any {
+	return map[string]any{
+		"parent_id":         category.ParentID,
+		"name":              category.Name,
+		"is_active":         category.IsActive,
+		"include_in_menu":   category.IncludeInMenu,
+		"position":          category.Position,
+		"custom_attributes": category.CustomAttributes,
+	}
+}
+
+func sortedKeys[K cmp.Ordered, V any](m map[K]V) []K {
+	keys := make([]K, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	return keys
+}
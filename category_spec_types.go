@@ -0,0 +1,53 @@
+package magento2
+
+// CategorySpec is the declarative form of a category and its subtree. It carries yaml tags next to the
+// json tags, so it can be written with any YAML encoder as well
+type CategorySpec struct {
+	Name          string                       `json:"name" yaml:"name"`
+	URLKey        string                       `json:"url_key,omitempty" yaml:"url_key,omitempty"`
+	IsActive      bool                         `json:"is_active" yaml:"is_active"`
+	IncludeInMenu bool                         `json:"include_in_menu" yaml:"include_in_menu"`
+	Position      int                          `json:"position" yaml:"position"`
+	Attributes    map[string]string            `json:"attributes,omitempty" yaml:"attributes,omitempty"`
+	StoreViews    map[string]CategoryStoreSpec `json:"store_views,omitempty" yaml:"store_views,omitempty"`
+	Children      []CategorySpec               `json:"children,omitempty" yaml:"children,omitempty"`
+}
+
+// CategoryStoreSpec holds the values a store view overrides
+type CategoryStoreSpec struct {
+	Name       string            `json:"name,omitempty" yaml:"name,omitempty"`
+	URLKey     string            `json:"url_key,omitempty" yaml:"url_key,omitempty"`
+	Attributes map[string]string `json:"attributes,omitempty" yaml:"attributes,omitempty"`
+}
+
+// CategoryChangeAction is the kind of a planned category change
+type CategoryChangeAction string
+
+const (
+	CategoryChangeCreate CategoryChangeAction = "create"
+	CategoryChangeUpdate CategoryChangeAction = "update"
+	CategoryChangeMove   CategoryChangeAction = "move"
+	CategoryChangeDelete CategoryChangeAction = "delete"
+)
+
+// CategoryChange is one step of a CategoryPlan. Path names the category by the names of its ancestors
+// below the root, StoreCode is set for store view updates and Fields lists the changed fields
+type CategoryChange struct {
+	Action     CategoryChangeAction `json:"action"`
+	Path       string               `json:"path"`
+	CategoryID int                  `json:"category_id,omitempty"`
+	StoreCode  string               `json:"store_code,omitempty"`
+	Fields     []string             `json:"fields,omitempty"`
+}
+
+// CategoryPlan lists the changes ApplyCategoryTree made, or would make in a dry run, in order
+type CategoryPlan struct {
+	Changes []CategoryChange `json:"changes"`
+}
+
+// ApplyCategoryTreeOptions configures ApplyCategoryTree. Categories below the root that the spec does not
+// contain are only deleted with Delete set. DryRun plans without changing anything
+type ApplyCategoryTreeOptions struct {
+	Delete bool
+	DryRun bool
+}
@@ -0,0 +1,69 @@
+package magento2
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/rs/zerolog/log"
+)
+
+// DefaultReferenceDataTTL is how long CategoryTreeCache and StoreConfigCache keep data by default
+const DefaultReferenceDataTTL = 15 * time.Minute
+
+type categoryTreeKey struct {
+	rootID int
+	depth  int
+}
+
+// CategoryTreeCache keeps category trees for pricing or feed jobs fetching them constantly. Trees expire
+// after the TTL and are dropped when a category is written through the same client. Concurrent misses
+// cause a single request. It is safe for concurrent use
+type CategoryTreeCache struct {
+	APIClient *Client
+	cache     ttlCache[categoryTreeKey, *CategoryTree]
+}
+
+// NewCategoryTreeCache returns a cache keeping trees for ttl, a zero ttl keeps them until invalidated.
+// It registers a hook on the client, so create one cache per client and reuse it
+func NewCategoryTreeCache(apiClient *Client, ttl time.Duration) *CategoryTreeCache {
+	ctc := &CategoryTreeCache{
+		APIClient: apiClient,
+		cache:     ttlCache[categoryTreeKey, *CategoryTree]{ttl: ttl},
+	}
+	apiClient.HTTPClient.OnAfterResponse(ctc.invalidateOnCategoryWrite)
+	return ctc
+}
+
+// Get returns the cached tree below rootID, fetching it with GetCategoryTree on a miss. The tree is
+// shared between callers and must not be modified
+func (ctc *CategoryTreeCache) Get(ctx context.Context, rootID, depth int) (*CategoryTree, error) {
+	key := categoryTreeKey{rootID: rootID, depth: depth}
+	return ctc.cache.get(ctx, key, func(ctx context.Context) (*CategoryTree, error) {
+		log.Debug().Int("rootID", rootID).Int("depth", depth).Msg("Category tree cache miss")
+		return GetCategoryTree(ctx, rootID, depth, ctc.APIClient)
+	})
+}
+
+// Invalidate drops all cached trees, the next lookups fetch them again
+func (ctc *CategoryTreeCache) Invalidate() {
+	ctc.cache.invalidate()
+}
+
+func (ctc *CategoryTreeCache) invalidateOnCategoryWrite(_ *resty.Client, resp *resty.Response) error {
+	if resp.Request.Method == http.MethodGet || !resp.IsSuccess() {
+		return nil
+	}
+	parsed, err := url.Parse(resp.Request.URL)
+	if err != nil {
+		return nil
+	}
+	if strings.Contains(parsed.Path, "/V1"+categories) {
+		log.Debug().Str("method", resp.Request.Method).Str("route", parsed.Path).Msg("Category written, invalidating category tree cache")
+		ctc.Invalidate()
+	}
+	return nil
+}
@@ -0,0 +1,164 @@
+package magento2
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+
+	"github.com/rs/zerolog/log"
+)
+
+var ErrUnknownTenant = errors.New("no client registered for tenant")
+
+// Clone derives a client from c, e.g. for another store view (WithStoreView) or token (WithBearerToken).
+// The clone shares the HTTP transport and with it the connection pool, and keeps retry, timeout and
+// the client-level settings. Credential options log in with a background context
+func (c *Client) Clone(opts ...ClientOption) (*Client, error) {
+	if c.storeConfig == nil {
+		return nil, ErrNoStoreConfig
+	}
+
+	storeConfig := *c.storeConfig
+	retryCount := c.HTTPClient.RetryCount
+	maxResponseBytes := c.HTTPClient.ResponseBodyLimit
+	current := c.HTTPClient.GetClient()
+	transport := current.Transport
+	if reauth, ok := transport.(*reauthTransport); ok {
+		transport = reauth.base
+	}
+	var singleflightRoutes []string
+	singleflight, isSingleflight := transport.(*singleflightTransport)
+	if isSingleflight {
+		transport = singleflight.base
+		singleflightRoutes = singleflight.routePatterns
+	}
+	// the fault layer is rebuilt from its policy, wrapping the shared one would fail attempts twice
+	var faultPolicy *FaultPolicy
+	if fault, ok := transport.(*faultTransport); ok {
+		transport = fault.base
+		policy := fault.policy
+		faultPolicy = &policy
+	}
+	o := &clientOptions{
+		storeConfig:          &storeConfig,
+		bearerToken:          c.HTTPClient.Token,
+		retryCount:           &retryCount,
+		retryWait:            c.HTTPClient.RetryWaitTime,
+		retryMaxWait:         c.HTTPClient.RetryMaxWaitTime,
+		timeout:              current.Timeout,
+		maxResponseBytes:     &maxResponseBytes,
+		slowRequestThreshold: c.slowRequestThreshold,
+		runID:                c.runID,
+		singleflight:         isSingleflight,
+		singleflightRoutes:   singleflightRoutes,
+		faultPolicy:          faultPolicy,
+		httpClient: &http.Client{
+			Transport:     transport,
+			CheckRedirect: current.CheckRedirect,
+			Jar:           current.Jar,
+		},
+	}
+	if c.reauth != nil {
+		o.bearerToken = c.reauth.currentToken()
+		o.credentials = c.reauth.credentials
+		o.authenticationType = c.reauth.authenticationType
+		o.reauthenticationHook = c.reauth.hook
+	}
+	for key := range c.HTTPClient.Header {
+		_ = WithHeader(key, c.HTTPClient.Header.Get(key))(o)
+	}
+	if err := o.apply(opts); err != nil {
+		return nil, err
+	}
+
+	clone, err := newClientFromOptions(context.Background(), o)
+	if err != nil {
+		return nil, fmt.Errorf("error cloning client: %w", err)
+	}
+	clone.ValidatePayloads = c.ValidatePayloads
+	clone.ConcurrencyCheck = c.ConcurrencyCheck
+	clone.maintenanceHook = c.maintenanceHook
+	clone.contentValidator = c.contentValidator
+	clone.invoiceDocumentSource = c.invoiceDocumentSource
+	clone.SetJournal(c.journal.Load())
+	c.extensionsMu.RLock()
+	for name, extension := range c.extensions {
+		clone.RegisterExtension(name, extension.Prefix)
+	}
+	c.extensionsMu.RUnlock()
+	if c.idempotencyKeys {
+		clone.EnableIdempotencyKeys()
+	}
+	return clone, nil
+}
+
+// ClientRegistry keeps one client per tenant or store key, for processes serving many Magento
+// instances. It is safe for concurrent use
+type ClientRegistry struct {
+	mu      sync.RWMutex
+	clients map[string]*Client
+}
+
+func NewClientRegistry() *ClientRegistry {
+	return &ClientRegistry{
+		clients: map[string]*Client{},
+	}
+}
+
+func (r *ClientRegistry) Register(key string, client *Client) {
+	r.mu.Lock()
+	r.clients[key] = client
+	r.mu.Unlock()
+	log.Debug().Str("tenant", key).Msg("Client registered")
+}
+
+func (r *ClientRegistry) Get(key string) (*Client, error) {
+	r.mu.RLock()
+	client, ok := r.clients[key]
+	r.mu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("%w: '%s'", ErrUnknownTenant, key)
+	}
+	return client, nil
+}
+
+// GetOrCreate returns the registered client or creates and registers it. create runs under the
+// registry lock, so a key is never created twice but other lookups of unknown keys wait meanwhile
+func (r *ClientRegistry) GetOrCreate(key string, create func() (*Client, error)) (*Client, error) {
+	if client, err := r.Get(key); err == nil {
+		return client, nil
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if client, ok := r.clients[key]; ok {
+		return client, nil
+	}
+	client, err := create()
+	if err != nil {
+		return nil, fmt.Errorf("error creating client for tenant '%s': %w", key, err)
+	}
+	r.clients[key] = client
+	return client, nil
+}
+
+func (r *ClientRegistry) Remove(key string) {
+	r.mu.Lock()
+	delete(r.clients, key)
+	r.mu.Unlock()
+}
+
+// Keys returns the registered keys in sorted order
+func (r *ClientRegistry) Keys() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	keys := make([]string, 0, len(r.clients))
+	for key := range r.clients {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
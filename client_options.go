@@ -0,0 +1,359 @@
+package magento2
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+)
+
+var ErrNoStoreConfig = errors.New("no store configured, use WithStore or WithStoreURL")
+
+// ClientOption configures a Client created by NewClient
+type ClientOption func(*clientOptions) error
+
+type clientOptions struct {
+	storeConfig          *StoreConfig
+	bearerToken          string
+	credentials          *AuthenticationRequestPayload
+	authenticationType   AuthenticationType
+	httpClient           *http.Client
+	retryCount           *int
+	retryWait            time.Duration
+	retryMaxWait         time.Duration
+	timeout              time.Duration
+	logger               *zerolog.Logger
+	transport            transportOptions
+	headers              map[string]string
+	maxResponseBytes     *int
+	slowRequestThreshold time.Duration
+	runID                string
+	singleflight         bool
+	singleflightRoutes   []string
+	reauthenticationHook ReauthenticationHook
+	faultPolicy          *FaultPolicy
+}
+
+type transportOptions struct {
+	maxIdleConnsPerHost int
+	maxConnsPerHost     int
+	idleConnTimeout     time.Duration
+	http2               *bool
+}
+
+func (t transportOptions) isSet() bool {
+	return t.maxIdleConnsPerHost > 0 || t.maxConnsPerHost > 0 || t.idleConnTimeout > 0 || t.http2 != nil
+}
+
+func WithStore(storeConfig *StoreConfig) ClientOption {
+	return func(o *clientOptions) error {
+		o.storeConfig = storeConfig
+		return nil
+	}
+}
+
+// WithStoreView keeps scheme and host but targets another store code, mostly useful with Client.Clone.
+// For a single call use the WithStoreCode request option instead
+func WithStoreView(storeCode string) ClientOption {
+	return func(o *clientOptions) error {
+		if o.storeConfig == nil {
+			return ErrNoStoreConfig
+		}
+		storeConfig := *o.storeConfig
+		storeConfig.StoreCode = storeCode
+		o.storeConfig = &storeConfig
+		return nil
+	}
+}
+
+// WithStoreURL configures the store from a URL like "https://shop.example.com" and a store code
+func WithStoreURL(host, storeCode string) ClientOption {
+	return func(o *clientOptions) error {
+		storeConfig, err := StoreConfigFromURL(host, storeCode)
+		if err != nil {
+			return err
+		}
+		o.storeConfig = storeConfig
+		return nil
+	}
+}
+
+// WithBearerToken authenticates with an integration or previously issued token
+func WithBearerToken(token string) ClientOption {
+	return func(o *clientOptions) error {
+		o.bearerToken = token
+		o.credentials = nil
+		return nil
+	}
+}
+
+// WithAdminCredentials requests an admin token on creation
+func WithAdminCredentials(username, password string) ClientOption {
+	return func(o *clientOptions) error {
+		o.credentials = &AuthenticationRequestPayload{Username: username, Password: password}
+		o.authenticationType = Administrator
+		o.bearerToken = ""
+		return nil
+	}
+}
+
+// WithCustomerCredentials requests a customer token on creation, for the /mine endpoints
+func WithCustomerCredentials(username, password string) ClientOption {
+	return func(o *clientOptions) error {
+		o.credentials = &AuthenticationRequestPayload{Username: username, Password: password}
+		o.authenticationType = CustomerAuth
+		o.bearerToken = ""
+		return nil
+	}
+}
+
+// WithHTTPClient sends requests through the given client, e.g. one with a custom transport or proxy.
+// Its Timeout is overwritten with the client's default timeout
+func WithHTTPClient(httpClient *http.Client) ClientOption {
+	return func(o *clientOptions) error {
+		o.httpClient = httpClient
+		return nil
+	}
+}
+
+// WithRetry overrides the retry count and the wait bounds between attempts
+func WithRetry(count int, wait, maxWait time.Duration) ClientOption {
+	return func(o *clientOptions) error {
+		if count < 0 || wait < 0 || maxWait < wait {
+			return fmt.Errorf("invalid retry configuration: count %d, wait %s, max wait %s", count, wait, maxWait)
+		}
+		o.retryCount = &count
+		o.retryWait = wait
+		o.retryMaxWait = maxWait
+		return nil
+	}
+}
+
+// WithDefaultTimeout sets the time budget of a call, see Client.SetDefaultTimeout
+func WithDefaultTimeout(timeout time.Duration) ClientOption {
+	return func(o *clientOptions) error {
+		o.timeout = timeout
+		return nil
+	}
+}
+
+// WithMaxIdleConnsPerHost sets how many keep-alive connections to Magento are kept open. Go's default
+// of 2 makes highly concurrent jobs against one host reconnect constantly
+func WithMaxIdleConnsPerHost(n int) ClientOption {
+	return func(o *clientOptions) error {
+		o.transport.maxIdleConnsPerHost = n
+		return nil
+	}
+}
+
+// WithMaxConnsPerHost caps the open connections to Magento, 0 means unlimited
+func WithMaxConnsPerHost(n int) ClientOption {
+	return func(o *clientOptions) error {
+		o.transport.maxConnsPerHost = n
+		return nil
+	}
+}
+
+// WithIdleConnTimeout sets how long idle keep-alive connections are kept, keep it below the server's keep-alive timeout
+func WithIdleConnTimeout(timeout time.Duration) ClientOption {
+	return func(o *clientOptions) error {
+		o.transport.idleConnTimeout = timeout
+		return nil
+	}
+}
+
+// WithHTTP2 toggles HTTP/2 for TLS connections. Disabling it helps with proxies that multiplex poorly
+func WithHTTP2(enabled bool) ClientOption {
+	return func(o *clientOptions) error {
+		o.transport.http2 = &enabled
+		return nil
+	}
+}
+
+// WithUserAgent replaces the default "go-m2rest" User-Agent
+func WithUserAgent(userAgent string) ClientOption {
+	return WithHeader("User-Agent", userAgent)
+}
+
+// WithHeader adds a header sent with every request, e.g. a CDN bypass token
+func WithHeader(key, value string) ClientOption {
+	return func(o *clientOptions) error {
+		if o.headers == nil {
+			o.headers = map[string]string{}
+		}
+		o.headers[http.CanonicalHeaderKey(key)] = value
+		return nil
+	}
+}
+
+// WithHeaders adds headers sent with every request
+func WithHeaders(headers map[string]string) ClientOption {
+	return func(o *clientOptions) error {
+		for key, value := range headers {
+			_ = WithHeader(key, value)(o)
+		}
+		return nil
+	}
+}
+
+// WithMaxResponseSize caps the response bodies the client reads, larger ones fail with
+// ErrResponseTooLarge. Without it responses aren't capped, DefaultMaxResponseBytes is a sensible size.
+// Zero or a negative size removes the limit
+func WithMaxResponseSize(bytes int) ClientOption {
+	return func(o *clientOptions) error {
+		o.maxResponseBytes = &bytes
+		return nil
+	}
+}
+
+// WithLogger sets the logger of the package. Logging is package-wide, so it applies to every client,
+// the same as SetZeroLogger
+func WithLogger(logger zerolog.Logger) ClientOption {
+	return func(o *clientOptions) error {
+		o.logger = &logger
+		return nil
+	}
+}
+
+// NewClient is the single entry point for creating clients, e.g.
+//
+//	client, err := NewClient(ctx, WithStoreURL("https://shop.example.com", "default"), WithBearerToken(token))
+//
+// Without a token or credentials the client is unauthenticated
+func NewClient(ctx context.Context, opts ...ClientOption) (*Client, error) {
+	o := &clientOptions{}
+	if err := o.apply(opts); err != nil {
+		return nil, err
+	}
+	return newClientFromOptions(ctx, o)
+}
+
+func (o *clientOptions) apply(opts []ClientOption) error {
+	for _, opt := range opts {
+		if err := opt(o); err != nil {
+			return fmt.Errorf("error applying client option: %w", err)
+		}
+	}
+	return nil
+}
+
+func newClientFromOptions(ctx context.Context, o *clientOptions) (*Client, error) {
+	if o.storeConfig == nil {
+		return nil, ErrNoStoreConfig
+	}
+	if o.logger != nil {
+		SetZeroLogger(*o.logger)
+	}
+
+	var httpClient *resty.Client
+	if o.httpClient != nil {
+		httpClient = configureHTTPClient(resty.NewWithClient(o.httpClient), o.storeConfig)
+	} else {
+		httpClient = buildBasicHTTPClient(o.storeConfig)
+	}
+	if o.retryCount != nil {
+		httpClient.SetRetryCount(*o.retryCount).
+			SetRetryWaitTime(o.retryWait).
+			SetRetryMaxWaitTime(o.retryMaxWait)
+	}
+	if o.timeout > 0 {
+		httpClient.SetTimeout(o.timeout)
+	}
+	httpClient.SetHeaders(o.headers)
+	if o.maxResponseBytes != nil {
+		httpClient.SetResponseBodyLimit(*o.maxResponseBytes)
+	}
+	if o.transport.isSet() {
+		transport, ok := httpClient.GetClient().Transport.(*http.Transport)
+		if !ok {
+			return nil, fmt.Errorf("connection pool options need an *http.Transport, got %T", httpClient.GetClient().Transport)
+		}
+		httpClient.SetTransport(o.transport.applyTo(transport.Clone()))
+	}
+
+	if o.faultPolicy != nil {
+		httpClient.SetTransport(newFaultTransport(httpClient.GetClient().Transport, *o.faultPolicy))
+	}
+
+	if o.singleflight {
+		httpClient.SetTransport(&singleflightTransport{
+			base:          httpClient.GetClient().Transport,
+			routePatterns: o.singleflightRoutes,
+		})
+	}
+
+	client := newClient(httpClient, o.storeConfig)
+	client.slowRequestThreshold = o.slowRequestThreshold
+	client.runID = o.runID
+	if o.slowRequestThreshold > 0 {
+		httpClient.OnAfterResponse(client.logSlowRequest)
+	}
+
+	switch {
+	case o.bearerToken != "":
+		httpClient.SetAuthToken(o.bearerToken)
+	case o.credentials != nil:
+		token, err := client.requestToken(ctx, o.authenticationType, o.credentials)
+		if err != nil {
+			return nil, err
+		}
+		httpClient.SetAuthToken(token)
+	}
+	if o.credentials != nil {
+		client.enableReauthentication(o.authenticationType, o.credentials, o.reauthenticationHook)
+	}
+
+	log.Info().
+		Str("scheme", o.storeConfig.Scheme).
+		Str("hostName", o.storeConfig.HostName).
+		Str("storeCode", o.storeConfig.StoreCode).
+		Bool("authenticated", o.bearerToken != "" || o.credentials != nil).
+		Msg("Created API client")
+	return client, nil
+}
+
+func (c *Client) requestToken(ctx context.Context, authenticationType AuthenticationType, credentials *AuthenticationRequestPayload) (string, error) {
+	log.Info().Str("authenticationType", authenticationType.Route()).Str("username", credentials.Username).Msg("Authenticating API client")
+
+	resp, err := c.HTTPClient.R().SetContext(ctx).SetBody(credentials).Post(authenticationType.Route())
+	if err != nil {
+		return "", fmt.Errorf("error authenticating API client: %w", err)
+	}
+
+	httpErr := mayReturnErrorForHTTPResponse(resp, "authenticate API client")
+	if httpErr != nil {
+		return "", httpErr
+	}
+	return mayTrimSurroundingQuotes(resp.String()), nil
+}
+
+func (t transportOptions) applyTo(transport *http.Transport) *http.Transport {
+	if t.maxIdleConnsPerHost > 0 {
+		transport.MaxIdleConnsPerHost = t.maxIdleConnsPerHost
+		if transport.MaxIdleConns != 0 && transport.MaxIdleConns < t.maxIdleConnsPerHost {
+			transport.MaxIdleConns = t.maxIdleConnsPerHost
+		}
+	}
+	if t.maxConnsPerHost > 0 {
+		transport.MaxConnsPerHost = t.maxConnsPerHost
+	}
+	if t.idleConnTimeout > 0 {
+		transport.IdleConnTimeout = t.idleConnTimeout
+	}
+	if t.http2 != nil {
+		transport.ForceAttemptHTTP2 = *t.http2
+		if *t.http2 {
+			transport.TLSNextProto = nil
+		} else {
+			// a non-nil empty map disables the automatic HTTP/2 upgrade
+			transport.TLSNextProto = map[string]func(string, *tls.Conn) http.RoundTripper{}
+		}
+	}
+	return transport
+}
@@ -0,0 +1,92 @@
+package magento2
+
+import (
+	"errors"
+	"net/http"
+	"sync/atomic"
+
+	"github.com/go-resty/resty/v2"
+)
+
+// ClientStats is a snapshot of the request counters of a client since it was created. Requests and
+// Retries count attempts, so a call retried twice counts three requests and two retries. The error
+// counters count calls by their outcome once retries are done, so that call counts one error at most
+type ClientStats struct {
+	Requests int64 `json:"requests"`
+	Retries  int64 `json:"retries"`
+	// ClientErrors counts calls ending with a 4xx response, including the rate-limited ones
+	ClientErrors int64 `json:"client_errors"`
+	ServerErrors int64 `json:"server_errors"`
+	RateLimited  int64 `json:"rate_limited"`
+	// TransportErrors counts calls failing without an error status, e.g. on timeouts or refused connections
+	TransportErrors int64 `json:"transport_errors"`
+}
+
+// Failures is the number of calls that failed, with or without a response
+func (s ClientStats) Failures() int64 {
+	return s.ClientErrors + s.ServerErrors + s.TransportErrors
+}
+
+type clientStats struct {
+	requests        atomic.Int64
+	retries         atomic.Int64
+	clientErrors    atomic.Int64
+	serverErrors    atomic.Int64
+	rateLimited     atomic.Int64
+	transportErrors atomic.Int64
+}
+
+func (s *clientStats) countRequest(_ *resty.Client, r *resty.Request) error {
+	s.requests.Add(1)
+	if r.Attempt > 1 {
+		s.retries.Add(1)
+	}
+	return nil
+}
+
+// countResponse counts the final response of a call, resty calls it once retries are done
+func (s *clientStats) countResponse(_ *resty.Client, resp *resty.Response) {
+	s.countStatus(resp.StatusCode())
+}
+
+// countError counts a call that ended with an error. Errors of hooks processing a response are
+// counted by the response status
+func (s *clientStats) countError(_ *resty.Request, err error) {
+	var responseErr *resty.ResponseError
+	if errors.As(err, &responseErr) && responseErr.Response.RawResponse != nil && s.countStatus(responseErr.Response.StatusCode()) {
+		return
+	}
+	s.transportErrors.Add(1)
+}
+
+// countStatus counts error statuses and reports whether the status was one
+func (s *clientStats) countStatus(status int) bool {
+	if status == http.StatusTooManyRequests {
+		s.rateLimited.Add(1)
+	}
+	switch {
+	case status >= http.StatusInternalServerError:
+		s.serverErrors.Add(1)
+	case status >= http.StatusBadRequest:
+		s.clientErrors.Add(1)
+	default:
+		return false
+	}
+	return true
+}
+
+// Stats returns the retry and failure counters of the client, e.g. for a daemon's health report.
+// Clones keep counters of their own
+func (c *Client) Stats() ClientStats {
+	if c.stats == nil {
+		return ClientStats{}
+	}
+	return ClientStats{
+		Requests:        c.stats.requests.Load(),
+		Retries:         c.stats.retries.Load(),
+		ClientErrors:    c.stats.clientErrors.Load(),
+		ServerErrors:    c.stats.serverErrors.Load(),
+		RateLimited:     c.stats.rateLimited.Load(),
+		TransportErrors: c.stats.transportErrors.Load(),
+	}
+}
@@ -4,6 +4,7 @@ type Address struct {
 	ID                  int                      `json:"id,omitempty"`
 	RegionID            int                      `json:"region_id,omitempty"`
 	RegionCode          string                   `json:"region_code,omitempty"`
+	Region              *AddressRegion           `json:"region,omitempty"`
 	CountryID           string                   `json:"country_id"`
 	Street              []string                 `json:"street"`
 	Company             string                   `json:"company,omitempty"`
@@ -22,6 +23,8 @@ type Address struct {
 	SameAsBilling       int                      `json:"same_as_billing,omitempty"`
 	CustomerAddressID   int                      `json:"customer_address_id,omitempty"`
 	SaveInAddressBook   int                      `json:"save_in_address_book,omitempty"`
+	DefaultBilling      bool                     `json:"default_billing,omitempty"`
+	DefaultShipping     bool                     `json:"default_shipping,omitempty"`
 	ExtensionAttributes map[string]any   `json:"extension_attributes,omitempty"`
 	CustomAttributes    []map[string]any `json:"custom_attributes,omitempty"`
 }
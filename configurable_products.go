@@ -1,7 +1,9 @@
 package magento2
 
 import (
+	"context"
 	"fmt"
+	"strconv"
 
 	"github.com/rs/zerolog/log"
 )
@@ -146,3 +148,39 @@ func (mConfigurableProduct *MConfigurableProduct) UpdateOptionByID(o *Configurab
 	}
 	return nil
 }
+
+// BuildConfigurableProductOption builds the option payload for SetOptionForExistingConfigurableProduct
+// from an attribute code and option labels, resolving the numeric IDs through the cache
+func BuildConfigurableProductOption(ctx context.Context, attributeCode string, optionLabels []string, cache *AttributeCache) (*ConfigurableProductOption, error) {
+	attribute, err := cache.Get(ctx, attributeCode)
+	if err != nil {
+		return nil, fmt.Errorf("error resolving attribute for configurable option: %w", err)
+	}
+
+	values := make([]Value, 0, len(optionLabels))
+	for _, label := range optionLabels {
+		optionValue, err := cache.OptionValue(ctx, attributeCode, label)
+		if err != nil {
+			return nil, err
+		}
+		valueIndex, err := strconv.Atoi(optionValue)
+		if err != nil {
+			return nil, fmt.Errorf("unexpected non-numeric value '%s' for option '%s': %w", optionValue, label, err)
+		}
+		values = append(values, Value{ValueIndex: valueIndex})
+	}
+
+	option := &ConfigurableProductOption{
+		AttributeID: strconv.Itoa(attribute.AttributeID),
+		Label:       attribute.DefaultFrontendLabel,
+		Values:      values,
+	}
+
+	log.Debug().
+		Str("attributeCode", attributeCode).
+		Strs("optionLabels", optionLabels).
+		Interface("option", option).
+		Msg("Built configurable product option")
+
+	return option, nil
+}
@@ -0,0 +1,197 @@
+package magento2
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+const (
+	ContentRuleMinLength          = "min_length"
+	ContentRuleBannedWords        = "banned_words"
+	ContentRuleRequiredAttributes = "required_attributes"
+)
+
+// DefaultContentAttributes are the attributes BannedWordsRule checks when none are given
+var DefaultContentAttributes = []string{"name", "description", "short_description", "meta_title", "meta_keyword", "meta_description"}
+
+var htmlTagPattern = regexp.MustCompile(`<[^>]*>`)
+
+// ContentViolation is one finding of a ContentRule. Rule names the rule, Field the offending attribute
+type ContentViolation struct {
+	Sku     string `json:"sku"`
+	Rule    string `json:"rule"`
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+func (v ContentViolation) String() string {
+	return fmt.Sprintf("%s: %s %s (%s)", v.Sku, v.Field, v.Message, v.Rule)
+}
+
+// ContentViolationsError is returned instead of saving a product whose content breaks the rules of the
+// client's ContentValidator. It unwraps to ErrValidation
+type ContentViolationsError struct {
+	Violations []ContentViolation
+}
+
+func (e *ContentViolationsError) Error() string {
+	messages := make([]string, 0, len(e.Violations))
+	for _, violation := range e.Violations {
+		messages = append(messages, violation.String())
+	}
+	return fmt.Sprintf("product content violates %d rule(s): %s", len(e.Violations), strings.Join(messages, "; "))
+}
+
+func (e *ContentViolationsError) Unwrap() error {
+	return ErrValidation
+}
+
+// ContentRule checks the content of a product. Rules only look at the product passed in, they must not
+// call the remote
+type ContentRule interface {
+	CheckProduct(product *Product) []ContentViolation
+}
+
+// ContentRuleFunc adapts a plain function to a ContentRule
+type ContentRuleFunc func(product *Product) []ContentViolation
+
+func (f ContentRuleFunc) CheckProduct(product *Product) []ContentViolation {
+	return f(product)
+}
+
+// ContentValidator runs content rules on products before they are saved. Set it on a client with
+// SetContentValidator, or call Check directly, e.g. while reading an import file
+type ContentValidator struct {
+	Rules []ContentRule
+}
+
+func NewContentValidator(rules ...ContentRule) *ContentValidator {
+	return &ContentValidator{Rules: rules}
+}
+
+// Check runs all rules and returns their violations, nil if the product passes
+func (v *ContentValidator) Check(product *Product) []ContentViolation {
+	var violations []ContentViolation
+	for _, rule := range v.Rules {
+		for _, violation := range rule.CheckProduct(product) {
+			if violation.Sku == "" {
+				violation.Sku = product.Sku
+			}
+			violations = append(violations, violation)
+		}
+	}
+	return violations
+}
+
+// Validate is Check returning a *ContentViolationsError when there are violations
+func (v *ContentValidator) Validate(product *Product) error {
+	violations := v.Check(product)
+	if len(violations) == 0 {
+		return nil
+	}
+	return &ContentViolationsError{Violations: violations}
+}
+
+// SetContentValidator makes CreateOrReplaceProduct check products with the validator before sending them,
+// nil disables the check
+func (c *Client) SetContentValidator(validator *ContentValidator) *Client {
+	c.contentValidator = validator
+	return c
+}
+
+// contentValue returns name and sku from the product fields, other codes from its custom attributes
+func contentValue(product *Product, code string) string {
+	switch code {
+	case "name":
+		return product.Name
+	case "sku":
+		return product.Sku
+	}
+	return product.CustomAttributeString(code)
+}
+
+// plainText strips HTML tags and collapses whitespace, so markup does not count towards lengths
+func plainText(value string) string {
+	return strings.Join(strings.Fields(htmlTagPattern.ReplaceAllString(value, " ")), " ")
+}
+
+// MinLengthRule requires the plain text of an attribute, e.g. "description", to have at least Min
+// characters. Missing values are violations as well
+type MinLengthRule struct {
+	Attribute string
+	Min       int
+}
+
+func (r MinLengthRule) CheckProduct(product *Product) []ContentViolation {
+	length := len([]rune(plainText(contentValue(product, r.Attribute))))
+	if length >= r.Min {
+		return nil
+	}
+	return []ContentViolation{{
+		Rule:    ContentRuleMinLength,
+		Field:   r.Attribute,
+		Message: fmt.Sprintf("has %d characters, at least %d required", length, r.Min),
+	}}
+}
+
+// BannedWordsRule rejects attributes containing one of Words, matched case-insensitively as whole words
+// or phrases. Attributes defaults to DefaultContentAttributes
+type BannedWordsRule struct {
+	Words      []string
+	Attributes []string
+}
+
+func (r BannedWordsRule) CheckProduct(product *Product) []ContentViolation {
+	attributes := r.Attributes
+	if len(attributes) == 0 {
+		attributes = DefaultContentAttributes
+	}
+	var violations []ContentViolation
+	for _, code := range attributes {
+		words := contentWords(plainText(contentValue(product, code)))
+		if words == "" {
+			continue
+		}
+		for _, banned := range r.Words {
+			phrase := contentWords(banned)
+			if phrase != "" && strings.Contains(" "+words+" ", " "+phrase+" ") {
+				violations = append(violations, ContentViolation{
+					Rule:    ContentRuleBannedWords,
+					Field:   code,
+					Message: fmt.Sprintf("contains banned word %q", banned),
+				})
+			}
+		}
+	}
+	return violations
+}
+
+// contentWords lower cases the text and reduces it to words separated by single spaces
+func contentWords(text string) string {
+	return strings.Join(strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}), " ")
+}
+
+// RequiredAttributesRule lists the attributes a product must have a value for, by attribute set id.
+// Products of sets not listed pass
+type RequiredAttributesRule struct {
+	BySet map[int][]string
+}
+
+func (r RequiredAttributesRule) CheckProduct(product *Product) []ContentViolation {
+	var violations []ContentViolation
+	for _, code := range r.BySet[product.AttributeSetID] {
+		if strings.TrimSpace(contentValue(product, code)) != "" {
+			continue
+		}
+		violations = append(violations, ContentViolation{
+			Rule:    ContentRuleRequiredAttributes,
+			Field:   code,
+			Message: fmt.Sprintf("is required for attribute set %d", product.AttributeSetID),
+		})
+	}
+	return violations
+}
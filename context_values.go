@@ -0,0 +1,83 @@
+package magento2
+
+import (
+	"context"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+)
+
+const HeaderRequestID = "X-Request-ID"
+
+type requestIDContextKey struct{}
+
+type tenantIDContextKey struct{}
+
+// ContextWithRequestID attaches the ID of the inbound request, it is sent as X-Request-ID with every
+// Magento call made with the context and logged with the request events
+func ContextWithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDContextKey{}, requestID)
+}
+
+func RequestIDFromContext(ctx context.Context) string {
+	requestID, _ := ctx.Value(requestIDContextKey{}).(string)
+	return requestID
+}
+
+// ContextWithTenantID attaches a tenant ID, which is logged with the request events
+func ContextWithTenantID(ctx context.Context, tenantID string) context.Context {
+	return context.WithValue(ctx, tenantIDContextKey{}, tenantID)
+}
+
+func TenantIDFromContext(ctx context.Context) string {
+	tenantID, _ := ctx.Value(tenantIDContextKey{}).(string)
+	return tenantID
+}
+
+// LoggerFromContext returns the logger of the context, attached with zerolog's Logger.WithContext, or
+// else the package logger, with the request and tenant ID of the context as fields
+func LoggerFromContext(ctx context.Context) zerolog.Logger {
+	logger := log.Logger
+	// zerolog.Ctx falls back to a shared default logger when the context has none
+	if ctxLogger := zerolog.Ctx(ctx); ctxLogger != zerolog.Ctx(context.Background()) {
+		logger = *ctxLogger
+	}
+	logContext := logger.With()
+	if requestID := RequestIDFromContext(ctx); requestID != "" {
+		logContext = logContext.Str("requestID", requestID)
+	}
+	if tenantID := TenantIDFromContext(ctx); tenantID != "" {
+		logContext = logContext.Str("tenantID", tenantID)
+	}
+	return logContext.Logger()
+}
+
+func propagateRequestID(_ *resty.Client, r *resty.Request) error {
+	if requestID := RequestIDFromContext(r.Context()); requestID != "" && r.Header.Get(HeaderRequestID) == "" {
+		r.SetHeader(HeaderRequestID, requestID)
+	}
+	return nil
+}
+
+func logResponseWithContext(_ *resty.Client, resp *resty.Response) error {
+	logger := LoggerFromContext(resp.Request.Context())
+	logger.Debug().
+		Str("method", resp.Request.Method).
+		Str("url", resp.Request.URL).
+		Int("status", resp.StatusCode()).
+		Dur("duration", resp.Time()).
+		Int("attempt", resp.Request.Attempt).
+		Msg("Magento request completed")
+	return nil
+}
+
+func logErrorWithContext(r *resty.Request, err error) {
+	logger := LoggerFromContext(r.Context())
+	logger.Error().
+		Err(err).
+		Str("method", r.Method).
+		Str("url", r.URL).
+		Int("attempt", r.Attempt).
+		Msg("Magento request failed")
+}
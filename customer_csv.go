@@ -0,0 +1,381 @@
+package magento2
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	customerCSVBillingPrefix  = "billing_"
+	customerCSVShippingPrefix = "shipping_"
+)
+
+var (
+	customerCSVColumns        = []string{"email", "firstname", "lastname", "middlename", "prefix", "suffix", "dob", "gender", "taxvat", "website_id", "store_id", "group", "created_at"}
+	customerCSVAddressColumns = []string{"firstname", "lastname", "company", "street", "city", "region", "region_code", "region_id", "postcode", "country_id", "telephone", "vat_id"}
+)
+
+// CustomerCSVHeader returns the columns of customer CSV files: the customer fields, the customer group by
+// name, then the default billing and shipping address with billing_ and shipping_ prefixed columns.
+// Street lines are separated by newlines
+func CustomerCSVHeader() []string {
+	header := slices.Clone(customerCSVColumns)
+	for _, prefix := range []string{customerCSVBillingPrefix, customerCSVShippingPrefix} {
+		for _, column := range customerCSVAddressColumns {
+			header = append(header, prefix+column)
+		}
+	}
+	return header
+}
+
+// ExportCustomersCSV writes the customers matching criteria to w, one row per customer, and returns the
+// number of rows written. A nil criteria exports all customers
+func ExportCustomersCSV(ctx context.Context, w io.Writer, criteria *SearchCriteria, apiClient *Client) (int, error) {
+	return ExportCustomers(ctx, NewCSVEncoder(w), criteria, apiClient)
+}
+
+// ExportCustomers writes the customers matching criteria to enc with the columns of CustomerCSVHeader
+// and returns the number of rows written
+func ExportCustomers(ctx context.Context, enc Encoder, criteria *SearchCriteria, apiClient *Client) (int, error) {
+	groups, err := SearchCustomerGroups(ctx, nil, apiClient)
+	if err != nil {
+		return 0, fmt.Errorf("error loading customer groups for export: %w", err)
+	}
+	groupNames := make(map[int]string, len(groups))
+	for _, group := range groups {
+		groupNames[group.ID] = group.Code
+	}
+
+	header := CustomerCSVHeader()
+	columns := StringColumns(header...)
+	for i, name := range header {
+		if name == "website_id" || name == "store_id" || strings.HasSuffix(name, "region_id") {
+			columns[i].Type = ColumnNumber
+		}
+	}
+	if err := enc.Begin(columns); err != nil {
+		return 0, fmt.Errorf("error writing customer export header: %w", err)
+	}
+
+	log.Debug().Int("groups", len(groups)).Msg("Exporting customers")
+
+	rows := 0
+	err = searchPagesParallel(ctx, customersSearch, criteria, 1, "search customers for export", apiClient, func(items []Customer) error {
+		for i := range items {
+			if err := enc.Encode(header, customerCSVRecord(&items[i], groupNames)); err != nil {
+				return fmt.Errorf("error writing customer %s: %w", items[i].Email, err)
+			}
+			rows++
+		}
+		return nil
+	})
+	if err != nil {
+		return rows, err
+	}
+	if err := enc.Close(); err != nil {
+		return rows, fmt.Errorf("error writing customer export: %w", err)
+	}
+	return rows, nil
+}
+
+func customerCSVRecord(customer *Customer, groupNames map[int]string) []string {
+	record := []string{
+		customer.Email, customer.Firstname, customer.Lastname, customer.Middlename, customer.Prefix, customer.Suffix,
+		customer.Dob, formatOptionalInt(customer.Gender), customer.Taxvat, formatOptionalInt(customer.WebsiteID),
+		formatOptionalInt(customer.StoreID), groupNames[customer.GroupID], customer.CreatedAt,
+	}
+	record = append(record, customerCSVAddress(defaultCustomerAddress(customer, true))...)
+	return append(record, customerCSVAddress(defaultCustomerAddress(customer, false))...)
+}
+
+// defaultCustomerAddress returns the default billing or shipping address, nil if the customer has none
+func defaultCustomerAddress(customer *Customer, billing bool) *Address {
+	defaultID := customer.DefaultShipping
+	if billing {
+		defaultID = customer.DefaultBilling
+	}
+	for i := range customer.Addresses {
+		address := &customer.Addresses[i]
+		if (billing && address.DefaultBilling) || (!billing && address.DefaultShipping) || (defaultID != "" && strconv.Itoa(address.ID) == defaultID) {
+			return address
+		}
+	}
+	return nil
+}
+
+func customerCSVAddress(address *Address) []string {
+	if address == nil {
+		return make([]string, len(customerCSVAddressColumns))
+	}
+	// customer addresses carry the region as an object, the flat fields are a fallback
+	region := AddressRegion{RegionCode: address.RegionCode, RegionID: address.RegionID}
+	if address.Region != nil {
+		region = *address.Region
+		if region.RegionID == 0 {
+			region.RegionID = address.RegionID
+		}
+	}
+	return []string{
+		address.Firstname, address.Lastname, address.Company, strings.Join(address.Street, "\n"), address.City,
+		region.Region, region.RegionCode, formatOptionalInt(region.RegionID), address.Postcode, address.CountryID, address.Telephone, address.VatID,
+	}
+}
+
+func formatOptionalInt(value int) string {
+	if value == 0 {
+		return ""
+	}
+	return strconv.Itoa(value)
+}
+
+// CustomerCSVImportOptions configures ImportCustomersCSV. WebsiteID is used for rows without website_id,
+// 0 leaves the website to Magento. With a Checkpoint, rows it reports done are skipped and every
+// processed row is recorded
+type CustomerCSVImportOptions struct {
+	WebsiteID  int
+	Checkpoint *ImportCheckpoint
+}
+
+// ImportCustomersCSV creates the customers of a file in the format of ExportCustomersCSV, e.g. when
+// migrating from another platform. Columns may be in any order and missing. Customers whose email already
+// exists on the website are skipped. Passwords cannot be migrated, customers set one through the
+// password reset email.
+//
+// Rows Magento rejects are reported as failed and the import goes on. A transient error, e.g. rate
+// limiting, stops the import with the results so far; with a checkpoint it resumes at the failed row
+func ImportCustomersCSV(ctx context.Context, r io.Reader, opts CustomerCSVImportOptions, apiClient *Client) ([]ImportRowResult, error) {
+	reader := csv.NewReader(r)
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("error reading customer csv header: %w", err)
+	}
+	if !slices.Contains(header, "email") {
+		return nil, &ValidationError{Entity: "customer csv", Field: "email", Reason: "column is missing"}
+	}
+
+	groups, err := SearchCustomerGroups(ctx, nil, apiClient)
+	if err != nil {
+		return nil, fmt.Errorf("error loading customer groups for import: %w", err)
+	}
+	groupIDs := make(map[string]int, len(groups))
+	for _, group := range groups {
+		groupIDs[strings.ToLower(group.Code)] = group.ID
+	}
+
+	importer := &customerCSVImporter{opts: opts, groupIDs: groupIDs, apiClient: apiClient}
+	row := 0
+	for {
+		chunk := make([]map[string]string, 0, inFilterChunkSize)
+		for len(chunk) < inFilterChunkSize {
+			values, err := reader.Read()
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			if err != nil {
+				return importer.results, fmt.Errorf("error reading customer csv: %w", err)
+			}
+			record := make(map[string]string, len(header))
+			for i, column := range header {
+				if i < len(values) {
+					record[column] = strings.TrimSpace(values[i])
+				}
+			}
+			chunk = append(chunk, record)
+		}
+		if len(chunk) == 0 {
+			break
+		}
+		if err := importer.importChunk(ctx, row, chunk); err != nil {
+			return importer.results, err
+		}
+		row += len(chunk)
+	}
+
+	if opts.Checkpoint != nil {
+		if err := opts.Checkpoint.Save(); err != nil {
+			return importer.results, err
+		}
+	}
+	return importer.results, nil
+}
+
+type customerCSVImporter struct {
+	opts      CustomerCSVImportOptions
+	groupIDs  map[string]int
+	apiClient *Client
+	results   []ImportRowResult
+}
+
+// importChunk looks up the existing emails of the chunk with a single search, then creates the others
+func (imp *customerCSVImporter) importChunk(ctx context.Context, firstRow int, chunk []map[string]string) error {
+	emails := []string{}
+	for i, record := range chunk {
+		if record["email"] != "" && !imp.done(firstRow+i) {
+			emails = append(emails, record["email"])
+		}
+	}
+	existing := map[string][]int{}
+	if len(emails) > 0 {
+		found, err := SearchCustomers(ctx, NewSearchCriteria(SearchFilter{Field: "email", Value: strings.Join(emails, ","), ConditionType: "in"}), imp.apiClient)
+		if err != nil {
+			return fmt.Errorf("error searching existing customers: %w", err)
+		}
+		for _, customer := range found {
+			key := strings.ToLower(customer.Email)
+			existing[key] = append(existing[key], customer.WebsiteID)
+		}
+	}
+
+	for i, record := range chunk {
+		row := firstRow + i
+		if imp.done(row) {
+			continue
+		}
+		result := ImportRowResult{Row: row, Key: record["email"]}
+		customer, err := customerFromCSVRecord(record, imp.groupIDs, imp.opts.WebsiteID)
+		switch {
+		case err != nil:
+			result.Status, result.Error = ImportRowFailed, err.Error()
+		case customerExists(existing[strings.ToLower(customer.Email)], customer.WebsiteID):
+			result.Status = ImportRowSkipped
+		default:
+			if _, err := CreateCustomer(ctx, customer, "", imp.apiClient); err != nil {
+				if isTransientWriteError(err) {
+					return fmt.Errorf("error importing customer csv row %d: %w", row, err)
+				}
+				result.Status, result.Error = ImportRowFailed, err.Error()
+			} else {
+				result.Status = ImportRowCreated
+			}
+		}
+		imp.results = append(imp.results, result)
+		if imp.opts.Checkpoint != nil {
+			if err := imp.opts.Checkpoint.Record(result); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (imp *customerCSVImporter) done(row int) bool {
+	return imp.opts.Checkpoint != nil && imp.opts.Checkpoint.Done(row)
+}
+
+// customerExists matches any website for rows without one
+func customerExists(websiteIDs []int, websiteID int) bool {
+	return len(websiteIDs) > 0 && (websiteID == 0 || slices.Contains(websiteIDs, websiteID))
+}
+
+func customerFromCSVRecord(record map[string]string, groupIDs map[string]int, defaultWebsiteID int) (*Customer, error) {
+	entity := "customer csv row " + record["email"]
+	if record["email"] == "" {
+		return nil, &ValidationError{Entity: entity, Field: "email", Reason: "is required"}
+	}
+	customer := &Customer{
+		Email:      record["email"],
+		Firstname:  record["firstname"],
+		Lastname:   record["lastname"],
+		Middlename: record["middlename"],
+		Prefix:     record["prefix"],
+		Suffix:     record["suffix"],
+		Dob:        record["dob"],
+		Taxvat:     record["taxvat"],
+		WebsiteID:  defaultWebsiteID,
+	}
+	for column, target := range map[string]*int{"gender": &customer.Gender, "website_id": &customer.WebsiteID, "store_id": &customer.StoreID} {
+		if record[column] == "" {
+			continue
+		}
+		value, err := strconv.Atoi(record[column])
+		if err != nil {
+			return nil, &ValidationError{Entity: entity, Field: column, Reason: fmt.Sprintf("is not a number: %q", record[column])}
+		}
+		*target = value
+	}
+	if name := record["group"]; name != "" {
+		groupID, ok := groupIDs[strings.ToLower(name)]
+		if !ok {
+			return nil, &ValidationError{Entity: entity, Field: "group", Reason: fmt.Sprintf("unknown customer group %q", name)}
+		}
+		customer.GroupID = groupID
+	}
+
+	billing, err := customerCSVAddressFromRecord(record, customerCSVBillingPrefix, customer)
+	if err != nil {
+		return nil, err
+	}
+	shipping, err := customerCSVAddressFromRecord(record, customerCSVShippingPrefix, customer)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case billing != nil && shipping != nil && slices.Equal(customerCSVAddress(billing), customerCSVAddress(shipping)):
+		billing.DefaultShipping = true
+		customer.Addresses = []Address{*billing}
+	default:
+		for _, address := range []*Address{billing, shipping} {
+			if address != nil {
+				customer.Addresses = append(customer.Addresses, *address)
+			}
+		}
+	}
+	return customer, nil
+}
+
+// customerCSVAddressFromRecord reads the prefixed address columns, nil if they are all empty. Names
+// default to the customer's
+func customerCSVAddressFromRecord(record map[string]string, prefix string, customer *Customer) (*Address, error) {
+	empty := true
+	for _, column := range customerCSVAddressColumns {
+		if record[prefix+column] != "" {
+			empty = false
+			break
+		}
+	}
+	if empty {
+		return nil, nil
+	}
+
+	address := &Address{
+		Firstname:       record[prefix+"firstname"],
+		Lastname:        record[prefix+"lastname"],
+		Company:         record[prefix+"company"],
+		City:            record[prefix+"city"],
+		Postcode:        record[prefix+"postcode"],
+		CountryID:       record[prefix+"country_id"],
+		Telephone:       record[prefix+"telephone"],
+		VatID:           record[prefix+"vat_id"],
+		DefaultBilling:  prefix == customerCSVBillingPrefix,
+		DefaultShipping: prefix == customerCSVShippingPrefix,
+	}
+	if street := record[prefix+"street"]; street != "" {
+		address.Street = strings.Split(street, "\n")
+	}
+	if regionID := record[prefix+"region_id"]; regionID != "" {
+		id, err := strconv.Atoi(regionID)
+		if err != nil {
+			return nil, &ValidationError{Entity: "customer csv row " + record["email"], Field: prefix + "region_id", Reason: fmt.Sprintf("is not a number: %q", regionID)}
+		}
+		address.RegionID = id
+	}
+	// customer addresses take the region as an object, Magento rejects a flat region_code
+	if record[prefix+"region"] != "" || record[prefix+"region_code"] != "" || address.RegionID != 0 {
+		address.Region = &AddressRegion{RegionCode: record[prefix+"region_code"], Region: record[prefix+"region"], RegionID: address.RegionID}
+	}
+	if address.Firstname == "" {
+		address.Firstname = customer.Firstname
+	}
+	if address.Lastname == "" {
+		address.Lastname = customer.Lastname
+	}
+	return address, nil
+}
@@ -0,0 +1,76 @@
+package magento2
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// CustomerDataExport bundles everything Magento stores about a customer, e.g. to answer a
+// data subject access request
+type CustomerDataExport struct {
+	ExportedAt  time.Time
+	Customer    *Customer
+	Addresses   []Address
+	Orders      []Order
+	Invoices    []Invoice
+	Shipments   []Shipment
+	CreditMemos []CreditMemo
+}
+
+// ExportCustomerData collects the customer record with addresses, all of the customer's orders and
+// the invoices, shipments and credit memos of those orders. Guest orders placed with the same email
+// are not included, look them up with GetOrdersForCustomerEmail if needed
+func ExportCustomerData(ctx context.Context, customerID int, apiClient *Client) (*CustomerDataExport, error) {
+	customer, err := GetCustomerByID(ctx, customerID, apiClient)
+	if err != nil {
+		return nil, fmt.Errorf("error exporting customer data: %w", err)
+	}
+
+	export := &CustomerDataExport{
+		ExportedAt: time.Now().UTC(),
+		Customer:   customer,
+		Addresses:  customer.Addresses,
+	}
+
+	byCustomer := NewSearchCriteria(SearchFilter{Field: "customer_id", Value: strconv.Itoa(customerID), ConditionType: "eq"})
+	export.Orders, err = searchAll[Order](ctx, Orders, byCustomer, "search orders for customer export", apiClient)
+	if err != nil {
+		return nil, fmt.Errorf("error exporting customer orders: %w", err)
+	}
+	if len(export.Orders) == 0 {
+		return export, nil
+	}
+
+	orderIDs := make([]string, 0, len(export.Orders))
+	for i := range export.Orders {
+		orderIDs = append(orderIDs, strconv.Itoa(export.Orders[i].EntityID))
+	}
+	byOrders := NewSearchCriteria(SearchFilter{Field: "order_id", Value: strings.Join(orderIDs, ","), ConditionType: "in"})
+
+	export.Invoices, err = searchAll[Invoice](ctx, invoices, byOrders, "search invoices for customer export", apiClient)
+	if err != nil {
+		return nil, fmt.Errorf("error exporting customer invoices: %w", err)
+	}
+	export.Shipments, err = searchAll[Shipment](ctx, shipments, byOrders, "search shipments for customer export", apiClient)
+	if err != nil {
+		return nil, fmt.Errorf("error exporting customer shipments: %w", err)
+	}
+	export.CreditMemos, err = searchAll[CreditMemo](ctx, creditmemos, byOrders, "search credit memos for customer export", apiClient)
+	if err != nil {
+		return nil, fmt.Errorf("error exporting customer credit memos: %w", err)
+	}
+
+	log.Debug().
+		Int("customerID", customerID).
+		Int("orders", len(export.Orders)).
+		Int("invoices", len(export.Invoices)).
+		Int("shipments", len(export.Shipments)).
+		Int("creditMemos", len(export.CreditMemos)).
+		Msg("Customer data exported")
+	return export, nil
+}
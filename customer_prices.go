@@ -0,0 +1,157 @@
+package magento2
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	CustomerPriceSourceBase    = "base"
+	CustomerPriceSourceSpecial = "special"
+	CustomerPriceSourceTier    = "tier"
+)
+
+// GetCustomerPrices computes what the customer pays for each SKU when buying qty units: the lowest of
+// the base price of the customer's store, an active special price and the tier prices of the customer's
+// group and website. Catalog price rules are not applied
+func GetCustomerPrices(ctx context.Context, customerID int, skus []string, qty float64, apiClient *Client) (*CustomerPriceReport, error) {
+	customer, err := GetCustomerByID(ctx, customerID, apiClient)
+	if err != nil {
+		return nil, fmt.Errorf("error getting customer prices: %w", err)
+	}
+
+	var (
+		wg            sync.WaitGroup
+		group         *CustomerGroup
+		basePrices    []BasePrice
+		specialPrices []SpecialPrice
+		tierPrices    []TierPrice
+		errs          = make([]error, 4)
+	)
+
+	wg.Add(4)
+	go func() {
+		defer wg.Done()
+		group, errs[0] = GetCustomerGroup(ctx, customer.GroupID, apiClient)
+	}()
+	go func() {
+		defer wg.Done()
+		basePrices, errs[1] = GetBasePrices(ctx, skus, apiClient)
+	}()
+	go func() {
+		defer wg.Done()
+		specialPrices, errs[2] = GetSpecialPrices(ctx, skus, apiClient)
+	}()
+	go func() {
+		defer wg.Done()
+		tierPrices, errs[3] = GetTierPrices(ctx, skus, apiClient)
+	}()
+	wg.Wait()
+
+	if err := errors.Join(errs...); err != nil {
+		return nil, fmt.Errorf("error getting customer prices: %w", err)
+	}
+
+	now := time.Now().UTC()
+	report := &CustomerPriceReport{Customer: customer, Group: group}
+	for _, sku := range skus {
+		price := CustomerPrice{Sku: sku, Qty: qty}
+		price.BasePrice = storePrice(basePrices, sku, customer.StoreID)
+		if price.BasePrice != nil {
+			price.Price = price.BasePrice.Price
+			price.Source = CustomerPriceSourceBase
+		}
+
+		for i := range specialPrices {
+			special := &specialPrices[i]
+			if special.Sku != sku || (special.StoreID != customer.StoreID && special.StoreID != 0) || !isSpecialPriceActive(special, now) {
+				continue
+			}
+			if price.SpecialPrice == nil || special.Price < price.SpecialPrice.Price {
+				price.SpecialPrice = special
+			}
+		}
+		if price.SpecialPrice != nil && (price.Source == "" || price.SpecialPrice.Price < price.Price) {
+			price.Price = price.SpecialPrice.Price
+			price.Source = CustomerPriceSourceSpecial
+		}
+
+		for i := range tierPrices {
+			tier := &tierPrices[i]
+			if tier.Sku != sku || tier.Quantity > qty || (tier.WebsiteID != 0 && tier.WebsiteID != customer.WebsiteID) {
+				continue
+			}
+			if tier.CustomerGroup != AllCustomerGroups && !strings.EqualFold(tier.CustomerGroup, group.Code) {
+				continue
+			}
+			tierValue, ok := tierPriceValue(tier, price.BasePrice)
+			if !ok {
+				continue
+			}
+			if price.Source == "" || tierValue < price.Price {
+				price.Price = tierValue
+				price.Source = CustomerPriceSourceTier
+				price.TierPrice = tier
+			}
+		}
+
+		report.Prices = append(report.Prices, price)
+	}
+
+	log.Debug().
+		Int("customerID", customerID).
+		Str("group", group.Code).
+		Int("skus", len(skus)).
+		Msg("Customer prices computed")
+	return report, nil
+}
+
+// storePrice returns the base price of the store view, falling back to the default scope
+func storePrice(prices []BasePrice, sku string, storeID int) *BasePrice {
+	var fallback *BasePrice
+	for i := range prices {
+		if prices[i].Sku != sku {
+			continue
+		}
+		if prices[i].StoreID == storeID {
+			return &prices[i]
+		}
+		if prices[i].StoreID == 0 {
+			fallback = &prices[i]
+		}
+	}
+	return fallback
+}
+
+// isSpecialPriceActive reports whether now is within the dates of the special price. Like Magento the
+// price_to day is included, the price ends at the start of the following day
+func isSpecialPriceActive(special *SpecialPrice, now time.Time) bool {
+	if from, err := time.Parse(DateTimeFormat, special.PriceFrom); err == nil && now.Before(from) {
+		return false
+	}
+	if to, err := time.Parse(DateTimeFormat, special.PriceTo); err == nil {
+		end := time.Date(to.Year(), to.Month(), to.Day()+1, 0, 0, 0, 0, to.Location())
+		if !now.Before(end) {
+			return false
+		}
+	}
+	return true
+}
+
+// tierPriceValue resolves a tier price to an amount. Discount tiers are a percentage off the base price
+func tierPriceValue(tier *TierPrice, base *BasePrice) (float64, bool) {
+	if tier.PriceType != "discount" {
+		return tier.Price, true
+	}
+	if base == nil {
+		return 0, false
+	}
+	discounted := NewDecimalFromFloat(base.Price).Mul(NewDecimalFromFloat(100 - tier.Price)).Mul(NewDecimal(1, 2))
+	return discounted.Round(2).Float64(), true
+}
@@ -0,0 +1,126 @@
+package magento2
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/rs/zerolog/log"
+)
+
+func GetCustomerByID(ctx context.Context, customerID int, apiClient *Client) (*Customer, error) {
+	endpoint := fmt.Sprintf("%s/%d", customers, customerID)
+	customer := &Customer{}
+
+	log.Debug().
+		Int("customerID", customerID).
+		Str("endpoint", endpoint).
+		Msg("Getting customer by ID")
+
+	resp, err := apiClient.HTTPClient.R().SetContext(ctx).SetResult(customer).Get(endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("error getting customer by ID: %w", err)
+	}
+
+	httpErr := mayReturnErrorForHTTPResponse(resp, "get customer by id from remote")
+	if httpErr != nil {
+		return nil, httpErr
+	}
+
+	return customer, nil
+}
+
+// GetCurrentCustomer returns the customer a customer-token client is authenticated as
+func GetCurrentCustomer(ctx context.Context, apiClient *Client) (*Customer, error) {
+	customer := &Customer{}
+
+	log.Debug().Str("endpoint", customersMe).Msg("Getting current customer")
+
+	resp, err := apiClient.HTTPClient.R().SetContext(ctx).SetResult(customer).Get(customersMe)
+	if err != nil {
+		return nil, fmt.Errorf("error getting current customer: %w", err)
+	}
+
+	httpErr := mayReturnErrorForHTTPResponse(resp, "get current customer from remote")
+	if httpErr != nil {
+		return nil, httpErr
+	}
+
+	return customer, nil
+}
+
+func GetCustomerGroup(ctx context.Context, groupID int, apiClient *Client) (*CustomerGroup, error) {
+	endpoint := fmt.Sprintf("%s/%d", customerGroups, groupID)
+	group := &CustomerGroup{}
+
+	log.Debug().
+		Int("groupID", groupID).
+		Str("endpoint", endpoint).
+		Msg("Getting customer group")
+
+	resp, err := apiClient.HTTPClient.R().SetContext(ctx).SetResult(group).Get(endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("error getting customer group: %w", err)
+	}
+
+	httpErr := mayReturnErrorForHTTPResponse(resp, "get customer group from remote")
+	if httpErr != nil {
+		return nil, httpErr
+	}
+
+	return group, nil
+}
+
+// SearchCustomerGroups returns all customer groups matching the criteria, a nil criteria returns every group
+func SearchCustomerGroups(ctx context.Context, criteria *SearchCriteria, apiClient *Client) ([]CustomerGroup, error) {
+	return searchAll[CustomerGroup](ctx, customerGroupsSearch, criteria, "search customer groups", apiClient)
+}
+
+type createCustomerPayload struct {
+	Customer *Customer `json:"customer"`
+	Password string    `json:"password,omitempty"`
+}
+
+// CreateCustomer creates the customer account. Without password the customer sets one through the
+// password reset email
+func CreateCustomer(ctx context.Context, customer *Customer, password string, apiClient *Client) (*Customer, error) {
+	created := &Customer{}
+	payLoad := &createCustomerPayload{
+		Customer: customer,
+		Password: password,
+	}
+
+	log.Debug().
+		Str("email", customer.Email).
+		Str("endpoint", customers).
+		Msg("Creating customer")
+
+	resp, err := apiClient.HTTPClient.R().SetContext(ctx).SetBody(payLoad).SetResult(created).Post(customers)
+	if err != nil {
+		return nil, fmt.Errorf("error creating customer: %w", err)
+	}
+
+	httpErr := mayReturnErrorForHTTPResponse(resp, "create customer")
+	if httpErr != nil {
+		return nil, httpErr
+	}
+
+	return created, nil
+}
+
+// SearchCustomers returns all customers matching the criteria, paging through the results
+func SearchCustomers(ctx context.Context, criteria *SearchCriteria, apiClient *Client) ([]Customer, error) {
+	return searchAll[Customer](ctx, customersSearch, criteria, "search customers", apiClient)
+}
+
+func DeleteCustomer(ctx context.Context, customerID int, apiClient *Client) error {
+	endpoint := fmt.Sprintf("%s/%d", customers, customerID)
+
+	log.Debug().Int("customerID", customerID).Str("endpoint", endpoint).Msg("Deleting customer")
+
+	resp, err := apiClient.HTTPClient.R().SetContext(ctx).Delete(endpoint)
+	if err != nil {
+		return fmt.Errorf("error deleting customer: %w", err)
+	}
+
+	return mayReturnErrorForHTTPResponse(resp, "delete customer")
+}
@@ -0,0 +1,9 @@
+package magento2
+
+const (
+	customers            = "/customers"
+	customersMe          = "/customers/me"
+	customerGroups       = "/customerGroups"
+	customerGroupsSearch = "/customerGroups/search"
+	customersSearch      = "/customers/search"
+)
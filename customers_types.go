@@ -0,0 +1,30 @@
+package magento2
+
+// AllCustomerGroups is the customer_group of tier prices that apply to every group
+const AllCustomerGroups = "ALL GROUPS"
+
+type CustomerGroup struct {
+	ID                  int            `json:"id"`
+	Code                string         `json:"code"`
+	TaxClassID          int            `json:"tax_class_id"`
+	TaxClassName        string         `json:"tax_class_name,omitempty"`
+	ExtensionAttributes map[string]any `json:"extension_attributes,omitempty"`
+}
+
+// CustomerPrice is what a customer pays for a SKU at a quantity. Source tells which price won:
+// "base", "special" or "tier"
+type CustomerPrice struct {
+	Sku          string
+	Qty          float64
+	BasePrice    *BasePrice
+	SpecialPrice *SpecialPrice
+	TierPrice    *TierPrice
+	Price        float64
+	Source       string
+}
+
+type CustomerPriceReport struct {
+	Customer *Customer
+	Group    *CustomerGroup
+	Prices   []CustomerPrice
+}
@@ -0,0 +1,224 @@
+package magento2
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// DecimalPlaces is the precision Magento stores amounts and quantities with (decimal(20,4))
+const DecimalPlaces = 4
+
+const decimalScale = 10000
+
+// Decimal is a fixed-point number with DecimalPlaces digits, so sums of amounts and quantities don't
+// drift the way float64 does. The zero value is 0
+type Decimal struct {
+	units int64
+}
+
+// NewDecimal returns value * 10^-places, e.g. NewDecimal(1999, 2) is 19.99
+func NewDecimal(value int64, places int) Decimal {
+	for ; places < DecimalPlaces; places++ {
+		value *= 10
+	}
+	d := Decimal{units: value}
+	for ; places > DecimalPlaces; places-- {
+		d.units = roundedDiv(d.units, 10)
+	}
+	return d
+}
+
+// NewDecimalFromFloat rounds f to DecimalPlaces, which recovers the exact value of amounts decoded from JSON
+func NewDecimalFromFloat(f float64) Decimal {
+	return Decimal{units: int64(math.Round(f * decimalScale))}
+}
+
+// ParseDecimal parses a plain decimal string like "-12.5"
+func ParseDecimal(s string) (Decimal, error) {
+	s = strings.TrimSpace(s)
+	negative := strings.HasPrefix(s, "-")
+	digits := strings.TrimPrefix(strings.TrimPrefix(s, "-"), "+")
+
+	intPart, fracPart, _ := strings.Cut(digits, ".")
+	if intPart == "" && fracPart == "" {
+		return Decimal{}, fmt.Errorf("invalid decimal '%s'", s)
+	}
+	if intPart == "" {
+		intPart = "0"
+	}
+
+	roundUp := false
+	if len(fracPart) > DecimalPlaces {
+		roundUp = fracPart[DecimalPlaces] >= '5'
+		if strings.Trim(fracPart[DecimalPlaces:], "0123456789") != "" {
+			return Decimal{}, fmt.Errorf("invalid decimal '%s'", s)
+		}
+		fracPart = fracPart[:DecimalPlaces]
+	}
+	fracPart += strings.Repeat("0", DecimalPlaces-len(fracPart))
+
+	units, err := strconv.ParseInt(intPart+fracPart, 10, 64)
+	if err != nil || units < 0 {
+		return Decimal{}, fmt.Errorf("invalid decimal '%s'", s)
+	}
+	if roundUp {
+		units++
+	}
+	if negative {
+		units = -units
+	}
+	return Decimal{units: units}, nil
+}
+
+func roundedDiv(a, b int64) int64 {
+	q, r := a/b, a%b
+	if 2*abs64(r) >= abs64(b) {
+		if (a < 0) != (b < 0) {
+			q--
+		} else {
+			q++
+		}
+	}
+	return q
+}
+
+func abs64(v int64) int64 {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
+
+func (d Decimal) Add(other Decimal) Decimal {
+	return Decimal{units: d.units + other.units}
+}
+
+func (d Decimal) Sub(other Decimal) Decimal {
+	return Decimal{units: d.units - other.units}
+}
+
+// Mul multiplies and rounds half away from zero to DecimalPlaces
+func (d Decimal) Mul(other Decimal) Decimal {
+	product := new(big.Int).Mul(big.NewInt(d.units), big.NewInt(other.units))
+	quotient, remainder := new(big.Int).QuoRem(product, big.NewInt(decimalScale), new(big.Int))
+	if new(big.Int).Abs(new(big.Int).Mul(remainder, big.NewInt(2))).Cmp(big.NewInt(decimalScale)) >= 0 {
+		quotient.Add(quotient, big.NewInt(int64(product.Sign())))
+	}
+	return Decimal{units: quotient.Int64()}
+}
+
+// mulDiv returns d * num / den rounded half away from zero, without the intermediate rounding of Mul.
+// A zero den returns zero
+func (d Decimal) mulDiv(num, den Decimal) Decimal {
+	if den.IsZero() {
+		return Decimal{}
+	}
+	product := new(big.Int).Mul(big.NewInt(d.units), big.NewInt(num.units))
+	divisor := big.NewInt(den.units)
+	quotient, remainder := new(big.Int).QuoRem(product, divisor, new(big.Int))
+	if new(big.Int).Abs(new(big.Int).Mul(remainder, big.NewInt(2))).Cmp(new(big.Int).Abs(divisor)) >= 0 {
+		quotient.Add(quotient, big.NewInt(int64(product.Sign()*divisor.Sign())))
+	}
+	return Decimal{units: quotient.Int64()}
+}
+
+// MulInt multiplies by an integer factor, e.g. a unit price by a whole quantity
+func (d Decimal) MulInt(factor int64) Decimal {
+	return Decimal{units: d.units * factor}
+}
+
+func (d Decimal) Neg() Decimal {
+	return Decimal{units: -d.units}
+}
+
+// Round rounds half away from zero to the given number of places, e.g. 2 for display amounts
+func (d Decimal) Round(places int) Decimal {
+	if places >= DecimalPlaces {
+		return d
+	}
+	factor := int64(1)
+	for i := places; i < DecimalPlaces; i++ {
+		factor *= 10
+	}
+	return Decimal{units: roundedDiv(d.units, factor) * factor}
+}
+
+// Cmp returns -1, 0 or +1 depending on d being less than, equal to or greater than other
+func (d Decimal) Cmp(other Decimal) int {
+	switch {
+	case d.units < other.units:
+		return -1
+	case d.units > other.units:
+		return 1
+	}
+	return 0
+}
+
+func (d Decimal) IsZero() bool {
+	return d.units == 0
+}
+
+func (d Decimal) Sign() int {
+	return d.Cmp(Decimal{})
+}
+
+func (d Decimal) Float64() float64 {
+	return float64(d.units) / decimalScale
+}
+
+// StringFixed formats with exactly the given number of places, e.g. "19.90"
+func (d Decimal) StringFixed(places int) string {
+	rounded := d.Round(places)
+	sign := ""
+	units := rounded.units
+	if units < 0 {
+		sign = "-"
+		units = -units
+	}
+	s := fmt.Sprintf("%s%d.%04d", sign, units/decimalScale, units%decimalScale)
+	if places <= 0 {
+		return s[:strings.Index(s, ".")]
+	}
+	if places < DecimalPlaces {
+		return s[:len(s)-(DecimalPlaces-places)]
+	}
+	return s
+}
+
+// String formats without trailing zeros, e.g. "19.9"
+func (d Decimal) String() string {
+	s := d.StringFixed(DecimalPlaces)
+	s = strings.TrimRight(s, "0")
+	return strings.TrimSuffix(s, ".")
+}
+
+func (d Decimal) MarshalJSON() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+// UnmarshalJSON accepts JSON numbers as well as numeric strings, Magento returns both depending on the endpoint
+func (d *Decimal) UnmarshalJSON(data []byte) error {
+	data = bytes.Trim(data, `"`)
+	if len(data) == 0 || string(data) == "null" {
+		*d = Decimal{}
+		return nil
+	}
+	if bytes.ContainsAny(data, "eE") {
+		f, err := strconv.ParseFloat(string(data), 64)
+		if err != nil {
+			return fmt.Errorf("invalid decimal '%s': %w", data, err)
+		}
+		*d = NewDecimalFromFloat(f)
+		return nil
+	}
+	parsed, err := ParseDecimal(string(data))
+	if err != nil {
+		return err
+	}
+	*d = parsed
+	return nil
+}
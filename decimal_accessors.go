@@ -0,0 +1,47 @@
+package magento2
+
+// The JSON fields stay float64 for compatibility, these accessors convert at the boundary so
+// arithmetic on quantities and prices is done in Decimal. Converting back is exact for values
+// with up to DecimalPlaces digits. StockItem keeps its int fields, see stock_item_quantities.go
+
+func (item *CartItem) DecimalQty() Decimal {
+	return NewDecimalFromFloat(item.Qty)
+}
+
+func (item *CartItem) SetDecimalQty(qty Decimal) {
+	item.Qty = qty.Float64()
+}
+
+func (item *CartItem) DecimalPrice() Decimal {
+	return NewDecimalFromFloat(item.Price)
+}
+
+// DecimalRowTotal is price times quantity without float rounding
+func (item *CartItem) DecimalRowTotal() Decimal {
+	return item.DecimalPrice().Mul(item.DecimalQty())
+}
+
+func (p *BasePrice) DecimalPrice() Decimal {
+	return NewDecimalFromFloat(p.Price)
+}
+
+func (p *SpecialPrice) DecimalPrice() Decimal {
+	return NewDecimalFromFloat(p.Price)
+}
+
+func (p *TierPrice) DecimalPrice() Decimal {
+	return NewDecimalFromFloat(p.Price)
+}
+
+func (p *TierPrice) DecimalQuantity() Decimal {
+	return NewDecimalFromFloat(p.Quantity)
+}
+
+func (sourceItem *SourceItem) DecimalQuantity() Decimal {
+	return NewDecimalFromFloat(sourceItem.Quantity)
+}
+
+// NetRowTotal is the row total after discount and before tax
+func (i *CartTotalsItem) NetRowTotal() Decimal {
+	return NewDecimalFromFloat(i.RowTotal).Sub(NewDecimalFromFloat(i.DiscountAmount))
+}
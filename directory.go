@@ -0,0 +1,52 @@
+package magento2
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/rs/zerolog/log"
+)
+
+func GetCountries(ctx context.Context, apiClient *Client) ([]Country, error) {
+	countries := &[]Country{}
+
+	log.Debug().Str("endpoint", directoryCountries).Msg("Getting countries")
+
+	resp, err := apiClient.HTTPClient.R().SetContext(ctx).SetResult(countries).Get(directoryCountries)
+	if err != nil {
+		return nil, fmt.Errorf("error getting countries: %w", err)
+	}
+
+	httpErr := mayReturnErrorForHTTPResponse(resp, "get countries from directory")
+	if httpErr != nil {
+		return nil, httpErr
+	}
+
+	return *countries, nil
+}
+
+func GetCountry(ctx context.Context, countryID string, apiClient *Client) (*Country, error) {
+	endpoint := directoryCountries + "/" + url.PathEscape(countryID)
+	country := &Country{}
+
+	log.Debug().Str("endpoint", endpoint).Msg("Getting country")
+
+	resp, err := apiClient.HTTPClient.R().SetContext(ctx).SetResult(country).Get(endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("error getting country: %w", err)
+	}
+
+	httpErr := mayReturnErrorForHTTPResponse(resp, fmt.Sprintf("get country '%s' from directory", countryID))
+	if httpErr != nil {
+		return nil, httpErr
+	}
+
+	return country, nil
+}
+
+// RequiresRegion reports whether Magento expects a region_id for addresses in the country, which is
+// the case for every country the directory lists regions for
+func (c *Country) RequiresRegion() bool {
+	return len(c.AvailableRegions) > 0
+}
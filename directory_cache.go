@@ -0,0 +1,102 @@
+package magento2
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// DirectoryCache keeps the country list of the directory API, which rarely changes, so resolving
+// regions does not cost a request per address. It is safe for concurrent use
+type DirectoryCache struct {
+	APIClient *Client
+	mu        sync.RWMutex
+	countries map[string]*Country
+}
+
+func NewDirectoryCache(apiClient *Client) *DirectoryCache {
+	return &DirectoryCache{
+		APIClient: apiClient,
+	}
+}
+
+// Country returns the cached country, loading all countries from remote on first use
+func (dc *DirectoryCache) Country(ctx context.Context, countryID string) (*Country, error) {
+	dc.mu.RLock()
+	countries := dc.countries
+	dc.mu.RUnlock()
+
+	if countries == nil {
+		list, err := GetCountries(ctx, dc.APIClient)
+		if err != nil {
+			return nil, fmt.Errorf("error loading countries for directory cache: %w", err)
+		}
+		countries = make(map[string]*Country, len(list))
+		for i := range list {
+			countries[list[i].ID] = &list[i]
+		}
+		dc.mu.Lock()
+		dc.countries = countries
+		dc.mu.Unlock()
+	}
+
+	country, ok := countries[strings.ToUpper(countryID)]
+	if !ok {
+		return nil, fmt.Errorf("%w: country '%s'", ErrNotFound, countryID)
+	}
+	return country, nil
+}
+
+// Invalidate drops the cached countries, the next lookup reloads them
+func (dc *DirectoryCache) Invalidate() {
+	dc.mu.Lock()
+	dc.countries = nil
+	dc.mu.Unlock()
+}
+
+// ResolveRegion finds a region of the country by code or name, case-insensitive, e.g. "CA" or "California"
+func (dc *DirectoryCache) ResolveRegion(ctx context.Context, countryID, regionNameOrCode string) (*CountryRegion, error) {
+	country, err := dc.Country(ctx, countryID)
+	if err != nil {
+		return nil, err
+	}
+	wanted := strings.TrimSpace(regionNameOrCode)
+	for i := range country.AvailableRegions {
+		region := &country.AvailableRegions[i]
+		if strings.EqualFold(region.Code, wanted) || strings.EqualFold(region.Name, wanted) {
+			return region, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: region '%s' of country '%s'", ErrNotFound, regionNameOrCode, countryID)
+}
+
+// FillRegion sets RegionID and RegionCode of the address from its region code or name, when the
+// country has regions and the ID is not set yet
+func (dc *DirectoryCache) FillRegion(ctx context.Context, address *Address, regionNameOrCode string) error {
+	if address.RegionID != 0 {
+		return nil
+	}
+	country, err := dc.Country(ctx, address.CountryID)
+	if err != nil {
+		return err
+	}
+	if !country.RequiresRegion() {
+		return nil
+	}
+	if regionNameOrCode == "" {
+		regionNameOrCode = address.RegionCode
+	}
+	region, err := dc.ResolveRegion(ctx, address.CountryID, regionNameOrCode)
+	if err != nil {
+		return err
+	}
+	regionID, err := strconv.Atoi(region.ID)
+	if err != nil {
+		return fmt.Errorf("unexpected region id '%s': %w", region.ID, err)
+	}
+	address.RegionID = regionID
+	address.RegionCode = region.Code
+	return nil
+}
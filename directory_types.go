@@ -0,0 +1,17 @@
+package magento2
+
+type CountryRegion struct {
+	ID   string `json:"id"`
+	Code string `json:"code"`
+	Name string `json:"name"`
+}
+
+type Country struct {
+	ID                      string          `json:"id"`
+	TwoLetterAbbreviation   string          `json:"two_letter_abbreviation"`
+	ThreeLetterAbbreviation string          `json:"three_letter_abbreviation"`
+	FullNameLocale          string          `json:"full_name_locale"`
+	FullNameEnglish         string          `json:"full_name_english"`
+	AvailableRegions        []CountryRegion `json:"available_regions,omitempty"`
+	ExtensionAttributes     map[string]any  `json:"extension_attributes,omitempty"`
+}
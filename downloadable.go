@@ -0,0 +1,133 @@
+package magento2
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/rs/zerolog/log"
+)
+
+// NewDownloadableFileContent reads a local file into the base64 payload Magento expects for link and sample files
+func NewDownloadableFileContent(path string) (*DownloadableFileContent, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading downloadable file: %w", err)
+	}
+	if len(data) == 0 {
+		return nil, fmt.Errorf("error reading downloadable file: %s is empty", path)
+	}
+
+	// Magento takes only the data and the name, it derives the file type from the name's extension
+	log.Debug().
+		Str("path", path).
+		Int("size", len(data)).
+		Msg("Read downloadable file content")
+
+	return &DownloadableFileContent{
+		FileData: base64.StdEncoding.EncodeToString(data),
+		Name:     filepath.Base(path),
+	}, nil
+}
+
+func NewFileDownloadableLink(title, path string, price float64) (*DownloadableLink, error) {
+	content, err := NewDownloadableFileContent(path)
+	if err != nil {
+		return nil, err
+	}
+	return &DownloadableLink{
+		Title:           title,
+		IsShareable:     ShareableConfig,
+		Price:           price,
+		LinkType:        DownloadableTypeFile,
+		LinkFileContent: content,
+	}, nil
+}
+
+func NewURLDownloadableLink(title, url string, price float64) *DownloadableLink {
+	return &DownloadableLink{
+		Title:       title,
+		IsShareable: ShareableConfig,
+		Price:       price,
+		LinkType:    DownloadableTypeURL,
+		LinkURL:     url,
+	}
+}
+
+func NewFileDownloadableSample(title, path string) (*DownloadableSample, error) {
+	content, err := NewDownloadableFileContent(path)
+	if err != nil {
+		return nil, err
+	}
+	return &DownloadableSample{
+		Title:             title,
+		SampleType:        DownloadableTypeFile,
+		SampleFileContent: content,
+	}, nil
+}
+
+func NewURLDownloadableSample(title, url string) *DownloadableSample {
+	return &DownloadableSample{
+		Title:      title,
+		SampleType: DownloadableTypeURL,
+		SampleURL:  url,
+	}
+}
+
+// AddDownloadableLink creates the link on a downloadable product and returns its ID
+func (mProduct *MProduct) AddDownloadableLink(ctx context.Context, link *DownloadableLink) (int, error) {
+	endpoint := mProduct.Route + "/" + downloadableLinksRelative
+	payLoad := addDownloadableLinkPayload{
+		Link:                 *link,
+		IsGlobalScopeContent: true,
+	}
+
+	log.Debug().
+		Str("sku", mProduct.Product.Sku).
+		Str("title", link.Title).
+		Str("linkType", link.LinkType).
+		Str("endpoint", endpoint).
+		Msg("Adding downloadable link to product")
+
+	return mProduct.postDownloadable(ctx, endpoint, payLoad, "add downloadable link to product")
+}
+
+// AddDownloadableSample creates the sample on a downloadable product and returns its ID
+func (mProduct *MProduct) AddDownloadableSample(ctx context.Context, sample *DownloadableSample) (int, error) {
+	endpoint := mProduct.Route + "/" + downloadableSamplesRelative
+	payLoad := addDownloadableSamplePayload{
+		Sample:               *sample,
+		IsGlobalScopeContent: true,
+	}
+
+	log.Debug().
+		Str("sku", mProduct.Product.Sku).
+		Str("title", sample.Title).
+		Str("sampleType", sample.SampleType).
+		Str("endpoint", endpoint).
+		Msg("Adding downloadable sample to product")
+
+	return mProduct.postDownloadable(ctx, endpoint, payLoad, "add downloadable sample to product")
+}
+
+func (mProduct *MProduct) postDownloadable(ctx context.Context, endpoint string, payLoad any, tryTo string) (int, error) {
+	resp, err := mProduct.APIClient.HTTPClient.R().SetContext(ctx).SetBody(payLoad).Post(endpoint)
+	if err != nil {
+		log.Error().Err(err).Str("operation", tryTo).Msg("Error posting downloadable content")
+		return 0, fmt.Errorf("error while trying to %s: %w", tryTo, err)
+	}
+
+	httpErr := mayReturnErrorForHTTPResponse(resp, tryTo)
+	if httpErr != nil {
+		return 0, httpErr
+	}
+
+	id, err := strconv.Atoi(mayTrimSurroundingQuotes(resp.String()))
+	if err != nil {
+		return 0, fmt.Errorf("unexpected error while extracting downloadable ID: %w", err)
+	}
+	return id, nil
+}
@@ -0,0 +1,6 @@
+package magento2
+
+const (
+	downloadableLinksRelative   = "downloadable-links"
+	downloadableSamplesRelative = "downloadable-links/samples"
+)
@@ -0,0 +1,54 @@
+package magento2
+
+const (
+	DownloadableTypeFile = "file"
+	DownloadableTypeURL  = "url"
+)
+
+const (
+	ShareableNo     = 0
+	ShareableYes    = 1
+	ShareableConfig = 2
+)
+
+type DownloadableFileContent struct {
+	FileData string `json:"file_data"`
+	Name     string `json:"name"`
+}
+
+type DownloadableLink struct {
+	ID                int                      `json:"id,omitempty"`
+	Title             string                   `json:"title"`
+	SortOrder         int                      `json:"sort_order"`
+	IsShareable       int                      `json:"is_shareable"`
+	Price             float64                  `json:"price"`
+	NumberOfDownloads int                      `json:"number_of_downloads"`
+	LinkType          string                   `json:"link_type"`
+	LinkFile          string                   `json:"link_file,omitempty"`
+	LinkFileContent   *DownloadableFileContent `json:"link_file_content,omitempty"`
+	LinkURL           string                   `json:"link_url,omitempty"`
+	SampleType        string                   `json:"sample_type,omitempty"`
+	SampleFile        string                   `json:"sample_file,omitempty"`
+	SampleFileContent *DownloadableFileContent `json:"sample_file_content,omitempty"`
+	SampleURL         string                   `json:"sample_url,omitempty"`
+}
+
+type DownloadableSample struct {
+	ID                int                      `json:"id,omitempty"`
+	Title             string                   `json:"title"`
+	SortOrder         int                      `json:"sort_order"`
+	SampleType        string                   `json:"sample_type"`
+	SampleFile        string                   `json:"sample_file,omitempty"`
+	SampleFileContent *DownloadableFileContent `json:"sample_file_content,omitempty"`
+	SampleURL         string                   `json:"sample_url,omitempty"`
+}
+
+type addDownloadableLinkPayload struct {
+	Link                 DownloadableLink `json:"link"`
+	IsGlobalScopeContent bool             `json:"isGlobalScopeContent"`
+}
+
+type addDownloadableSamplePayload struct {
+	Sample               DownloadableSample `json:"sample"`
+	IsGlobalScopeContent bool               `json:"isGlobalScopeContent"`
+}
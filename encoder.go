@@ -0,0 +1,205 @@
+package magento2
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+)
+
+// ColumnType is the type of the values of an export column
+type ColumnType string
+
+const (
+	ColumnString ColumnType = "string"
+	// ColumnNumber values are decimal numbers like "119.00" or "2"
+	ColumnNumber ColumnType = "number"
+	// ColumnBool values are "true" or "false"
+	ColumnBool ColumnType = "bool"
+)
+
+// ExportColumn is a column of an export with the type of its values
+type ExportColumn struct {
+	Name string
+	Type ColumnType
+}
+
+// StringColumns returns string columns with the given names
+func StringColumns(names ...string) []ExportColumn {
+	columns := make([]ExportColumn, len(names))
+	for i, name := range names {
+		columns[i] = ExportColumn{Name: name, Type: ColumnString}
+	}
+	return columns
+}
+
+func columnNames(columns []ExportColumn) []string {
+	names := make([]string, len(columns))
+	for i, column := range columns {
+		names[i] = column.Name
+	}
+	return names
+}
+
+// Encoder serializes the rows of an export. Begin receives every column a row can have, in order and
+// with its type, before the first row, e.g. to derive a Parquet or Avro schema. Encode receives the
+// columns and values of one row, a subset of the Begin columns in the same order; values are formatted
+// as their column type says and an empty value of a number or bool column means there is none. Close
+// flushes buffered output, it does not close the underlying writer
+type Encoder interface {
+	Begin(columns []ExportColumn) error
+	Encode(columns, values []string) error
+	Close() error
+}
+
+// CSVEncoder writes a header line and one line per row, columns a row does not have stay empty
+type CSVEncoder struct {
+	w       *csv.Writer
+	columns []string
+}
+
+func NewCSVEncoder(w io.Writer) *CSVEncoder {
+	return &CSVEncoder{w: csv.NewWriter(w)}
+}
+
+func (e *CSVEncoder) Begin(columns []ExportColumn) error {
+	e.columns = columnNames(columns)
+	if err := e.w.Write(e.columns); err != nil {
+		return fmt.Errorf("error writing csv header: %w", err)
+	}
+	return nil
+}
+
+func (e *CSVEncoder) Encode(columns, values []string) error {
+	record := values
+	if !slices.Equal(columns, e.columns) {
+		record = make([]string, len(e.columns))
+		for i, column := range columns {
+			if index := slices.Index(e.columns, column); index >= 0 {
+				record[index] = values[i]
+			}
+		}
+	}
+	return e.w.Write(record)
+}
+
+func (e *CSVEncoder) Close() error {
+	e.w.Flush()
+	return e.w.Error()
+}
+
+// jsonObjectEncoder writes rows as JSON objects with the row's columns in order. Number and bool values
+// are written as JSON numbers and booleans, empty ones as null; columns without a type are strings
+type jsonObjectEncoder struct {
+	types map[string]ColumnType
+}
+
+func (e *jsonObjectEncoder) begin(columns []ExportColumn) {
+	e.types = make(map[string]ColumnType, len(columns))
+	for _, column := range columns {
+		e.types[column.Name] = column.Type
+	}
+}
+
+func (e *jsonObjectEncoder) object(buf *bytes.Buffer, columns, values []string) {
+	buf.WriteByte('{')
+	for i, column := range columns {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		key, _ := json.Marshal(column)
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(e.value(column, values[i]))
+	}
+	buf.WriteByte('}')
+}
+
+func (e *jsonObjectEncoder) value(column, value string) []byte {
+	switch e.types[column] {
+	case ColumnNumber:
+		if value == "" {
+			return []byte("null")
+		}
+		if _, err := strconv.ParseFloat(value, 64); err == nil && json.Valid([]byte(value)) {
+			return []byte(value)
+		}
+	case ColumnBool:
+		if value == "" {
+			return []byte("null")
+		}
+		if b, err := strconv.ParseBool(value); err == nil {
+			return []byte(strconv.FormatBool(b))
+		}
+	}
+	encoded, _ := json.Marshal(value)
+	return encoded
+}
+
+// JSONLEncoder writes one JSON object per row with the row's columns in order
+type JSONLEncoder struct {
+	jsonObjectEncoder
+	w io.Writer
+}
+
+func NewJSONLEncoder(w io.Writer) *JSONLEncoder {
+	return &JSONLEncoder{w: w}
+}
+
+func (e *JSONLEncoder) Begin(columns []ExportColumn) error {
+	e.begin(columns)
+	return nil
+}
+
+func (e *JSONLEncoder) Encode(columns, values []string) error {
+	var buf bytes.Buffer
+	e.object(&buf, columns, values)
+	buf.WriteByte('\n')
+	_, err := e.w.Write(buf.Bytes())
+	return err
+}
+
+func (e *JSONLEncoder) Close() error {
+	return nil
+}
+
+// JSONEncoder writes the rows as a JSON array of objects, one per line, with the row's columns in order
+type JSONEncoder struct {
+	jsonObjectEncoder
+	w    io.Writer
+	rows int
+}
+
+func NewJSONEncoder(w io.Writer) *JSONEncoder {
+	return &JSONEncoder{w: w}
+}
+
+func (e *JSONEncoder) Begin(columns []ExportColumn) error {
+	e.begin(columns)
+	return nil
+}
+
+func (e *JSONEncoder) Encode(columns, values []string) error {
+	var buf bytes.Buffer
+	if e.rows == 0 {
+		buf.WriteString("[\n")
+	} else {
+		buf.WriteString(",\n")
+	}
+	e.object(&buf, columns, values)
+	e.rows++
+	_, err := e.w.Write(buf.Bytes())
+	return err
+}
+
+func (e *JSONEncoder) Close() error {
+	end := "\n]\n"
+	if e.rows == 0 {
+		end = "[]\n"
+	}
+	_, err := io.WriteString(e.w, end)
+	return err
+}
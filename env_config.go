@@ -0,0 +1,128 @@
+package magento2
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+)
+
+const (
+	EnvHost        = "MAGENTO_HOST"
+	EnvBearerToken = "MAGENTO_BEARER_TOKEN"
+	EnvStoreCode   = "MAGENTO_STORE_CODE"
+	EnvTimeout     = "MAGENTO_TIMEOUT"
+
+	defaultEnvStoreCode = "all"
+)
+
+// StoreConfigFromURL builds a StoreConfig from a host with or without scheme, e.g. "https://shop.example.com"
+// or "shop.example.com:8443". Hosts without scheme use https. A path is kept as the BasePath of
+// installations in a subdirectory, e.g. "https://example.com/shop"
+func StoreConfigFromURL(host, storeCode string) (*StoreConfig, error) {
+	host = strings.TrimSpace(host)
+	if host == "" {
+		return nil, fmt.Errorf("host is required")
+	}
+	if !strings.Contains(host, "://") {
+		host = "https://" + host
+	}
+
+	parsed, err := url.Parse(host)
+	if err != nil {
+		return nil, fmt.Errorf("invalid host '%s': %w", host, err)
+	}
+	if parsed.Scheme != "http" && parsed.Scheme != "https" {
+		return nil, fmt.Errorf("invalid host '%s': unsupported scheme '%s'", host, parsed.Scheme)
+	}
+	if parsed.Host == "" {
+		return nil, fmt.Errorf("invalid host '%s': missing host name", host)
+	}
+
+	return &StoreConfig{
+		Scheme:    parsed.Scheme,
+		HostName:  parsed.Host,
+		BasePath:  strings.TrimRight(parsed.EscapedPath(), "/"),
+		StoreCode: storeCode,
+	}, nil
+}
+
+// EnvConfig is the client configuration LoadEnvConfig reads from the environment. Timeout is 0 unless
+// MAGENTO_TIMEOUT is set
+type EnvConfig struct {
+	Host        string
+	BearerToken string
+	StoreCode   string
+	Timeout     time.Duration
+}
+
+// LoadEnvConfig reads MAGENTO_HOST, MAGENTO_BEARER_TOKEN, MAGENTO_STORE_CODE (default "all") and
+// MAGENTO_TIMEOUT (a Go duration like "90s", optional), so tests and scripts parse them the same way
+func LoadEnvConfig() (*EnvConfig, error) {
+	return LoadEnvConfigWithDefaults(EnvConfig{StoreCode: defaultEnvStoreCode})
+}
+
+// LoadEnvConfigWithDefaults reads the variables of LoadEnvConfig, taking the values of defaults for
+// those that are not set. MAGENTO_HOST is only required without a default host
+func LoadEnvConfigWithDefaults(defaults EnvConfig) (*EnvConfig, error) {
+	config := &defaults
+	if host := os.Getenv(EnvHost); host != "" {
+		config.Host = host
+	}
+	if token := os.Getenv(EnvBearerToken); token != "" {
+		config.BearerToken = token
+	}
+	if storeCode := os.Getenv(EnvStoreCode); storeCode != "" {
+		config.StoreCode = storeCode
+	}
+	if config.Host == "" {
+		return nil, fmt.Errorf("%s environment variable is required", EnvHost)
+	}
+	if config.BearerToken == "" {
+		return nil, fmt.Errorf("%s environment variable is required", EnvBearerToken)
+	}
+	if timeout := os.Getenv(EnvTimeout); timeout != "" {
+		d, err := time.ParseDuration(timeout)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s: %w", EnvTimeout, err)
+		}
+		config.Timeout = d
+	}
+	return config, nil
+}
+
+// StoreConfig returns the store configuration of the environment's host and store code
+func (e *EnvConfig) StoreConfig() (*StoreConfig, error) {
+	storeConfig, err := StoreConfigFromURL(e.Host, e.StoreCode)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", EnvHost, err)
+	}
+	return storeConfig, nil
+}
+
+// NewAPIClientFromEnv creates an integration client from the environment as read by LoadEnvConfig
+func NewAPIClientFromEnv(ctx context.Context) (*Client, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	config, err := LoadEnvConfig()
+	if err != nil {
+		return nil, err
+	}
+	storeConfig, err := config.StoreConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := NewAPIClientFromIntegration(storeConfig, config.BearerToken)
+	if err != nil {
+		return nil, err
+	}
+	if config.Timeout > 0 {
+		client.SetDefaultTimeout(config.Timeout)
+	}
+	return client, nil
+}
@@ -0,0 +1,135 @@
+package magento2
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/rs/zerolog/log"
+)
+
+// Extension calls the REST endpoints of a third-party Magento module below Prefix, e.g. "/wishlist".
+// Calls share the client's retries, logging, error handling and RequestOptions such as WithStoreCode
+type Extension struct {
+	Prefix    string
+	APIClient *Client
+}
+
+func (c *Client) Extension(prefix string) *Extension {
+	return &Extension{
+		Prefix:    prefix,
+		APIClient: c,
+	}
+}
+
+// Get decodes the response of route, relative to the prefix, into result
+func (e *Extension) Get(ctx context.Context, route string, result any, opts ...RequestOption) error {
+	return e.Do(ctx, http.MethodGet, route, nil, result, opts...)
+}
+
+func (e *Extension) Post(ctx context.Context, route string, body, result any, opts ...RequestOption) error {
+	return e.Do(ctx, http.MethodPost, route, body, result, opts...)
+}
+
+func (e *Extension) Put(ctx context.Context, route string, body, result any, opts ...RequestOption) error {
+	return e.Do(ctx, http.MethodPut, route, body, result, opts...)
+}
+
+func (e *Extension) Delete(ctx context.Context, route string, result any, opts ...RequestOption) error {
+	return e.Do(ctx, http.MethodDelete, route, nil, result, opts...)
+}
+
+// Do sends body to route with the given method and decodes the response into result. Both may be nil
+func (e *Extension) Do(ctx context.Context, method, route string, body, result any, opts ...RequestOption) error {
+	o := newRequestOptions(opts)
+	endpoint := o.endpoint(e.APIClient, e.Prefix+route)
+	tryTo := fmt.Sprintf("call extension endpoint %s %s", method, e.Prefix+route)
+
+	log.Debug().
+		Str("method", method).
+		Str("endpoint", endpoint).
+		Msg("Calling extension endpoint")
+
+	req, cancel := o.newRequest(ctx, e.APIClient)
+	defer cancel()
+
+	if body != nil {
+		req.SetBody(body)
+	}
+	if result != nil {
+		req.SetResult(result)
+	}
+	resp, err := req.Execute(method, endpoint)
+	if err != nil {
+		return fmt.Errorf("error while trying to %s: %w", tryTo, err)
+	}
+
+	return mayReturnErrorForHTTPResponse(resp, tryTo)
+}
+
+// RegisterExtension registers the endpoint group of a module under name, so code holding only the
+// client can look it up with RegisteredExtension. Clones keep the registrations
+func (c *Client) RegisterExtension(name, prefix string) *Extension {
+	extension := c.Extension(prefix)
+	c.extensionsMu.Lock()
+	if c.extensions == nil {
+		c.extensions = map[string]*Extension{}
+	}
+	c.extensions[name] = extension
+	c.extensionsMu.Unlock()
+	log.Debug().Str("name", name).Str("prefix", prefix).Msg("Extension registered")
+	return extension
+}
+
+// RegisteredExtension returns the extension registered under name or an ErrUnknownExtension error
+func (c *Client) RegisteredExtension(name string) (*Extension, error) {
+	c.extensionsMu.RLock()
+	extension, ok := c.extensions[name]
+	c.extensionsMu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("%w: '%s'", ErrUnknownExtension, name)
+	}
+	return extension, nil
+}
+
+// Endpoint is a typed route of an extension. Route is relative to the extension prefix and may contain
+// fmt verbs filled in by Path, e.g. NewEndpoint[struct{}, []WishlistItem](http.MethodGet, "/items")
+type Endpoint[Req, Resp any] struct {
+	Method string
+	Route  string
+}
+
+func NewEndpoint[Req, Resp any](method, route string) Endpoint[Req, Resp] {
+	return Endpoint[Req, Resp]{
+		Method: method,
+		Route:  route,
+	}
+}
+
+// Path fills the route parameters. String parameters are path escaped
+func (ep Endpoint[Req, Resp]) Path(params ...any) Endpoint[Req, Resp] {
+	escaped := make([]any, len(params))
+	for i, param := range params {
+		if s, ok := param.(string); ok {
+			param = url.PathEscape(s)
+		}
+		escaped[i] = param
+	}
+	ep.Route = fmt.Sprintf(ep.Route, escaped...)
+	return ep
+}
+
+// Call sends request, which may be nil for calls without body, and returns the decoded response
+func (ep Endpoint[Req, Resp]) Call(ctx context.Context, extension *Extension, request *Req, opts ...RequestOption) (*Resp, error) {
+	var body any
+	if request != nil {
+		body = request
+	}
+	response := new(Resp)
+	err := extension.Do(ctx, ep.Method, ep.Route, body, response, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
@@ -0,0 +1,192 @@
+package magento2
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"math/rand"
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// HeaderInjectedFault marks the responses fabricated by fault injection
+const HeaderInjectedFault = "X-Injected-Fault"
+
+var (
+	ErrInjectedFault = errors.New("injected fault")
+	// ErrFaultInjectionDisabled is returned by WithFaultInjection in builds without the faultinjection tag
+	ErrFaultInjectionDisabled = errors.New("fault injection is disabled, build with the faultinjection tag")
+)
+
+// FaultPolicy decides which request attempts WithFaultInjection fails. Each attempt draws once: with
+// TimeoutRate it times out, with RateLimitRate it gets a 429, with ServerErrorRate a ServerErrorStatus
+// (503 by default), otherwise it goes through. Rates are in [0, 1] and add up to at most 1
+type FaultPolicy struct {
+	TimeoutRate       float64
+	RateLimitRate     float64
+	ServerErrorRate   float64
+	ServerErrorStatus int
+	// TimeoutAfter is how long an injected timeout hangs before it fails, bounded by the request's
+	// context. 0 fails at once
+	TimeoutAfter time.Duration
+	// RetryAfter is sent as Retry-After with injected 429s, rounded up to whole seconds. 0 leaves the
+	// header out
+	RetryAfter time.Duration
+	// Routes limits injection to the routes matching one of the path.Match patterns, relative to the REST
+	// prefix as with WithSingleflight. Without patterns all requests can fail
+	Routes []string
+	// Seed makes the injected faults reproducible, 0 seeds from the clock
+	Seed int64
+}
+
+func (p FaultPolicy) validate() error {
+	for _, rate := range []float64{p.TimeoutRate, p.RateLimitRate, p.ServerErrorRate} {
+		if rate < 0 || rate > 1 {
+			return fmt.Errorf("invalid fault rate %v, must be between 0 and 1", rate)
+		}
+	}
+	if sum := p.TimeoutRate + p.RateLimitRate + p.ServerErrorRate; sum > 1 {
+		return fmt.Errorf("invalid fault rates, they add up to %v", sum)
+	}
+	if p.ServerErrorStatus != 0 && (p.ServerErrorStatus < 500 || p.ServerErrorStatus > 599) {
+		return fmt.Errorf("invalid fault server error status %d", p.ServerErrorStatus)
+	}
+	for _, pattern := range p.Routes {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("invalid fault route pattern '%s': %w", pattern, err)
+		}
+	}
+	return nil
+}
+
+// WithFaultInjection fails request attempts at the transport layer as the policy says, so applications
+// can verify their retry, backoff and idempotency handling against this client. Injected faults pass
+// through the same retry conditions and error handling as real ones. It is opt-in per build: only
+// builds with the faultinjection tag, e.g. go test -tags faultinjection, accept the option, all others
+// reject it with ErrFaultInjectionDisabled
+func WithFaultInjection(policy FaultPolicy) ClientOption {
+	return func(o *clientOptions) error {
+		if !faultInjectionAvailable {
+			return ErrFaultInjectionDisabled
+		}
+		if err := policy.validate(); err != nil {
+			return err
+		}
+		if policy.ServerErrorStatus == 0 {
+			policy.ServerErrorStatus = http.StatusServiceUnavailable
+		}
+		o.faultPolicy = &policy
+		return nil
+	}
+}
+
+// faultTimeoutError is returned for injected timeouts, it reports itself as a net.Error timeout
+type faultTimeoutError struct{}
+
+func (faultTimeoutError) Error() string   { return "injected fault: timeout" }
+func (faultTimeoutError) Timeout() bool   { return true }
+func (faultTimeoutError) Temporary() bool { return true }
+func (faultTimeoutError) Unwrap() error   { return ErrInjectedFault }
+
+// faultTransport fails request attempts according to its policy before they reach the base transport
+type faultTransport struct {
+	base   http.RoundTripper
+	policy FaultPolicy
+	mu     sync.Mutex
+	rand   *rand.Rand
+}
+
+func newFaultTransport(base http.RoundTripper, policy FaultPolicy) *faultTransport {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	seed := policy.Seed
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+	return &faultTransport{
+		base:   base,
+		policy: policy,
+		rand:   rand.New(rand.NewSource(seed)),
+	}
+}
+
+func (t *faultTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if !t.matches(req.URL.Path) {
+		return t.base.RoundTrip(req)
+	}
+	t.mu.Lock()
+	draw := t.rand.Float64()
+	t.mu.Unlock()
+
+	switch {
+	case draw < t.policy.TimeoutRate:
+		log.Debug().Str("method", req.Method).Str("url", req.URL.String()).Msg("Injecting timeout")
+		if t.policy.TimeoutAfter > 0 {
+			timer := time.NewTimer(t.policy.TimeoutAfter)
+			defer timer.Stop()
+			select {
+			case <-req.Context().Done():
+				return nil, req.Context().Err()
+			case <-timer.C:
+			}
+		}
+		return nil, faultTimeoutError{}
+	case draw < t.policy.TimeoutRate+t.policy.RateLimitRate:
+		resp := t.response(req, http.StatusTooManyRequests)
+		if t.policy.RetryAfter > 0 {
+			resp.Header.Set("Retry-After", strconv.Itoa(int(math.Ceil(t.policy.RetryAfter.Seconds()))))
+		}
+		return resp, nil
+	case draw < t.policy.TimeoutRate+t.policy.RateLimitRate+t.policy.ServerErrorRate:
+		return t.response(req, t.policy.ServerErrorStatus), nil
+	}
+	return t.base.RoundTrip(req)
+}
+
+// response fabricates an error response in the format Magento uses
+func (t *faultTransport) response(req *http.Request, status int) *http.Response {
+	log.Debug().Str("method", req.Method).Str("url", req.URL.String()).Int("status", status).Msg("Injecting error response")
+	if req.Body != nil {
+		req.Body.Close()
+	}
+	body := fmt.Sprintf(`{"message":"%s: %d %s"}`, ErrInjectedFault, status, http.StatusText(status))
+	header := http.Header{}
+	header.Set("Content-Type", "application/json")
+	header.Set(HeaderInjectedFault, strconv.Itoa(status))
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", status, http.StatusText(status)),
+		StatusCode:    status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader([]byte(body))),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}
+}
+
+func (t *faultTransport) matches(urlPath string) bool {
+	if len(t.policy.Routes) == 0 {
+		return true
+	}
+	route := urlPath
+	if i := strings.Index(urlPath, "/V1/"); i >= 0 {
+		route = urlPath[i+len("/V1"):]
+	}
+	for _, pattern := range t.policy.Routes {
+		if ok, _ := path.Match(pattern, route); ok {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,5 @@
+//go:build faultinjection
+
+package magento2
+
+const faultInjectionAvailable = true
@@ -0,0 +1,65 @@
+// Package features tells which optional Magento APIs a store provides, based on the version it reports,
+// so calling code can branch per Magento version instead of probing endpoints
+package features
+
+import (
+	"context"
+	"fmt"
+
+	magento2 "github.com/florinel-chis/go-m2rest"
+)
+
+type Feature string
+
+const (
+	// MSI are the multi-source inventory endpoints, e.g. /inventory/sources
+	MSI Feature = "msi"
+	// AsyncBulk are the /async/bulk endpoints of the asynchronous web API
+	AsyncBulk Feature = "async_bulk"
+	// ReviewsREST are product review endpoints. Magento core exposes reviews only through GraphQL,
+	// set a requirement in Matrix when a module provides them
+	ReviewsREST Feature = "reviews_rest"
+	// TwoFactorAuth means admin tokens are issued only after a second factor, e.g. /tfa/provider/google/authenticate
+	TwoFactorAuth Feature = "two_factor_auth"
+)
+
+// Requirement describes the stores providing a feature. An empty MinVersion means core doesn't provide it,
+// an empty Editions list means all editions do
+type Requirement struct {
+	MinVersion string
+	Editions   []string
+}
+
+// Matrix holds the requirement of each feature. Adjust it before use when modules add or remove APIs
+var Matrix = map[Feature]Requirement{
+	MSI:           {MinVersion: "2.3"},
+	AsyncBulk:     {MinVersion: "2.3"},
+	ReviewsREST:   {},
+	TwoFactorAuth: {MinVersion: "2.4"},
+}
+
+// Supported reports whether the store of the client provides the feature. The version is detected once per client
+func Supported(ctx context.Context, client *magento2.Client, feature Feature) (bool, error) {
+	version, err := magento2.GetMagentoVersion(ctx, client)
+	if err != nil {
+		return false, fmt.Errorf("error checking support of feature '%s': %w", feature, err)
+	}
+	return SupportedBy(version, feature), nil
+}
+
+// SupportedBy reports whether a store of the given version provides the feature
+func SupportedBy(version *magento2.MagentoVersion, feature Feature) bool {
+	requirement, ok := Matrix[feature]
+	if !ok || requirement.MinVersion == "" || !version.AtLeast(requirement.MinVersion) {
+		return false
+	}
+	if len(requirement.Editions) == 0 {
+		return true
+	}
+	for _, edition := range requirement.Editions {
+		if edition == version.Edition {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,177 @@
+// Package feeds streams product feeds, e.g. the price and availability feeds marketplaces and
+// comparison sites poll, straight from the product search into a CSV or JSON writer
+package feeds
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	magento2 "github.com/florinel-chis/go-m2rest"
+	"github.com/rs/zerolog/log"
+)
+
+// Format selects the encoding of a feed
+type Format string
+
+const (
+	FormatCSV  Format = "csv"
+	FormatJSON Format = "json"
+)
+
+// DefaultWorkers is the number of product pages fetched concurrently when Options.Workers is not set
+const DefaultWorkers = 4
+
+var ErrUnknownFormat = errors.New("unknown feed format")
+
+// PriceStockRow is one line of the price and availability feed. SpecialPrice is only set while the
+// special price is active, Salable requires the product to be enabled and in stock
+type PriceStockRow struct {
+	Sku          string   `json:"sku"`
+	Price        float64  `json:"price"`
+	SpecialPrice *float64 `json:"special_price"`
+	Qty          float64  `json:"qty"`
+	Salable      bool     `json:"salable"`
+}
+
+var priceStockColumns = []magento2.ExportColumn{
+	{Name: "sku", Type: magento2.ColumnString},
+	{Name: "price", Type: magento2.ColumnNumber},
+	{Name: "special_price", Type: magento2.ColumnNumber},
+	{Name: "qty", Type: magento2.ColumnNumber},
+	{Name: "salable", Type: magento2.ColumnBool},
+}
+
+// Options configures a feed. Criteria filters the products, nil exports the whole catalog.
+// Now decides which special prices are active and defaults to time.Now. Encoder replaces the encoder
+// of Format, e.g. with a Parquet writer, the feed writer is not used then
+type Options struct {
+	Criteria *magento2.SearchCriteria
+	Format   Format
+	Encoder  magento2.Encoder
+	Workers  int
+	Now      time.Time
+}
+
+func (o *Options) withDefaults() *Options {
+	options := *o
+	if options.Criteria == nil {
+		options.Criteria = &magento2.SearchCriteria{}
+	}
+	if options.Format == "" {
+		options.Format = FormatCSV
+	}
+	if options.Workers == 0 {
+		options.Workers = DefaultWorkers
+	}
+	if options.Now.IsZero() {
+		options.Now = time.Now()
+	}
+	return &options
+}
+
+// NewPriceStockRow builds the feed row of a product as returned by the product search
+func NewPriceStockRow(product *magento2.Product, now time.Time) PriceStockRow {
+	row := PriceStockRow{
+		Sku:   product.Sku,
+		Price: product.Price,
+	}
+	if specialPrice, ok := product.ActiveSpecialPrice(now); ok {
+		row.SpecialPrice = &specialPrice
+	}
+	if stockItem, ok := product.StockItem(); ok {
+		row.Qty = stockItem.DecimalQty().Float64()
+		row.Salable = stockItem.IsInStock && product.Status == magento2.ProductStatusEnabled
+	}
+	return row
+}
+
+// WritePriceStock streams the price and availability feed of all products matching opts.Criteria to w
+// and returns the number of rows written. Pages are fetched in parallel but written in order
+func WritePriceStock(ctx context.Context, apiClient *magento2.Client, w io.Writer, opts Options) (int, error) {
+	o := opts.withDefaults()
+
+	enc, err := newEncoder(o, w, priceStockColumns)
+	if err != nil {
+		return 0, err
+	}
+
+	log.Debug().
+		Str("format", string(o.Format)).
+		Int("workers", o.Workers).
+		Msg("Writing price and stock feed")
+
+	return writeFeed(ctx, apiClient, enc, o, priceStockColumns, func(product *magento2.Product) ([]string, error) {
+		return NewPriceStockRow(product, o.Now).record(), nil
+	})
+}
+
+// writeFeed encodes the values built for every product matching o.Criteria and finishes the feed
+func writeFeed(ctx context.Context, apiClient *magento2.Client, enc magento2.Encoder, o *Options, columns []magento2.ExportColumn, newRow func(product *magento2.Product) ([]string, error)) (int, error) {
+	names := make([]string, len(columns))
+	for i, column := range columns {
+		names[i] = column.Name
+	}
+	rows := 0
+	err := magento2.ForEachProductPage(ctx, o.Criteria, o.Workers, apiClient, func(products []magento2.Product) error {
+		for i := range products {
+			values, err := newRow(&products[i])
+			if err != nil {
+				return err
+			}
+			if err := enc.Encode(names, values); err != nil {
+				return fmt.Errorf("error writing feed row for sku %s: %w", products[i].Sku, err)
+			}
+			rows++
+		}
+		return nil
+	})
+	if err != nil {
+		return rows, err
+	}
+	if err := enc.Close(); err != nil {
+		return rows, fmt.Errorf("error finishing feed: %w", err)
+	}
+	return rows, nil
+}
+
+// record returns the values of the row in the order of priceStockColumns
+func (row PriceStockRow) record() []string {
+	specialPrice := ""
+	if row.SpecialPrice != nil {
+		specialPrice = formatFloat(*row.SpecialPrice)
+	}
+	return []string{
+		row.Sku,
+		formatFloat(row.Price),
+		specialPrice,
+		formatFloat(row.Qty),
+		strconv.FormatBool(row.Salable),
+	}
+}
+
+// newEncoder returns o.Encoder or the encoder of o.Format and begins the feed with the columns
+func newEncoder(o *Options, w io.Writer, columns []magento2.ExportColumn) (magento2.Encoder, error) {
+	enc := o.Encoder
+	if enc == nil {
+		switch o.Format {
+		case FormatCSV:
+			enc = magento2.NewCSVEncoder(w)
+		case FormatJSON:
+			enc = magento2.NewJSONEncoder(w)
+		default:
+			return nil, fmt.Errorf("%w: %q", ErrUnknownFormat, o.Format)
+		}
+	}
+	if err := enc.Begin(columns); err != nil {
+		return nil, fmt.Errorf("error beginning feed: %w", err)
+	}
+	return enc, nil
+}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
@@ -0,0 +1,255 @@
+package feeds
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"html"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	magento2 "github.com/florinel-chis/go-m2rest"
+	"github.com/rs/zerolog/log"
+)
+
+// Derived sources a Column can read besides product fields and custom attribute codes
+const (
+	SourceQty          = "qty"
+	SourceIsInStock    = "is_in_stock"
+	SourceSalable      = "salable"
+	SourceSpecialPrice = "special_price"
+)
+
+var (
+	ErrInvalidMapping = errors.New("invalid feed mapping")
+	ErrMissingValue   = errors.New("required feed value is empty")
+)
+
+var htmlTags = regexp.MustCompile(`<[^>]*>`)
+
+// Transform rewrites the value of a column. It sees the whole product, so transforms can combine attributes
+type Transform func(value string, product *magento2.Product) (string, error)
+
+// Column maps a Magento attribute code, a product field like "sku" or "price" or one of the Source*
+// values to a feed column. Transforms run in order, Default replaces an empty result and Required
+// fails the feed on an empty value instead of emitting an incomplete row
+type Column struct {
+	Name       string
+	Source     string
+	Transforms []Transform
+	Default    string
+	Required   bool
+}
+
+// Mapping is the ordered list of columns of a channel-specific feed
+type Mapping []Column
+
+// Validate checks that every column has a name and a source and that the names are unique
+func (m Mapping) Validate() error {
+	if len(m) == 0 {
+		return fmt.Errorf("%w: no columns", ErrInvalidMapping)
+	}
+	seen := make(map[string]bool, len(m))
+	for i, column := range m {
+		if column.Name == "" || column.Source == "" {
+			return fmt.Errorf("%w: column %d needs a name and a source", ErrInvalidMapping, i)
+		}
+		if seen[column.Name] {
+			return fmt.Errorf("%w: duplicate column %q", ErrInvalidMapping, column.Name)
+		}
+		seen[column.Name] = true
+	}
+	return nil
+}
+
+func (m Mapping) header() []string {
+	header := make([]string, len(m))
+	for i, column := range m {
+		header[i] = column.Name
+	}
+	return header
+}
+
+// Row maps a product to the values of the columns
+func (m Mapping) Row(product *magento2.Product, now time.Time) ([]string, error) {
+	values := make([]string, len(m))
+	for i, column := range m {
+		value := sourceValue(product, column.Source, now)
+		for _, transform := range column.Transforms {
+			var err error
+			if value, err = transform(value, product); err != nil {
+				return nil, fmt.Errorf("error transforming column %s: %w", column.Name, err)
+			}
+		}
+		if value == "" {
+			value = column.Default
+		}
+		if value == "" && column.Required {
+			return nil, fmt.Errorf("%w: column %s", ErrMissingValue, column.Name)
+		}
+		values[i] = value
+	}
+	return values, nil
+}
+
+func sourceValue(product *magento2.Product, source string, now time.Time) string {
+	switch source {
+	case "id":
+		return strconv.Itoa(product.ID)
+	case "sku":
+		return product.Sku
+	case "name":
+		return product.Name
+	case "price":
+		return formatFloat(product.Price)
+	case "status":
+		return strconv.Itoa(product.Status)
+	case "visibility":
+		return strconv.Itoa(product.Visibility)
+	case "type_id":
+		return product.TypeID
+	case "weight":
+		return formatFloat(product.Weight)
+	case "attribute_set_id":
+		return strconv.Itoa(product.AttributeSetID)
+	case "created_at":
+		return product.CreatedAt
+	case "updated_at":
+		return product.UpdatedAt
+	case SourceSpecialPrice:
+		if specialPrice, ok := product.ActiveSpecialPrice(now); ok {
+			return formatFloat(specialPrice)
+		}
+		return ""
+	case SourceQty:
+		return formatFloat(NewPriceStockRow(product, now).Qty)
+	case SourceIsInStock:
+		stockItem, ok := product.StockItem()
+		return strconv.FormatBool(ok && stockItem.IsInStock)
+	case SourceSalable:
+		return strconv.FormatBool(NewPriceStockRow(product, now).Salable)
+	}
+	return product.CustomAttributeString(source)
+}
+
+// WriteMapped streams a feed with the columns of the mapping for all products matching opts.Criteria
+// to w and returns the number of rows written
+func WriteMapped(ctx context.Context, apiClient *magento2.Client, w io.Writer, mapping Mapping, opts Options) (int, error) {
+	if err := mapping.Validate(); err != nil {
+		return 0, err
+	}
+	o := opts.withDefaults()
+
+	header := mapping.header()
+	// transforms produce strings, so every mapped column is one
+	columns := magento2.StringColumns(header...)
+	enc, err := newEncoder(o, w, columns)
+	if err != nil {
+		return 0, err
+	}
+
+	log.Debug().
+		Str("format", string(o.Format)).
+		Strs("columns", header).
+		Int("workers", o.Workers).
+		Msg("Writing mapped feed")
+
+	return writeFeed(ctx, apiClient, enc, o, columns, func(product *magento2.Product) ([]string, error) {
+		values, err := mapping.Row(product, o.Now)
+		if err != nil {
+			return nil, fmt.Errorf("error mapping sku %s: %w", product.Sku, err)
+		}
+		return values, nil
+	})
+}
+
+// MapValues replaces values found in the map, e.g. "true" with "in_stock". Other values pass unchanged
+func MapValues(values map[string]string) Transform {
+	return func(value string, _ *magento2.Product) (string, error) {
+		if mapped, ok := values[value]; ok {
+			return mapped, nil
+		}
+		return value, nil
+	}
+}
+
+// Prefix prepends prefix to non-empty values, e.g. a base URL to url_key
+func Prefix(prefix string) Transform {
+	return func(value string, _ *magento2.Product) (string, error) {
+		if value == "" {
+			return "", nil
+		}
+		return prefix + value, nil
+	}
+}
+
+// Suffix appends suffix to non-empty values, e.g. ".html" to url_key
+func Suffix(suffix string) Transform {
+	return func(value string, _ *magento2.Product) (string, error) {
+		if value == "" {
+			return "", nil
+		}
+		return value + suffix, nil
+	}
+}
+
+// StripHTML removes tags and decodes entities, most channels reject markup in descriptions
+func StripHTML() Transform {
+	return func(value string, _ *magento2.Product) (string, error) {
+		return strings.Join(strings.Fields(html.UnescapeString(htmlTags.ReplaceAllString(value, " "))), " "), nil
+	}
+}
+
+// Truncate shortens values to at most maxLength characters
+func Truncate(maxLength int) Transform {
+	return func(value string, _ *magento2.Product) (string, error) {
+		runes := []rune(value)
+		if len(runes) <= maxLength {
+			return value, nil
+		}
+		return strings.TrimSpace(string(runes[:maxLength])), nil
+	}
+}
+
+// FormatPrice formats a non-empty price with two decimals followed by the currency, e.g. "15.00 USD"
+func FormatPrice(currency string) Transform {
+	return func(value string, _ *magento2.Product) (string, error) {
+		if value == "" {
+			return "", nil
+		}
+		price, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return "", fmt.Errorf("error parsing price %q: %w", value, err)
+		}
+		return strconv.FormatFloat(price, 'f', 2, 64) + " " + currency, nil
+	}
+}
+
+// ResolveImageURL turns a media gallery file like the "image" attribute into an absolute URL of the store
+// view, e.g. for an image_link column. "no_selection" counts as no image
+func ResolveImageURL(ctx context.Context, resolver *magento2.MediaURLResolver, storeCode string) Transform {
+	return func(value string, _ *magento2.Product) (string, error) {
+		if value == "" || value == "no_selection" {
+			return "", nil
+		}
+		return resolver.ImageURL(ctx, storeCode, value)
+	}
+}
+
+// GoogleShoppingMapping maps the fields a Google Merchant Center product feed requires. productURL
+// is prefixed to url_key, e.g. "https://example.com/" with urlSuffix ".html"
+func GoogleShoppingMapping(currency, productURL, urlSuffix string) Mapping {
+	return Mapping{
+		{Name: "id", Source: "sku", Required: true},
+		{Name: "title", Source: "name", Transforms: []Transform{StripHTML(), Truncate(150)}, Required: true},
+		{Name: "description", Source: "description", Transforms: []Transform{StripHTML(), Truncate(5000)}},
+		{Name: "link", Source: "url_key", Transforms: []Transform{Prefix(productURL), Suffix(urlSuffix)}},
+		{Name: "price", Source: "price", Transforms: []Transform{FormatPrice(currency)}, Required: true},
+		{Name: "sale_price", Source: SourceSpecialPrice, Transforms: []Transform{FormatPrice(currency)}},
+		{Name: "availability", Source: SourceSalable, Transforms: []Transform{MapValues(map[string]string{"true": "in_stock", "false": "out_of_stock"})}},
+		{Name: "condition", Source: "condition", Default: "new"},
+	}
+}
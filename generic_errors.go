@@ -2,6 +2,9 @@ package magento2
 
 import (
 	"errors"
+	"net/http"
+
+	"github.com/go-resty/resty/v2"
 )
 
 var ErrNoPointer = errors.New("target interface must be a pointer")
@@ -9,3 +12,50 @@ var ErrNoPointer = errors.New("target interface must be a pointer")
 var ErrNotFound = errors.New("no document found")
 
 var ErrBadRequest = errors.New("bad request")
+
+var ErrValidation = errors.New("payload validation failed")
+
+var ErrAlreadyInvoiced = errors.New("order items are already invoiced")
+
+var ErrAlreadyShipped = errors.New("order items are already shipped")
+
+var ErrAlreadyRefunded = errors.New("order items are already refunded")
+
+var ErrNotGuestCart = errors.New("cart is not a guest cart")
+
+var ErrUnknownExtension = errors.New("no extension registered under name")
+
+var ErrUnexpectedVersion = errors.New("unexpected magento version format")
+
+var ErrEmptyRunID = errors.New("run ID must not be empty")
+
+var ErrItemDoesNotFit = errors.New("order item does not fit into any box")
+
+var ErrConflict = errors.New("remote entity was modified since it was read")
+
+var ErrInvalidTrackingURL = errors.New("tracking URL template must contain exactly one %s")
+
+// ErrResponseTooLarge is returned when a response body exceeds the client's maximum response size
+var ErrResponseTooLarge = resty.ErrResponseBodyTooLarge
+
+// HTTPStatusError carries the status code of a failed response. It unwraps to the sentinel the
+// status maps to, so errors.Is(err, ErrBadRequest) keeps working
+type HTTPStatusError struct {
+	StatusCode int
+	Err        error
+}
+
+func (e *HTTPStatusError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *HTTPStatusError) Unwrap() error {
+	return e.Err
+}
+
+// IsTransient reports server-side and throttling errors, which may succeed when retried
+func (e *HTTPStatusError) IsTransient() bool {
+	return e.StatusCode >= http.StatusInternalServerError || e.StatusCode == http.StatusTooManyRequests
+}
+
+var ErrInvalidYAML = errors.New("invalid yaml")
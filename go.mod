@@ -5,6 +5,7 @@ go 1.22.12
 require (
 	github.com/go-resty/resty/v2 v2.16.5
 	github.com/rs/zerolog v1.34.0
+	gopkg.in/yaml.v3 v3.0.1
 )
 
 require (
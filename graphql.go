@@ -0,0 +1,121 @@
+package magento2
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+)
+
+var ErrGraphQL = errors.New("graphql request failed")
+
+const graphQLRoute = "/graphql"
+
+type graphQLRequest struct {
+	Query     string         `json:"query"`
+	Variables map[string]any `json:"variables,omitempty"`
+}
+
+// GraphQLError is an entry of the errors list of a GraphQL response
+type GraphQLError struct {
+	Message    string         `json:"message"`
+	Path       []any          `json:"path,omitempty"`
+	Extensions map[string]any `json:"extensions,omitempty"`
+}
+
+// GraphQLErrors are returned when the response carries errors, any data is still decoded into the target.
+// They match ErrGraphQL with errors.Is
+type GraphQLErrors []GraphQLError
+
+func (e GraphQLErrors) Error() string {
+	messages := make([]string, 0, len(e))
+	for _, graphQLErr := range e {
+		messages = append(messages, graphQLErr.Message)
+	}
+	return strings.Join(messages, "; ")
+}
+
+func (e GraphQLErrors) Unwrap() error {
+	return ErrGraphQL
+}
+
+type graphQLResponse struct {
+	Data   json.RawMessage `json:"data"`
+	Errors GraphQLErrors   `json:"errors"`
+}
+
+// graphQLEndpoint derives the GraphQL URL from the REST base URL, e.g. http://host/rest/default/V1 -> http://host/graphql
+func (c *Client) graphQLEndpoint() string {
+	return c.storeRootURL() + graphQLRoute
+}
+
+// storeRootURL strips the REST prefix from the base URL, e.g. http://host/rest/default/V1 -> http://host
+func (c *Client) storeRootURL() string {
+	baseURL := c.HTTPClient.BaseURL
+	if i := strings.Index(baseURL, "/rest/"); i >= 0 {
+		return baseURL[:i]
+	}
+	return strings.TrimSuffix(baseURL, "/")
+}
+
+// GraphQL sends a query or mutation to the GraphQL endpoint of the store with the client's token and decodes
+// the data into target. Requests share the client's logging, error handling and RequestOptions, WithStoreCode
+// selects the store view. Queries are retried like GETs, mutations like POSTs
+func (c *Client) GraphQL(ctx context.Context, query string, variables map[string]any, target any, opts ...RequestOption) error {
+	return graphQL(ctx, query, variables, target, "execute GraphQL operation", c, opts...)
+}
+
+func graphQL(ctx context.Context, query string, variables map[string]any, target any, tryTo string, apiClient *Client, opts ...RequestOption) error {
+	endpoint := apiClient.graphQLEndpoint()
+	result := &graphQLResponse{}
+	o := newRequestOptions(opts)
+	if o.retries == nil && !isGraphQLMutation(query) {
+		retries := apiClient.HTTPClient.RetryCount
+		o.retries = &retries
+	}
+
+	log.Debug().
+		Str("endpoint", endpoint).
+		Str("operation", tryTo).
+		Msg("Sending GraphQL request")
+
+	req, cancel := o.newRequest(ctx, apiClient)
+	defer cancel()
+	req.SetBody(&graphQLRequest{Query: query, Variables: variables}).
+		SetResult(result)
+	storeCode := o.storeCode
+	if storeCode == "" && apiClient.storeConfig != nil {
+		storeCode = apiClient.storeConfig.StoreCode
+	}
+	if storeCode != "" && storeCode != "all" {
+		req.SetHeader("Store", storeCode)
+	}
+	resp, err := req.Post(endpoint)
+	if err != nil {
+		return fmt.Errorf("error while trying to %s: %w", tryTo, err)
+	}
+
+	httpErr := mayReturnErrorForHTTPResponse(resp, tryTo)
+	if httpErr != nil {
+		return httpErr
+	}
+
+	if target != nil && len(result.Data) > 0 && string(result.Data) != "null" {
+		if err := json.Unmarshal(result.Data, target); err != nil {
+			return fmt.Errorf("error decoding GraphQL response to %s: %w", tryTo, err)
+		}
+	}
+	if len(result.Errors) > 0 {
+		log.Error().Str("errors", result.Errors.Error()).Str("operation", tryTo).Msg("GraphQL request returned errors")
+		return fmt.Errorf("error while trying to %s: %w", tryTo, result.Errors)
+	}
+	return nil
+}
+
+// isGraphQLMutation reports whether the operation of the document is a mutation
+func isGraphQLMutation(query string) bool {
+	return strings.HasPrefix(strings.TrimSpace(query), "mutation")
+}
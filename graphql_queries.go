@@ -0,0 +1,75 @@
+package magento2
+
+import (
+	"context"
+)
+
+// Operations which Magento exposes only through GraphQL or which need several REST calls otherwise
+
+const productsQuery = `query($search: String, $filter: ProductAttributeFilterInput, $sort: ProductAttributeSortInput, $pageSize: Int, $currentPage: Int) {
+  products(search: $search, filter: $filter, sort: $sort, pageSize: $pageSize, currentPage: $currentPage) {
+    total_count
+    items {
+      __typename uid sku name url_key
+      price_range { minimum_price { final_price { value currency } } }
+    }
+    aggregations { attribute_code label count options { label value count } }
+    page_info { current_page page_size total_pages }
+  }
+}`
+
+const customerCartQuery = `query {
+  customerCart {
+    id total_quantity
+    items { uid quantity product { sku name } prices { row_total { value currency } } }
+    prices {
+      grand_total { value currency }
+      subtotal_excluding_tax { value currency }
+      subtotal_including_tax { value currency }
+      subtotal_with_discount_excluding_tax { value currency }
+    }
+  }
+}`
+
+// QueryProducts searches the storefront catalog of the store view together with the layered navigation
+// aggregations, which the REST search doesn't provide
+func QueryProducts(ctx context.Context, query *ProductQuery, apiClient *Client, opts ...RequestOption) (*ProductQueryResult, error) {
+	variables := map[string]any{}
+	if query.Search != "" {
+		variables["search"] = query.Search
+	}
+	if len(query.Filter) > 0 {
+		variables["filter"] = query.Filter
+	}
+	if len(query.Sort) > 0 {
+		variables["sort"] = query.Sort
+	}
+	if query.PageSize > 0 {
+		variables["pageSize"] = query.PageSize
+	}
+	if query.CurrentPage > 0 {
+		variables["currentPage"] = query.CurrentPage
+	}
+
+	var data struct {
+		Products ProductQueryResult `json:"products"`
+	}
+	err := graphQL(ctx, productsQuery, variables, &data, "query products", apiClient, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &data.Products, nil
+}
+
+// GetCustomerCartSummary returns the active cart of the customer a customer-token client is authenticated as,
+// creating it if needed, in a single request
+func GetCustomerCartSummary(ctx context.Context, apiClient *Client, opts ...RequestOption) (*CustomerCartSummary, error) {
+	var data struct {
+		CustomerCart CustomerCartSummary `json:"customerCart"`
+	}
+	err := graphQL(ctx, customerCartQuery, nil, &data, "get customer cart summary", apiClient, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &data.CustomerCart, nil
+}
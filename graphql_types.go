@@ -0,0 +1,80 @@
+package magento2
+
+// ProductQuery selects products of the GraphQL products query. Filter takes ProductAttributeFilterInput,
+// e.g. {"category_id": {"eq": "3"}}, Sort ProductAttributeSortInput, e.g. {"price": "ASC"}
+type ProductQuery struct {
+	Search      string         `json:"search,omitempty"`
+	Filter      map[string]any `json:"filter,omitempty"`
+	Sort        map[string]any `json:"sort,omitempty"`
+	PageSize    int            `json:"pageSize,omitempty"`
+	CurrentPage int            `json:"currentPage,omitempty"`
+}
+
+type ProductQueryResult struct {
+	TotalCount   int                  `json:"total_count"`
+	Items        []ProductQueryItem   `json:"items"`
+	Aggregations []ProductAggregation `json:"aggregations"`
+	PageInfo     struct {
+		CurrentPage int `json:"current_page"`
+		PageSize    int `json:"page_size"`
+		TotalPages  int `json:"total_pages"`
+	} `json:"page_info"`
+}
+
+type ProductQueryItem struct {
+	UID        string `json:"uid"`
+	Sku        string `json:"sku"`
+	Name       string `json:"name"`
+	TypeName   string `json:"__typename"`
+	URLKey     string `json:"url_key"`
+	PriceRange struct {
+		MinimumPrice struct {
+			FinalPrice GraphQLMoney `json:"final_price"`
+		} `json:"minimum_price"`
+	} `json:"price_range"`
+}
+
+// ProductAggregation is a layered navigation filter of a product search, e.g. the price buckets
+type ProductAggregation struct {
+	AttributeCode string                     `json:"attribute_code"`
+	Label         string                     `json:"label"`
+	Count         int                        `json:"count"`
+	Options       []ProductAggregationOption `json:"options"`
+}
+
+type ProductAggregationOption struct {
+	Label string `json:"label"`
+	Value string `json:"value"`
+	Count int    `json:"count"`
+}
+
+// GraphQLMoney is the Money type of the GraphQL schema
+type GraphQLMoney struct {
+	Value    Decimal `json:"value"`
+	Currency string  `json:"currency"`
+}
+
+// CustomerCartSummary is the active cart of the customer with the totals Magento calculated
+type CustomerCartSummary struct {
+	ID            string            `json:"id"`
+	TotalQuantity float64           `json:"total_quantity"`
+	Items         []CartSummaryItem `json:"items"`
+	Prices        struct {
+		GrandTotal           GraphQLMoney `json:"grand_total"`
+		SubtotalExcludingTax GraphQLMoney `json:"subtotal_excluding_tax"`
+		SubtotalIncludingTax GraphQLMoney `json:"subtotal_including_tax"`
+		SubtotalWithDiscount GraphQLMoney `json:"subtotal_with_discount_excluding_tax"`
+	} `json:"prices"`
+}
+
+type CartSummaryItem struct {
+	UID      string  `json:"uid"`
+	Quantity float64 `json:"quantity"`
+	Product  struct {
+		Sku  string `json:"sku"`
+		Name string `json:"name"`
+	} `json:"product"`
+	Prices struct {
+		RowTotal GraphQLMoney `json:"row_total"`
+	} `json:"prices"`
+}
@@ -0,0 +1,171 @@
+package magento2
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	defaultImageCheckConcurrency = 8
+	defaultImageCheckTimeout     = 10 * time.Second
+)
+
+// BrokenImage is an image URL that did not answer with 2xx. StatusCode is 0 when the request failed,
+// Error holds the reason then
+type BrokenImage struct {
+	Sku        string `json:"sku"`
+	URL        string `json:"url"`
+	StatusCode int    `json:"status_code,omitempty"`
+	Error      string `json:"error,omitempty"`
+}
+
+// ImageVerificationReport is the outcome of ImageVerifier.Verify. Images shared by several products are
+// checked once but reported for each product
+type ImageVerificationReport struct {
+	ProductsScanned int           `json:"products_scanned"`
+	ImagesChecked   int           `json:"images_checked"`
+	Broken          []BrokenImage `json:"broken"`
+}
+
+// NotFound returns the images that answered 404
+func (r *ImageVerificationReport) NotFound() []BrokenImage {
+	var images []BrokenImage
+	for _, image := range r.Broken {
+		if image.StatusCode == http.StatusNotFound {
+			images = append(images, image)
+		}
+	}
+	return images
+}
+
+// ImageVerifier checks that the gallery images of products can be fetched. Media is often served by a CDN,
+// so requests go through a plain HTTPClient without the API token
+type ImageVerifier struct {
+	Resolver   *MediaURLResolver
+	HTTPClient *http.Client
+	// Concurrency bounds the image requests in flight
+	Concurrency int
+	// StoreCode selects the store view whose media URL is used, empty for the store of the client
+	StoreCode string
+}
+
+// NewImageVerifier returns a verifier with 8 concurrent requests and a 10s timeout per image
+func NewImageVerifier(resolver *MediaURLResolver) *ImageVerifier {
+	return &ImageVerifier{
+		Resolver:    resolver,
+		HTTPClient:  &http.Client{Timeout: defaultImageCheckTimeout},
+		Concurrency: defaultImageCheckConcurrency,
+	}
+}
+
+type imageCheckResult struct {
+	statusCode int
+	err        error
+}
+
+// Verify pages through the products matching the criteria, with up to workers pages in flight, and sends
+// a HEAD request for each enabled gallery image. Servers rejecting HEAD are asked with GET
+func (v *ImageVerifier) Verify(ctx context.Context, criteria *SearchCriteria, workers int) (*ImageVerificationReport, error) {
+	report := &ImageVerificationReport{Broken: []BrokenImage{}}
+	checked := map[string]imageCheckResult{}
+	concurrency := max(v.Concurrency, 1)
+
+	err := ForEachProductPage(ctx, criteria, workers, v.Resolver.APIClient, func(page []Product) error {
+		type productImage struct{ sku, url string }
+		var images []productImage
+		pending := map[string]bool{}
+		for i := range page {
+			report.ProductsScanned++
+			urls, err := v.Resolver.ProductImageURLs(ctx, v.StoreCode, &page[i])
+			if err != nil {
+				return err
+			}
+			for _, url := range urls {
+				images = append(images, productImage{sku: page[i].Sku, url: url})
+				if _, ok := checked[url]; !ok {
+					pending[url] = true
+				}
+			}
+		}
+
+		results := make(map[string]imageCheckResult, len(pending))
+		var mu sync.Mutex
+		var wg sync.WaitGroup
+		semaphore := make(chan struct{}, concurrency)
+		for url := range pending {
+			wg.Add(1)
+			semaphore <- struct{}{}
+			go func() {
+				defer wg.Done()
+				defer func() { <-semaphore }()
+				statusCode, err := v.CheckURL(ctx, url)
+				mu.Lock()
+				results[url] = imageCheckResult{statusCode: statusCode, err: err}
+				mu.Unlock()
+			}()
+		}
+		wg.Wait()
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		for url, result := range results {
+			checked[url] = result
+		}
+		report.ImagesChecked += len(results)
+
+		for _, image := range images {
+			result := checked[image.url]
+			switch {
+			case result.err != nil:
+				report.Broken = append(report.Broken, BrokenImage{Sku: image.sku, URL: image.url, Error: result.err.Error()})
+			case result.statusCode < 200 || result.statusCode > 299:
+				report.Broken = append(report.Broken, BrokenImage{Sku: image.sku, URL: image.url, StatusCode: result.statusCode})
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error verifying product images: %w", err)
+	}
+
+	log.Debug().
+		Int("products", report.ProductsScanned).
+		Int("images", report.ImagesChecked).
+		Int("broken", len(report.Broken)).
+		Msg("Product image verification finished")
+	return report, nil
+}
+
+// CheckURL returns the status code of a HEAD request for the URL, falling back to GET when the server
+// does not allow HEAD
+func (v *ImageVerifier) CheckURL(ctx context.Context, url string) (int, error) {
+	statusCode, err := v.request(ctx, http.MethodHead, url)
+	if err == nil && statusCode == http.StatusMethodNotAllowed {
+		return v.request(ctx, http.MethodGet, url)
+	}
+	return statusCode, err
+}
+
+func (v *ImageVerifier) request(ctx context.Context, method, url string) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, method, url, nil)
+	if err != nil {
+		return 0, err
+	}
+	httpClient := v.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+	return resp.StatusCode, nil
+}
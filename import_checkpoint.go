@@ -0,0 +1,180 @@
+package magento2
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// DefaultCheckpointSaveEvery is the number of recorded rows after which an ImportCheckpoint is saved
+const DefaultCheckpointSaveEvery = 100
+
+var ErrCheckpointMismatch = errors.New("checkpoint belongs to another import source")
+
+// ImportRowStatus is the outcome of importing one row
+type ImportRowStatus string
+
+const (
+	ImportRowCreated   ImportRowStatus = "created"
+	ImportRowUpdated   ImportRowStatus = "updated"
+	ImportRowUnchanged ImportRowStatus = "unchanged"
+	ImportRowSkipped   ImportRowStatus = "skipped"
+	ImportRowFailed    ImportRowStatus = "failed"
+)
+
+// ImportRowResult is the outcome of one row. Row is the zero-based data row, Key identifies the entity,
+// e.g. the SKU
+type ImportRowResult struct {
+	Row    int             `json:"row"`
+	Key    string          `json:"key"`
+	Status ImportRowStatus `json:"status"`
+	Error  string          `json:"error,omitempty"`
+}
+
+// ImportCheckpoint tracks the progress of a row based import in a state file, so a crashed or rate
+// limited import resumes where it stopped instead of starting over. All rows before Offset are done,
+// rows recorded out of order (by parallel workers) are kept in Completed until the gap closes.
+//
+// Only failed rows are kept with their result, the others are counted. A row is recorded once its write
+// went through, rows recorded after the last save are written again on resume, so the write must be
+// idempotent, e.g. CreateOrReplaceProduct. Do not record rows that failed with a transient error, they
+// are retried on resume
+type ImportCheckpoint struct {
+	Source    string                  `json:"source"`
+	Offset    int                     `json:"offset"`
+	Completed []int                   `json:"completed,omitempty"`
+	Counts    map[ImportRowStatus]int `json:"counts"`
+	Failures  []ImportRowResult       `json:"failures,omitempty"`
+	UpdatedAt time.Time               `json:"updated_at"`
+
+	// SaveEvery is the number of recorded rows between saves, 0 means DefaultCheckpointSaveEvery
+	SaveEvery int `json:"-"`
+
+	path      string
+	mu        sync.Mutex
+	completed map[int]bool
+	unsaved   int
+}
+
+// OpenImportCheckpoint loads the checkpoint at path, or starts a new one if the file does not exist.
+// source identifies the input, e.g. file name and size; resuming from a checkpoint of another source
+// fails with ErrCheckpointMismatch
+func OpenImportCheckpoint(path, source string) (*ImportCheckpoint, error) {
+	checkpoint := &ImportCheckpoint{
+		Source:    source,
+		Counts:    map[ImportRowStatus]int{},
+		path:      path,
+		completed: map[int]bool{},
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return checkpoint, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading import checkpoint: %w", err)
+	}
+	if err := json.Unmarshal(data, checkpoint); err != nil {
+		return nil, fmt.Errorf("error decoding import checkpoint %s: %w", path, err)
+	}
+	if checkpoint.Source != source {
+		return nil, fmt.Errorf("%w: %s was written for %q, not %q", ErrCheckpointMismatch, path, checkpoint.Source, source)
+	}
+	if checkpoint.Counts == nil {
+		checkpoint.Counts = map[ImportRowStatus]int{}
+	}
+	for _, row := range checkpoint.Completed {
+		checkpoint.completed[row] = true
+	}
+	return checkpoint, nil
+}
+
+// Done reports whether the row was imported by this or an earlier run
+func (c *ImportCheckpoint) Done(row int) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return row < c.Offset || c.completed[row]
+}
+
+// Record marks the row as done and saves the checkpoint every SaveEvery rows. Recording a row twice
+// is a no-op
+func (c *ImportCheckpoint) Record(result ImportRowResult) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if result.Row < c.Offset || c.completed[result.Row] {
+		return nil
+	}
+
+	c.completed[result.Row] = true
+	for c.completed[c.Offset] {
+		delete(c.completed, c.Offset)
+		c.Offset++
+	}
+	c.Counts[result.Status]++
+	if result.Status == ImportRowFailed {
+		c.Failures = append(c.Failures, result)
+	}
+
+	c.unsaved++
+	saveEvery := c.SaveEvery
+	if saveEvery <= 0 {
+		saveEvery = DefaultCheckpointSaveEvery
+	}
+	if c.unsaved < saveEvery {
+		return nil
+	}
+	return c.save()
+}
+
+// Save writes the checkpoint to its file. The file is replaced atomically, a crash while saving keeps the
+// previous state
+func (c *ImportCheckpoint) Save() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.save()
+}
+
+func (c *ImportCheckpoint) save() error {
+	c.Completed = sortedKeys(c.completed)
+	c.UpdatedAt = time.Now().UTC()
+	data, err := json.Marshal(c)
+	if err != nil {
+		return fmt.Errorf("error encoding import checkpoint: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(c.path), filepath.Base(c.path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("error saving import checkpoint: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("error saving import checkpoint: %w", err)
+	}
+	// without the sync a crash after the rename can leave an empty file in place of the previous state
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return fmt.Errorf("error saving import checkpoint: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("error saving import checkpoint: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), c.path); err != nil {
+		return fmt.Errorf("error saving import checkpoint: %w", err)
+	}
+	c.unsaved = 0
+	return nil
+}
+
+// Remove deletes the state file, e.g. once the import finished
+func (c *ImportCheckpoint) Remove() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := os.Remove(c.path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("error removing import checkpoint: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,240 @@
+package magento2
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"html"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// importProductFields are the targets set on Product fields instead of custom attributes
+var importProductFields = map[string]bool{
+	"sku": true, "name": true, "price": true, "status": true, "visibility": true,
+	"type_id": true, "weight": true, "attribute_set_id": true,
+}
+
+// ReadImportProfile reads a profile from JSON and validates it
+func ReadImportProfile(r io.Reader) (*ImportProfile, error) {
+	profile := &ImportProfile{}
+	if err := json.NewDecoder(r).Decode(profile); err != nil {
+		return nil, fmt.Errorf("error reading import profile: %w", err)
+	}
+	if err := profile.Validate(); err != nil {
+		return nil, err
+	}
+	return profile, nil
+}
+
+// ReadImportProfileYAML reads a profile from YAML and validates it
+func ReadImportProfileYAML(r io.Reader) (*ImportProfile, error) {
+	profile := &ImportProfile{}
+	if err := readYAML(r, profile); err != nil {
+		return nil, fmt.Errorf("error reading import profile: %w", err)
+	}
+	if err := profile.Validate(); err != nil {
+		return nil, err
+	}
+	return profile, nil
+}
+
+// LoadImportProfile reads the profile from a file, e.g. at the start of an import run. Files ending in
+// .yaml or .yml are read as YAML, all others as JSON
+func LoadImportProfile(path string) (*ImportProfile, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("error opening import profile: %w", err)
+	}
+	defer file.Close()
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		return ReadImportProfileYAML(file)
+	}
+	return ReadImportProfile(file)
+}
+
+// Validate checks that every column has a name and a target, targets are unique, one column targets
+// the sku and all transforms are known
+func (p *ImportProfile) Validate() error {
+	entity := "import profile " + p.Name
+	targets := map[string]bool{}
+	for i, column := range p.Columns {
+		if column.Column == "" || column.Target == "" {
+			return &ValidationError{Entity: entity, Field: "columns", Reason: fmt.Sprintf("column %d needs a column and a target", i)}
+		}
+		if targets[column.Target] {
+			return &ValidationError{Entity: entity, Field: "columns", Reason: fmt.Sprintf("target %s is mapped twice", column.Target)}
+		}
+		targets[column.Target] = true
+		for _, transform := range column.Transforms {
+			if err := transform.validate(); err != nil {
+				return &ValidationError{Entity: entity, Field: "columns", Reason: fmt.Sprintf("column %s %s", column.Column, err)}
+			}
+		}
+	}
+	if !targets["sku"] {
+		return &ValidationError{Entity: entity, Field: "columns", Reason: "no column targets the sku"}
+	}
+	return nil
+}
+
+func (t ImportTransform) validate() error {
+	switch t.Type {
+	case ImportTransformTrim, ImportTransformLower, ImportTransformUpper, ImportTransformStripHTML, ImportTransformURLKey, ImportTransformOption:
+	case ImportTransformPrefix, ImportTransformSuffix:
+		if t.Value == "" {
+			return fmt.Errorf("transform %s needs a value", t.Type)
+		}
+	case ImportTransformReplace:
+		if t.From == "" {
+			return fmt.Errorf("transform %s needs from", t.Type)
+		}
+	case ImportTransformMap:
+		if len(t.Values) == 0 {
+			return fmt.Errorf("transform %s needs values", t.Type)
+		}
+	default:
+		return fmt.Errorf("has unknown transform %q", t.Type)
+	}
+	return nil
+}
+
+// Values maps a record, keyed by column name, to the values of the targets. attributes resolves option
+// labels and is only needed by profiles using the option transform
+func (p *ImportProfile) Values(ctx context.Context, record map[string]string, attributes *AttributeCache) (map[string]string, error) {
+	values := map[string]string{}
+	for _, column := range p.Columns {
+		value := record[column.Column]
+		for _, transform := range column.Transforms {
+			var err error
+			if value, err = transform.apply(ctx, value, column.Target, attributes); err != nil {
+				return nil, fmt.Errorf("error transforming column %s: %w", column.Column, err)
+			}
+		}
+		if value == "" {
+			value = p.Defaults[column.Target]
+		}
+		if value == "" && column.Required {
+			return nil, &ValidationError{Entity: "import row", Field: column.Column, Reason: "is required"}
+		}
+		values[column.Target] = value
+	}
+	for target, value := range p.Defaults {
+		if _, mapped := values[target]; !mapped {
+			values[target] = value
+		}
+	}
+	return values, nil
+}
+
+func (t ImportTransform) apply(ctx context.Context, value, target string, attributes *AttributeCache) (string, error) {
+	switch t.Type {
+	case ImportTransformTrim:
+		return strings.TrimSpace(value), nil
+	case ImportTransformLower:
+		return strings.ToLower(value), nil
+	case ImportTransformUpper:
+		return strings.ToUpper(value), nil
+	case ImportTransformStripHTML:
+		return html.UnescapeString(plainText(value)), nil
+	case ImportTransformURLKey:
+		return FormatURLKey(value), nil
+	case ImportTransformPrefix:
+		if value == "" {
+			return "", nil
+		}
+		return t.Value + value, nil
+	case ImportTransformSuffix:
+		if value == "" {
+			return "", nil
+		}
+		return value + t.Value, nil
+	case ImportTransformReplace:
+		return strings.ReplaceAll(value, t.From, t.To), nil
+	case ImportTransformMap:
+		if mapped, ok := t.Values[value]; ok {
+			return mapped, nil
+		}
+		return value, nil
+	case ImportTransformOption:
+		if value == "" {
+			return "", nil
+		}
+		if attributes == nil {
+			return "", fmt.Errorf("transform %s needs an attribute cache", t.Type)
+		}
+		labels := strings.Split(value, ",")
+		for i, label := range labels {
+			optionValue, err := attributes.OptionValue(ctx, target, strings.TrimSpace(label))
+			if err != nil {
+				return "", err
+			}
+			labels[i] = optionValue
+		}
+		return strings.Join(labels, ","), nil
+	}
+	return "", fmt.Errorf("unknown transform %q", t.Type)
+}
+
+// Product maps a record to a product. Targets without a value are left out, so the product can be sent
+// as a partial update; numeric fields that do not parse reject the row
+func (p *ImportProfile) Product(ctx context.Context, record map[string]string, attributes *AttributeCache) (*Product, error) {
+	values, err := p.Values(ctx, record, attributes)
+	if err != nil {
+		return nil, err
+	}
+
+	product := &Product{}
+	for _, target := range sortedKeys(values) {
+		value := values[target]
+		if value == "" {
+			continue
+		}
+		if !importProductFields[target] {
+			product.CustomAttributes = append(product.CustomAttributes, map[string]any{"attribute_code": target, "value": value})
+			continue
+		}
+		if err := setImportProductField(product, target, value); err != nil {
+			return nil, &ValidationError{Entity: "import row " + values["sku"], Field: target, Reason: err.Error()}
+		}
+	}
+	return product, nil
+}
+
+func setImportProductField(product *Product, target, value string) error {
+	var err error
+	switch target {
+	case "sku":
+		product.Sku = value
+	case "name":
+		product.Name = value
+	case "type_id":
+		product.TypeID = value
+	case "price":
+		product.Price, err = strconv.ParseFloat(value, 64)
+	case "weight":
+		product.Weight, err = strconv.ParseFloat(value, 64)
+	case "status":
+		product.Status, err = strconv.Atoi(value)
+	case "visibility":
+		product.Visibility, err = strconv.Atoi(value)
+	case "attribute_set_id":
+		product.AttributeSetID, err = strconv.Atoi(value)
+	}
+	if err != nil {
+		return fmt.Errorf("is not a number: %q", value)
+	}
+	return nil
+}
+
+// RequestOptions returns the options that send writes of the profile's rows to its store view
+func (p *ImportProfile) RequestOptions() []RequestOption {
+	if p.StoreCode == "" {
+		return nil
+	}
+	return []RequestOption{WithStoreCode(p.StoreCode)}
+}
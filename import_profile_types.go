@@ -0,0 +1,50 @@
+package magento2
+
+// ImportProfile maps the columns of an import file to product fields and attributes. It is plain data,
+// so non-Go users maintain it as JSON; like CatalogSpec it carries yaml tags next to the json tags for
+// any YAML decoder. StoreCode is the store view the rows are written to, empty means the client's store
+// and "all" the default scope
+type ImportProfile struct {
+	Name      string            `json:"name" yaml:"name"`
+	StoreCode string            `json:"store_code,omitempty" yaml:"store_code,omitempty"`
+	Columns   []ImportColumn    `json:"columns" yaml:"columns"`
+	Defaults  map[string]string `json:"defaults,omitempty" yaml:"defaults,omitempty"`
+}
+
+// ImportColumn maps one column of the file to a target: a product field (sku, name, price, status,
+// visibility, type_id, weight, attribute_set_id) or an attribute code. Transforms run in order, the
+// profile's default for the target replaces an empty result and Required rejects rows that still have
+// no value. Columns of the file the profile does not list are ignored
+type ImportColumn struct {
+	Column     string            `json:"column" yaml:"column"`
+	Target     string            `json:"target" yaml:"target"`
+	Transforms []ImportTransform `json:"transforms,omitempty" yaml:"transforms,omitempty"`
+	Required   bool              `json:"required,omitempty" yaml:"required,omitempty"`
+}
+
+// ImportTransformType names a transform of an ImportColumn
+type ImportTransformType string
+
+const (
+	ImportTransformTrim      ImportTransformType = "trim"
+	ImportTransformLower     ImportTransformType = "lower"
+	ImportTransformUpper     ImportTransformType = "upper"
+	ImportTransformStripHTML ImportTransformType = "strip_html"
+	ImportTransformURLKey    ImportTransformType = "url_key"
+	ImportTransformPrefix    ImportTransformType = "prefix"
+	ImportTransformSuffix    ImportTransformType = "suffix"
+	ImportTransformReplace   ImportTransformType = "replace"
+	ImportTransformMap       ImportTransformType = "map"
+	ImportTransformOption    ImportTransformType = "option"
+)
+
+// ImportTransform rewrites a column value. Value is the text of prefix and suffix, From and To are used
+// by replace and Values by map, which passes values it has no entry for unchanged. option resolves
+// comma separated option labels of the target attribute to their values
+type ImportTransform struct {
+	Type   ImportTransformType `json:"type" yaml:"type"`
+	Value  string              `json:"value,omitempty" yaml:"value,omitempty"`
+	From   string              `json:"from,omitempty" yaml:"from,omitempty"`
+	To     string              `json:"to,omitempty" yaml:"to,omitempty"`
+	Values map[string]string   `json:"values,omitempty" yaml:"values,omitempty"`
+}
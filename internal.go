@@ -3,6 +3,7 @@ package magento2
 import (
 	"fmt"
 	"net/http"
+	"regexp"
 
 	"github.com/rs/zerolog/log"
 	"github.com/go-resty/resty/v2"
@@ -37,7 +38,7 @@ func mayReturnErrorForHTTPResponse(resp *resty.Response, triedTo string) error {
 				Str("operation", triedTo).
 				Interface("additionalDetails", additional).
 				Msg("Bad request error")
-			return wrapError(ErrBadRequest, triedTo, additional)
+			return wrapError(&HTTPStatusError{StatusCode: resp.StatusCode(), Err: ErrBadRequest}, triedTo, additional)
 		}
 		// For other non-2xx and non-404 errors, still wrap and log
 		additional := map[string]any{
@@ -49,7 +50,7 @@ func mayReturnErrorForHTTPResponse(resp *resty.Response, triedTo string) error {
 			Str("operation", triedTo).
 			Interface("additionalDetails", additional).
 			Msg("HTTP error")
-		return wrapError(fmt.Errorf("http status error: %d", resp.StatusCode()), triedTo, additional) // Wrap with a generic HTTP error
+		return wrapError(&HTTPStatusError{StatusCode: resp.StatusCode(), Err: fmt.Errorf("http status error: %d", resp.StatusCode())}, triedTo, additional) // Wrap with a generic HTTP error
 	}
 	return nil
 }
@@ -63,3 +64,15 @@ func mayTrimSurroundingQuotes(s string) string {
 	}
 	return s
 }
+
+var restStorePrefixPattern = regexp.MustCompile(`/rest/[^/]*/V1$`)
+
+// storeScopedURL rewrites the client base URL to target the given store view,
+// e.g. http://host/rest/default/V1 + /products -> http://host/rest/de/V1/products
+func storeScopedURL(baseURL, storeCode, route string) string {
+	if !restStorePrefixPattern.MatchString(baseURL) {
+		log.Warn().Str("baseURL", baseURL).Str("storeCode", storeCode).Msg("Base URL has no store prefix, using it unchanged")
+		return baseURL + route
+	}
+	return restStorePrefixPattern.ReplaceAllString(baseURL, "/rest/"+storeCode+"/V1") + route
+}
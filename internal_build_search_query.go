@@ -3,6 +3,7 @@ package magento2
 import (
 	"fmt"
 	"net/url"
+	"strconv"
 
 	"github.com/rs/zerolog/log"
 )
@@ -106,3 +107,91 @@ func BuildFlexibleSearchQuery(criteria []SearchQueryCriteria, additionalQuery ..
 		Msg("Built flexible search query")
 	return queryString
 }
+
+const (
+	SortAscending  = "ASC"
+	SortDescending = "DESC"
+)
+
+// DateTimeFormat is the layout of Magento timestamps such as created_at and updated_at, which are in UTC
+const DateTimeFormat = "2006-01-02 15:04:05"
+
+type SortOrder struct {
+	Field     string
+	Direction string
+}
+
+// SearchCriteria is a typed searchCriteria for the list endpoints. Filters inside a group are ORed,
+// the groups themselves are ANDed
+type SearchCriteria struct {
+	FilterGroups [][]SearchFilter
+	SortOrders   []SortOrder
+	PageSize     int
+	CurrentPage  int
+}
+
+// NewSearchCriteria returns criteria ANDing the given filters
+func NewSearchCriteria(filters ...SearchFilter) *SearchCriteria {
+	sc := &SearchCriteria{}
+	for _, filter := range filters {
+		sc.And(filter)
+	}
+	return sc
+}
+
+// And adds the filters as a new group, i.e. ORed with each other and ANDed with the existing groups
+func (sc *SearchCriteria) And(filters ...SearchFilter) *SearchCriteria {
+	sc.FilterGroups = append(sc.FilterGroups, filters)
+	return sc
+}
+
+// Clone returns a copy that can be extended without changing the original
+func (sc *SearchCriteria) Clone() *SearchCriteria {
+	if sc == nil {
+		return &SearchCriteria{}
+	}
+	clone := *sc
+	clone.FilterGroups = append([][]SearchFilter{}, sc.FilterGroups...)
+	clone.SortOrders = append([]SortOrder{}, sc.SortOrders...)
+	return &clone
+}
+
+// Query encodes the criteria as query string, without leading "?"
+func (sc *SearchCriteria) Query() string {
+	queryString := sc.values().Encode()
+	log.Debug().
+		Str("query", queryString).
+		Msg("Built search criteria query")
+	return queryString
+}
+
+// values returns the query parameters of the criteria, for endpoints taking more parameters
+func (sc *SearchCriteria) values() url.Values {
+	params := url.Values{}
+	for i, group := range sc.FilterGroups {
+		for y, filter := range group {
+			params.Add(fmt.Sprintf("searchCriteria[filter_groups][%d][filters][%d][field]", i, y), filter.Field)
+			params.Add(fmt.Sprintf("searchCriteria[filter_groups][%d][filters][%d][value]", i, y), filter.Value)
+			conditionType := filter.ConditionType
+			if conditionType == "" {
+				conditionType = "eq"
+			}
+			params.Add(fmt.Sprintf("searchCriteria[filter_groups][%d][filters][%d][condition_type]", i, y), conditionType)
+		}
+	}
+	for i, sortOrder := range sc.SortOrders {
+		params.Add(fmt.Sprintf("searchCriteria[sortOrders][%d][field]", i), sortOrder.Field)
+		params.Add(fmt.Sprintf("searchCriteria[sortOrders][%d][direction]", i), sortOrder.Direction)
+	}
+	if sc.PageSize > 0 {
+		params.Add("searchCriteria[pageSize]", strconv.Itoa(sc.PageSize))
+	}
+	if sc.CurrentPage > 0 {
+		params.Add("searchCriteria[currentPage]", strconv.Itoa(sc.CurrentPage))
+	}
+	if len(params) == 0 {
+		// list endpoints reject requests without any searchCriteria
+		params.Add("searchCriteria", "")
+	}
+	return params
+}
@@ -0,0 +1,126 @@
+package magento2
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/rs/zerolog/log"
+)
+
+const searchAllPageSize = 100
+
+type searchResult[T any] struct {
+	Items      []T `json:"items"`
+	TotalCount int `json:"total_count"`
+}
+
+// searchPage runs one search against a list endpoint
+func searchPage[T any](ctx context.Context, route string, criteria *SearchCriteria, tryTo string, apiClient *Client) (*searchResult[T], error) {
+	endpoint := route + "?" + criteria.Query()
+	result := &searchResult[T]{}
+
+	log.Debug().
+		Str("endpoint", endpoint).
+		Str("operation", tryTo).
+		Msg("Searching entities")
+
+	resp, err := apiClient.HTTPClient.R().SetContext(ctx).SetResult(result).Get(endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("error while trying to %s: %w", tryTo, err)
+	}
+
+	httpErr := mayReturnErrorForHTTPResponse(resp, tryTo)
+	if httpErr != nil {
+		return nil, httpErr
+	}
+	return result, nil
+}
+
+// searchAll pages through a list endpoint and returns every matching entity
+func searchAll[T any](ctx context.Context, route string, criteria *SearchCriteria, tryTo string, apiClient *Client) ([]T, error) {
+	sc := criteria.Clone()
+	if sc.PageSize == 0 {
+		sc.PageSize = searchAllPageSize
+	}
+	sc.CurrentPage = 1
+
+	var items []T
+	for {
+		result, err := searchPage[T](ctx, route, sc, tryTo, apiClient)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, result.Items...)
+		if len(result.Items) == 0 || sc.CurrentPage*sc.PageSize >= result.TotalCount {
+			return items, nil
+		}
+		sc.CurrentPage++
+	}
+}
+
+// searchPagesParallel fetches the first page, then the remaining pages in batches of up to workers
+// concurrent requests, and hands every page to fn in order. An error of fn stops the search. Without
+// sort orders the pages are sorted by entity_id, otherwise MySQL may return rows on several pages or
+// on none as pages are fetched independently
+func searchPagesParallel[T any](ctx context.Context, route string, criteria *SearchCriteria, workers int, tryTo string, apiClient *Client, fn func(items []T) error) error {
+	sc := criteria.Clone()
+	if sc.PageSize == 0 {
+		sc.PageSize = searchAllPageSize
+	}
+	if len(sc.SortOrders) == 0 {
+		sc.SortOrders = []SortOrder{{Field: "entity_id", Direction: SortAscending}}
+	}
+	if workers < 1 {
+		workers = 1
+	}
+	sc.CurrentPage = 1
+
+	first, err := searchPage[T](ctx, route, sc, tryTo, apiClient)
+	if err != nil {
+		return err
+	}
+	if err := fn(first.Items); err != nil {
+		return err
+	}
+	totalPages := (first.TotalCount + sc.PageSize - 1) / sc.PageSize
+
+	log.Debug().
+		Int("totalCount", first.TotalCount).
+		Int("pages", totalPages).
+		Int("workers", workers).
+		Str("operation", tryTo).
+		Msg("Fetching remaining pages in parallel")
+
+	for batchStart := 2; batchStart <= totalPages; batchStart += workers {
+		batchSize := min(workers, totalPages-batchStart+1)
+		pages := make([][]T, batchSize)
+		errs := make([]error, batchSize)
+		var wg sync.WaitGroup
+		for i := 0; i < batchSize; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				pageCriteria := sc.Clone()
+				pageCriteria.CurrentPage = batchStart + i
+				result, err := searchPage[T](ctx, route, pageCriteria, tryTo, apiClient)
+				if err != nil {
+					errs[i] = fmt.Errorf("error fetching page %d: %w", pageCriteria.CurrentPage, err)
+					return
+				}
+				pages[i] = result.Items
+			}()
+		}
+		wg.Wait()
+		if err := errors.Join(errs...); err != nil {
+			return err
+		}
+		for _, items := range pages {
+			if err := fn(items); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,100 @@
+package magento2
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+type flightCall[V any] struct {
+	done  chan struct{}
+	value V
+	err   error
+}
+
+// flightGroup collapses concurrent loads of the same key into one. Waiters get the result of the
+// first caller, or their own context error when they give up earlier
+type flightGroup[K comparable, V any] struct {
+	mu    sync.Mutex
+	calls map[K]*flightCall[V]
+}
+
+// do runs load once per key at a time. shared reports whether the result came from another caller's load
+func (g *flightGroup[K, V]) do(ctx context.Context, key K, load func() (V, error)) (value V, shared bool, err error) {
+	g.mu.Lock()
+	if g.calls == nil {
+		g.calls = map[K]*flightCall[V]{}
+	}
+	if call, ok := g.calls[key]; ok {
+		g.mu.Unlock()
+		select {
+		case <-call.done:
+			return call.value, true, call.err
+		case <-ctx.Done():
+			return value, true, ctx.Err()
+		}
+	}
+	call := &flightCall[V]{done: make(chan struct{})}
+	g.calls[key] = call
+	g.mu.Unlock()
+
+	call.value, call.err = load()
+
+	g.mu.Lock()
+	delete(g.calls, key)
+	g.mu.Unlock()
+	close(call.done)
+	return call.value, false, call.err
+}
+
+type ttlCacheEntry[V any] struct {
+	value   V
+	expires time.Time
+}
+
+// ttlCache keeps loaded values for ttl, a zero ttl keeps them until invalidated. Concurrent misses of
+// the same key cause a single load
+type ttlCache[K comparable, V any] struct {
+	ttl        time.Duration
+	mu         sync.Mutex
+	entries    map[K]ttlCacheEntry[V]
+	generation uint64
+	flight     flightGroup[K, V]
+}
+
+// get returns the cached value or loads it. load gets a context detached from the caller's cancellation,
+// since other callers may wait for the same load after this one gave up; it keeps the caller's values
+func (c *ttlCache[K, V]) get(ctx context.Context, key K, load func(ctx context.Context) (V, error)) (V, error) {
+	c.mu.Lock()
+	entry, ok := c.entries[key]
+	generation := c.generation
+	c.mu.Unlock()
+	if ok && (c.ttl <= 0 || time.Now().Before(entry.expires)) {
+		return entry.value, nil
+	}
+
+	value, _, err := c.flight.do(ctx, key, func() (V, error) {
+		value, err := load(context.WithoutCancel(ctx))
+		if err != nil {
+			return value, err
+		}
+		c.mu.Lock()
+		// a value loaded while the cache was invalidated may be stale, so it is returned but not kept
+		if c.generation == generation {
+			if c.entries == nil {
+				c.entries = map[K]ttlCacheEntry[V]{}
+			}
+			c.entries[key] = ttlCacheEntry[V]{value: value, expires: time.Now().Add(c.ttl)}
+		}
+		c.mu.Unlock()
+		return value, nil
+	})
+	return value, err
+}
+
+func (c *ttlCache[K, V]) invalidate() {
+	c.mu.Lock()
+	c.entries = nil
+	c.generation++
+	c.mu.Unlock()
+}
@@ -0,0 +1,28 @@
+package magento2
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"gopkg.in/yaml.v3"
+)
+
+// readYAML reads the first document of r into v through the yaml tags of its structs. Like
+// encoding/json unknown keys are ignored, an empty document leaves v as it is
+func readYAML(r io.Reader, v any) error {
+	if err := yaml.NewDecoder(r).Decode(v); err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("%w: %v", ErrInvalidYAML, err)
+	}
+	return nil
+}
+
+// writeYAML writes v in block style with two space indentation
+func writeYAML(w io.Writer, v any) error {
+	encoder := yaml.NewEncoder(w)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(v); err != nil {
+		return err
+	}
+	return encoder.Close()
+}
@@ -0,0 +1,229 @@
+package magento2
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+)
+
+const lowStockPageSize = 200
+
+// SearchSourceItems returns the MSI source items matching the criteria
+func SearchSourceItems(ctx context.Context, criteria *SearchCriteria, apiClient *Client) (*SourceItemSearchResult, error) {
+	endpoint := inventorySourceItems + "?" + criteria.Clone().Query()
+	result := &SourceItemSearchResult{}
+
+	log.Debug().
+		Str("endpoint", endpoint).
+		Msg("Searching source items")
+
+	resp, err := apiClient.HTTPClient.R().SetContext(ctx).SetResult(result).Get(endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("error searching source items: %w", err)
+	}
+
+	httpErr := mayReturnErrorForHTTPResponse(resp, "search source items")
+	if httpErr != nil {
+		return nil, httpErr
+	}
+
+	return result, nil
+}
+
+// GetLowStockItems returns every source item with a quantity at or below threshold, narrowed down by
+// the optional criteria (e.g. a source_code filter). All pages are fetched unless criteria sets CurrentPage
+func GetLowStockItems(ctx context.Context, threshold float64, criteria *SearchCriteria, apiClient *Client) ([]LowStockItem, error) {
+	sc := criteria.Clone().And(SearchFilter{
+		Field:         "quantity",
+		Value:         strconv.FormatFloat(threshold, 'f', -1, 64),
+		ConditionType: "lteq",
+	})
+	singlePage := sc.CurrentPage > 0
+	if sc.PageSize == 0 {
+		sc.PageSize = lowStockPageSize
+	}
+	if !singlePage {
+		sc.CurrentPage = 1
+	}
+
+	var items []LowStockItem
+	for {
+		result, err := SearchSourceItems(ctx, sc, apiClient)
+		if err != nil {
+			return nil, fmt.Errorf("error getting low stock items: %w", err)
+		}
+		for _, sourceItem := range result.Items {
+			items = append(items, LowStockItem{
+				Sku:        sourceItem.Sku,
+				SourceCode: sourceItem.SourceCode,
+				Qty:        sourceItem.Quantity,
+			})
+		}
+		if singlePage || len(result.Items) == 0 || sc.CurrentPage*sc.PageSize >= result.TotalCount {
+			break
+		}
+		sc.CurrentPage++
+	}
+
+	log.Debug().Float64("threshold", threshold).Int("items", len(items)).Msg("Low stock items fetched")
+	return items, nil
+}
+
+// TransferSourceItems moves the quantities of the SKUs from one source to another in a single call,
+// optionally unassigning the SKUs from the origin source afterwards
+func TransferSourceItems(ctx context.Context, skus []string, originSource, destinationSource string, unassignFromOrigin bool, apiClient *Client) error {
+	payLoad := bulkSourceTransferPayload{
+		Skus:               skus,
+		OriginSource:       originSource,
+		DestinationSource:  destinationSource,
+		UnassignFromOrigin: unassignFromOrigin,
+	}
+
+	log.Debug().
+		Str("endpoint", inventoryBulkProductSourceTransfer).
+		Interface("payload", payLoad).
+		Msg("Transferring source items")
+
+	resp, err := apiClient.HTTPClient.R().SetContext(ctx).SetBody(payLoad).Post(inventoryBulkProductSourceTransfer)
+	if err != nil {
+		return fmt.Errorf("error transferring source items: %w", err)
+	}
+
+	return mayReturnErrorForHTTPResponse(resp, fmt.Sprintf("transfer source items from '%s' to '%s'", originSource, destinationSource))
+}
+
+// AssignSources assigns every SKU to every source, returning the number of created assignments
+func AssignSources(ctx context.Context, skus, sourceCodes []string, apiClient *Client) (int, error) {
+	return postBulkSourceAssignment(ctx, inventoryBulkProductSourceAssign, skus, sourceCodes, "assign sources to products", apiClient)
+}
+
+// UnassignSources removes the SKUs from the sources, returning the number of removed assignments
+func UnassignSources(ctx context.Context, skus, sourceCodes []string, apiClient *Client) (int, error) {
+	return postBulkSourceAssignment(ctx, inventoryBulkProductSourceUnassign, skus, sourceCodes, "unassign sources from products", apiClient)
+}
+
+func postBulkSourceAssignment(ctx context.Context, endpoint string, skus, sourceCodes []string, tryTo string, apiClient *Client) (int, error) {
+	payLoad := bulkSourceAssignPayload{
+		Skus:        skus,
+		SourceCodes: sourceCodes,
+	}
+
+	log.Debug().
+		Str("endpoint", endpoint).
+		Interface("payload", payLoad).
+		Msg("Updating source assignments")
+
+	resp, err := apiClient.HTTPClient.R().SetContext(ctx).SetBody(payLoad).Post(endpoint)
+	if err != nil {
+		return 0, fmt.Errorf("error while trying to %s: %w", tryTo, err)
+	}
+
+	httpErr := mayReturnErrorForHTTPResponse(resp, tryTo)
+	if httpErr != nil {
+		return 0, httpErr
+	}
+
+	count, err := strconv.Atoi(mayTrimSurroundingQuotes(resp.String()))
+	if err != nil {
+		return 0, fmt.Errorf("unexpected response while trying to %s: %w", tryTo, err)
+	}
+	return count, nil
+}
+
+// GetSalableQty returns the salable quantity of the SKU in the stock, i.e. source quantity minus reservations
+func GetSalableQty(ctx context.Context, sku string, stockID int, apiClient *Client) (float64, error) {
+	endpoint := fmt.Sprintf("%s/%s/%d", inventoryProductSalableQuantity, url.PathEscape(sku), stockID)
+
+	log.Debug().
+		Str("endpoint", endpoint).
+		Msg("Getting salable quantity")
+
+	resp, err := apiClient.HTTPClient.R().SetContext(ctx).Get(endpoint)
+	if err != nil {
+		return 0, fmt.Errorf("error getting salable quantity: %w", err)
+	}
+
+	httpErr := mayReturnErrorForHTTPResponse(resp, fmt.Sprintf("get salable quantity of '%s'", sku))
+	if httpErr != nil {
+		return 0, httpErr
+	}
+
+	qty, err := strconv.ParseFloat(mayTrimSurroundingQuotes(resp.String()), 64)
+	if err != nil {
+		return 0, fmt.Errorf("unexpected salable quantity response for '%s': %w", sku, err)
+	}
+	return qty, nil
+}
+
+// GetStockSourceCodes returns the codes of the sources linked to the stock
+func GetStockSourceCodes(ctx context.Context, stockID int, apiClient *Client) ([]string, error) {
+	sc := NewSearchCriteria(SearchFilter{Field: "stock_id", Value: strconv.Itoa(stockID), ConditionType: "eq"})
+	endpoint := inventoryStockSourceLinks + "?" + sc.Query()
+	result := &stockSourceLinkSearchResult{}
+
+	log.Debug().
+		Str("endpoint", endpoint).
+		Msg("Getting stock source links")
+
+	resp, err := apiClient.HTTPClient.R().SetContext(ctx).SetResult(result).Get(endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("error getting stock source links: %w", err)
+	}
+
+	httpErr := mayReturnErrorForHTTPResponse(resp, "get stock source links")
+	if httpErr != nil {
+		return nil, httpErr
+	}
+
+	codes := make([]string, 0, len(result.Items))
+	for _, link := range result.Items {
+		codes = append(codes, link.SourceCode)
+	}
+	return codes, nil
+}
+
+// GetStockReservations reports physical, salable and reserved quantity per SKU for the stock, which
+// explains products that are in stock at a source but not salable on the storefront
+func GetStockReservations(ctx context.Context, skus []string, stockID int, apiClient *Client) ([]StockReservation, error) {
+	sourceCodes, err := GetStockSourceCodes(ctx, stockID, apiClient)
+	if err != nil {
+		return nil, err
+	}
+
+	physical := make(map[string]float64, len(skus))
+	if len(sourceCodes) > 0 && len(skus) > 0 {
+		sc := NewSearchCriteria(
+			SearchFilter{Field: "sku", Value: strings.Join(skus, ","), ConditionType: "in"},
+			SearchFilter{Field: "source_code", Value: strings.Join(sourceCodes, ","), ConditionType: "in"},
+		)
+		result, err := SearchSourceItems(ctx, sc, apiClient)
+		if err != nil {
+			return nil, fmt.Errorf("error getting source items for reservations: %w", err)
+		}
+		for _, sourceItem := range result.Items {
+			if sourceItem.Status == SourceItemStatusInStock {
+				physical[sourceItem.Sku] += sourceItem.Quantity
+			}
+		}
+	}
+
+	reservations := make([]StockReservation, 0, len(skus))
+	for _, sku := range skus {
+		salable, err := GetSalableQty(ctx, sku, stockID, apiClient)
+		if err != nil {
+			return nil, err
+		}
+		reservations = append(reservations, StockReservation{
+			Sku:         sku,
+			StockID:     stockID,
+			PhysicalQty: physical[sku],
+			SalableQty:  salable,
+			ReservedQty: physical[sku] - salable,
+		})
+	}
+	return reservations, nil
+}
@@ -0,0 +1,10 @@
+package magento2
+
+const (
+	inventorySourceItems               = "/inventory/source-items"
+	inventoryBulkProductSourceTransfer = "/inventory/bulk-product-source-transfer"
+	inventoryBulkProductSourceAssign   = "/inventory/bulk-product-source-assign"
+	inventoryBulkProductSourceUnassign = "/inventory/bulk-product-source-unassign"
+	inventoryStockSourceLinks          = "/inventory/stock-source-links"
+	inventoryProductSalableQuantity    = "/inventory/get-product-salable-quantity"
+)
@@ -0,0 +1,59 @@
+package magento2
+
+const (
+	SourceItemStatusOutOfStock = 0
+	SourceItemStatusInStock    = 1
+)
+
+type SourceItem struct {
+	Sku                 string         `json:"sku"`
+	SourceCode          string         `json:"source_code"`
+	Quantity            float64        `json:"quantity"`
+	Status              int            `json:"status"`
+	ExtensionAttributes map[string]any `json:"extension_attributes,omitempty"`
+}
+
+type SourceItemSearchResult struct {
+	Items      []SourceItem `json:"items"`
+	TotalCount int          `json:"total_count"`
+}
+
+type LowStockItem struct {
+	Sku        string
+	SourceCode string
+	Qty        float64
+}
+
+type bulkSourceTransferPayload struct {
+	Skus               []string `json:"skus"`
+	OriginSource       string   `json:"originSource"`
+	DestinationSource  string   `json:"destinationSource"`
+	UnassignFromOrigin bool     `json:"unassignFromOrigin"`
+}
+
+type bulkSourceAssignPayload struct {
+	Skus        []string `json:"skus"`
+	SourceCodes []string `json:"sourceCodes"`
+}
+
+type StockSourceLink struct {
+	StockID    int    `json:"stock_id"`
+	SourceCode string `json:"source_code"`
+	Priority   int    `json:"priority"`
+}
+
+type stockSourceLinkSearchResult struct {
+	Items      []StockSourceLink `json:"items"`
+	TotalCount int               `json:"total_count"`
+}
+
+// StockReservation explains the gap between physical and salable quantity of a SKU in a stock.
+// PhysicalQty sums the in-stock source items of the stock's sources, ReservedQty is what open
+// reservations (and the out-of-stock threshold) hold back from it
+type StockReservation struct {
+	Sku         string
+	StockID     int
+	PhysicalQty float64
+	SalableQty  float64
+	ReservedQty float64
+}
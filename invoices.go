@@ -0,0 +1,119 @@
+package magento2
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+)
+
+var ErrNoInvoiceDocumentSource = errors.New("no invoice document source configured on client")
+
+type MInvoice struct {
+	Route     string
+	Invoice   *Invoice
+	APIClient *Client
+}
+
+// InvoiceDocumentSource retrieves the printable document (usually a PDF) of an invoice. Magento core
+// has no such endpoint, so it is provided by a PDF module or by the application itself
+type InvoiceDocumentSource interface {
+	InvoiceDocument(ctx context.Context, apiClient *Client, invoice *Invoice) ([]byte, error)
+}
+
+// RESTInvoiceDocumentSource fetches invoice documents from a module endpoint. RouteTemplate receives
+// the invoice entity ID, e.g. "/invoices/%d/pdf". Most modules answer with a base64 encoded JSON string,
+// set Raw when the endpoint streams the PDF bytes instead
+type RESTInvoiceDocumentSource struct {
+	RouteTemplate string
+	Raw           bool
+}
+
+func (s *RESTInvoiceDocumentSource) InvoiceDocument(ctx context.Context, apiClient *Client, invoice *Invoice) ([]byte, error) {
+	endpoint := fmt.Sprintf(s.RouteTemplate, invoice.EntityID)
+
+	log.Debug().
+		Int("invoiceID", invoice.EntityID).
+		Str("endpoint", endpoint).
+		Msg("Getting invoice document")
+
+	resp, err := apiClient.HTTPClient.R().SetContext(ctx).Get(endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("error getting invoice document: %w", err)
+	}
+
+	httpErr := mayReturnErrorForHTTPResponse(resp, "get invoice document from remote")
+	if httpErr != nil {
+		return nil, httpErr
+	}
+
+	if s.Raw {
+		return resp.Body(), nil
+	}
+
+	encoded := strings.TrimSpace(mayTrimSurroundingQuotes(strings.TrimSpace(resp.String())))
+	document, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding invoice document: %w", err)
+	}
+	return document, nil
+}
+
+// SetInvoiceDocumentSource configures where MInvoice.Document retrieves invoice documents from
+func (c *Client) SetInvoiceDocumentSource(source InvoiceDocumentSource) *Client {
+	c.invoiceDocumentSource = source
+	return c
+}
+
+func GetInvoiceByID(ctx context.Context, id int, apiClient *Client) (*MInvoice, error) {
+	mInvoice := &MInvoice{
+		Route:     fmt.Sprintf("%s/%d", invoices, id),
+		Invoice:   &Invoice{},
+		APIClient: apiClient,
+	}
+
+	log.Debug().
+		Int("invoiceID", id).
+		Str("route", mInvoice.Route).
+		Msg("Getting invoice by ID")
+
+	resp, err := apiClient.HTTPClient.R().SetContext(ctx).SetResult(mInvoice.Invoice).Get(mInvoice.Route)
+	if err != nil {
+		return nil, fmt.Errorf("error getting invoice by ID: %w", err)
+	}
+
+	httpErr := mayReturnErrorForHTTPResponse(resp, "get invoice by id from remote")
+	if httpErr != nil {
+		return nil, httpErr
+	}
+
+	return mInvoice, nil
+}
+
+// Document returns the printable invoice document from the client's InvoiceDocumentSource
+func (mi *MInvoice) Document(ctx context.Context) ([]byte, error) {
+	if mi.APIClient.invoiceDocumentSource == nil {
+		return nil, ErrNoInvoiceDocumentSource
+	}
+	document, err := mi.APIClient.invoiceDocumentSource.InvoiceDocument(ctx, mi.APIClient, mi.Invoice)
+	if err != nil {
+		return nil, fmt.Errorf("error getting document for invoice %d: %w", mi.Invoice.EntityID, err)
+	}
+	return document, nil
+}
+
+// GetInvoiceWithDocument fetches the invoice entity together with its printable document, e.g. for ERP archiving
+func GetInvoiceWithDocument(ctx context.Context, id int, apiClient *Client) (*MInvoice, []byte, error) {
+	mInvoice, err := GetInvoiceByID(ctx, id, apiClient)
+	if err != nil {
+		return nil, nil, err
+	}
+	document, err := mInvoice.Document(ctx)
+	if err != nil {
+		return mInvoice, nil, err
+	}
+	return mInvoice, document, nil
+}
@@ -0,0 +1,293 @@
+package magento2
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/rs/zerolog/log"
+)
+
+// JournalEntry records one attempt of a mutating request. Route is relative to the REST version
+// prefix, e.g. "/products/shirt", StoreCode the store view of the REST prefix. PayloadHash is the hex
+// SHA-256 of the request body, Payload the body itself when Journal.KeepPayloads is set and it is JSON.
+// Both are taken after the passwords in the body were replaced by RedactedValue, Redacted is set then.
+// Status is 0 when the request failed without a response, Error holds the transport error then. Created
+// is set for product saves the response shows as a creation rather than an update of an existing product
+type JournalEntry struct {
+	ID          string          `json:"id"`
+	Time        time.Time       `json:"time"`
+	Method      string          `json:"method"`
+	StoreCode   string          `json:"store_code"`
+	Route       string          `json:"route"`
+	PayloadHash string          `json:"payload_hash"`
+	Payload     json.RawMessage `json:"payload,omitempty"`
+	Status      int             `json:"status"`
+	Error       string          `json:"error,omitempty"`
+	Duration    time.Duration   `json:"duration"`
+	Attempt     int             `json:"attempt"`
+	RequestID   string          `json:"request_id,omitempty"`
+	RunID       string          `json:"run_id,omitempty"`
+	Created     bool            `json:"created,omitempty"`
+	Redacted    bool            `json:"redacted,omitempty"`
+}
+
+// Succeeded reports whether Magento accepted the request
+func (e *JournalEntry) Succeeded() bool {
+	return e.Status >= http.StatusOK && e.Status < http.StatusMultipleChoices
+}
+
+// JournalStore keeps journal entries. Entries returns the entries in [since, until) in the order they
+// were appended, zero times leave the range open. FileJournalStore is the built-in store, it needs no
+// database; the journalsqlite package keeps entries in a SQLite table opened with any driver
+type JournalStore interface {
+	Append(entry JournalEntry) error
+	Entries(since, until time.Time) ([]JournalEntry, error)
+}
+
+// Journal records every mutating request of a client (POST, PUT and DELETE) to its store, to audit what
+// an integration changed and when. Every attempt is recorded, retried calls show up once per attempt.
+// Token requests are left out, their bodies hold credentials, and passwords in other bodies, e.g. of
+// customer creations, are redacted
+type Journal struct {
+	Store JournalStore
+	// KeepPayloads stores the request bodies with the entries, which replaying them requires. Bodies
+	// can hold customer data
+	KeepPayloads bool
+}
+
+// SetJournal records the mutating requests of the client with the journal, nil stops recording.
+// It can be called while requests are in flight. A failing store does not fail the request, the error
+// is logged
+func (c *Client) SetJournal(journal *Journal) *Client {
+	if journal != nil {
+		c.journalHooks.Do(func() {
+			c.HTTPClient.OnAfterResponse(c.journalResponse).
+				OnError(c.journalError)
+		})
+	}
+	c.journal.Store(journal)
+	return c
+}
+
+func (c *Client) journalResponse(_ *resty.Client, resp *resty.Response) error {
+	c.recordJournalEntry(resp.Request, resp.StatusCode(), resp.Time(), resp.Body(), nil)
+	return nil
+}
+
+func (c *Client) journalError(r *resty.Request, err error) {
+	var responseErr *resty.ResponseError
+	if errors.As(err, &responseErr) && responseErr.Response != nil && responseErr.Response.RawResponse != nil {
+		// recorded with the response
+		return
+	}
+	c.recordJournalEntry(r, 0, time.Since(r.Time), nil, err)
+}
+
+func (c *Client) recordJournalEntry(r *resty.Request, status int, duration time.Duration, response []byte, requestErr error) {
+	journal := c.journal.Load()
+	if journal == nil || r.Method == http.MethodGet || r.Method == http.MethodHead || r.Method == http.MethodOptions {
+		return
+	}
+	storeCode, route := journalRoute(r.URL)
+	if strings.HasPrefix(route, "/integration/") {
+		return
+	}
+
+	payload, err := journalPayload(r.Body)
+	if err != nil {
+		log.Error().Err(err).Str("route", route).Msg("Error encoding request body for journal")
+	}
+	payload, redacted := redactJournalPayload(payload)
+	hash := sha256.Sum256(payload)
+	entry := JournalEntry{
+		ID:          newIdempotencyKey(),
+		Time:        r.Time.UTC(),
+		Method:      r.Method,
+		StoreCode:   storeCode,
+		Route:       route,
+		PayloadHash: hex.EncodeToString(hash[:]),
+		Status:      status,
+		Duration:    duration,
+		Attempt:     r.Attempt,
+		RequestID:   RequestIDFromContext(r.Context()),
+		RunID:       c.runID,
+		Redacted:    redacted,
+	}
+	if r.Method == http.MethodPost && route == products && entry.Succeeded() {
+		entry.Created = isProductCreation(response)
+	}
+	if journal.KeepPayloads && json.Valid(payload) {
+		entry.Payload = payload
+	}
+	if requestErr != nil {
+		entry.Error = requestErr.Error()
+	}
+	if err := journal.Store.Append(entry); err != nil {
+		log.Error().Err(err).Str("method", entry.Method).Str("route", route).Msg("Error appending journal entry")
+	}
+}
+
+// journalRoute splits a request URL into the store code and the route after the REST version prefix
+func journalRoute(requestURL string) (string, string) {
+	path := requestURL
+	if parsed, err := url.Parse(requestURL); err == nil {
+		path = parsed.EscapedPath()
+		if parsed.RawQuery != "" {
+			path += "?" + parsed.RawQuery
+		}
+	}
+	_, rest, found := strings.Cut(path, "/rest/")
+	if !found {
+		return "", path
+	}
+	storeCode, route, _ := strings.Cut(rest, "/")
+	if version, tail, ok := strings.Cut(route, "/"); ok && strings.HasPrefix(version, "V") {
+		route = tail
+	}
+	return storeCode, "/" + route
+}
+
+// isProductCreation tells from the response of a product save whether it created the product: Magento
+// sets both timestamps to the same value on creation, an update moves updated_at
+func isProductCreation(response []byte) bool {
+	var product struct {
+		CreatedAt string `json:"created_at"`
+		UpdatedAt string `json:"updated_at"`
+	}
+	if err := json.Unmarshal(response, &product); err != nil {
+		return false
+	}
+	return product.CreatedAt != "" && product.CreatedAt == product.UpdatedAt
+}
+
+// RedactedValue replaces passwords in journaled payloads
+const RedactedValue = "[redacted]"
+
+// journalRedactedFields are the normalized names, lower case without underscores, of the body fields
+// holding passwords: password, new_password and current_password and their camel case forms
+var journalRedactedFields = map[string]bool{"password": true, "newpassword": true, "currentpassword": true}
+
+// redactJournalPayload replaces password values anywhere in a JSON body and reports whether it did.
+// Bodies without passwords are returned as they are
+func redactJournalPayload(payload []byte) ([]byte, bool) {
+	if !json.Valid(payload) || !bytes.Contains(bytes.ToLower(payload), []byte("password")) {
+		return payload, false
+	}
+	decoder := json.NewDecoder(bytes.NewReader(payload))
+	decoder.UseNumber()
+	var body any
+	if err := decoder.Decode(&body); err != nil || !redactJournalValue(body) {
+		return payload, false
+	}
+	redacted, err := json.Marshal(body)
+	if err != nil {
+		return payload, false
+	}
+	return redacted, true
+}
+
+func redactJournalValue(value any) bool {
+	redacted := false
+	switch v := value.(type) {
+	case map[string]any:
+		for key, field := range v {
+			if journalRedactedFields[strings.ReplaceAll(strings.ToLower(key), "_", "")] {
+				v[key] = RedactedValue
+				redacted = true
+				continue
+			}
+			redacted = redactJournalValue(field) || redacted
+		}
+	case []any:
+		for _, item := range v {
+			redacted = redactJournalValue(item) || redacted
+		}
+	}
+	return redacted
+}
+
+// journalPayload returns the body as sent, resty encodes everything but bytes and strings as JSON
+func journalPayload(body any) ([]byte, error) {
+	switch b := body.(type) {
+	case nil:
+		return nil, nil
+	case []byte:
+		return b, nil
+	case string:
+		return []byte(b), nil
+	}
+	return json.Marshal(body)
+}
+
+// FileJournalStore appends entries as JSON lines to a file. It is safe for concurrent use, but not for
+// several processes writing the same file
+type FileJournalStore struct {
+	path string
+	mu   sync.Mutex
+}
+
+func NewFileJournalStore(path string) *FileJournalStore {
+	return &FileJournalStore{path: path}
+}
+
+func (s *FileJournalStore) Append(entry JournalEntry) error {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("error encoding journal entry: %w", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	file, err := os.OpenFile(s.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return fmt.Errorf("error opening journal: %w", err)
+	}
+	if _, err := file.Write(append(data, '\n')); err != nil {
+		file.Close()
+		return fmt.Errorf("error writing journal: %w", err)
+	}
+	return file.Close()
+}
+
+func (s *FileJournalStore) Entries(since, until time.Time) ([]JournalEntry, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	file, err := os.Open(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return []JournalEntry{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error opening journal: %w", err)
+	}
+	defer file.Close()
+
+	entries := []JournalEntry{}
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), DefaultMaxResponseBytes)
+	for line := 1; scanner.Scan(); line++ {
+		var entry JournalEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			return nil, fmt.Errorf("error decoding journal %s line %d: %w", s.path, line, err)
+		}
+		if (!since.IsZero() && entry.Time.Before(since)) || (!until.IsZero() && !entry.Time.Before(until)) {
+			continue
+		}
+		entries = append(entries, entry)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("error reading journal: %w", err)
+	}
+	return entries, nil
+}
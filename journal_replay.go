@@ -0,0 +1,203 @@
+package magento2
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+
+	"github.com/rs/zerolog/log"
+)
+
+// ReplayAction is what ReplayJournal did with an entry
+type ReplayAction string
+
+const (
+	ReplayApplied ReplayAction = "applied"
+	ReplayPlanned ReplayAction = "planned"
+	ReplaySkipped ReplayAction = "skipped"
+	ReplayFailed  ReplayAction = "failed"
+)
+
+// ReplayOptions configures ReplayJournal. StoreCode sends the requests to another store view than the
+// recorded one. Filter selects the entries to replay, nil replays all. DryRun only plans the requests.
+// Invert undoes the entries in reverse order instead of applying them again
+type ReplayOptions struct {
+	StoreCode string
+	Filter    func(entry *JournalEntry) bool
+	DryRun    bool
+	Invert    bool
+}
+
+// ReplayResult is the outcome of one entry. Method and Route are the request sent, or planned, for it;
+// Reason tells why an entry was skipped or failed
+type ReplayResult struct {
+	EntryID string       `json:"entry_id"`
+	Action  ReplayAction `json:"action"`
+	Method  string       `json:"method,omitempty"`
+	Route   string       `json:"route,omitempty"`
+	Reason  string       `json:"reason,omitempty"`
+}
+
+// replayInversion undoes a request: route matches the recorded route, undo returns the route of the
+// DELETE for the match and the recorded payload. createdOnly inverts only entries marked Created, for
+// routes that create or replace
+type replayInversion struct {
+	method      string
+	route       *regexp.Regexp
+	createdOnly bool
+	undo        func(match []string, payload json.RawMessage) (string, error)
+}
+
+// replayInversions lists the requests whose inverse follows from the request alone. Updates and deletes
+// would need the previous state, and creates with generated IDs the response, neither is journaled
+var replayInversions = []replayInversion{
+	{
+		method:      http.MethodPost,
+		route:       regexp.MustCompile(`^/products$`),
+		createdOnly: true,
+		undo: func(_ []string, payload json.RawMessage) (string, error) {
+			var body struct {
+				Product struct {
+					Sku string `json:"sku"`
+				} `json:"product"`
+			}
+			if err := json.Unmarshal(payload, &body); err != nil || body.Product.Sku == "" {
+				return "", fmt.Errorf("payload has no product sku")
+			}
+			return products + "/" + url.PathEscape(body.Product.Sku), nil
+		},
+	},
+	{
+		method: http.MethodPut,
+		route:  regexp.MustCompile(`^/categories/(\d+)/products$`),
+		undo: func(match []string, payload json.RawMessage) (string, error) {
+			var body assignProductPayload
+			if err := json.Unmarshal(payload, &body); err != nil || body.ProductLink.Sku == "" {
+				return "", fmt.Errorf("payload has no product link sku")
+			}
+			return fmt.Sprintf("%s/%s/%s/%s", categories, match[1], categoriesProductsRelative, url.PathEscape(body.ProductLink.Sku)), nil
+		},
+	},
+	{
+		method: http.MethodPost,
+		route:  regexp.MustCompile(`^/configurable-products/([^/]+)/child$`),
+		undo: func(match []string, payload json.RawMessage) (string, error) {
+			var body addChildSKUPayload
+			if err := json.Unmarshal(payload, &body); err != nil || body.Sku == "" {
+				return "", fmt.Errorf("payload has no child sku")
+			}
+			return fmt.Sprintf("%s/%s/children/%s", configurableProducts, match[1], url.PathEscape(body.Sku)), nil
+		},
+	},
+}
+
+// ReplayJournal sends the requests of journal entries again to apiClient, e.g. to restore a store after
+// an incident or to promote changes from staging. Only entries Magento accepted are replayed, POST and
+// PUT entries need their payload (Journal.KeepPayloads), entries with redacted passwords are skipped. With opts.Invert the entries are undone in
+// reverse order where the inverse is known: products the journal recorded as created are deleted,
+// category assignments and configurable children removed; other entries, including product saves that
+// updated an existing product, are skipped. Replaying stops at the first failure
+func ReplayJournal(ctx context.Context, entries []JournalEntry, opts ReplayOptions, apiClient *Client) ([]ReplayResult, error) {
+	log.Debug().
+		Int("entries", len(entries)).
+		Str("storeCode", opts.StoreCode).
+		Bool("dryRun", opts.DryRun).
+		Bool("invert", opts.Invert).
+		Msg("Replaying journal")
+
+	order := make([]int, len(entries))
+	for i := range entries {
+		order[i] = i
+		if opts.Invert {
+			order[i] = len(entries) - 1 - i
+		}
+	}
+
+	results := []ReplayResult{}
+	for _, i := range order {
+		entry := &entries[i]
+		if opts.Filter != nil && !opts.Filter(entry) {
+			continue
+		}
+		result := ReplayResult{EntryID: entry.ID}
+		method, route, payload, reason := replayRequest(entry, opts.Invert)
+		if reason != "" {
+			result.Action = ReplaySkipped
+			result.Reason = reason
+			results = append(results, result)
+			continue
+		}
+		result.Method, result.Route = method, route
+		if opts.DryRun {
+			result.Action = ReplayPlanned
+			results = append(results, result)
+			continue
+		}
+
+		storeCode := entry.StoreCode
+		if opts.StoreCode != "" {
+			storeCode = opts.StoreCode
+		}
+		if err := sendReplayRequest(ctx, method, storeCode, route, payload, apiClient); err != nil {
+			result.Action = ReplayFailed
+			result.Reason = err.Error()
+			results = append(results, result)
+			return results, fmt.Errorf("error replaying journal entry %s: %w", entry.ID, err)
+		}
+		result.Action = ReplayApplied
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+// replayRequest returns the request replaying or inverting the entry, or why there is none
+func replayRequest(entry *JournalEntry, invert bool) (string, string, json.RawMessage, string) {
+	if !entry.Succeeded() {
+		return "", "", nil, fmt.Sprintf("not accepted originally (status %d)", entry.Status)
+	}
+	if !invert {
+		if entry.Method != http.MethodDelete && len(entry.Payload) == 0 {
+			return "", "", nil, "no payload recorded"
+		}
+		if entry.Redacted {
+			// the passwords were replaced by RedactedValue, sending them would set that as password
+			return "", "", nil, "payload redacted"
+		}
+		return entry.Method, entry.Route, entry.Payload, ""
+	}
+
+	for _, inversion := range replayInversions {
+		match := inversion.route.FindStringSubmatch(entry.Route)
+		if inversion.method != entry.Method || match == nil {
+			continue
+		}
+		if inversion.createdOnly && !entry.Created {
+			return "", "", nil, "updated an existing entity, the previous state is unknown"
+		}
+		if len(entry.Payload) == 0 {
+			return "", "", nil, "no payload recorded"
+		}
+		route, err := inversion.undo(match, entry.Payload)
+		if err != nil {
+			return "", "", nil, err.Error()
+		}
+		return http.MethodDelete, route, nil, ""
+	}
+	return "", "", nil, fmt.Sprintf("%s %s cannot be inverted", entry.Method, entry.Route)
+}
+
+func sendReplayRequest(ctx context.Context, method, storeCode, route string, payload json.RawMessage, apiClient *Client) error {
+	endpoint := newRequestOptions([]RequestOption{WithStoreCode(storeCode)}).endpoint(apiClient, route)
+	req := apiClient.HTTPClient.R().SetContext(ctx)
+	if len(payload) > 0 {
+		req.SetHeader("Content-Type", "application/json").SetBody([]byte(payload))
+	}
+	resp, err := req.Execute(method, endpoint)
+	if err != nil {
+		return fmt.Errorf("error sending replayed request: %w", err)
+	}
+	return mayReturnErrorForHTTPResponse(resp, fmt.Sprintf("replay %s %s", method, route))
+}
@@ -0,0 +1,139 @@
+// Package journalsqlite keeps the request journal of magento2.Journal in a SQLite table. It only uses
+// database/sql: the application opens the database with the driver of its choice, e.g.
+// modernc.org/sqlite or github.com/mattn/go-sqlite3, so neither this package nor the client depend on one
+package journalsqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"regexp"
+	"time"
+
+	magento2 "github.com/florinel-chis/go-m2rest"
+)
+
+// DefaultTable is the table New uses when none is given
+const DefaultTable = "m2rest_journal"
+
+var ErrInvalidTable = errors.New("invalid journal table name")
+
+var tableName = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// Store is a magento2.JournalStore appending to a SQLite table with an index on the time. It is safe
+// for concurrent use as far as the driver is
+type Store struct {
+	db    *sql.DB
+	table string
+}
+
+// New creates the table and its index unless they exist and returns the store writing to it
+func New(ctx context.Context, db *sql.DB, table string) (*Store, error) {
+	if table == "" {
+		table = DefaultTable
+	}
+	if !tableName.MatchString(table) {
+		return nil, fmt.Errorf("%w: '%s'", ErrInvalidTable, table)
+	}
+
+	statements := []string{
+		`CREATE TABLE IF NOT EXISTS ` + table + ` (
+			seq INTEGER PRIMARY KEY,
+			id TEXT NOT NULL,
+			time INTEGER NOT NULL,
+			method TEXT NOT NULL,
+			store_code TEXT NOT NULL,
+			route TEXT NOT NULL,
+			payload_hash TEXT NOT NULL,
+			payload TEXT,
+			status INTEGER NOT NULL,
+			error TEXT NOT NULL,
+			duration INTEGER NOT NULL,
+			attempt INTEGER NOT NULL,
+			request_id TEXT NOT NULL,
+			run_id TEXT NOT NULL,
+			created INTEGER NOT NULL,
+			redacted INTEGER NOT NULL
+		)`,
+		`CREATE INDEX IF NOT EXISTS ` + table + `_time ON ` + table + ` (time)`,
+	}
+	for _, statement := range statements {
+		if _, err := db.ExecContext(ctx, statement); err != nil {
+			return nil, fmt.Errorf("error creating journal table %s: %w", table, err)
+		}
+	}
+	return &Store{db: db, table: table}, nil
+}
+
+func (s *Store) Append(entry magento2.JournalEntry) error {
+	var payload sql.NullString
+	if len(entry.Payload) > 0 {
+		payload = sql.NullString{String: string(entry.Payload), Valid: true}
+	}
+	created, redacted := 0, 0
+	if entry.Created {
+		created = 1
+	}
+	if entry.Redacted {
+		redacted = 1
+	}
+
+	_, err := s.db.Exec(`INSERT INTO `+s.table+` (id, time, method, store_code, route, payload_hash, payload, status,
+		error, duration, attempt, request_id, run_id, created, redacted) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		entry.ID, entry.Time.UnixNano(), entry.Method, entry.StoreCode, entry.Route, entry.PayloadHash, payload, entry.Status,
+		entry.Error, int64(entry.Duration), entry.Attempt, entry.RequestID, entry.RunID, created, redacted)
+	if err != nil {
+		return fmt.Errorf("error appending journal entry: %w", err)
+	}
+	return nil
+}
+
+func (s *Store) Entries(since, until time.Time) ([]magento2.JournalEntry, error) {
+	query := `SELECT id, time, method, store_code, route, payload_hash, payload, status, error, duration, attempt,
+		request_id, run_id, created, redacted FROM ` + s.table + ` WHERE 1 = 1`
+	args := []any{}
+	if !since.IsZero() {
+		query += ` AND time >= ?`
+		args = append(args, since.UnixNano())
+	}
+	if !until.IsZero() {
+		query += ` AND time < ?`
+		args = append(args, until.UnixNano())
+	}
+	query += ` ORDER BY seq`
+
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("error querying journal: %w", err)
+	}
+	defer rows.Close()
+
+	entries := []magento2.JournalEntry{}
+	for rows.Next() {
+		var (
+			entry    magento2.JournalEntry
+			nanos    int64
+			payload  sql.NullString
+			duration int64
+			created  int
+			redacted int
+		)
+		if err := rows.Scan(&entry.ID, &nanos, &entry.Method, &entry.StoreCode, &entry.Route, &entry.PayloadHash, &payload,
+			&entry.Status, &entry.Error, &duration, &entry.Attempt, &entry.RequestID, &entry.RunID, &created, &redacted); err != nil {
+			return nil, fmt.Errorf("error reading journal entry: %w", err)
+		}
+		entry.Time = time.Unix(0, nanos).UTC()
+		entry.Duration = time.Duration(duration)
+		entry.Created = created != 0
+		entry.Redacted = redacted != 0
+		if payload.Valid {
+			entry.Payload = []byte(payload.String)
+		}
+		entries = append(entries, entry)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error reading journal: %w", err)
+	}
+	return entries, nil
+}
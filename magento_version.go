@@ -0,0 +1,88 @@
+package magento2
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+)
+
+const magentoVersionRoute = "/magento_version"
+
+// magentoVersionPattern matches the answer of /magento_version, e.g. "Magento/2.4 (Community)"
+var magentoVersionPattern = regexp.MustCompile(`^Magento/([0-9.]+) \(([^)]+)\)$`)
+
+const (
+	EditionCommunity  = "Community"
+	EditionEnterprise = "Enterprise"
+)
+
+// MagentoVersion is the version a store reports. Magento only discloses major and minor version, e.g. 2.4
+type MagentoVersion struct {
+	Version string
+	Edition string
+}
+
+// ParseMagentoVersion parses the answer of the /magento_version endpoint
+func ParseMagentoVersion(s string) (*MagentoVersion, error) {
+	matches := magentoVersionPattern.FindStringSubmatch(strings.TrimSpace(s))
+	if matches == nil {
+		return nil, fmt.Errorf("%w: '%s'", ErrUnexpectedVersion, s)
+	}
+	return &MagentoVersion{Version: matches[1], Edition: matches[2]}, nil
+}
+
+// AtLeast reports whether the version is the given one or newer, e.g. AtLeast("2.3")
+func (v *MagentoVersion) AtLeast(version string) bool {
+	have := strings.Split(v.Version, ".")
+	want := strings.Split(version, ".")
+	for i := range want {
+		w, _ := strconv.Atoi(want[i])
+		h := 0
+		if i < len(have) {
+			h, _ = strconv.Atoi(have[i])
+		}
+		if h != w {
+			return h > w
+		}
+	}
+	return true
+}
+
+func (v *MagentoVersion) String() string {
+	return fmt.Sprintf("Magento/%s (%s)", v.Version, v.Edition)
+}
+
+// GetMagentoVersion returns the version of the store. The version is fetched once per client, failed
+// lookups are retried on the next call
+func GetMagentoVersion(ctx context.Context, apiClient *Client) (*MagentoVersion, error) {
+	apiClient.versionMu.Lock()
+	defer apiClient.versionMu.Unlock()
+	if apiClient.version != nil {
+		return apiClient.version, nil
+	}
+
+	endpoint := apiClient.storeRootURL() + magentoVersionRoute
+	log.Debug().Str("endpoint", endpoint).Msg("Detecting Magento version")
+
+	resp, err := apiClient.HTTPClient.R().SetContext(ctx).Get(endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("error getting magento version: %w", err)
+	}
+
+	httpErr := mayReturnErrorForHTTPResponse(resp, "get magento version")
+	if httpErr != nil {
+		return nil, httpErr
+	}
+
+	version, err := ParseMagentoVersion(resp.String())
+	if err != nil {
+		return nil, err
+	}
+	log.Debug().Str("version", version.Version).Str("edition", version.Edition).Msg("Magento version detected")
+	apiClient.version = version
+	return version, nil
+}
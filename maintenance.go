@@ -0,0 +1,134 @@
+package magento2
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	IndexerCategoryProduct   = "catalog_category_product"
+	IndexerProductCategory   = "catalog_product_category"
+	IndexerProductAttribute  = "catalog_product_attribute"
+	IndexerProductPrice      = "catalog_product_price"
+	IndexerStock             = "cataloginventory_stock"
+	IndexerInventory         = "inventory"
+	IndexerCatalogRule       = "catalogrule_rule"
+	IndexerCatalogSearch     = "catalogsearch_fulltext"
+	IndexerCatalogURLRewrite = "catalog_url_rewrite"
+)
+
+const (
+	CacheTypeConfig     = "config"
+	CacheTypeLayout     = "layout"
+	CacheTypeBlockHTML  = "block_html"
+	CacheTypeCollection = "collections"
+	CacheTypeEAV        = "eav"
+	CacheTypeFullPage   = "full_page"
+)
+
+var ErrNoMaintenanceHook = errors.New("no maintenance hook configured on client")
+
+// MaintenanceHook triggers reindexing and cache flushes. Magento core exposes neither over REST,
+// so stores either install a module that does (see RESTMaintenanceHook) or plug in their own
+// mechanism, e.g. a deployment pipeline job
+type MaintenanceHook interface {
+	Reindex(ctx context.Context, indexerIDs ...string) error
+	FlushCache(ctx context.Context, cacheTypes ...string) error
+}
+
+// RESTMaintenanceHook calls reindex and cache endpoints provided by a third-party module.
+// Both routes are relative to the REST prefix, e.g. "/indexer/reindex" and "/cache/flush"
+type RESTMaintenanceHook struct {
+	APIClient    *Client
+	ReindexRoute string
+	CacheRoute   string
+}
+
+type reindexPayload struct {
+	IndexerIDs []string `json:"indexerIds"`
+}
+
+type flushCachePayload struct {
+	CacheTypes []string `json:"cacheTypes"`
+}
+
+func (h *RESTMaintenanceHook) Reindex(ctx context.Context, indexerIDs ...string) error {
+	return h.post(ctx, h.ReindexRoute, reindexPayload{IndexerIDs: indexerIDs}, "reindex")
+}
+
+func (h *RESTMaintenanceHook) FlushCache(ctx context.Context, cacheTypes ...string) error {
+	return h.post(ctx, h.CacheRoute, flushCachePayload{CacheTypes: cacheTypes}, "flush cache")
+}
+
+func (h *RESTMaintenanceHook) post(ctx context.Context, endpoint string, payLoad any, tryTo string) error {
+	if endpoint == "" {
+		return fmt.Errorf("%w: no route configured to %s", ErrNoMaintenanceHook, tryTo)
+	}
+
+	log.Debug().
+		Str("endpoint", endpoint).
+		Interface("payload", payLoad).
+		Msg("Calling maintenance endpoint")
+
+	resp, err := h.APIClient.HTTPClient.R().SetContext(ctx).SetBody(payLoad).Post(endpoint)
+	if err != nil {
+		return fmt.Errorf("error while trying to %s: %w", tryTo, err)
+	}
+
+	return mayReturnErrorForHTTPResponse(resp, tryTo)
+}
+
+// MaintenanceHookFuncs adapts plain functions to a MaintenanceHook. A nil func is a no-op
+type MaintenanceHookFuncs struct {
+	ReindexFunc    func(ctx context.Context, indexerIDs ...string) error
+	FlushCacheFunc func(ctx context.Context, cacheTypes ...string) error
+}
+
+func (h MaintenanceHookFuncs) Reindex(ctx context.Context, indexerIDs ...string) error {
+	if h.ReindexFunc == nil {
+		return nil
+	}
+	return h.ReindexFunc(ctx, indexerIDs...)
+}
+
+func (h MaintenanceHookFuncs) FlushCache(ctx context.Context, cacheTypes ...string) error {
+	if h.FlushCacheFunc == nil {
+		return nil
+	}
+	return h.FlushCacheFunc(ctx, cacheTypes...)
+}
+
+// SetMaintenanceHook configures how the client triggers reindexing and cache flushes
+func (c *Client) SetMaintenanceHook(hook MaintenanceHook) *Client {
+	c.maintenanceHook = hook
+	return c
+}
+
+// Reindex runs the given indexers through the configured MaintenanceHook, e.g. after a large import
+func (c *Client) Reindex(ctx context.Context, indexerIDs ...string) error {
+	if c.maintenanceHook == nil {
+		return ErrNoMaintenanceHook
+	}
+	log.Info().Strs("indexerIDs", indexerIDs).Msg("Triggering reindex")
+	err := c.maintenanceHook.Reindex(ctx, indexerIDs...)
+	if err != nil {
+		return fmt.Errorf("error triggering reindex: %w", err)
+	}
+	return nil
+}
+
+// FlushCache flushes the given cache types through the configured MaintenanceHook
+func (c *Client) FlushCache(ctx context.Context, cacheTypes ...string) error {
+	if c.maintenanceHook == nil {
+		return ErrNoMaintenanceHook
+	}
+	log.Info().Strs("cacheTypes", cacheTypes).Msg("Triggering cache flush")
+	err := c.maintenanceHook.FlushCache(ctx, cacheTypes...)
+	if err != nil {
+		return fmt.Errorf("error flushing cache: %w", err)
+	}
+	return nil
+}
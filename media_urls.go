@@ -0,0 +1,144 @@
+package magento2
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	catalogProductMediaPath = "catalog/product"
+	// allStoresCode is the store code of the REST routes acting on all store views, it has no store config
+	allStoresCode      = "all"
+	defaultStoreCode   = "default"
+	mediaTypeImage     = "image"
+	imageRoleBase      = "image"
+	imageRoleSmall     = "small_image"
+	imageRoleThumbnail = "thumbnail"
+)
+
+// CatalogProductMediaURL joins the base media URL of a store and a media gallery file like "/m/b/mb01-blue-0.jpg"
+func CatalogProductMediaURL(baseMediaURL, file string) string {
+	if file == "" {
+		return ""
+	}
+	return strings.TrimSuffix(baseMediaURL, "/") + "/" + catalogProductMediaPath + "/" + strings.TrimPrefix(file, "/")
+}
+
+// MediaURLResolver turns media gallery file paths into absolute image URLs using the base media URL of
+// the store view, which it caches. It is safe for concurrent use
+type MediaURLResolver struct {
+	APIClient *Client
+	Configs   *StoreConfigCache
+	// Secure selects secure_base_media_url over base_media_url
+	Secure bool
+}
+
+// NewMediaURLResolver returns a resolver caching the store configs for ttl. Pass a StoreConfigCache to
+// Configs instead to share it with other users
+func NewMediaURLResolver(apiClient *Client, ttl time.Duration) *MediaURLResolver {
+	return &MediaURLResolver{
+		APIClient: apiClient,
+		Configs:   NewStoreConfigCache(apiClient, ttl),
+		Secure:    true,
+	}
+}
+
+// BaseMediaURL returns the base media URL of the store view. An empty code resolves to the store of the client
+func (r *MediaURLResolver) BaseMediaURL(ctx context.Context, storeCode string) (string, error) {
+	if storeCode == "" {
+		storeCode = r.APIClient.storeConfig.StoreCode
+	}
+	if storeCode == allStoresCode {
+		storeCode = defaultStoreCode
+	}
+	config, err := r.Configs.Get(ctx, storeCode)
+	if err != nil {
+		return "", err
+	}
+	baseURL := config.BaseMediaURL
+	if r.Secure && config.SecureBaseMediaURL != "" {
+		baseURL = config.SecureBaseMediaURL
+	}
+	if baseURL == "" {
+		return "", fmt.Errorf("%w: base media url of store '%s'", ErrNotFound, storeCode)
+	}
+	return baseURL, nil
+}
+
+// ImageURL returns the absolute URL of a media gallery file in the store view
+func (r *MediaURLResolver) ImageURL(ctx context.Context, storeCode, file string) (string, error) {
+	baseURL, err := r.BaseMediaURL(ctx, storeCode)
+	if err != nil {
+		return "", err
+	}
+	return CatalogProductMediaURL(baseURL, file), nil
+}
+
+// ProductImageURLs returns the URLs of the enabled images of the product in gallery position order
+func (r *MediaURLResolver) ProductImageURLs(ctx context.Context, storeCode string, product *Product) ([]string, error) {
+	baseURL, err := r.BaseMediaURL(ctx, storeCode)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]MediaGalleryEntries, 0, len(product.MediaGalleryEntries))
+	for _, entry := range product.MediaGalleryEntries {
+		if entry.Disabled || entry.File == "" || (entry.MediaType != "" && entry.MediaType != mediaTypeImage) {
+			continue
+		}
+		entries = append(entries, entry)
+	}
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].Position < entries[j].Position
+	})
+
+	urls := make([]string, len(entries))
+	for i, entry := range entries {
+		urls[i] = CatalogProductMediaURL(baseURL, entry.File)
+	}
+	return urls, nil
+}
+
+// MainImageURL returns the URL of the base image of the product, falling back to the small image, the
+// thumbnail and the first gallery image. Products without images yield ""
+func (r *MediaURLResolver) MainImageURL(ctx context.Context, storeCode string, product *Product) (string, error) {
+	file := mainImageFile(product)
+	if file == "" {
+		return "", nil
+	}
+	return r.ImageURL(ctx, storeCode, file)
+}
+
+func mainImageFile(product *Product) string {
+	for _, role := range []string{imageRoleBase, imageRoleSmall, imageRoleThumbnail} {
+		for _, entry := range product.MediaGalleryEntries {
+			if entry.Disabled {
+				continue
+			}
+			for _, entryRole := range entry.Types {
+				if entryRole == role {
+					return entry.File
+				}
+			}
+		}
+		// Magento stores "no_selection" when a role is not assigned
+		if file := product.CustomAttributeString(role); file != "" && file != "no_selection" {
+			return file
+		}
+	}
+
+	first := ""
+	firstPosition := 0
+	for _, entry := range product.MediaGalleryEntries {
+		if entry.Disabled || entry.File == "" {
+			continue
+		}
+		if first == "" || entry.Position < firstPosition {
+			first, firstPosition = entry.File, entry.Position
+		}
+	}
+	return first
+}
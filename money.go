@@ -0,0 +1,73 @@
+package magento2
+
+import (
+	"errors"
+	"fmt"
+)
+
+var ErrCurrencyMismatch = errors.New("currencies of money values don't match")
+
+// Money is an amount together with its ISO 4217 currency code
+type Money struct {
+	Amount   Decimal
+	Currency string
+}
+
+func NewMoney(amount float64, currency string) Money {
+	return Money{Amount: NewDecimalFromFloat(amount), Currency: currency}
+}
+
+func (m Money) Add(other Money) (Money, error) {
+	if m.Currency != other.Currency {
+		return Money{}, fmt.Errorf("%w: %s and %s", ErrCurrencyMismatch, m.Currency, other.Currency)
+	}
+	return Money{Amount: m.Amount.Add(other.Amount), Currency: m.Currency}, nil
+}
+
+func (m Money) Sub(other Money) (Money, error) {
+	return m.Add(Money{Amount: other.Amount.Neg(), Currency: other.Currency})
+}
+
+// String formats the amount with two decimals followed by the currency, e.g. "19.90 EUR"
+func (m Money) String() string {
+	return m.Amount.StringFixed(2) + " " + m.Currency
+}
+
+// Amounts are the totals of a cart or order in one currency
+type Amounts struct {
+	Subtotal   Money
+	Discount   Money
+	Shipping   Money
+	Tax        Money
+	GrandTotal Money
+}
+
+func newAmounts(currency string, subtotal, discount, shipping, tax, grandTotal float64) Amounts {
+	return Amounts{
+		Subtotal:   NewMoney(subtotal, currency),
+		Discount:   NewMoney(discount, currency),
+		Shipping:   NewMoney(shipping, currency),
+		Tax:        NewMoney(tax, currency),
+		GrandTotal: NewMoney(grandTotal, currency),
+	}
+}
+
+// QuoteAmounts returns the totals in the currency the customer sees
+func (t *CartTotals) QuoteAmounts() Amounts {
+	return newAmounts(t.QuoteCurrencyCode, t.Subtotal, t.DiscountAmount, t.ShippingAmount, t.TaxAmount, t.GrandTotal)
+}
+
+// BaseAmounts returns the totals in the website's base currency
+func (t *CartTotals) BaseAmounts() Amounts {
+	return newAmounts(t.BaseCurrencyCode, t.BaseSubtotal, t.BaseDiscountAmount, t.BaseShippingAmount, t.BaseTaxAmount, t.BaseGrandTotal)
+}
+
+// OrderAmounts returns the totals in the currency the order was placed in
+func (o *Order) OrderAmounts() Amounts {
+	return newAmounts(o.OrderCurrencyCode, o.Subtotal, o.DiscountAmount, o.ShippingAmount, o.TaxAmount, o.GrandTotal)
+}
+
+// BaseAmounts returns the totals in the website's base currency, which is what accounting usually books
+func (o *Order) BaseAmounts() Amounts {
+	return newAmounts(o.BaseCurrencyCode, o.BaseSubtotal, o.BaseDiscountAmount, o.BaseShippingAmount, o.BaseTaxAmount, o.BaseGrandTotal)
+}
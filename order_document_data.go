@@ -0,0 +1,213 @@
+package magento2
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/rs/zerolog/log"
+)
+
+// DocumentItemOption is an option of an ordered item as printed on documents, e.g. "Color: Red"
+type DocumentItemOption struct {
+	Label string
+	Value string
+}
+
+// DocumentItem is a top-level order item with its option labels and, for configurables and bundles,
+// the child items
+type DocumentItem struct {
+	Item     Item
+	Options  []DocumentItemOption
+	Children []Item
+}
+
+// OrderDocumentData is everything an invoice or packing slip renderer needs about an order
+type OrderDocumentData struct {
+	Order           *Order
+	Items           []DocumentItem
+	Totals          Amounts
+	BillingAddress  *BillingAddress
+	ShippingAddress *ShippingAddress
+	ShippingMethod  string
+	PaymentMethod   string
+	Store           *StoreView
+	StoreConfig     *StoreConfiguration
+	Invoices        []Invoice
+	Shipments       []Shipment
+}
+
+// DocumentData gathers the order with item option labels, totals, addresses, store information,
+// invoices and shipments. The lookups run concurrently
+func (mo *MOrder) DocumentData(ctx context.Context) (*OrderDocumentData, error) {
+	data := &OrderDocumentData{
+		Order:          mo.Order,
+		Totals:         mo.Order.OrderAmounts(),
+		BillingAddress: mo.Order.BillingAddress,
+		ShippingMethod: mo.Order.ShippingDescription,
+	}
+	if mo.Order.Payment != nil {
+		data.PaymentMethod = mo.Order.Payment.Method
+	}
+	if mo.Order.ExtensionAttributes != nil {
+		for _, assignment := range mo.Order.ExtensionAttributes.ShippingAssignments {
+			if assignment.Shipping != nil && assignment.Shipping.Address != nil {
+				data.ShippingAddress = assignment.Shipping.Address
+				break
+			}
+		}
+	}
+
+	byOrder := NewSearchCriteria(SearchFilter{Field: "order_id", Value: strconv.Itoa(mo.Order.EntityID), ConditionType: "eq"})
+	var (
+		wg      sync.WaitGroup
+		views   []StoreView
+		configs []StoreConfiguration
+		errs    = make([]error, 5)
+	)
+
+	wg.Add(5)
+	go func() {
+		defer wg.Done()
+		data.Items, errs[0] = documentItems(ctx, mo.Order, mo.APIClient)
+	}()
+	go func() {
+		defer wg.Done()
+		views, errs[1] = GetStoreViews(ctx, mo.APIClient)
+	}()
+	go func() {
+		defer wg.Done()
+		configs, errs[2] = GetStoreConfigs(ctx, mo.APIClient)
+	}()
+	go func() {
+		defer wg.Done()
+		data.Invoices, errs[3] = searchAll[Invoice](ctx, invoices, byOrder, "search invoices for order document", mo.APIClient)
+	}()
+	go func() {
+		defer wg.Done()
+		data.Shipments, errs[4] = searchAll[Shipment](ctx, shipments, byOrder, "search shipments for order document", mo.APIClient)
+	}()
+	wg.Wait()
+
+	if err := errors.Join(errs...); err != nil {
+		return nil, fmt.Errorf("error gathering document data for order %d: %w", mo.Order.EntityID, err)
+	}
+
+	storeID := int(mo.Order.StoreID)
+	for i := range views {
+		if views[i].ID == storeID {
+			data.Store = &views[i]
+		}
+	}
+	for i := range configs {
+		if configs[i].ID == storeID {
+			data.StoreConfig = &configs[i]
+		}
+	}
+
+	log.Debug().
+		Int("orderID", mo.Order.EntityID).
+		Int("items", len(data.Items)).
+		Int("invoices", len(data.Invoices)).
+		Int("shipments", len(data.Shipments)).
+		Msg("Order document data gathered")
+	return data, nil
+}
+
+func documentItems(ctx context.Context, order *Order, apiClient *Client) ([]DocumentItem, error) {
+	cache := NewAttributeCache(apiClient)
+	customOptions := map[string]map[string]Options{}
+
+	var items []DocumentItem
+	for i := range order.Items {
+		item := &order.Items[i]
+		if item.ParentItemID != 0 {
+			continue
+		}
+		documentItem := DocumentItem{
+			Item:     *item,
+			Children: order.ChildItems(item),
+		}
+
+		selected, err := ResolveConfigurableOptions(ctx, item, cache)
+		if err != nil {
+			return nil, err
+		}
+		for _, attribute := range selected {
+			documentItem.Options = append(documentItem.Options, DocumentItemOption{Label: attribute.Label, Value: attribute.ValueLabel})
+		}
+
+		for _, option := range item.CustomOptions() {
+			productOptions, ok := customOptions[item.Sku]
+			if !ok {
+				productOptions, err = customOptionsForSku(ctx, item.Sku, apiClient)
+				if err != nil {
+					return nil, err
+				}
+				customOptions[item.Sku] = productOptions
+			}
+			documentItem.Options = append(documentItem.Options, customOptionDocumentItemOption(option, productOptions))
+		}
+
+		items = append(items, documentItem)
+	}
+	return items, nil
+}
+
+// customOptionDocumentItemOption labels the selected option with its title. The values of select type
+// options, e.g. drop-downs and checkboxes, are the IDs of the selected values and are resolved to their
+// titles
+func customOptionDocumentItemOption(selected OrderItemCustomOption, productOptions map[string]Options) DocumentItemOption {
+	option, ok := productOptions[selected.OptionID]
+	if !ok {
+		return DocumentItemOption{Label: "Option " + selected.OptionID, Value: selected.OptionValue}
+	}
+	if len(option.Values) == 0 {
+		return DocumentItemOption{Label: option.Title, Value: selected.OptionValue}
+	}
+
+	titles := make(map[string]string, len(option.Values))
+	for _, value := range option.Values {
+		titles[strconv.Itoa(value.OptionTypeID)] = value.Title
+	}
+	labels := []string{}
+	for _, valueID := range strings.Split(selected.OptionValue, ",") {
+		valueID = strings.TrimSpace(valueID)
+		if title, ok := titles[valueID]; ok {
+			labels = append(labels, title)
+		} else {
+			labels = append(labels, valueID)
+		}
+	}
+	return DocumentItemOption{Label: option.Title, Value: strings.Join(labels, ", ")}
+}
+
+// customOptionsForSku maps option IDs to the product's custom options. A product deleted since the
+// order was placed yields no options instead of an error
+func customOptionsForSku(ctx context.Context, sku string, apiClient *Client) (map[string]Options, error) {
+	endpoint := products + "/" + url.PathEscape(sku) + "/options"
+	options := &[]Options{}
+
+	resp, err := apiClient.HTTPClient.R().SetContext(ctx).SetResult(options).Get(endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("error getting custom options of product '%s': %w", sku, err)
+	}
+
+	httpErr := mayReturnErrorForHTTPResponse(resp, fmt.Sprintf("get custom options of product '%s'", sku))
+	if errors.Is(httpErr, ErrNotFound) {
+		return map[string]Options{}, nil
+	}
+	if httpErr != nil {
+		return nil, httpErr
+	}
+
+	byID := make(map[string]Options, len(*options))
+	for _, option := range *options {
+		byID[strconv.Itoa(option.OptionID)] = option
+	}
+	return byID, nil
+}
@@ -0,0 +1,156 @@
+package magento2
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+
+	"github.com/rs/zerolog/log"
+)
+
+// CreateInvoice invoices the order and returns the new invoice ID. The order is re-read first and the call
+// fails with ErrAlreadyInvoiced when the requested quantities were already invoiced, e.g. by a retried
+// request whose first attempt timed out but succeeded. Pass WithForceDuplicate to skip the check
+func (mo *MOrder) CreateInvoice(ctx context.Context, request *InvoiceRequest, opts ...RequestOption) (int, error) {
+	o := newRequestOptions(opts)
+	if !o.forceDuplicate {
+		err := mo.UpdateFromRemote()
+		if err != nil {
+			return 0, fmt.Errorf("error refreshing order before invoicing: %w", err)
+		}
+
+		requested := map[int]float64{}
+		for _, item := range request.Items {
+			requested[item.OrderItemID] = item.Qty
+		}
+		err = guardRemainingQty(mo.Order.Items, requested, qtyToInvoice, ErrAlreadyInvoiced)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	endpoint := fmt.Sprintf("%s/%d/%s", order, mo.Order.EntityID, orderInvoice)
+	return mo.postOrderDocument(ctx, o, endpoint, request, "create invoice for order")
+}
+
+// CreateShipment ships the order and returns the new shipment ID, guarding against duplicates
+// the same way CreateInvoice does. Pass WithForceDuplicate to skip the check
+func (mo *MOrder) CreateShipment(ctx context.Context, request *ShipmentRequest, opts ...RequestOption) (int, error) {
+	o := newRequestOptions(opts)
+	if !o.forceDuplicate {
+		err := mo.UpdateFromRemote()
+		if err != nil {
+			return 0, fmt.Errorf("error refreshing order before shipping: %w", err)
+		}
+
+		requested := map[int]float64{}
+		for _, item := range request.Items {
+			requested[item.OrderItemID] = item.Qty
+		}
+		err = guardRemainingQty(mo.Order.Items, requested, qtyToShip, ErrAlreadyShipped)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	endpoint := fmt.Sprintf("%s/%d/%s", order, mo.Order.EntityID, orderShip)
+	return mo.postOrderDocument(ctx, o, endpoint, request, "create shipment for order")
+}
+
+// Refund refunds the order offline and returns the new credit memo ID, guarding against duplicates the
+// same way CreateInvoice does. Build the request with CalculateRefund to know the amounts up front
+func (mo *MOrder) Refund(ctx context.Context, request *RefundRequest, opts ...RequestOption) (int, error) {
+	o := newRequestOptions(opts)
+	if !o.forceDuplicate {
+		err := mo.UpdateFromRemote()
+		if err != nil {
+			return 0, fmt.Errorf("error refreshing order before refunding: %w", err)
+		}
+
+		requested := map[int]float64{}
+		for _, item := range request.Items {
+			requested[item.OrderItemID] = item.Qty
+		}
+		err = guardRemainingQty(mo.Order.Items, requested, qtyToRefund, ErrAlreadyRefunded)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	endpoint := fmt.Sprintf("%s/%d/%s", order, mo.Order.EntityID, orderRefund)
+	return mo.postOrderDocument(ctx, o, endpoint, request, "refund order")
+}
+
+func (mo *MOrder) postOrderDocument(ctx context.Context, o *requestOptions, endpoint string, payLoad any, tryTo string) (int, error) {
+	log.Debug().
+		Int("orderID", mo.Order.EntityID).
+		Str("endpoint", endpoint).
+		Interface("payload", payLoad).
+		Msg("Posting order document")
+
+	req, cancel := o.newRequest(ctx, mo.APIClient)
+	defer cancel()
+
+	resp, err := req.SetBody(payLoad).Post(endpoint)
+	if err != nil {
+		log.Error().Err(err).Str("operation", tryTo).Msg("Error posting order document")
+		return 0, fmt.Errorf("error while trying to %s: %w", tryTo, err)
+	}
+
+	httpErr := mayReturnErrorForHTTPResponse(resp, tryTo)
+	if httpErr != nil {
+		return 0, httpErr
+	}
+
+	documentID, err := strconv.Atoi(mayTrimSurroundingQuotes(resp.String()))
+	if err != nil {
+		return 0, fmt.Errorf("unexpected error while extracting document ID: %w", err)
+	}
+
+	log.Debug().Int("orderID", mo.Order.EntityID).Int("documentID", documentID).Msg("Order document created successfully")
+	return documentID, nil
+}
+
+func qtyToInvoice(item *Item) float64 {
+	return math.Max(item.QtyOrdered-item.QtyInvoiced-item.QtyCanceled, 0)
+}
+
+// qtyToShip is zero for virtual items, Magento never ships them
+func qtyToShip(item *Item) float64 {
+	if item.IsVirtual == 1 {
+		return 0
+	}
+	return math.Max(item.QtyOrdered-item.QtyShipped-item.QtyRefunded-item.QtyCanceled, 0)
+}
+
+func qtyToRefund(item *Item) float64 {
+	return math.Max(item.QtyInvoiced-item.QtyRefunded, 0)
+}
+
+// guardRemainingQty fails with sentinel when the requested quantities exceed what is left on the order.
+// An empty request means "everything left", which only fails when nothing is left at all
+func guardRemainingQty(items []Item, requested map[int]float64, remaining func(*Item) float64, sentinel error) error {
+	if len(requested) == 0 {
+		for i := range items {
+			if items[i].ParentItemID == 0 && NewDecimalFromFloat(remaining(&items[i])).Sign() > 0 {
+				return nil
+			}
+		}
+		return sentinel
+	}
+
+	for i := range items {
+		itemID := int(items[i].ItemID)
+		qty, ok := requested[itemID]
+		if !ok {
+			continue
+		}
+		left := remaining(&items[i])
+		// compared as decimals, float subtraction leaves e.g. 0.30000000000000004 for fractional quantities
+		if NewDecimalFromFloat(qty).Cmp(NewDecimalFromFloat(left)) > 0 {
+			return fmt.Errorf("%w: item %d requested %v, remaining %v", sentinel, itemID, qty, left)
+		}
+	}
+	return nil
+}
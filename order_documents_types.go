@@ -0,0 +1,195 @@
+package magento2
+
+type EntityComment struct {
+	Comment            string `json:"comment"`
+	IsVisibleOnFront   int    `json:"is_visible_on_front"`
+	IsCustomerNotified int    `json:"is_customer_notified,omitempty"`
+	EntityID           int    `json:"entity_id,omitempty"`
+	ParentID           int    `json:"parent_id,omitempty"`
+	CreatedAt          string `json:"created_at,omitempty"`
+}
+
+type InvoiceItem struct {
+	OrderItemID int     `json:"order_item_id"`
+	Qty         float64 `json:"qty"`
+}
+
+// InvoiceRequest is the body of POST /order/{orderId}/invoice. Leave Items empty to invoice everything left
+type InvoiceRequest struct {
+	Capture       bool           `json:"capture"`
+	Items         []InvoiceItem  `json:"items,omitempty"`
+	Notify        bool           `json:"notify"`
+	AppendComment bool           `json:"appendComment"`
+	Comment       *EntityComment `json:"comment,omitempty"`
+}
+
+type ShipmentItem struct {
+	OrderItemID int     `json:"order_item_id"`
+	Qty         float64 `json:"qty"`
+}
+
+type ShipmentTrack struct {
+	TrackNumber string `json:"track_number"`
+	Title       string `json:"title"`
+	CarrierCode string `json:"carrier_code"`
+}
+
+// ShipmentRequest is the body of POST /order/{orderId}/ship. Leave Items empty to ship everything left
+type ShipmentRequest struct {
+	Items         []ShipmentItem  `json:"items,omitempty"`
+	Notify        bool            `json:"notify"`
+	AppendComment bool            `json:"appendComment"`
+	Comment       *EntityComment  `json:"comment,omitempty"`
+	Tracks        []ShipmentTrack `json:"tracks,omitempty"`
+}
+
+type RefundItem struct {
+	OrderItemID int     `json:"order_item_id"`
+	Qty         float64 `json:"qty"`
+}
+
+type RefundArgumentsExtension struct {
+	ReturnToStockItems []int `json:"return_to_stock_items,omitempty"`
+}
+
+// RefundArguments are the amounts of a refund besides the items. Magento refunds the whole remaining
+// shipping when ShippingAmount is not sent, so it is always sent
+type RefundArguments struct {
+	ShippingAmount      float64                   `json:"shipping_amount"`
+	AdjustmentPositive  float64                   `json:"adjustment_positive"`
+	AdjustmentNegative  float64                   `json:"adjustment_negative"`
+	ExtensionAttributes *RefundArgumentsExtension `json:"extension_attributes,omitempty"`
+}
+
+// RefundRequest is the body of POST /order/{orderId}/refund, an offline refund creating a credit memo
+type RefundRequest struct {
+	Items         []RefundItem     `json:"items,omitempty"`
+	Notify        bool             `json:"notify"`
+	AppendComment bool             `json:"appendComment"`
+	Comment       *EntityComment   `json:"comment,omitempty"`
+	Arguments     *RefundArguments `json:"arguments,omitempty"`
+}
+
+// Box is a package type for PackShipments. A zero MaxWeight or MaxQty means no limit
+type Box struct {
+	Name      string
+	MaxWeight float64
+	MaxQty    float64
+}
+
+// PackedShipment is one parcel planned by PackShipments, with the shipment request that ships its items
+type PackedShipment struct {
+	Box     Box
+	Weight  float64
+	Qty     float64
+	Request *ShipmentRequest
+}
+
+type InvoiceEntityItem struct {
+	EntityID        int     `json:"entity_id,omitempty"`
+	OrderItemID     int     `json:"order_item_id"`
+	Sku             string  `json:"sku"`
+	Name            string  `json:"name,omitempty"`
+	Qty             float64 `json:"qty"`
+	Price           float64 `json:"price,omitempty"`
+	PriceInclTax    float64 `json:"price_incl_tax,omitempty"`
+	RowTotal        float64 `json:"row_total,omitempty"`
+	RowTotalInclTax float64 `json:"row_total_incl_tax,omitempty"`
+	TaxAmount       float64 `json:"tax_amount,omitempty"`
+	DiscountAmount  float64 `json:"discount_amount,omitempty"`
+}
+
+type Invoice struct {
+	EntityID            int                 `json:"entity_id,omitempty"`
+	OrderID             int                 `json:"order_id"`
+	IncrementID         string              `json:"increment_id,omitempty"`
+	State               int                 `json:"state,omitempty"`
+	StoreID             int                 `json:"store_id,omitempty"`
+	BaseCurrencyCode    string              `json:"base_currency_code,omitempty"`
+	OrderCurrencyCode   string              `json:"order_currency_code,omitempty"`
+	Subtotal            float64             `json:"subtotal,omitempty"`
+	BaseSubtotal        float64             `json:"base_subtotal,omitempty"`
+	TaxAmount           float64             `json:"tax_amount,omitempty"`
+	BaseTaxAmount       float64             `json:"base_tax_amount,omitempty"`
+	ShippingAmount      float64             `json:"shipping_amount,omitempty"`
+	BaseShippingAmount  float64             `json:"base_shipping_amount,omitempty"`
+	DiscountAmount      float64             `json:"discount_amount,omitempty"`
+	GrandTotal          float64             `json:"grand_total,omitempty"`
+	BaseGrandTotal      float64             `json:"base_grand_total,omitempty"`
+	TotalQty            float64             `json:"total_qty,omitempty"`
+	CreatedAt           string              `json:"created_at,omitempty"`
+	UpdatedAt           string              `json:"updated_at,omitempty"`
+	Items               []InvoiceEntityItem `json:"items,omitempty"`
+	Comments            []EntityComment     `json:"comments,omitempty"`
+	ExtensionAttributes map[string]any      `json:"extension_attributes,omitempty"`
+}
+
+type ShipmentEntityItem struct {
+	EntityID    int     `json:"entity_id,omitempty"`
+	OrderItemID int     `json:"order_item_id"`
+	Sku         string  `json:"sku"`
+	Name        string  `json:"name,omitempty"`
+	Qty         float64 `json:"qty"`
+	Price       float64 `json:"price,omitempty"`
+	Weight      float64 `json:"weight,omitempty"`
+}
+
+type ShipmentEntityTrack struct {
+	EntityID    int    `json:"entity_id,omitempty"`
+	OrderID     int    `json:"order_id"`
+	ParentID    int    `json:"parent_id,omitempty"`
+	TrackNumber string `json:"track_number"`
+	Title       string `json:"title,omitempty"`
+	CarrierCode string `json:"carrier_code"`
+	CreatedAt   string `json:"created_at,omitempty"`
+}
+
+type Shipment struct {
+	EntityID            int                   `json:"entity_id,omitempty"`
+	OrderID             int                   `json:"order_id"`
+	IncrementID         string                `json:"increment_id,omitempty"`
+	StoreID             int                   `json:"store_id,omitempty"`
+	TotalQty            float64               `json:"total_qty,omitempty"`
+	CreatedAt           string                `json:"created_at,omitempty"`
+	UpdatedAt           string                `json:"updated_at,omitempty"`
+	Items               []ShipmentEntityItem  `json:"items,omitempty"`
+	Tracks              []ShipmentEntityTrack `json:"tracks,omitempty"`
+	Comments            []EntityComment       `json:"comments,omitempty"`
+	ExtensionAttributes map[string]any        `json:"extension_attributes,omitempty"`
+}
+
+type CreditMemoEntityItem struct {
+	EntityID       int     `json:"entity_id,omitempty"`
+	OrderItemID    int     `json:"order_item_id"`
+	Sku            string  `json:"sku"`
+	Name           string  `json:"name,omitempty"`
+	Qty            float64 `json:"qty"`
+	Price          float64 `json:"price,omitempty"`
+	RowTotal       float64 `json:"row_total,omitempty"`
+	TaxAmount      float64 `json:"tax_amount,omitempty"`
+	DiscountAmount float64 `json:"discount_amount,omitempty"`
+}
+
+type CreditMemo struct {
+	EntityID            int                    `json:"entity_id,omitempty"`
+	OrderID             int                    `json:"order_id"`
+	InvoiceID           int                    `json:"invoice_id,omitempty"`
+	IncrementID         string                 `json:"increment_id,omitempty"`
+	State               int                    `json:"state,omitempty"`
+	StoreID             int                    `json:"store_id,omitempty"`
+	BaseCurrencyCode    string                 `json:"base_currency_code,omitempty"`
+	OrderCurrencyCode   string                 `json:"order_currency_code,omitempty"`
+	Subtotal            float64                `json:"subtotal,omitempty"`
+	ShippingAmount      float64                `json:"shipping_amount,omitempty"`
+	TaxAmount           float64                `json:"tax_amount,omitempty"`
+	DiscountAmount      float64                `json:"discount_amount,omitempty"`
+	AdjustmentPositive  float64                `json:"adjustment_positive,omitempty"`
+	AdjustmentNegative  float64                `json:"adjustment_negative,omitempty"`
+	GrandTotal          float64                `json:"grand_total,omitempty"`
+	BaseGrandTotal      float64                `json:"base_grand_total,omitempty"`
+	CreatedAt           string                 `json:"created_at,omitempty"`
+	UpdatedAt           string                 `json:"updated_at,omitempty"`
+	Items               []CreditMemoEntityItem `json:"items,omitempty"`
+	Comments            []EntityComment        `json:"comments,omitempty"`
+	ExtensionAttributes map[string]any         `json:"extension_attributes,omitempty"`
+}
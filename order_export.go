@@ -0,0 +1,347 @@
+package magento2
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// OrderRecordType is the kind of a normalized order export record
+type OrderRecordType string
+
+const (
+	OrderRecordOrder   OrderRecordType = "order"
+	OrderRecordItem    OrderRecordType = "item"
+	OrderRecordTax     OrderRecordType = "tax"
+	OrderRecordPayment OrderRecordType = "payment"
+	OrderRecordRefund  OrderRecordType = "refund"
+)
+
+// OrderRecordTypes lists the record types in the order they are written for each order
+var OrderRecordTypes = []OrderRecordType{OrderRecordOrder, OrderRecordItem, OrderRecordTax, OrderRecordPayment, OrderRecordRefund}
+
+// OrderExportFormat is the output format of ExportOrders
+type OrderExportFormat string
+
+const (
+	OrderExportCSV   OrderExportFormat = "csv"
+	OrderExportJSONL OrderExportFormat = "jsonl"
+)
+
+// DefaultOrderExportFields lists every field of each record type. Every record starts with
+// order_increment_id, the key accounting imports join on
+var DefaultOrderExportFields = map[OrderRecordType][]string{
+	OrderRecordOrder: {
+		"order_increment_id", "created_at", "updated_at", "status", "state", "store_id", "customer_email",
+		"customer_name", "customer_group_id", "currency", "subtotal", "discount_amount", "shipping_amount",
+		"tax_amount", "grand_total", "total_paid", "total_refunded", "base_currency", "base_grand_total", "coupon_code",
+	},
+	OrderRecordItem: {
+		"order_increment_id", "item_id", "sku", "name", "product_type", "qty_ordered", "qty_refunded", "price",
+		"discount_amount", "tax_percent", "tax_amount", "row_total", "row_total_incl_tax",
+	},
+	OrderRecordTax: {
+		"order_increment_id", "code", "title", "percent", "amount",
+	},
+	OrderRecordPayment: {
+		"order_increment_id", "method", "amount_ordered", "amount_paid", "amount_refunded", "shipping_captured",
+		"last_trans_id", "cc_type",
+	},
+	OrderRecordRefund: {
+		"order_increment_id", "creditmemo_increment_id", "created_at", "currency", "subtotal", "shipping_amount",
+		"discount_amount", "adjustment_positive", "adjustment_negative", "tax_amount", "grand_total",
+	},
+}
+
+// OrderExportOptions configures ExportOrders. From and To bound DateField (created_at by default, or
+// updated_at) as [From, To), zero values leave the range open. Fields selects the fields per record type
+// from DefaultOrderExportFields, types it does not list are exported with all fields and types mapped to
+// an empty list are left out; write each type to its own file by listing only that type. Criteria narrows
+// the orders further, e.g. by status. Encoder replaces the encoder of Format, e.g. with a Parquet writer
+type OrderExportOptions struct {
+	Format    OrderExportFormat
+	Encoder   Encoder
+	From      time.Time
+	To        time.Time
+	DateField string
+	Fields    map[OrderRecordType][]string
+	Criteria  *SearchCriteria
+	Workers   int
+}
+
+// OrderExportRecord is one normalized row. Values holds the selected fields in order
+type OrderExportRecord struct {
+	Type   OrderRecordType
+	Fields []string
+	Values []string
+}
+
+// columns returns the columns and values of the record as passed to an Encoder
+func (r OrderExportRecord) columns() ([]string, []string) {
+	return append([]string{"record_type"}, r.Fields...), append([]string{string(r.Type)}, r.Values...)
+}
+
+func (o OrderExportOptions) fields() (map[OrderRecordType][]string, error) {
+	selected := map[OrderRecordType][]string{}
+	for _, recordType := range OrderRecordTypes {
+		fields, ok := o.Fields[recordType]
+		if !ok {
+			fields = DefaultOrderExportFields[recordType]
+		}
+		for _, field := range fields {
+			if !slices.Contains(DefaultOrderExportFields[recordType], field) {
+				return nil, &ValidationError{Entity: "order export", Field: string(recordType), Reason: "has no field " + field}
+			}
+		}
+		if len(fields) > 0 {
+			selected[recordType] = fields
+		}
+	}
+	for recordType := range o.Fields {
+		if _, known := DefaultOrderExportFields[recordType]; !known {
+			return nil, &ValidationError{Entity: "order export", Field: "fields", Reason: "unknown record type " + string(recordType)}
+		}
+	}
+	return selected, nil
+}
+
+// ExportOrders writes the orders in the date range as normalized records to w and returns the number of
+// records written. Every record starts with a record_type column, CSV output has the union of the
+// selected fields as columns, JSONL output one object per line with the fields of the record, amounts
+// and quantities as numbers. w is not used when opts.Encoder is set
+func ExportOrders(ctx context.Context, w io.Writer, opts OrderExportOptions, apiClient *Client) (int, error) {
+	fields, err := opts.fields()
+	if err != nil {
+		return 0, err
+	}
+	if opts.Format == "" {
+		opts.Format = OrderExportCSV
+	}
+	if opts.DateField == "" {
+		opts.DateField = "created_at"
+	}
+	if opts.DateField != "created_at" && opts.DateField != "updated_at" {
+		return 0, &ValidationError{Entity: "order export", Field: "date_field", Reason: "must be created_at or updated_at"}
+	}
+
+	enc := opts.Encoder
+	if enc == nil {
+		if enc, err = newOrderExportEncoder(opts.Format, w); err != nil {
+			return 0, err
+		}
+	}
+	if err := enc.Begin(orderExportColumns(fields)); err != nil {
+		return 0, fmt.Errorf("error beginning order export: %w", err)
+	}
+
+	criteria := opts.Criteria.Clone()
+	if !opts.From.IsZero() {
+		criteria = criteria.And(SearchFilter{Field: opts.DateField, Value: opts.From.UTC().Format(DateTimeFormat), ConditionType: "gteq"})
+	}
+	if !opts.To.IsZero() {
+		criteria = criteria.And(SearchFilter{Field: opts.DateField, Value: opts.To.UTC().Format(DateTimeFormat), ConditionType: "lt"})
+	}
+
+	log.Debug().
+		Str("format", string(opts.Format)).
+		Str("dateField", opts.DateField).
+		Time("from", opts.From).
+		Time("to", opts.To).
+		Msg("Exporting orders")
+
+	records := 0
+	err = searchPagesParallel(ctx, Orders, criteria, opts.Workers, "search orders for export", apiClient, func(orders []Order) error {
+		refunds := map[int][]CreditMemo{}
+		if _, ok := fields[OrderRecordRefund]; ok && len(orders) > 0 {
+			var err error
+			if refunds, err = creditMemosByOrder(ctx, orders, apiClient); err != nil {
+				return err
+			}
+		}
+		for i := range orders {
+			for _, record := range orderExportRecords(&orders[i], refunds[orders[i].EntityID], fields) {
+				if err := enc.Encode(record.columns()); err != nil {
+					return fmt.Errorf("error writing order %s: %w", orders[i].IncrementID, err)
+				}
+				records++
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return records, err
+	}
+	return records, enc.Close()
+}
+
+func creditMemosByOrder(ctx context.Context, orders []Order, apiClient *Client) (map[int][]CreditMemo, error) {
+	ids := make([]string, len(orders))
+	for i := range orders {
+		ids[i] = strconv.Itoa(orders[i].EntityID)
+	}
+	byOrder := map[int][]CreditMemo{}
+	for start := 0; start < len(ids); start += inFilterChunkSize {
+		chunk := ids[start:min(start+inFilterChunkSize, len(ids))]
+		memos, err := searchAll[CreditMemo](ctx, creditmemos, NewSearchCriteria(SearchFilter{Field: "order_id", Value: strings.Join(chunk, ","), ConditionType: "in"}), "search credit memos for order export", apiClient)
+		if err != nil {
+			return nil, err
+		}
+		for _, memo := range memos {
+			byOrder[memo.OrderID] = append(byOrder[memo.OrderID], memo)
+		}
+	}
+	return byOrder, nil
+}
+
+// orderExportRecords normalizes one order. Child items of configurable and bundle products are left out,
+// their parent carries the amounts
+func orderExportRecords(order *Order, refunds []CreditMemo, fields map[OrderRecordType][]string) []OrderExportRecord {
+	var records []OrderExportRecord
+	add := func(recordType OrderRecordType, values map[string]string) {
+		selected, ok := fields[recordType]
+		if !ok {
+			return
+		}
+		values["order_increment_id"] = order.IncrementID
+		record := OrderExportRecord{Type: recordType, Fields: selected, Values: make([]string, len(selected))}
+		for i, field := range selected {
+			record.Values[i] = values[field]
+		}
+		records = append(records, record)
+	}
+
+	add(OrderRecordOrder, map[string]string{
+		"created_at":        order.CreatedAt,
+		"updated_at":        order.UpdatedAt,
+		"status":            order.Status,
+		"state":             order.State,
+		"store_id":          formatQty(order.StoreID),
+		"customer_email":    order.CustomerEmail,
+		"customer_name":     strings.TrimSpace(order.CustomerFirstname + " " + order.CustomerLastname),
+		"customer_group_id": formatQty(order.CustomerGroupID),
+		"currency":          order.OrderCurrencyCode,
+		"subtotal":          formatAmount(order.Subtotal),
+		"discount_amount":   formatAmount(order.DiscountAmount),
+		"shipping_amount":   formatAmount(order.ShippingAmount),
+		"tax_amount":        formatAmount(order.TaxAmount),
+		"grand_total":       formatAmount(order.GrandTotal),
+		"total_paid":        formatAmount(order.TotalPaid),
+		"total_refunded":    formatAmount(order.TotalRefunded),
+		"base_currency":     order.BaseCurrencyCode,
+		"base_grand_total":  formatAmount(order.BaseGrandTotal),
+		"coupon_code":       order.CouponCode,
+	})
+
+	for _, item := range order.Items {
+		if item.ParentItemID != 0 {
+			continue
+		}
+		add(OrderRecordItem, map[string]string{
+			"item_id":            formatQty(item.ItemID),
+			"sku":                item.Sku,
+			"name":               item.Name,
+			"product_type":       item.ProductType,
+			"qty_ordered":        formatQty(item.QtyOrdered),
+			"qty_refunded":       formatQty(item.QtyRefunded),
+			"price":              formatAmount(item.Price),
+			"discount_amount":    formatAmount(item.DiscountAmount),
+			"tax_percent":        formatQty(item.TaxPercent),
+			"tax_amount":         formatAmount(item.TaxAmount),
+			"row_total":          formatAmount(item.RowTotal),
+			"row_total_incl_tax": formatAmount(item.RowTotalInclTax),
+		})
+	}
+
+	if order.ExtensionAttributes != nil {
+		for _, tax := range order.ExtensionAttributes.AppliedTaxes {
+			add(OrderRecordTax, map[string]string{
+				"code":    tax.Code,
+				"title":   tax.Title,
+				"percent": formatQty(tax.Percent),
+				"amount":  formatAmount(tax.Amount),
+			})
+		}
+	}
+
+	if payment := order.Payment; payment != nil {
+		add(OrderRecordPayment, map[string]string{
+			"method":            payment.Method,
+			"amount_ordered":    formatAmount(payment.AmountOrdered),
+			"amount_paid":       formatAmount(payment.AmountPaid),
+			"amount_refunded":   formatAmount(payment.AmountRefunded),
+			"shipping_captured": formatAmount(payment.ShippingCaptured),
+			"last_trans_id":     payment.LastTransID,
+			"cc_type":           payment.CcType,
+		})
+	}
+
+	for _, memo := range refunds {
+		add(OrderRecordRefund, map[string]string{
+			"creditmemo_increment_id": memo.IncrementID,
+			"created_at":              memo.CreatedAt,
+			"currency":                memo.OrderCurrencyCode,
+			"subtotal":                formatAmount(memo.Subtotal),
+			"shipping_amount":         formatAmount(memo.ShippingAmount),
+			"discount_amount":         formatAmount(memo.DiscountAmount),
+			"adjustment_positive":     formatAmount(memo.AdjustmentPositive),
+			"adjustment_negative":     formatAmount(memo.AdjustmentNegative),
+			"tax_amount":              formatAmount(memo.TaxAmount),
+			"grand_total":             formatAmount(memo.GrandTotal),
+		})
+	}
+	return records
+}
+
+// formatAmount writes money with two decimals, accounting imports expect a fixed scale
+func formatAmount(value float64) string {
+	return NewDecimalFromFloat(value).StringFixed(2)
+}
+
+func formatQty(value float64) string {
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
+
+// orderExportNumberFields are the fields of DefaultOrderExportFields holding amounts, quantities or IDs,
+// the others are strings
+var orderExportNumberFields = []string{
+	"store_id", "customer_group_id", "subtotal", "discount_amount", "shipping_amount", "tax_amount", "grand_total",
+	"total_paid", "total_refunded", "base_grand_total", "item_id", "qty_ordered", "qty_refunded", "price",
+	"tax_percent", "row_total", "row_total_incl_tax", "percent", "amount", "amount_ordered", "amount_paid",
+	"amount_refunded", "shipping_captured", "adjustment_positive", "adjustment_negative",
+}
+
+// orderExportColumns is record_type followed by the union of the selected fields
+func orderExportColumns(fields map[OrderRecordType][]string) []ExportColumn {
+	columns := StringColumns("record_type")
+	names := []string{"record_type"}
+	for _, recordType := range OrderRecordTypes {
+		for _, field := range fields[recordType] {
+			if slices.Contains(names, field) {
+				continue
+			}
+			names = append(names, field)
+			columnType := ColumnString
+			if slices.Contains(orderExportNumberFields, field) {
+				columnType = ColumnNumber
+			}
+			columns = append(columns, ExportColumn{Name: field, Type: columnType})
+		}
+	}
+	return columns
+}
+
+func newOrderExportEncoder(format OrderExportFormat, w io.Writer) (Encoder, error) {
+	switch format {
+	case OrderExportCSV:
+		return NewCSVEncoder(w), nil
+	case OrderExportJSONL:
+		return NewJSONLEncoder(w), nil
+	}
+	return nil, &ValidationError{Entity: "order export", Field: "format", Reason: fmt.Sprintf("unsupported format %q", format)}
+}
@@ -0,0 +1,117 @@
+package magento2
+
+// ItemFulfillment is the state of an order item: the quantities from the order item and what is left to
+// invoice, ship and refund. Backordered is the part of ToShip that was backordered when the order was placed
+type ItemFulfillment struct {
+	OrderItemID int
+	Sku         string
+	Ordered     Decimal
+	Invoiced    Decimal
+	Shipped     Decimal
+	Refunded    Decimal
+	Canceled    Decimal
+	ToInvoice   Decimal
+	ToShip      Decimal
+	ToRefund    Decimal
+	Backordered Decimal
+}
+
+// QtyToInvoice is the ordered quantity that is neither invoiced nor canceled
+func (item *Item) QtyToInvoice() Decimal {
+	return NewDecimalFromFloat(qtyToInvoice(item))
+}
+
+// QtyToShip is the ordered quantity that is neither shipped, refunded nor canceled. It is zero for
+// virtual items
+func (item *Item) QtyToShip() Decimal {
+	return NewDecimalFromFloat(qtyToShip(item))
+}
+
+// QtyToRefund is the invoiced quantity that is not refunded yet
+func (item *Item) QtyToRefund() Decimal {
+	return NewDecimalFromFloat(qtyToRefund(item))
+}
+
+// QtyBackorderedToShip is the backordered quantity that is still to ship. Magento does not track which
+// units of a partially shipped item were backordered, so the shipped units are assumed to be the ones in stock
+func (item *Item) QtyBackorderedToShip() Decimal {
+	toShip := item.QtyToShip()
+	backordered := NewDecimalFromFloat(item.QtyBackordered)
+	if backordered.Cmp(toShip) > 0 {
+		return toShip
+	}
+	return backordered
+}
+
+// Fulfillment returns the state of the top-level items of the order, in order. Child items of configurable
+// and bundle products are left out, documents reference their parents
+func (mo *MOrder) Fulfillment() []ItemFulfillment {
+	var items []ItemFulfillment
+	for i := range mo.Order.Items {
+		item := &mo.Order.Items[i]
+		if item.ParentItemID != 0 {
+			continue
+		}
+		items = append(items, ItemFulfillment{
+			OrderItemID: int(item.ItemID),
+			Sku:         item.Sku,
+			Ordered:     NewDecimalFromFloat(item.QtyOrdered),
+			Invoiced:    NewDecimalFromFloat(item.QtyInvoiced),
+			Shipped:     NewDecimalFromFloat(item.QtyShipped),
+			Refunded:    NewDecimalFromFloat(item.QtyRefunded),
+			Canceled:    NewDecimalFromFloat(item.QtyCanceled),
+			ToInvoice:   item.QtyToInvoice(),
+			ToShip:      item.QtyToShip(),
+			ToRefund:    item.QtyToRefund(),
+			Backordered: item.QtyBackorderedToShip(),
+		})
+	}
+	return items
+}
+
+// ItemsToInvoice lists the top-level items with a quantity left to invoice, ready for an InvoiceRequest
+func (mo *MOrder) ItemsToInvoice() []InvoiceItem {
+	var items []InvoiceItem
+	for _, item := range mo.Fulfillment() {
+		if item.ToInvoice.Sign() > 0 {
+			items = append(items, InvoiceItem{OrderItemID: item.OrderItemID, Qty: item.ToInvoice.Float64()})
+		}
+	}
+	return items
+}
+
+// ItemsToShip lists the top-level items with a quantity left to ship, ready for a ShipmentRequest. With
+// skipBackordered the backordered units are left out, to ship what is in stock now
+func (mo *MOrder) ItemsToShip(skipBackordered bool) []ShipmentItem {
+	var items []ShipmentItem
+	for _, item := range mo.Fulfillment() {
+		qty := item.ToShip
+		if skipBackordered {
+			qty = qty.Sub(item.Backordered)
+		}
+		if qty.Sign() > 0 {
+			items = append(items, ShipmentItem{OrderItemID: item.OrderItemID, Qty: qty.Float64()})
+		}
+	}
+	return items
+}
+
+// IsFullyInvoiced reports whether nothing is left to invoice
+func (mo *MOrder) IsFullyInvoiced() bool {
+	return len(mo.ItemsToInvoice()) == 0
+}
+
+// IsFullyShipped reports whether nothing is left to ship
+func (mo *MOrder) IsFullyShipped() bool {
+	return len(mo.ItemsToShip(false)) == 0
+}
+
+// HasBackorders reports whether backordered units are still waiting to ship
+func (mo *MOrder) HasBackorders() bool {
+	for _, item := range mo.Fulfillment() {
+		if item.Backordered.Sign() > 0 {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,141 @@
+package magento2
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+)
+
+// Reason codes for HoldWithComment. Any code of lowercase letters, digits and underscores works, these
+// cover the usual review flows
+const (
+	HoldReasonFraudReview         = "fraud_review"
+	HoldReasonPaymentReview       = "payment_review"
+	HoldReasonAddressVerification = "address_verification"
+	HoldReasonCustomerRequest     = "customer_request"
+)
+
+const (
+	// OrderStateHolded is the state and status Magento gives orders on hold
+	OrderStateHolded = "holded"
+
+	holdReasonPrefix = "m2rest-hold:"
+)
+
+// HoldReason is the structured reason of a hold, recorded as a status history comment of the form
+// "m2rest-hold:<code> <detail>"
+type HoldReason struct {
+	Code   string
+	Detail string
+}
+
+// String formats the reason as it is stored in the comment
+func (r HoldReason) String() string {
+	return strings.TrimSpace(holdReasonPrefix + r.Code + " " + r.Detail)
+}
+
+func (r HoldReason) validate() error {
+	if r.Code == "" {
+		return &ValidationError{Entity: "hold reason", Field: "code", Reason: "is required"}
+	}
+	for _, c := range r.Code {
+		if (c < 'a' || c > 'z') && (c < '0' || c > '9') && c != '_' {
+			return &ValidationError{Entity: "hold reason", Field: "code", Reason: fmt.Sprintf("contains unsupported character %q", c)}
+		}
+	}
+	return nil
+}
+
+// ParseHoldReason parses a comment written by HoldWithComment
+func ParseHoldReason(comment string) (HoldReason, bool) {
+	rest, ok := strings.CutPrefix(comment, holdReasonPrefix)
+	if !ok {
+		return HoldReason{}, false
+	}
+	code, detail, _ := strings.Cut(rest, " ")
+	reason := HoldReason{Code: code, Detail: strings.TrimSpace(detail)}
+	return reason, reason.validate() == nil
+}
+
+// HoldWithComment puts the order on hold and records the reason as a status history comment. Magento has
+// no call doing both, so when adding the comment fails the order is released again and the error returned
+func (mo *MOrder) HoldWithComment(ctx context.Context, reason HoldReason) error {
+	if err := reason.validate(); err != nil {
+		return err
+	}
+
+	log.Debug().Int("orderID", mo.Order.EntityID).Str("reason", reason.Code).Msg("Holding order with reason")
+
+	err := mo.postOrderAction(ctx, orderHold, "hold order")
+	if err != nil {
+		return err
+	}
+
+	endpoint := mo.Route + "/" + OrderComments
+	payLoad := map[string]any{"statusHistory": &StatusHistory{Comment: reason.String(), Status: OrderStateHolded}}
+	resp, err := mo.APIClient.HTTPClient.R().SetContext(ctx).SetBody(payLoad).Post(endpoint)
+	if err == nil {
+		err = mayReturnErrorForHTTPResponse(resp, "add hold reason to order")
+	} else {
+		err = fmt.Errorf("error adding hold reason to order: %w", err)
+	}
+	if err != nil {
+		log.Warn().Err(err).Int("orderID", mo.Order.EntityID).Msg("Releasing order after failing to record hold reason")
+		if unholdErr := mo.postOrderAction(ctx, orderUnhold, "unhold order"); unholdErr != nil {
+			return errors.Join(err, unholdErr)
+		}
+		return err
+	}
+
+	mo.Order.State = OrderStateHolded
+	mo.Order.Status = OrderStateHolded
+	return nil
+}
+
+func (mo *MOrder) postOrderAction(ctx context.Context, action, tryTo string) error {
+	resp, err := mo.APIClient.HTTPClient.R().SetContext(ctx).Post(mo.Route + "/" + action)
+	if err != nil {
+		return fmt.Errorf("error trying to %s: %w", tryTo, err)
+	}
+	return mayReturnErrorForHTTPResponse(resp, tryTo)
+}
+
+// HoldReason returns the reason of the latest hold recorded by HoldWithComment, as long as the order is
+// still on hold. It reads the status histories loaded with the order
+func (o *Order) HoldReason() (HoldReason, bool) {
+	if o.State != OrderStateHolded {
+		return HoldReason{}, false
+	}
+	latest := -1
+	var found HoldReason
+	for _, history := range o.StatusHistories {
+		reason, ok := ParseHoldReason(history.Comment)
+		if ok && history.EntityID > latest {
+			latest = history.EntityID
+			found = reason
+		}
+	}
+	return found, latest >= 0
+}
+
+// SearchHeldOrders returns the orders on hold whose latest hold reason has the given code. Comments can't
+// be searched, so the held orders are filtered locally. An empty code returns every order held with a reason
+func SearchHeldOrders(ctx context.Context, code string, apiClient *Client) ([]Order, error) {
+	held := NewSearchCriteria(SearchFilter{Field: "state", Value: OrderStateHolded, ConditionType: "eq"})
+	orders, err := searchAll[Order](ctx, Orders, held, "search held orders", apiClient)
+	if err != nil {
+		return nil, fmt.Errorf("error searching held orders: %w", err)
+	}
+
+	var result []Order
+	for i := range orders {
+		reason, ok := orders[i].HoldReason()
+		if ok && (code == "" || reason.Code == code) {
+			result = append(result, orders[i])
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,101 @@
+package magento2
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+)
+
+// SelectedAttribute is a configurable option of an order item resolved to attribute and option labels
+type SelectedAttribute struct {
+	AttributeID   int
+	AttributeCode string
+	Label         string
+	ValueID       string
+	ValueLabel    string
+}
+
+func (item *Item) optionExtension() *OrderItemOptionExtension {
+	if item.ProductOption.ExtensionAttributes == nil {
+		return &OrderItemOptionExtension{}
+	}
+	return item.ProductOption.ExtensionAttributes
+}
+
+// ConfigurableOptions returns the super attributes selected for a configurable item
+func (item *Item) ConfigurableOptions() []OrderItemConfigurableOption {
+	return item.optionExtension().ConfigurableItemOptions
+}
+
+// BundleOptions returns the selections bought for a bundle item
+func (item *Item) BundleOptions() []OrderItemBundleOption {
+	return item.optionExtension().BundleOptions
+}
+
+// CustomOptions returns the custom option values entered for the item
+func (item *Item) CustomOptions() []OrderItemCustomOption {
+	return item.optionExtension().CustomOptions
+}
+
+// DownloadableLinks returns the IDs of the links bought with a downloadable item
+func (item *Item) DownloadableLinks() []int {
+	option := item.optionExtension().DownloadableOption
+	if option == nil {
+		return nil
+	}
+	links := make([]int, 0, len(option.DownloadableLinks))
+	for _, link := range option.DownloadableLinks {
+		links = append(links, int(link))
+	}
+	return links
+}
+
+// SelectionIDs returns the IDs of the bundle selections bought for the option
+func (o *OrderItemBundleOption) SelectionIDs() []int {
+	ids := make([]int, 0, len(o.OptionSelections))
+	for _, selection := range o.OptionSelections {
+		ids = append(ids, int(selection))
+	}
+	return ids
+}
+
+// ChildItems returns the items ordered as part of parent, i.e. the simple product of a configurable
+// or the components of a bundle
+func (o *Order) ChildItems(parent *Item) []Item {
+	var children []Item
+	for _, item := range o.Items {
+		if item.ParentItemID != 0 && item.ParentItemID == parent.ItemID {
+			children = append(children, item)
+		}
+	}
+	return children
+}
+
+// ResolveConfigurableOptions looks up the attribute and option labels of the configurable options
+// of item, e.g. color "Red" and size "M"
+func ResolveConfigurableOptions(ctx context.Context, item *Item, cache *AttributeCache) ([]SelectedAttribute, error) {
+	options := item.ConfigurableOptions()
+	selected := make([]SelectedAttribute, 0, len(options))
+	for _, option := range options {
+		attribute, err := cache.Get(ctx, option.OptionID)
+		if err != nil {
+			return nil, fmt.Errorf("error resolving configurable option '%s' of item '%s': %w", option.OptionID, item.Sku, err)
+		}
+
+		valueID := strconv.FormatFloat(option.OptionValue, 'f', -1, 64)
+		attr := SelectedAttribute{
+			AttributeID:   attribute.AttributeID,
+			AttributeCode: attribute.AttributeCode,
+			Label:         attribute.DefaultFrontendLabel,
+			ValueID:       valueID,
+		}
+		for _, attributeOption := range attribute.Options {
+			if attributeOption.Value == valueID {
+				attr.ValueLabel = attributeOption.Label
+				break
+			}
+		}
+		selected = append(selected, attr)
+	}
+	return selected, nil
+}
@@ -0,0 +1,166 @@
+package magento2
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// OrderEventType is the kind of an OrderEvent
+type OrderEventType string
+
+const (
+	OrderEventPlaced        OrderEventType = "placed"
+	OrderEventStatusComment OrderEventType = "status_comment"
+	OrderEventInvoice       OrderEventType = "invoice"
+	OrderEventShipment      OrderEventType = "shipment"
+	OrderEventCreditMemo    OrderEventType = "credit_memo"
+)
+
+// orderEventRank orders events of the same second: the document first, then the comment Magento adds for it
+var orderEventRank = map[OrderEventType]int{
+	OrderEventPlaced:        0,
+	OrderEventInvoice:       1,
+	OrderEventShipment:      2,
+	OrderEventCreditMemo:    3,
+	OrderEventStatusComment: 4,
+}
+
+// OrderEvent is an entry of an order timeline. Time is in UTC, as Magento stores it. Amount is the grand
+// total of the order, invoice or credit memo, TrackNumbers are set for shipments
+type OrderEvent struct {
+	Time             time.Time
+	Type             OrderEventType
+	EntityID         int
+	IncrementID      string
+	Status           string
+	Comment          string
+	Amount           float64
+	TrackNumbers     []string
+	CustomerNotified bool
+}
+
+// Timeline merges the placement, status history comments, invoices, shipments and credit memos of the order
+// into one chronological slice. Comments on documents are part of the document's event
+func (mo *MOrder) Timeline(ctx context.Context) ([]OrderEvent, error) {
+	order := mo.Order
+	byOrder := NewSearchCriteria(SearchFilter{Field: "order_id", Value: strconv.Itoa(order.EntityID), ConditionType: "eq"})
+
+	log.Debug().Int("orderID", order.EntityID).Msg("Building order timeline")
+
+	var (
+		orderInvoices    []Invoice
+		orderShipments   []Shipment
+		orderCreditMemos []CreditMemo
+		errs             = make([]error, 3)
+		wg               sync.WaitGroup
+	)
+	wg.Add(3)
+	go func() {
+		defer wg.Done()
+		orderInvoices, errs[0] = searchAll[Invoice](ctx, invoices, byOrder, "search invoices for order timeline", mo.APIClient)
+	}()
+	go func() {
+		defer wg.Done()
+		orderShipments, errs[1] = searchAll[Shipment](ctx, shipments, byOrder, "search shipments for order timeline", mo.APIClient)
+	}()
+	go func() {
+		defer wg.Done()
+		orderCreditMemos, errs[2] = searchAll[CreditMemo](ctx, creditmemos, byOrder, "search credit memos for order timeline", mo.APIClient)
+	}()
+	wg.Wait()
+	if err := errors.Join(errs...); err != nil {
+		return nil, fmt.Errorf("error building order timeline: %w", err)
+	}
+
+	events := []OrderEvent{{
+		Time:        parseOrderEventTime(order.CreatedAt),
+		Type:        OrderEventPlaced,
+		EntityID:    order.EntityID,
+		IncrementID: order.IncrementID,
+		Amount:      order.GrandTotal,
+	}}
+	for _, history := range order.StatusHistories {
+		events = append(events, OrderEvent{
+			Time:             parseOrderEventTime(history.CreatedAt),
+			Type:             OrderEventStatusComment,
+			EntityID:         history.EntityID,
+			Status:           history.Status,
+			Comment:          history.Comment,
+			CustomerNotified: history.IsCustomerNotified == 1,
+		})
+	}
+	for _, invoice := range orderInvoices {
+		events = append(events, OrderEvent{
+			Time:        parseOrderEventTime(invoice.CreatedAt),
+			Type:        OrderEventInvoice,
+			EntityID:    invoice.EntityID,
+			IncrementID: invoice.IncrementID,
+			Comment:     joinEntityComments(invoice.Comments),
+			Amount:      invoice.GrandTotal,
+		})
+	}
+	for _, shipment := range orderShipments {
+		event := OrderEvent{
+			Time:        parseOrderEventTime(shipment.CreatedAt),
+			Type:        OrderEventShipment,
+			EntityID:    shipment.EntityID,
+			IncrementID: shipment.IncrementID,
+			Comment:     joinEntityComments(shipment.Comments),
+		}
+		for _, track := range shipment.Tracks {
+			event.TrackNumbers = append(event.TrackNumbers, track.TrackNumber)
+		}
+		events = append(events, event)
+	}
+	for _, creditMemo := range orderCreditMemos {
+		events = append(events, OrderEvent{
+			Time:        parseOrderEventTime(creditMemo.CreatedAt),
+			Type:        OrderEventCreditMemo,
+			EntityID:    creditMemo.EntityID,
+			IncrementID: creditMemo.IncrementID,
+			Comment:     joinEntityComments(creditMemo.Comments),
+			Amount:      creditMemo.GrandTotal,
+		})
+	}
+
+	sort.SliceStable(events, func(i, j int) bool {
+		if !events[i].Time.Equal(events[j].Time) {
+			return events[i].Time.Before(events[j].Time)
+		}
+		if events[i].Type != events[j].Type {
+			return orderEventRank[events[i].Type] < orderEventRank[events[j].Type]
+		}
+		return events[i].EntityID < events[j].EntityID
+	})
+	return events, nil
+}
+
+func parseOrderEventTime(value string) time.Time {
+	t, err := time.Parse(DateTimeFormat, value)
+	if err != nil {
+		log.Warn().Str("createdAt", value).Msg("Unparseable timestamp in order timeline")
+		return time.Time{}
+	}
+	return t
+}
+
+func joinEntityComments(comments []EntityComment) string {
+	joined := ""
+	for _, comment := range comments {
+		if comment.Comment == "" {
+			continue
+		}
+		if joined != "" {
+			joined += "\n"
+		}
+		joined += comment.Comment
+	}
+	return joined
+}
@@ -0,0 +1,54 @@
+package magento2
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/rs/zerolog/log"
+)
+
+// SearchOrders returns the orders matching the criteria, e.g. NewSearchCriteria(SearchFilter{Field: "status", Value: "pending"})
+func SearchOrders(ctx context.Context, criteria *SearchCriteria, apiClient *Client) (*OrderSearchResult, error) {
+	return searchOrdersAt(ctx, Orders, criteria, apiClient)
+}
+
+// GetOrdersForCustomerID returns the orders of a registered customer, narrowed down by the optional criteria
+func GetOrdersForCustomerID(ctx context.Context, customerID int, criteria *SearchCriteria, apiClient *Client) (*OrderSearchResult, error) {
+	sc := criteria.Clone().And(SearchFilter{Field: "customer_id", Value: strconv.Itoa(customerID), ConditionType: "eq"})
+	return searchOrdersAt(ctx, Orders, sc, apiClient)
+}
+
+// GetOrdersForCustomerEmail returns the orders placed with the email, including guest orders
+func GetOrdersForCustomerEmail(ctx context.Context, email string, criteria *SearchCriteria, apiClient *Client) (*OrderSearchResult, error) {
+	sc := criteria.Clone().And(SearchFilter{Field: "customer_email", Value: email, ConditionType: "eq"})
+	return searchOrdersAt(ctx, Orders, sc, apiClient)
+}
+
+// GetMyOrders returns the orders of the customer a customer-token client is authenticated as.
+// Magento core has no /orders/mine route, so this needs a module providing it and returns ErrNotFound otherwise
+func GetMyOrders(ctx context.Context, criteria *SearchCriteria, apiClient *Client) (*OrderSearchResult, error) {
+	return searchOrdersAt(ctx, ordersMine, criteria.Clone(), apiClient)
+}
+
+func searchOrdersAt(ctx context.Context, route string, criteria *SearchCriteria, apiClient *Client) (*OrderSearchResult, error) {
+	endpoint := route + "?" + criteria.Clone().Query()
+	result := &OrderSearchResult{}
+
+	log.Debug().
+		Str("endpoint", endpoint).
+		Msg("Searching orders")
+
+	resp, err := apiClient.HTTPClient.R().SetContext(ctx).SetResult(result).Get(endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("error searching orders: %w", err)
+	}
+
+	httpErr := mayReturnErrorForHTTPResponse(resp, "search orders")
+	if httpErr != nil {
+		return nil, httpErr
+	}
+
+	log.Debug().Int("totalCount", result.TotalCount).Int("items", len(result.Items)).Msg("Orders search completed")
+	return result, nil
+}
@@ -0,0 +1,62 @@
+package magento2
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+)
+
+// LookupGuestOrderStatus finds the order by increment ID and only returns it when both email and
+// billing last name match. Any mismatch is reported as ErrNotFound, so callers can't probe for orders
+func LookupGuestOrderStatus(ctx context.Context, incrementID, email, lastname string, apiClient *Client) (*GuestOrderStatus, error) {
+	criteria := NewSearchCriteria(SearchFilter{Field: "increment_id", Value: incrementID, ConditionType: "eq"})
+	result, err := SearchOrders(ctx, criteria, apiClient)
+	if err != nil {
+		return nil, fmt.Errorf("error looking up guest order: %w", err)
+	}
+
+	for i := range result.Items {
+		o := &result.Items[i]
+		if o.IncrementID != incrementID || !orderMatchesCustomer(o, email, lastname) {
+			continue
+		}
+
+		status := &GuestOrderStatus{
+			IncrementID:       o.IncrementID,
+			State:             o.State,
+			Status:            o.Status,
+			CreatedAt:         o.CreatedAt,
+			UpdatedAt:         o.UpdatedAt,
+			GrandTotal:        o.GrandTotal,
+			OrderCurrencyCode: o.OrderCurrencyCode,
+		}
+		for _, item := range o.Items {
+			if item.ParentItemID != 0 {
+				continue
+			}
+			status.Items = append(status.Items, GuestOrderStatusItem{
+				Sku:        item.Sku,
+				Name:       item.Name,
+				QtyOrdered: item.QtyOrdered,
+				QtyShipped: item.QtyShipped,
+			})
+		}
+		return status, nil
+	}
+
+	log.Warn().Str("incrementID", incrementID).Msg("Guest order not found or verification failed")
+	return nil, ErrNotFound
+}
+
+func orderMatchesCustomer(o *Order, email, lastname string) bool {
+	if !strings.EqualFold(strings.TrimSpace(o.CustomerEmail), strings.TrimSpace(email)) {
+		return false
+	}
+	orderLastname := o.CustomerLastname
+	if o.BillingAddress != nil {
+		orderLastname = o.BillingAddress.Lastname
+	}
+	return strings.EqualFold(strings.TrimSpace(orderLastname), strings.TrimSpace(lastname))
+}
@@ -2,5 +2,19 @@ package magento2
 
 const (
 	Orders        = "/orders"
+	ordersMine    = "/orders/mine"
 	OrderComments = "comments"
+	order         = "/order"
+	orderInvoice  = "invoice"
+	orderShip     = "ship"
+	orderRefund   = "refund"
+	orderHold     = "hold"
+	orderUnhold   = "unhold"
+)
+
+const (
+	invoices    = "/invoices"
+	shipments   = "/shipments"
+	shipment    = "/shipment"
+	creditmemos = "/creditmemos"
 )
@@ -399,45 +399,58 @@ type Order struct {
 }
 
 type OrdersProductOption struct {
+	ExtensionAttributes *OrderItemOptionExtension `json:"extension_attributes,omitempty"`
+}
+
+type OrderItemOptionExtension struct {
+	CustomOptions           []OrderItemCustomOption       `json:"custom_options,omitempty"`
+	BundleOptions           []OrderItemBundleOption       `json:"bundle_options,omitempty"`
+	ConfigurableItemOptions []OrderItemConfigurableOption `json:"configurable_item_options,omitempty"`
+	DownloadableOption      *struct {
+		DownloadableLinks []float64 `json:"downloadable_links,omitempty"`
+	} `json:"downloadable_option,omitempty"`
+	GiftcardItemOption *struct {
+		GiftcardAmount         string  `json:"giftcard_amount,omitempty"`
+		CustomGiftcardAmount   float64 `json:"custom_giftcard_amount,omitempty"`
+		GiftcardSenderName     string  `json:"giftcard_sender_name,omitempty"`
+		GiftcardRecipientName  string  `json:"giftcard_recipient_name,omitempty"`
+		GiftcardSenderEmail    string  `json:"giftcard_sender_email,omitempty"`
+		GiftcardRecipientEmail string  `json:"giftcard_recipient_email,omitempty"`
+		GiftcardMessage        string  `json:"giftcard_message,omitempty"`
+		ExtensionAttributes    *struct {
+		} `json:"extension_attributes,omitempty"`
+	} `json:"giftcard_item_option,omitempty"`
+}
+
+// OrderItemCustomOption is the value entered or picked for a custom option. For select-type options
+// OptionValue holds the ID(s) of the chosen value(s), comma separated
+type OrderItemCustomOption struct {
+	OptionID            string `json:"option_id,omitempty"`
+	OptionValue         string `json:"option_value,omitempty"`
+	ExtensionAttributes *struct {
+		FileInfo *struct {
+			Base64EncodedData string `json:"base64_encoded_data,omitempty"`
+			Type              string `json:"type,omitempty"`
+			Name              string `json:"name,omitempty"`
+		} `json:"file_info,omitempty"`
+	} `json:"extension_attributes,omitempty"`
+}
+
+// OrderItemBundleOption holds the selections bought for one bundle option
+type OrderItemBundleOption struct {
+	OptionID            float64   `json:"option_id,omitempty"`
+	OptionQty           float64   `json:"option_qty,omitempty"`
+	OptionSelections    []float64 `json:"option_selections,omitempty"`
+	ExtensionAttributes *struct {
+	} `json:"extension_attributes,omitempty"`
+}
+
+// OrderItemConfigurableOption is a selected super attribute: OptionID is the attribute ID and
+// OptionValue the ID of the chosen attribute option
+type OrderItemConfigurableOption struct {
+	OptionID            string  `json:"option_id,omitempty"`
+	OptionValue         float64 `json:"option_value,omitempty"`
 	ExtensionAttributes *struct {
-		CustomOptions []struct {
-			OptionID            string `json:"option_id,omitempty"`
-			OptionValue         string `json:"option_value,omitempty"`
-			ExtensionAttributes *struct {
-				FileInfo *struct {
-					Base64EncodedData string `json:"base64_encoded_data,omitempty"`
-					Type              string `json:"type,omitempty"`
-					Name              string `json:"name,omitempty"`
-				} `json:"file_info,omitempty"`
-			} `json:"extension_attributes,omitempty"`
-		} `json:"custom_options,omitempty"`
-		BundleOptions []struct {
-			OptionID            float64   `json:"option_id,omitempty"`
-			OptionQty           float64   `json:"option_qty,omitempty"`
-			OptionSelections    []float64 `json:"option_selections,omitempty"`
-			ExtensionAttributes *struct {
-			} `json:"extension_attributes,omitempty"`
-		} `json:"bundle_options,omitempty"`
-		ConfigurableItemOptions []struct {
-			OptionID            string  `json:"option_id,omitempty"`
-			OptionValue         float64 `json:"option_value,omitempty"`
-			ExtensionAttributes *struct {
-			} `json:"extension_attributes,omitempty"`
-		} `json:"configurable_item_options,omitempty"`
-		DownloadableOption *struct {
-			DownloadableLinks []float64 `json:"downloadable_links,omitempty"`
-		} `json:"downloadable_option,omitempty"`
-		GiftcardItemOption *struct {
-			GiftcardAmount         string  `json:"giftcard_amount,omitempty"`
-			CustomGiftcardAmount   float64 `json:"custom_giftcard_amount,omitempty"`
-			GiftcardSenderName     string  `json:"giftcard_sender_name,omitempty"`
-			GiftcardRecipientName  string  `json:"giftcard_recipient_name,omitempty"`
-			GiftcardSenderEmail    string  `json:"giftcard_sender_email,omitempty"`
-			GiftcardRecipientEmail string  `json:"giftcard_recipient_email,omitempty"`
-			GiftcardMessage        string  `json:"giftcard_message,omitempty"`
-			ExtensionAttributes    *struct {
-			} `json:"extension_attributes,omitempty"`
-		} `json:"giftcard_item_option,omitempty"`
 	} `json:"extension_attributes,omitempty"`
 }
 
@@ -453,3 +466,27 @@ type StatusHistory struct {
 	ExtensionAttributes *struct {
 	} `json:"extension_attributes,omitempty"`
 }
+
+type OrderSearchResult struct {
+	Items      []Order `json:"items"`
+	TotalCount int     `json:"total_count"`
+}
+
+// GuestOrderStatus is the subset of an order that is safe to show on a "track my order" page
+type GuestOrderStatus struct {
+	IncrementID       string
+	State             string
+	Status            string
+	CreatedAt         string
+	UpdatedAt         string
+	GrandTotal        float64
+	OrderCurrencyCode string
+	Items             []GuestOrderStatusItem
+}
+
+type GuestOrderStatusItem struct {
+	Sku        string
+	Name       string
+	QtyOrdered float64
+	QtyShipped float64
+}
@@ -0,0 +1,151 @@
+package magento2
+
+import (
+	"context"
+	"sync"
+
+	"github.com/rs/zerolog/log"
+)
+
+// DefaultPaymentMethodTitles are the titles core and bundled payment methods ship with, used when a store
+// does not report its own title for a code
+var DefaultPaymentMethodTitles = map[string]string{
+	"checkmo":                  "Check / Money order",
+	"banktransfer":             "Bank Transfer Payment",
+	"cashondelivery":           "Cash On Delivery",
+	"purchaseorder":            "Purchase Order",
+	"free":                     "No Payment Information Required",
+	"braintree":                "Credit Card (Braintree)",
+	"braintree_paypal":         "PayPal (Braintree)",
+	"paypal_express":           "PayPal Express Checkout",
+	"payflowpro":               "Credit Card (Payflow Pro)",
+	"companycredit":            "Payment on Account",
+	"authorizenet_acceptjs":    "Credit Card (Authorize.Net)",
+	"substitution":             "Substitution",
+	"paypal_billing_agreement": "PayPal Billing Agreement",
+}
+
+// storeConfig only exposes the offline methods Magento ships GraphQL config for
+const paymentConfigQuery = `query {
+  storeConfig {
+    check_money_order_enable
+    check_money_order_title
+    zero_subtotal_enabled
+    zero_subtotal_title
+  }
+}`
+
+// GetActivePaymentMethods returns the payment methods the configuration of the store view enables. The
+// config API only covers check / money order and zero subtotal checkout, use EstimatePaymentMethods on a
+// cart of the store for the methods available at checkout
+func GetActivePaymentMethods(ctx context.Context, storeCode string, apiClient *Client) ([]PaymentMethod, error) {
+	var data struct {
+		StoreConfig struct {
+			CheckMoneyOrderEnable bool   `json:"check_money_order_enable"`
+			CheckMoneyOrderTitle  string `json:"check_money_order_title"`
+			ZeroSubtotalEnabled   bool   `json:"zero_subtotal_enabled"`
+			ZeroSubtotalTitle     string `json:"zero_subtotal_title"`
+		} `json:"storeConfig"`
+	}
+	err := graphQL(ctx, paymentConfigQuery, nil, &data, "get payment config of store "+storeCode, apiClient, WithStoreCode(storeCode))
+	if err != nil {
+		return nil, err
+	}
+
+	var methods []PaymentMethod
+	config := data.StoreConfig
+	if config.CheckMoneyOrderEnable {
+		methods = append(methods, PaymentMethod{Code: "checkmo", Title: config.CheckMoneyOrderTitle})
+	}
+	if config.ZeroSubtotalEnabled {
+		methods = append(methods, PaymentMethod{Code: "free", Title: config.ZeroSubtotalTitle})
+	}
+	return methods, nil
+}
+
+// PaymentMethodCatalog collects the payment method titles of store views, from their config and from cart
+// estimates, so codes like "checkmo" can be shown by name. It is safe for concurrent use
+type PaymentMethodCatalog struct {
+	APIClient *Client
+	mu        sync.RWMutex
+	stores    map[string]map[string]string
+}
+
+func NewPaymentMethodCatalog(apiClient *Client) *PaymentMethodCatalog {
+	return &PaymentMethodCatalog{
+		APIClient: apiClient,
+		stores:    map[string]map[string]string{},
+	}
+}
+
+// Load adds the methods enabled by the config of the store views
+func (pc *PaymentMethodCatalog) Load(ctx context.Context, storeCodes ...string) error {
+	for _, storeCode := range storeCodes {
+		methods, err := GetActivePaymentMethods(ctx, storeCode, pc.APIClient)
+		if err != nil {
+			return err
+		}
+		pc.Add(storeCode, methods...)
+	}
+	return nil
+}
+
+// AddCartEstimate adds the methods the cart can be paid with. They are recorded for the store view of
+// the cart's client
+func (pc *PaymentMethodCatalog) AddCartEstimate(cart *MCart) error {
+	methods, err := cart.EstimatePaymentMethods()
+	if err != nil {
+		return err
+	}
+	storeCode := ""
+	if cart.APIClient.storeConfig != nil {
+		storeCode = cart.APIClient.storeConfig.StoreCode
+	}
+	pc.Add(storeCode, methods...)
+	return nil
+}
+
+// Add records methods of a store view, e.g. ones known from elsewhere. Later titles win
+func (pc *PaymentMethodCatalog) Add(storeCode string, methods ...PaymentMethod) {
+	pc.mu.Lock()
+	defer pc.mu.Unlock()
+	titles, ok := pc.stores[storeCode]
+	if !ok {
+		titles = map[string]string{}
+		pc.stores[storeCode] = titles
+	}
+	for _, method := range methods {
+		titles[method.Code] = method.Title
+	}
+	log.Debug().Str("storeCode", storeCode).Int("methods", len(titles)).Msg("Payment methods added to catalog")
+}
+
+// Methods returns the methods known for the store view sorted by code
+func (pc *PaymentMethodCatalog) Methods(storeCode string) []PaymentMethod {
+	pc.mu.RLock()
+	defer pc.mu.RUnlock()
+	var methods []PaymentMethod
+	for _, code := range sortedKeys(pc.stores[storeCode]) {
+		methods = append(methods, PaymentMethod{Code: code, Title: pc.stores[storeCode][code]})
+	}
+	return methods
+}
+
+// Title returns the title of the method in the store view. It falls back to the title any other store
+// view reported, then to DefaultPaymentMethodTitles and finally to the code itself
+func (pc *PaymentMethodCatalog) Title(storeCode, code string) string {
+	pc.mu.RLock()
+	defer pc.mu.RUnlock()
+	if title := pc.stores[storeCode][code]; title != "" {
+		return title
+	}
+	for _, other := range sortedKeys(pc.stores) {
+		if title := pc.stores[other][code]; title != "" {
+			return title
+		}
+	}
+	if title, ok := DefaultPaymentMethodTitles[code]; ok {
+		return title
+	}
+	return code
+}
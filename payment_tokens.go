@@ -0,0 +1,61 @@
+package magento2
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// Magento core exposes stored payment tokens (vault) only through GraphQL, so these calls use the
+// GraphQL endpoint of the store with the client's customer token
+
+const customerPaymentTokensQuery = `query {
+  customerPaymentTokens {
+    items { public_hash payment_method_code type details }
+  }
+}`
+
+const deletePaymentTokenMutation = `mutation($publicHash: String!) {
+  deletePaymentToken(public_hash: $publicHash) { result }
+}`
+
+// GetPaymentTokens returns the stored payment methods of the customer a customer-token client is authenticated as
+func GetPaymentTokens(ctx context.Context, apiClient *Client) ([]PaymentToken, error) {
+	var data struct {
+		CustomerPaymentTokens struct {
+			Items []PaymentToken `json:"items"`
+		} `json:"customerPaymentTokens"`
+	}
+	err := graphQL(ctx, customerPaymentTokensQuery, nil, &data, "get customer payment tokens", apiClient)
+	if err != nil {
+		return nil, err
+	}
+	return data.CustomerPaymentTokens.Items, nil
+}
+
+// DeletePaymentToken removes a stored payment method of the customer
+func DeletePaymentToken(ctx context.Context, publicHash string, apiClient *Client) error {
+	var data struct {
+		DeletePaymentToken struct {
+			Result bool `json:"result"`
+		} `json:"deletePaymentToken"`
+	}
+	tryTo := fmt.Sprintf("delete payment token '%s'", publicHash)
+	err := graphQL(ctx, deletePaymentTokenMutation, map[string]any{"publicHash": publicHash}, &data, tryTo, apiClient)
+	if err != nil {
+		return err
+	}
+	if !data.DeletePaymentToken.Result {
+		return fmt.Errorf("%w: %s", ErrGraphQL, tryTo)
+	}
+	return nil
+}
+
+// CardDetails decodes the details of a card token, e.g. {"type":"VI","maskedCC":"1111","expirationDate":"12/2027"}
+func (t *PaymentToken) CardDetails() (*PaymentTokenCardDetails, error) {
+	details := &PaymentTokenCardDetails{}
+	if err := json.Unmarshal([]byte(t.Details), details); err != nil {
+		return nil, fmt.Errorf("error decoding details of payment token '%s': %w", t.PublicHash, err)
+	}
+	return details, nil
+}
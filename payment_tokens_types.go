@@ -0,0 +1,21 @@
+package magento2
+
+const (
+	PaymentTokenTypeCard    = "card"
+	PaymentTokenTypeAccount = "account"
+)
+
+// PaymentToken is a stored payment method. Details is a JSON document whose layout depends on the
+// payment method, see CardDetails for cards
+type PaymentToken struct {
+	PublicHash        string `json:"public_hash"`
+	PaymentMethodCode string `json:"payment_method_code"`
+	Type              string `json:"type"`
+	Details           string `json:"details"`
+}
+
+type PaymentTokenCardDetails struct {
+	Type           string `json:"type"`
+	MaskedCC       string `json:"maskedCC"`
+	ExpirationDate string `json:"expirationDate"`
+}
@@ -0,0 +1,218 @@
+package magento2
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/rs/zerolog/log"
+)
+
+func GetBasePrices(ctx context.Context, skus []string, apiClient *Client) ([]BasePrice, error) {
+	prices := &[]BasePrice{}
+	err := postPricesInformation(ctx, productsBasePricesInformation, skus, prices, "get base prices", apiClient)
+	return *prices, err
+}
+
+func GetSpecialPrices(ctx context.Context, skus []string, apiClient *Client) ([]SpecialPrice, error) {
+	prices := &[]SpecialPrice{}
+	err := postPricesInformation(ctx, productsSpecialPriceInformation, skus, prices, "get special prices", apiClient)
+	return *prices, err
+}
+
+func GetTierPrices(ctx context.Context, skus []string, apiClient *Client) ([]TierPrice, error) {
+	prices := &[]TierPrice{}
+	err := postPricesInformation(ctx, productsTierPricesInformation, skus, prices, "get tier prices", apiClient)
+	return *prices, err
+}
+
+func postPricesInformation(ctx context.Context, endpoint string, skus []string, target any, tryTo string, apiClient *Client) error {
+	payLoad := pricesInformationPayload{Skus: skus}
+
+	log.Debug().
+		Strs("skus", skus).
+		Str("endpoint", endpoint).
+		Msg("Getting prices information")
+
+	// the price storage endpoints only read, so retrying these POSTs is safe
+	req, cancel := newRequestOptions([]RequestOption{WithRetries(RetryAttempts)}).newRequest(ctx, apiClient)
+	defer cancel()
+
+	resp, err := req.SetBody(payLoad).SetResult(target).Post(endpoint)
+	if err != nil {
+		return fmt.Errorf("error while trying to %s: %w", tryTo, err)
+	}
+
+	return mayReturnErrorForHTTPResponse(resp, tryTo)
+}
+
+// UpdateBasePrices sets the base prices in one request. Magento applies the valid rows and reports the
+// rejected ones, which are returned together with an ErrBadRequest error
+func UpdateBasePrices(ctx context.Context, prices []BasePrice, apiClient *Client) ([]PriceUpdateResult, error) {
+	payLoad := basePricesPayload{Prices: prices}
+	results := &[]PriceUpdateResult{}
+
+	log.Debug().
+		Str("endpoint", productsBasePrices).
+		Int("prices", len(prices)).
+		Msg("Updating base prices")
+
+	resp, err := apiClient.HTTPClient.R().SetContext(ctx).SetBody(payLoad).SetResult(results).Post(productsBasePrices)
+	if err != nil {
+		return nil, fmt.Errorf("error updating base prices: %w", err)
+	}
+
+	httpErr := mayReturnErrorForHTTPResponse(resp, "update base prices")
+	if httpErr != nil {
+		return nil, httpErr
+	}
+
+	if len(*results) > 0 {
+		return *results, fmt.Errorf("%w: %d base prices rejected, first: %s", ErrBadRequest, len(*results), (*results)[0].Message)
+	}
+	return nil, nil
+}
+
+// AuditPrices fetches base, special, tier and final (render-info) prices of the SKUs concurrently
+// and consolidates them per SKU, flagging inconsistencies for the given store
+func AuditPrices(ctx context.Context, skus []string, storeID int, currencyCode string, apiClient *Client) (*PriceReport, error) {
+	var (
+		wg            sync.WaitGroup
+		basePrices    []BasePrice
+		specialPrices []SpecialPrice
+		tierPrices    []TierPrice
+		renderInfo    []ProductRenderInfo
+		errs          = make([]error, 4)
+	)
+
+	wg.Add(4)
+	go func() {
+		defer wg.Done()
+		basePrices, errs[0] = GetBasePrices(ctx, skus, apiClient)
+	}()
+	go func() {
+		defer wg.Done()
+		specialPrices, errs[1] = GetSpecialPrices(ctx, skus, apiClient)
+	}()
+	go func() {
+		defer wg.Done()
+		tierPrices, errs[2] = GetTierPrices(ctx, skus, apiClient)
+	}()
+	go func() {
+		defer wg.Done()
+		renderInfo, errs[3] = GetProductsRenderInfo(ctx, skus, storeID, currencyCode, apiClient)
+	}()
+	wg.Wait()
+
+	if err := errors.Join(errs...); err != nil {
+		return nil, fmt.Errorf("error auditing prices: %w", err)
+	}
+
+	// render info is keyed by product ID and name only, so map IDs back to SKUs through the search endpoint
+	skuByID, err := productSkusByID(ctx, skus, apiClient)
+	if err != nil {
+		return nil, fmt.Errorf("error auditing prices: %w", err)
+	}
+
+	entries := make(map[string]*PriceReportEntry, len(skus))
+	report := &PriceReport{StoreID: storeID, CurrencyCode: currencyCode}
+	for _, sku := range skus {
+		entries[sku] = &PriceReportEntry{Sku: sku}
+	}
+	for i := range basePrices {
+		if entry, ok := entries[basePrices[i].Sku]; ok && basePrices[i].StoreID == storeID {
+			entry.BasePrice = &basePrices[i]
+		}
+	}
+	for i := range basePrices {
+		if entry, ok := entries[basePrices[i].Sku]; ok && entry.BasePrice == nil && basePrices[i].StoreID == 0 {
+			entry.BasePrice = &basePrices[i]
+		}
+	}
+	for _, price := range specialPrices {
+		if entry, ok := entries[price.Sku]; ok {
+			entry.SpecialPrices = append(entry.SpecialPrices, price)
+		}
+	}
+	for _, price := range tierPrices {
+		if entry, ok := entries[price.Sku]; ok {
+			entry.TierPrices = append(entry.TierPrices, price)
+		}
+	}
+	for i := range renderInfo {
+		if entry, ok := entries[skuByID[renderInfo[i].ID]]; ok {
+			finalPrice := renderInfo[i].PriceInfo.FinalPrice
+			entry.FinalPrice = &finalPrice
+		}
+	}
+
+	for _, sku := range skus {
+		entry := entries[sku]
+		entry.Issues = priceIssues(entry)
+		report.Entries = append(report.Entries, *entry)
+	}
+
+	log.Debug().Int("skus", len(skus)).Int("storeID", storeID).Msg("Price audit completed")
+	return report, nil
+}
+
+func priceIssues(entry *PriceReportEntry) []string {
+	issues := []string{}
+	if entry.BasePrice == nil {
+		issues = append(issues, "no base price")
+		return issues
+	}
+	if entry.FinalPrice == nil {
+		issues = append(issues, "no final price, product may be disabled or not visible in store")
+	} else if *entry.FinalPrice > entry.BasePrice.Price {
+		issues = append(issues, fmt.Sprintf("final price %v exceeds base price %v", *entry.FinalPrice, entry.BasePrice.Price))
+	}
+	for _, special := range entry.SpecialPrices {
+		if special.Price >= entry.BasePrice.Price {
+			issues = append(issues, fmt.Sprintf("special price %v is not below base price %v", special.Price, entry.BasePrice.Price))
+		}
+	}
+	for _, tier := range entry.TierPrices {
+		if tier.PriceType == "fixed" && tier.Price >= entry.BasePrice.Price {
+			issues = append(issues, fmt.Sprintf("tier price %v for qty %v is not below base price %v", tier.Price, tier.Quantity, entry.BasePrice.Price))
+		}
+	}
+	return issues
+}
+
+// productSkusByID maps the IDs of the products to their SKUs. The SKUs are searched in chunks with "in"
+// filters, except SKUs holding a comma, which would split the filter value and are matched with "eq"
+func productSkusByID(ctx context.Context, skus []string, apiClient *Client) (map[int]string, error) {
+	var searches []*SearchCriteria
+	var values []string
+	var commaFilters []SearchFilter
+	for _, sku := range skus {
+		if strings.Contains(sku, ",") {
+			commaFilters = append(commaFilters, SearchFilter{Field: "sku", Value: sku, ConditionType: "eq"})
+			continue
+		}
+		values = append(values, sku)
+	}
+	for start := 0; start < len(values); start += inFilterChunkSize {
+		chunk := values[start:min(start+inFilterChunkSize, len(values))]
+		searches = append(searches, NewSearchCriteria(SearchFilter{Field: "sku", Value: strings.Join(chunk, ","), ConditionType: "in"}))
+	}
+	if len(commaFilters) > 0 {
+		// filters of one group are ORed
+		searches = append(searches, (&SearchCriteria{}).And(commaFilters...))
+	}
+
+	skuByID := make(map[int]string, len(skus))
+	for _, criteria := range searches {
+		found, err := searchAll[Product](ctx, products, criteria, "get product ids by sku", apiClient)
+		if err != nil {
+			return nil, fmt.Errorf("error getting product ids: %w", err)
+		}
+		for _, p := range found {
+			skuByID[p.ID] = p.Sku
+		}
+	}
+	return skuByID, nil
+}
@@ -0,0 +1,8 @@
+package magento2
+
+const (
+	productsBasePricesInformation   = "/products/base-prices-information"
+	productsSpecialPriceInformation = "/products/special-price-information"
+	productsTierPricesInformation   = "/products/tier-prices-information"
+	productsBasePrices              = "/products/base-prices"
+)
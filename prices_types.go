@@ -0,0 +1,54 @@
+package magento2
+
+type BasePrice struct {
+	Price   float64 `json:"price"`
+	StoreID int     `json:"store_id"`
+	Sku     string  `json:"sku"`
+}
+
+type SpecialPrice struct {
+	Price     float64 `json:"price"`
+	StoreID   int     `json:"store_id"`
+	Sku       string  `json:"sku"`
+	PriceFrom string  `json:"price_from"`
+	PriceTo   string  `json:"price_to"`
+}
+
+type TierPrice struct {
+	Price         float64 `json:"price"`
+	PriceType     string  `json:"price_type"`
+	WebsiteID     int     `json:"website_id"`
+	Sku           string  `json:"sku"`
+	CustomerGroup string  `json:"customer_group"`
+	Quantity      float64 `json:"quantity"`
+}
+
+type pricesInformationPayload struct {
+	Skus []string `json:"skus"`
+}
+
+type basePricesPayload struct {
+	Prices []BasePrice `json:"prices"`
+}
+
+// PriceUpdateResult is one failed row reported by the price storage endpoints
+type PriceUpdateResult struct {
+	Message    string   `json:"message"`
+	Parameters []string `json:"parameters"`
+}
+
+// PriceReportEntry consolidates every price Magento knows for one SKU in the audited store
+type PriceReportEntry struct {
+	Sku           string
+	BasePrice     *BasePrice
+	SpecialPrices []SpecialPrice
+	TierPrices    []TierPrice
+	FinalPrice    *float64
+	Issues        []string
+}
+
+type PriceReport struct {
+	StoreID      int
+	CurrencyCode string
+	Entries      []PriceReportEntry
+}
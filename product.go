@@ -1,7 +1,10 @@
 package magento2
 
 import (
+	"context"
 	"fmt"
+	"net/url"
+	"slices"
 
 	"github.com/rs/zerolog/log"
 )
@@ -14,15 +17,25 @@ type MProduct struct {
 	Route     string
 	Product   *Product
 	APIClient *Client
+
+	// version of the remote product when it was last read, see Client.ConcurrencyCheck, and the store
+	// view it was read from, empty for the client's store
+	version          string
+	versionStoreCode string
 }
 
 func CreateOrReplaceProduct(product *Product, saveOptions bool, apiClient *Client) (*MProduct, error) {
+	return CreateOrReplaceProductContext(context.Background(), product, saveOptions, apiClient)
+}
+
+// CreateOrReplaceProductContext is CreateOrReplaceProduct with a context for the requests it sends
+func CreateOrReplaceProductContext(ctx context.Context, product *Product, saveOptions bool, apiClient *Client) (*MProduct, error) {
 	mp := &MProduct{
 		Product:   product,
 		APIClient: apiClient,
 	}
 
-	err := mp.createOrReplaceProduct(saveOptions)
+	err := mp.createOrReplaceProduct(ctx, saveOptions)
 	if err != nil {
 		return mp, fmt.Errorf("error creating or replacing product: %w", err)
 	}
@@ -30,14 +43,16 @@ func CreateOrReplaceProduct(product *Product, saveOptions bool, apiClient *Clien
 	return mp, nil
 }
 
-func GetProductBySKU(sku string, apiClient *Client) (*MProduct, error) {
+// GetProductBySKU fetches the product. Pass WithStoreCode to get the values of a store view instead of
+// the client's StoreCode
+func GetProductBySKU(sku string, apiClient *Client, opts ...RequestOption) (*MProduct, error) {
 	mProduct := &MProduct{
 		Route:     products + "/" + sku,
 		Product:   &Product{},
 		APIClient: apiClient,
 	}
 
-	err := mProduct.UpdateProductFromRemote()
+	err := mProduct.UpdateProductFromRemote(opts...)
 	if err != nil {
 		return mProduct, fmt.Errorf("error updating product from remote when getting by SKU: %w", err)
 	}
@@ -45,14 +60,37 @@ func GetProductBySKU(sku string, apiClient *Client) (*MProduct, error) {
 	return mProduct, nil
 }
 
-func (mProduct *MProduct) createOrReplaceProduct(saveOptions bool) error {
+func (mProduct *MProduct) createOrReplaceProduct(ctx context.Context, saveOptions bool) error {
 	endpoint := products
 	httpClient := mProduct.APIClient.HTTPClient
 
+	if mProduct.APIClient.ValidatePayloads {
+		if err := mProduct.Product.Validate(); err != nil {
+			return err
+		}
+	}
+	if mProduct.APIClient.contentValidator != nil {
+		if err := mProduct.APIClient.contentValidator.Validate(mProduct.Product); err != nil {
+			return err
+		}
+	}
+
+	if err := mProduct.checkNotModified(ctx); err != nil {
+		return err
+	}
 	payLoad := AddProductPayload{
 		Product:     *mProduct.Product,
 		SaveOptions: saveOptions,
 	}
+	if runID := mProduct.APIClient.runID; runID != "" {
+		exists, err := productExists(ctx, mProduct.Product.Sku, mProduct.APIClient)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			payLoad.Product = mProduct.Product.stampRunID(runID)
+		}
+	}
 
 	log.Debug().
 		Str("sku", mProduct.Product.Sku).
@@ -61,7 +99,7 @@ func (mProduct *MProduct) createOrReplaceProduct(saveOptions bool) error {
 		Interface("payload", payLoad).
 		Msg("Creating or replacing product")
 
-	resp, err := httpClient.R().SetBody(payLoad).SetResult(mProduct.Product).Post(endpoint)
+	resp, err := httpClient.R().SetContext(ctx).SetBody(payLoad).SetResult(mProduct.Product).Post(endpoint)
 	productSKU := mayTrimSurroundingQuotes(mProduct.Product.Sku)
 	mProduct.Route = products + "/" + productSKU
 
@@ -79,17 +117,23 @@ func (mProduct *MProduct) createOrReplaceProduct(saveOptions bool) error {
 	if httpErr != nil {
 		return httpErr
 	}
-	return nil
+	return mProduct.rememberVersion(ctx, "", resp.Body())
 }
 
-func (mProduct *MProduct) UpdateProductFromRemote() error {
+// UpdateProductFromRemote replaces the local product with the remote one, read from the store view
+// given with WithStoreCode if any
+func (mProduct *MProduct) UpdateProductFromRemote(opts ...RequestOption) error {
 	httpClient := mProduct.APIClient.HTTPClient
+	o := newRequestOptions(opts)
+	endpoint := o.endpoint(mProduct.APIClient, mProduct.Route)
 
 	log.Debug().
 		Str("route", mProduct.Route).
+		Str("storeCode", o.storeCode).
+		Str("endpoint", endpoint).
 		Msg("Updating product details from remote")
 
-	resp, err := httpClient.R().SetResult(mProduct.Product).Get(mProduct.Route)
+	resp, err := httpClient.R().SetResult(mProduct.Product).Get(endpoint)
 
 	if err != nil {
 		log.Error().Err(err).Msg("Error updating product details from remote")
@@ -105,24 +149,38 @@ func (mProduct *MProduct) UpdateProductFromRemote() error {
 	if httpErr != nil {
 		return httpErr
 	}
+	mProduct.version = productVersion(mProduct.APIClient.ConcurrencyCheck, mProduct.Product)
+	mProduct.versionStoreCode = o.storeCode
 	return nil
 }
 
 func (mProduct *MProduct) UpdateQuantityForStockItem(stockItem string, quantity int, isInStock bool) error {
+	return mProduct.UpdateQuantityForStockItemContext(context.Background(), stockItem, quantity, isInStock)
+}
+
+// UpdateQuantityForStockItemContext is UpdateQuantityForStockItem with a context for the request
+func (mProduct *MProduct) UpdateQuantityForStockItemContext(ctx context.Context, stockItem string, quantity int, isInStock bool) error {
+	return mProduct.UpdateDecimalQuantityForStockItem(ctx, stockItem, NewDecimal(int64(quantity), 0), isInStock)
+}
+
+// UpdateDecimalQuantityForStockItem sets a fractional quantity, e.g. for products sold by kg or meter
+func (mProduct *MProduct) UpdateDecimalQuantityForStockItem(ctx context.Context, stockItem string, quantity Decimal, isInStock bool) error {
 	httpClient := mProduct.APIClient.HTTPClient
+	endpoint := mProduct.Route + "/" + stockItemsRelative + "/" + stockItem
 
-	updateStockPayload := updateStockPayload{StockItem: StockItem{Qty: quantity, IsInStock: isInStock}}
+	updateStockPayload := updateStockPayload{StockItem: StockItem{IsInStock: isInStock}}
+	updateStockPayload.StockItem.SetDecimalQty(quantity)
 
 	log.Debug().
 		Str("stockItem", stockItem).
 		Str("sku", mProduct.Product.Sku).
-		Int("quantity", quantity).
+		Stringer("quantity", quantity).
 		Bool("isInStock", isInStock).
-		Str("endpoint", mProduct.Route+"/"+stockItemsRelative+"/"+stockItem).
+		Str("endpoint", endpoint).
 		Interface("payload", updateStockPayload).
 		Msg("Updating quantity for stock item of product")
 
-	resp, err := httpClient.R().SetBody(updateStockPayload).Put(mProduct.Route + "/" + stockItemsRelative + "/" + stockItem)
+	resp, err := httpClient.R().SetContext(ctx).SetBody(updateStockPayload).Put(endpoint)
 
 	if err != nil {
 		log.Error().Err(err).Msg("Error updating stock for product")
@@ -140,3 +198,169 @@ func (mProduct *MProduct) UpdateQuantityForStockItem(stockItem string, quantity
 	}
 	return nil
 }
+
+func (mProduct *MProduct) AssignWebsite(ctx context.Context, websiteID int) error {
+	endpoint := mProduct.Route + "/" + productsWebsitesRelative
+	httpClient := mProduct.APIClient.HTTPClient
+
+	payLoad := productWebsiteLinkPayload{
+		ProductWebsiteLink: ProductWebsiteLink{
+			Sku:       mProduct.Product.Sku,
+			WebsiteID: websiteID,
+		},
+	}
+
+	log.Debug().
+		Str("sku", mProduct.Product.Sku).
+		Int("websiteID", websiteID).
+		Str("endpoint", endpoint).
+		Interface("payload", payLoad).
+		Msg("Assigning product to website")
+
+	resp, err := httpClient.R().SetContext(ctx).SetBody(payLoad).Post(endpoint)
+	if err != nil {
+		log.Error().Err(err).Msg("Error assigning product to website")
+		return fmt.Errorf("error assigning product to website: %w", err)
+	}
+
+	httpErr := mayReturnErrorForHTTPResponse(resp, "assign product to website")
+	if httpErr != nil {
+		return httpErr
+	}
+
+	websiteIDs := mProduct.Product.WebsiteIDs()
+	if !slices.Contains(websiteIDs, websiteID) {
+		mProduct.Product.SetWebsiteIDs(append(websiteIDs, websiteID))
+	}
+	return nil
+}
+
+func (mProduct *MProduct) RemoveWebsite(ctx context.Context, websiteID int) error {
+	endpoint := fmt.Sprintf("%s/%s/%d", mProduct.Route, productsWebsitesRelative, websiteID)
+	httpClient := mProduct.APIClient.HTTPClient
+
+	log.Debug().
+		Str("sku", mProduct.Product.Sku).
+		Int("websiteID", websiteID).
+		Str("endpoint", endpoint).
+		Msg("Removing product from website")
+
+	resp, err := httpClient.R().SetContext(ctx).Delete(endpoint)
+	if err != nil {
+		log.Error().Err(err).Msg("Error removing product from website")
+		return fmt.Errorf("error removing product from website: %w", err)
+	}
+
+	httpErr := mayReturnErrorForHTTPResponse(resp, "remove product from website")
+	if httpErr != nil {
+		return httpErr
+	}
+
+	websiteIDs := slices.DeleteFunc(mProduct.Product.WebsiteIDs(), func(id int) bool {
+		return id == websiteID
+	})
+	mProduct.Product.SetWebsiteIDs(websiteIDs)
+	return nil
+}
+
+// UpdateForStore saves the local product against the given store view instead of the client's store.
+// Every attribute sent is stored as a store-view value, so keep the product limited to the fields being localized
+func (mProduct *MProduct) UpdateForStore(ctx context.Context, storeCode string) error {
+	httpClient := mProduct.APIClient.HTTPClient
+	endpoint := newRequestOptions([]RequestOption{WithStoreCode(storeCode)}).endpoint(mProduct.APIClient, mProduct.Route)
+
+	if err := mProduct.checkNotModified(ctx); err != nil {
+		return err
+	}
+
+	payLoad := updateProductPayload{
+		Product: *mProduct.Product,
+	}
+
+	log.Debug().
+		Str("sku", mProduct.Product.Sku).
+		Str("storeCode", storeCode).
+		Str("endpoint", endpoint).
+		Interface("payload", payLoad).
+		Msg("Updating product for store view")
+
+	resp, err := httpClient.R().SetContext(ctx).SetBody(payLoad).Put(endpoint)
+	if err != nil {
+		log.Error().Err(err).Msg("Error updating product for store view")
+		return fmt.Errorf("error updating product for store view: %w", err)
+	}
+
+	log.Debug().
+		Int("status", resp.StatusCode()).
+		Str("body", resp.String()).
+		Msg("Product store view update response from remote")
+
+	httpErr := mayReturnErrorForHTTPResponse(resp, fmt.Sprintf("update product for store view '%s'", storeCode))
+	if httpErr != nil {
+		return httpErr
+	}
+	return mProduct.rememberVersion(ctx, storeCode, resp.Body())
+}
+
+// SetCategories replaces the categories the product is assigned to. Only the category links are sent,
+// so other product fields are left untouched
+func (mProduct *MProduct) SetCategories(ctx context.Context, categoryIDs []int) error {
+	httpClient := mProduct.APIClient.HTTPClient
+	if err := mProduct.checkNotModified(ctx); err != nil {
+		return err
+	}
+	mProduct.Product.SetCategoryIDs(categoryIDs)
+
+	payLoad := partialProductPayload{
+		Product: map[string]any{
+			"sku": mProduct.Product.Sku,
+			"extension_attributes": map[string]any{
+				extensionAttributeCategoryLinks: mProduct.Product.ExtensionAttributes[extensionAttributeCategoryLinks],
+			},
+		},
+	}
+
+	log.Debug().
+		Str("sku", mProduct.Product.Sku).
+		Ints("categoryIDs", categoryIDs).
+		Str("endpoint", mProduct.Route).
+		Interface("payload", payLoad).
+		Msg("Setting product categories")
+
+	resp, err := httpClient.R().SetContext(ctx).SetBody(payLoad).Put(mProduct.Route)
+	if err != nil {
+		log.Error().Err(err).Msg("Error setting product categories")
+		return fmt.Errorf("error setting product categories: %w", err)
+	}
+
+	httpErr := mayReturnErrorForHTTPResponse(resp, "set categories for product")
+	if httpErr != nil {
+		return httpErr
+	}
+	return mProduct.rememberVersion(ctx, "", resp.Body())
+}
+
+// SearchProducts returns all products matching the criteria, paging through the results
+func SearchProducts(ctx context.Context, criteria *SearchCriteria, apiClient *Client) ([]Product, error) {
+	return searchAll[Product](ctx, products, criteria, "search products", apiClient)
+}
+
+// ForEachProductPage fetches the products matching the criteria with up to workers pages in flight
+// and hands the pages to fn in order, so large catalogs can be streamed without holding them in memory
+func ForEachProductPage(ctx context.Context, criteria *SearchCriteria, workers int, apiClient *Client, fn func(products []Product) error) error {
+	return searchPagesParallel(ctx, products, criteria, workers, "search product pages", apiClient, fn)
+}
+
+// DeleteProduct deletes the product from all store views
+func DeleteProduct(ctx context.Context, sku string, apiClient *Client) error {
+	endpoint := products + "/" + url.PathEscape(sku)
+
+	log.Debug().Str("sku", sku).Str("endpoint", endpoint).Msg("Deleting product")
+
+	resp, err := apiClient.HTTPClient.R().SetContext(ctx).Delete(endpoint)
+	if err != nil {
+		return fmt.Errorf("error deleting product: %w", err)
+	}
+
+	return mayReturnErrorForHTTPResponse(resp, "delete product")
+}
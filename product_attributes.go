@@ -0,0 +1,81 @@
+package magento2
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const extensionAttributeStockItem = "stock_item"
+
+// CustomAttribute returns the value of a custom attribute, e.g. "special_price" or "url_key"
+func (p *Product) CustomAttribute(attributeCode string) (any, bool) {
+	for _, attribute := range p.CustomAttributes {
+		if attribute["attribute_code"] == attributeCode {
+			value, ok := attribute["value"]
+			return value, ok
+		}
+	}
+	return nil, false
+}
+
+// CustomAttributeString returns the value of a custom attribute as string, multiselect values are
+// joined with a comma. Missing attributes yield ""
+func (p *Product) CustomAttributeString(attributeCode string) string {
+	value, ok := p.CustomAttribute(attributeCode)
+	if !ok || value == nil {
+		return ""
+	}
+	switch v := value.(type) {
+	case string:
+		return v
+	case []any:
+		values := make([]string, 0, len(v))
+		for _, item := range v {
+			values = append(values, fmt.Sprint(item))
+		}
+		return strings.Join(values, ",")
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	}
+	return fmt.Sprint(value)
+}
+
+// StockItem decodes the stock_item extension attribute, which product reads and searches include
+func (p *Product) StockItem() (*StockItem, bool) {
+	raw, ok := p.ExtensionAttributes[extensionAttributeStockItem]
+	if !ok || raw == nil {
+		return nil, false
+	}
+	data, err := json.Marshal(raw)
+	if err != nil {
+		return nil, false
+	}
+	stockItem := &StockItem{}
+	if err := json.Unmarshal(data, stockItem); err != nil {
+		return nil, false
+	}
+	return stockItem, true
+}
+
+// ActiveSpecialPrice returns the special price when it is set and now lies within its from/to dates
+func (p *Product) ActiveSpecialPrice(now time.Time) (float64, bool) {
+	raw := p.CustomAttributeString("special_price")
+	if raw == "" {
+		return 0, false
+	}
+	specialPrice, err := strconv.ParseFloat(raw, 64)
+	if err != nil {
+		return 0, false
+	}
+	special := &SpecialPrice{
+		PriceFrom: p.CustomAttributeString("special_from_date"),
+		PriceTo:   p.CustomAttributeString("special_to_date"),
+	}
+	if !isSpecialPriceActive(special, now) {
+		return 0, false
+	}
+	return specialPrice, true
+}
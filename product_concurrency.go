@@ -0,0 +1,98 @@
+package magento2
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+
+	"github.com/rs/zerolog/log"
+)
+
+type ConcurrencyCheckMode int
+
+const (
+	ConcurrencyCheckOff ConcurrencyCheckMode = iota
+	// ConcurrencyCheckUpdatedAt compares updated_at, which has a resolution of one second
+	ConcurrencyCheckUpdatedAt
+	// ConcurrencyCheckHash compares a hash of the whole remote product, catching changes within the same second
+	ConcurrencyCheckHash
+)
+
+func productVersion(mode ConcurrencyCheckMode, product *Product) string {
+	switch mode {
+	case ConcurrencyCheckUpdatedAt:
+		return product.UpdatedAt
+	case ConcurrencyCheckHash:
+		data, err := json.Marshal(product)
+		if err != nil {
+			return ""
+		}
+		sum := sha256.Sum256(data)
+		return hex.EncodeToString(sum[:])
+	}
+	return ""
+}
+
+// readRemoteVersion reads the version from the store view the local product was read from, in hash
+// mode the values of other store views would not match
+func (mProduct *MProduct) readRemoteVersion(ctx context.Context) (string, error) {
+	endpoint := newRequestOptions([]RequestOption{WithStoreCode(mProduct.versionStoreCode)}).endpoint(mProduct.APIClient, mProduct.Route)
+	remote := &Product{}
+	resp, err := mProduct.APIClient.HTTPClient.R().SetContext(ctx).SetResult(remote).Get(endpoint)
+	if err != nil {
+		return "", fmt.Errorf("error reading product for concurrency check: %w", err)
+	}
+	httpErr := mayReturnErrorForHTTPResponse(resp, "read product for concurrency check")
+	if httpErr != nil {
+		return "", httpErr
+	}
+	return productVersion(mProduct.APIClient.ConcurrencyCheck, remote), nil
+}
+
+// checkNotModified fails with ErrConflict when the remote product changed since this MProduct read it.
+// Products that were never read (e.g. new ones) are not checked. The check and the following write are
+// two requests, so a write landing in between is not detected
+func (mProduct *MProduct) checkNotModified(ctx context.Context) error {
+	if mProduct.APIClient.ConcurrencyCheck == ConcurrencyCheckOff || mProduct.version == "" {
+		return nil
+	}
+	remoteVersion, err := mProduct.readRemoteVersion(ctx)
+	if err != nil {
+		return err
+	}
+	if remoteVersion != mProduct.version {
+		log.Warn().
+			Str("sku", mProduct.Product.Sku).
+			Str("readVersion", mProduct.version).
+			Str("remoteVersion", remoteVersion).
+			Msg("Product was modified remotely since it was read")
+		return fmt.Errorf("%w: product '%s'", ErrConflict, mProduct.Product.Sku)
+	}
+	return nil
+}
+
+// rememberVersion takes the version from the product a successful write to storeCode returned, so the
+// next write of this MProduct is checked against our own change. Without a product in the response, or
+// in hash mode when the write went to another store view than the version was read from, the version
+// is read again
+func (mProduct *MProduct) rememberVersion(ctx context.Context, storeCode string, response []byte) error {
+	if mProduct.APIClient.ConcurrencyCheck == ConcurrencyCheckOff {
+		return nil
+	}
+	if mProduct.APIClient.ConcurrencyCheck == ConcurrencyCheckHash && storeCode != mProduct.versionStoreCode {
+		response = nil
+	}
+	written := &Product{}
+	if err := json.Unmarshal(response, written); err == nil && written.Sku != "" {
+		mProduct.version = productVersion(mProduct.APIClient.ConcurrencyCheck, written)
+		return nil
+	}
+	version, err := mProduct.readRemoteVersion(ctx)
+	if err != nil {
+		return err
+	}
+	mProduct.version = version
+	return nil
+}
@@ -0,0 +1,61 @@
+package magento2
+
+// NewSimpleProduct returns an enabled simple product visible in catalog and search
+func NewSimpleProduct(sku, name string, attributeSetID int, price, weight float64) *Product {
+	return &Product{
+		Sku:            sku,
+		Name:           name,
+		AttributeSetID: attributeSetID,
+		Price:          price,
+		Weight:         weight,
+		TypeID:         ProductTypeSimple,
+		Status:         ProductStatusEnabled,
+		Visibility:     VisibilityCatalogSearch,
+	}
+}
+
+// NewVirtualProduct returns an enabled virtual product. Virtual products have no weight,
+// so none is sent to Magento
+func NewVirtualProduct(sku, name string, attributeSetID int, price float64) *Product {
+	return &Product{
+		Sku:            sku,
+		Name:           name,
+		AttributeSetID: attributeSetID,
+		Price:          price,
+		TypeID:         ProductTypeVirtual,
+		Status:         ProductStatusEnabled,
+		Visibility:     VisibilityCatalogSearch,
+	}
+}
+
+// NewConfigurableProduct returns an enabled configurable parent product.
+// Price and weight are taken from the children, so neither is set here
+func NewConfigurableProduct(sku, name string, attributeSetID int) *Product {
+	return &Product{
+		Sku:            sku,
+		Name:           name,
+		AttributeSetID: attributeSetID,
+		TypeID:         ProductTypeConfigurable,
+		Status:         ProductStatusEnabled,
+		Visibility:     VisibilityCatalogSearch,
+	}
+}
+
+// NewBundleProduct returns an enabled bundle product using dynamic price, sku and weight,
+// which is what Magento expects when no fixed values are supplied
+func NewBundleProduct(sku, name string, attributeSetID int) *Product {
+	return &Product{
+		Sku:            sku,
+		Name:           name,
+		AttributeSetID: attributeSetID,
+		TypeID:         ProductTypeBundle,
+		Status:         ProductStatusEnabled,
+		Visibility:     VisibilityCatalogSearch,
+		CustomAttributes: []map[string]any{
+			{"attribute_code": "price_type", "value": "0"},
+			{"attribute_code": "sku_type", "value": "0"},
+			{"attribute_code": "weight_type", "value": "0"},
+			{"attribute_code": "price_view", "value": "0"},
+		},
+	}
+}
@@ -0,0 +1,120 @@
+package magento2
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/rs/zerolog/log"
+)
+
+// DefaultFingerprintAttributes are the attributes FindDuplicateProducts compares when none are given.
+// manufacturer is the brand attribute of a default installation
+var DefaultFingerprintAttributes = []string{"name", "manufacturer"}
+
+// DuplicateCandidate is a product of a DuplicateGroup
+type DuplicateCandidate struct {
+	ID     int
+	Sku    string
+	Name   string
+	TypeID string
+}
+
+// DuplicateGroup holds products with the same fingerprint
+type DuplicateGroup struct {
+	Fingerprint string
+	Products    []DuplicateCandidate
+}
+
+// ProductFingerprint hashes the normalized values of the attributes: case-folded, without punctuation and
+// with single spaces, so "Acme  Mug, Blue" and "acme mug blue" match. "name" and "sku" are read from the
+// product, anything else from its custom attributes. Products without any of the values return ""
+func ProductFingerprint(product *Product, attributes []string) string {
+	values := make([]string, 0, len(attributes))
+	empty := true
+	for _, code := range attributes {
+		var value string
+		switch code {
+		case "name":
+			value = product.Name
+		case "sku":
+			value = product.Sku
+		default:
+			value = product.CustomAttributeString(code)
+		}
+		value = normalizeFingerprintValue(value)
+		if value != "" {
+			empty = false
+		}
+		values = append(values, value)
+	}
+	if empty {
+		return ""
+	}
+	sum := sha256.Sum256([]byte(strings.Join(values, "\x1f")))
+	return hex.EncodeToString(sum[:12])
+}
+
+func normalizeFingerprintValue(value string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(value) {
+		switch {
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			b.WriteRune(r)
+		case unicode.IsSpace(r) || unicode.IsPunct(r) || unicode.IsSymbol(r):
+			b.WriteByte(' ')
+		}
+	}
+	return strings.Join(strings.Fields(b.String()), " ")
+}
+
+// FindDuplicateProducts pages through the products matching the criteria and groups them by
+// ProductFingerprint of the attributes, DefaultFingerprintAttributes when nil. Only groups with more than
+// one product are returned, largest first. Children of configurables usually share name and brand, so
+// narrowing the criteria down, e.g. to visible products, avoids reporting them
+func FindDuplicateProducts(ctx context.Context, criteria *SearchCriteria, attributes []string, workers int, apiClient *Client) ([]DuplicateGroup, error) {
+	if len(attributes) == 0 {
+		attributes = DefaultFingerprintAttributes
+	}
+	groups := map[string][]DuplicateCandidate{}
+	scanned := 0
+
+	err := ForEachProductPage(ctx, criteria, workers, apiClient, func(page []Product) error {
+		for i := range page {
+			scanned++
+			fingerprint := ProductFingerprint(&page[i], attributes)
+			if fingerprint == "" {
+				continue
+			}
+			groups[fingerprint] = append(groups[fingerprint], DuplicateCandidate{
+				ID:     page[i].ID,
+				Sku:    page[i].Sku,
+				Name:   page[i].Name,
+				TypeID: page[i].TypeID,
+			})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	duplicates := []DuplicateGroup{}
+	for _, fingerprint := range sortedKeys(groups) {
+		if len(groups[fingerprint]) > 1 {
+			duplicates = append(duplicates, DuplicateGroup{Fingerprint: fingerprint, Products: groups[fingerprint]})
+		}
+	}
+	sort.SliceStable(duplicates, func(i, j int) bool {
+		return len(duplicates[i].Products) > len(duplicates[j].Products)
+	})
+
+	log.Debug().
+		Int("products", scanned).
+		Int("groups", len(duplicates)).
+		Msg("Duplicate product search finished")
+	return duplicates, nil
+}
@@ -0,0 +1,59 @@
+package magento2
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// ExportProductsUpdatedSince streams the products updated at or after since to fn, oldest change first,
+// one page at a time. Pages are read with a cursor on (updated_at, entity_id) instead of page numbers, so
+// products saved while the export runs neither shift other products out of a page nor get lost; they are
+// delivered again at the end. It returns the updated_at of the last product delivered, or since when there
+// were none, to pass as since to the next run. Products updated within that same second are delivered again
+func ExportProductsUpdatedSince(ctx context.Context, since time.Time, apiClient *Client, fn func(products []Product) error) (time.Time, error) {
+	checkpoint := since
+	cursorTime := since.UTC().Format(DateTimeFormat)
+	cursorID := 0
+
+	log.Debug().Str("since", cursorTime).Msg("Exporting products updated since")
+
+	for {
+		// (updated_at = cursor AND entity_id > last) OR updated_at > cursor
+		sc := NewSearchCriteria(SearchFilter{Field: "updated_at", Value: cursorTime, ConditionType: "gteq"}).
+			And(
+				SearchFilter{Field: "entity_id", Value: strconv.Itoa(cursorID), ConditionType: "gt"},
+				SearchFilter{Field: "updated_at", Value: cursorTime, ConditionType: "gt"},
+			)
+		sc.SortOrders = []SortOrder{{Field: "updated_at", Direction: SortAscending}, {Field: "entity_id", Direction: SortAscending}}
+		sc.PageSize = searchAllPageSize
+		sc.CurrentPage = 1
+
+		result, err := searchPage[Product](ctx, products, sc, "export products updated since", apiClient)
+		if err != nil {
+			return checkpoint, err
+		}
+		if len(result.Items) == 0 {
+			return checkpoint, nil
+		}
+		if err := fn(result.Items); err != nil {
+			return checkpoint, err
+		}
+
+		last := result.Items[len(result.Items)-1]
+		updatedAt, err := time.Parse(DateTimeFormat, last.UpdatedAt)
+		if err != nil {
+			return checkpoint, fmt.Errorf("error parsing updated_at '%s' of product '%s': %w", last.UpdatedAt, last.Sku, err)
+		}
+		checkpoint = updatedAt
+		cursorTime = last.UpdatedAt
+		cursorID = last.ID
+
+		if len(result.Items) < sc.PageSize {
+			return checkpoint, nil
+		}
+	}
+}
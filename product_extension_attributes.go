@@ -0,0 +1,149 @@
+package magento2
+
+import (
+	"encoding/json"
+	"strconv"
+)
+
+const (
+	extensionAttributeWebsiteIDs               = "website_ids"
+	extensionAttributeCategoryLinks            = "category_links"
+	extensionAttributeConfigurableProductLinks = "configurable_product_links"
+)
+
+const extensionAttributeBundleOptions = "bundle_product_options"
+
+const (
+	BundleOptionTypeSelect   = "select"
+	BundleOptionTypeRadio    = "radio"
+	BundleOptionTypeCheckbox = "checkbox"
+	BundleOptionTypeMulti    = "multi"
+)
+
+// BundleOption is an entry of the bundle_product_options extension attribute of a bundle product
+type BundleOption struct {
+	OptionID     int                 `json:"option_id,omitempty"`
+	Title        string              `json:"title"`
+	Required     bool                `json:"required"`
+	Type         string              `json:"type"`
+	Position     int                 `json:"position,omitempty"`
+	ProductLinks []BundleProductLink `json:"product_links"`
+}
+
+// BundleProductLink is a selection of a bundle option
+type BundleProductLink struct {
+	Sku          string  `json:"sku"`
+	Qty          float64 `json:"qty"`
+	IsDefault    bool    `json:"is_default"`
+	CanChangeQty int     `json:"can_change_quantity"`
+	Position     int     `json:"position,omitempty"`
+}
+
+// SetBundleOptions replaces the bundle_product_options extension attribute sent on the next save
+func (p *Product) SetBundleOptions(options []BundleOption) {
+	if p.ExtensionAttributes == nil {
+		p.ExtensionAttributes = map[string]any{}
+	}
+	p.ExtensionAttributes[extensionAttributeBundleOptions] = options
+}
+
+// WebsiteIDs returns the website_ids extension attribute, whichever shape it was decoded into
+func (p *Product) WebsiteIDs() []int {
+	raw, ok := p.ExtensionAttributes[extensionAttributeWebsiteIDs]
+	if !ok {
+		return []int{}
+	}
+
+	websiteIDs := []int{}
+	switch values := raw.(type) {
+	case []int:
+		websiteIDs = append(websiteIDs, values...)
+	case []any:
+		for _, v := range values {
+			if id, ok := anyToInt(v); ok {
+				websiteIDs = append(websiteIDs, id)
+			}
+		}
+	}
+	return websiteIDs
+}
+
+// SetWebsiteIDs replaces the website_ids extension attribute sent on the next save
+func (p *Product) SetWebsiteIDs(websiteIDs []int) {
+	if p.ExtensionAttributes == nil {
+		p.ExtensionAttributes = map[string]any{}
+	}
+	p.ExtensionAttributes[extensionAttributeWebsiteIDs] = websiteIDs
+}
+
+// CategoryLink is an entry of the category_links extension attribute
+type CategoryLink struct {
+	Position   int    `json:"position"`
+	CategoryID string `json:"category_id"`
+}
+
+// CategoryIDs returns the IDs of the category_links extension attribute
+func (p *Product) CategoryIDs() []int {
+	categoryIDs := []int{}
+	switch links := p.ExtensionAttributes[extensionAttributeCategoryLinks].(type) {
+	case []CategoryLink:
+		for _, link := range links {
+			if id, ok := anyToInt(link.CategoryID); ok {
+				categoryIDs = append(categoryIDs, id)
+			}
+		}
+	case []any:
+		for _, link := range links {
+			if m, ok := link.(map[string]any); ok {
+				if id, ok := anyToInt(m["category_id"]); ok {
+					categoryIDs = append(categoryIDs, id)
+				}
+			}
+		}
+	}
+	return categoryIDs
+}
+
+// SetCategoryIDs replaces the category_links extension attribute, using the slice order as position
+func (p *Product) SetCategoryIDs(categoryIDs []int) {
+	if p.ExtensionAttributes == nil {
+		p.ExtensionAttributes = map[string]any{}
+	}
+	links := make([]CategoryLink, 0, len(categoryIDs))
+	for i, id := range categoryIDs {
+		links = append(links, CategoryLink{Position: i, CategoryID: strconv.Itoa(id)})
+	}
+	p.ExtensionAttributes[extensionAttributeCategoryLinks] = links
+}
+
+// ConfigurableProductLinks returns the IDs of the children of a configurable product
+func (p *Product) ConfigurableProductLinks() []int {
+	childIDs := []int{}
+	switch links := p.ExtensionAttributes[extensionAttributeConfigurableProductLinks].(type) {
+	case []int:
+		childIDs = append(childIDs, links...)
+	case []any:
+		for _, link := range links {
+			if id, ok := anyToInt(link); ok {
+				childIDs = append(childIDs, id)
+			}
+		}
+	}
+	return childIDs
+}
+
+func anyToInt(v any) (int, bool) {
+	switch n := v.(type) {
+	case int:
+		return n, true
+	case float64:
+		return int(n), true
+	case json.Number:
+		i, err := n.Int64()
+		return int(i), err == nil
+	case string:
+		i, err := strconv.Atoi(n)
+		return i, err == nil
+	}
+	return 0, false
+}
@@ -0,0 +1,190 @@
+package magento2
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"sort"
+
+	"github.com/rs/zerolog/log"
+)
+
+// SetGroupedChildren links the given simple products to a grouped product using "associated" product links
+func (mProduct *MProduct) SetGroupedChildren(ctx context.Context, children []GroupedChild) error {
+	links := make([]ProductLinks, 0, len(children))
+	for _, child := range children {
+		links = append(links, ProductLinks{
+			Sku:               mProduct.Product.Sku,
+			LinkType:          LinkTypeAssociated,
+			LinkedProductSku:  child.Sku,
+			LinkedProductType: ProductTypeSimple,
+			Position:          child.Position,
+			ExtensionAttributes: map[string]any{
+				"qty": child.Qty,
+			},
+		})
+	}
+
+	return mProduct.SetProductLinks(ctx, links)
+}
+
+// SetProductLinks saves the given links on the product, keeping links of other types untouched
+func (mProduct *MProduct) SetProductLinks(ctx context.Context, links []ProductLinks) error {
+	endpoint := mProduct.Route + "/" + productsLinksRelative
+	httpClient := mProduct.APIClient.HTTPClient
+
+	payLoad := productLinksPayload{
+		Items: links,
+	}
+
+	log.Debug().
+		Str("sku", mProduct.Product.Sku).
+		Int("links", len(links)).
+		Str("endpoint", endpoint).
+		Interface("payload", payLoad).
+		Msg("Setting product links")
+
+	resp, err := httpClient.R().SetContext(ctx).SetBody(payLoad).Post(endpoint)
+	if err != nil {
+		log.Error().Err(err).Msg("Error setting product links")
+		return fmt.Errorf("error setting product links: %w", err)
+	}
+
+	httpErr := mayReturnErrorForHTTPResponse(resp, "set product links")
+	if httpErr != nil {
+		return httpErr
+	}
+
+	mProduct.Product.ProductLinks = mergeProductLinks(mProduct.Product.ProductLinks, links)
+	return nil
+}
+
+// mergeProductLinks replaces existing links to the same linked SKU and type, and appends the others
+func mergeProductLinks(existing, links []ProductLinks) []ProductLinks {
+	merged := append([]ProductLinks{}, existing...)
+	for _, link := range links {
+		replaced := false
+		for i := range merged {
+			if merged[i].LinkType == link.LinkType && merged[i].LinkedProductSku == link.LinkedProductSku {
+				merged[i] = link
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			merged = append(merged, link)
+		}
+	}
+	return merged
+}
+
+// GetProductLinks reads the product's links of the given type from remote, sorted by position
+func (mProduct *MProduct) GetProductLinks(ctx context.Context, linkType string) ([]ProductLinks, error) {
+	endpoint := mProduct.Route + "/" + productsLinksRelative + "/" + linkType
+	links := &[]ProductLinks{}
+
+	log.Debug().
+		Str("sku", mProduct.Product.Sku).
+		Str("linkType", linkType).
+		Str("endpoint", endpoint).
+		Msg("Getting product links")
+
+	resp, err := mProduct.APIClient.HTTPClient.R().SetContext(ctx).SetResult(links).Get(endpoint)
+	if err != nil {
+		log.Error().Err(err).Msg("Error getting product links")
+		return nil, fmt.Errorf("error getting product links: %w", err)
+	}
+
+	httpErr := mayReturnErrorForHTTPResponse(resp, "get product links")
+	if httpErr != nil {
+		return nil, httpErr
+	}
+
+	SortProductLinksByPosition(*links)
+	return *links, nil
+}
+
+// SetLinkedProducts replaces the links of one type with the given SKUs, using the slice order as position.
+// Links of the type to other SKUs are deleted, an empty list removes all links of the type
+func (mProduct *MProduct) SetLinkedProducts(ctx context.Context, linkType string, linkedSkus []string) error {
+	current, err := mProduct.GetProductLinks(ctx, linkType)
+	if err != nil {
+		return err
+	}
+	wanted := make(map[string]bool, len(linkedSkus))
+	for _, linkedSku := range linkedSkus {
+		wanted[linkedSku] = true
+	}
+	for _, link := range current {
+		if !wanted[link.LinkedProductSku] {
+			if err := mProduct.DeleteProductLink(ctx, linkType, link.LinkedProductSku); err != nil {
+				return err
+			}
+		}
+	}
+	if len(linkedSkus) == 0 {
+		return nil
+	}
+
+	links := make([]ProductLinks, 0, len(linkedSkus))
+	for i, linkedSku := range linkedSkus {
+		links = append(links, ProductLinks{
+			Sku:              mProduct.Product.Sku,
+			LinkType:         linkType,
+			LinkedProductSku: linkedSku,
+			Position:         i + 1,
+		})
+	}
+	return mProduct.SetProductLinks(ctx, links)
+}
+
+// DeleteProductLink removes the product's link of the given type to linkedSku
+func (mProduct *MProduct) DeleteProductLink(ctx context.Context, linkType, linkedSku string) error {
+	endpoint := mProduct.Route + "/" + productsLinksRelative + "/" + linkType + "/" + url.PathEscape(linkedSku)
+
+	log.Debug().
+		Str("sku", mProduct.Product.Sku).
+		Str("linkType", linkType).
+		Str("linkedSku", linkedSku).
+		Str("endpoint", endpoint).
+		Msg("Deleting product link")
+
+	resp, err := mProduct.APIClient.HTTPClient.R().SetContext(ctx).Delete(endpoint)
+	if err != nil {
+		log.Error().Err(err).Msg("Error deleting product link")
+		return fmt.Errorf("error deleting product link: %w", err)
+	}
+
+	httpErr := mayReturnErrorForHTTPResponse(resp, "delete product link")
+	if httpErr != nil {
+		return httpErr
+	}
+
+	links := []ProductLinks{}
+	for _, link := range mProduct.Product.ProductLinks {
+		if link.LinkType != linkType || link.LinkedProductSku != linkedSku {
+			links = append(links, link)
+		}
+	}
+	mProduct.Product.ProductLinks = links
+	return nil
+}
+
+// LinksByType returns the locally known links of the given type sorted by position
+func (p *Product) LinksByType(linkType string) []ProductLinks {
+	links := []ProductLinks{}
+	for _, link := range p.ProductLinks {
+		if link.LinkType == linkType {
+			links = append(links, link)
+		}
+	}
+	SortProductLinksByPosition(links)
+	return links
+}
+
+// SortProductLinksByPosition orders links by position, keeping the original order for equal positions
+func SortProductLinksByPosition(links []ProductLinks) {
+	sort.SliceStable(links, func(i, j int) bool {
+		return links[i].Position < links[j].Position
+	})
+}
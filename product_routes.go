@@ -1,5 +1,7 @@
 package magento2
 
 const (
-	stockItemsRelative = "stockItems"
+	stockItemsRelative       = "stockItems"
+	productsWebsitesRelative = "websites"
+	productsLinksRelative    = "links"
 )
@@ -1,10 +1,40 @@
 package magento2
 
+const (
+	ProductTypeSimple       = "simple"
+	ProductTypeVirtual      = "virtual"
+	ProductTypeConfigurable = "configurable"
+	ProductTypeBundle       = "bundle"
+	ProductTypeGrouped      = "grouped"
+	ProductTypeDownloadable = "downloadable"
+)
+
+const (
+	ProductStatusEnabled  = 1
+	ProductStatusDisabled = 2
+)
+
+const (
+	VisibilityNotVisible    = 1
+	VisibilityCatalog       = 2
+	VisibilitySearch        = 3
+	VisibilityCatalogSearch = 4
+)
+
 type AddProductPayload struct {
 	Product     Product `json:"product"`
 	SaveOptions bool    `json:"saveOptions"`
 }
 
+type updateProductPayload struct {
+	Product Product `json:"product"`
+}
+
+// partialProductPayload sends only the given fields, leaving everything else on the product untouched
+type partialProductPayload struct {
+	Product map[string]any `json:"product"`
+}
+
 type MediaGalleryEntries struct {
 	ID                  int                    `json:"id"`
 	MediaType           string                 `json:"media_type"`
@@ -33,6 +63,24 @@ type ProductLinks struct {
 	ExtensionAttributes map[string]any `json:"extension_attributes"`
 }
 
+const (
+	LinkTypeRelated    = "related"
+	LinkTypeUpSell     = "upsell"
+	LinkTypeCrossSell  = "crosssell"
+	LinkTypeAssociated = "associated"
+)
+
+// GroupedChild is a simple product shown on a grouped product, with its default quantity
+type GroupedChild struct {
+	Sku      string
+	Qty      float64
+	Position int
+}
+
+type productLinksPayload struct {
+	Items []ProductLinks `json:"items"`
+}
+
 type Product struct {
 	ID                  int                      `json:"id,omitempty"`
 	Sku                 string                   `json:"sku"`
@@ -123,8 +171,25 @@ type StockItem struct {
 	IsDecimalDivided               bool                   `json:"is_decimal_divided,omitempty"`
 	StockStatusChangedAuto         int                    `json:"stock_status_changed_auto,omitempty"`
 	ExtensionAttributes            map[string]any `json:"extension_attributes,omitempty"`
+
+	// exact values of the quantity fields, which truncate fractional quantities
+	quantities stockItemQuantities
+}
+
+type ProductWebsiteLink struct {
+	Sku       string `json:"sku"`
+	WebsiteID int    `json:"website_id"`
+}
+
+type productWebsiteLinkPayload struct {
+	ProductWebsiteLink ProductWebsiteLink `json:"productWebsiteLink"`
 }
 
 type updateStockPayload struct {
 	StockItem StockItem `json:"stockItem"`
 }
+
+type productSearchQueryResponse struct {
+	Products   []Product `json:"items"`
+	TotalCount int       `json:"total_count"`
+}
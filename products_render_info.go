@@ -0,0 +1,40 @@
+package magento2
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+)
+
+// GetProductsRenderInfo returns the storefront rendering data (final prices with catalog rules applied,
+// formatted prices, buttons and urls) of the given SKUs for a store and currency
+func GetProductsRenderInfo(ctx context.Context, skus []string, storeID int, currencyCode string, apiClient *Client) ([]ProductRenderInfo, error) {
+	params := NewSearchCriteria(SearchFilter{Field: "sku", Value: strings.Join(skus, ","), ConditionType: "in"}).values()
+	params.Add("storeId", strconv.Itoa(storeID))
+	params.Add("currencyCode", currencyCode)
+	endpoint := productsRenderInfo + "?" + params.Encode()
+
+	response := &productsRenderInfoResponse{}
+
+	log.Debug().
+		Strs("skus", skus).
+		Int("storeID", storeID).
+		Str("currencyCode", currencyCode).
+		Str("endpoint", endpoint).
+		Msg("Getting products render info")
+
+	resp, err := apiClient.HTTPClient.R().SetContext(ctx).SetResult(response).Get(endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("error getting products render info: %w", err)
+	}
+
+	httpErr := mayReturnErrorForHTTPResponse(resp, "get products render info from remote")
+	if httpErr != nil {
+		return nil, httpErr
+	}
+
+	return response.Items, nil
+}
@@ -0,0 +1,59 @@
+package magento2
+
+type FormattedPrices struct {
+	FinalPrice          string `json:"final_price"`
+	MaxPrice            string `json:"max_price"`
+	MinimalPrice        string `json:"minimal_price"`
+	MaxRegularPrice     string `json:"max_regular_price"`
+	MinimalRegularPrice string `json:"minimal_regular_price"`
+	SpecialPrice        string `json:"special_price"`
+	RegularPrice        string `json:"regular_price"`
+}
+
+type PriceInfo struct {
+	FinalPrice          float64         `json:"final_price"`
+	MaxPrice            float64         `json:"max_price"`
+	MaxRegularPrice     float64         `json:"max_regular_price"`
+	MinimalRegularPrice float64         `json:"minimal_regular_price"`
+	SpecialPrice        float64         `json:"special_price"`
+	MinimalPrice        float64         `json:"minimal_price"`
+	RegularPrice        float64         `json:"regular_price"`
+	FormattedPrices     FormattedPrices `json:"formatted_prices"`
+	ExtensionAttributes map[string]any  `json:"extension_attributes,omitempty"`
+}
+
+type RenderButton struct {
+	PostData        string `json:"post_data"`
+	URL             string `json:"url"`
+	RequiredOptions bool   `json:"required_options"`
+}
+
+type RenderImage struct {
+	URL           string  `json:"url"`
+	Code          string  `json:"code"`
+	Height        float64 `json:"height"`
+	Width         float64 `json:"width"`
+	Label         string  `json:"label"`
+	ResizedWidth  float64 `json:"resized_width"`
+	ResizedHeight float64 `json:"resized_height"`
+}
+
+type ProductRenderInfo struct {
+	ID                  int            `json:"id"`
+	Name                string         `json:"name"`
+	Type                string         `json:"type"`
+	URL                 string         `json:"url"`
+	IsSalable           string         `json:"is_salable"`
+	StoreID             int            `json:"store_id"`
+	CurrencyCode        string         `json:"currency_code"`
+	PriceInfo           PriceInfo      `json:"price_info"`
+	AddToCartButton     RenderButton   `json:"add_to_cart_button"`
+	AddToCompareButton  RenderButton   `json:"add_to_compare_button"`
+	Images              []RenderImage  `json:"images"`
+	ExtensionAttributes map[string]any `json:"extension_attributes,omitempty"`
+}
+
+type productsRenderInfoResponse struct {
+	Items      []ProductRenderInfo `json:"items"`
+	TotalCount int                 `json:"total_count"`
+}
@@ -0,0 +1,132 @@
+package magento2
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/rs/zerolog/log"
+)
+
+// ReauthenticationHook is called after a credential client logged in again because its token was
+// rejected, e.g. to refresh the session state of a UI
+type ReauthenticationHook func(ctx context.Context, authenticationType AuthenticationType)
+
+// WithReauthenticationHook sets the hook called when a client created with WithAdminCredentials or
+// WithCustomerCredentials renews its expired token
+func WithReauthenticationHook(hook ReauthenticationHook) ClientOption {
+	return func(o *clientOptions) error {
+		o.reauthenticationHook = hook
+		return nil
+	}
+}
+
+// reauthenticator renews the token of a credential client. A request answered with 401 logs in
+// again once and is replayed with the new token. The current token is kept here rather than in the
+// resty client, whose settings must not change while requests are in flight
+type reauthenticator struct {
+	client             *Client
+	authenticationType AuthenticationType
+	credentials        *AuthenticationRequestPayload
+	hook               ReauthenticationHook
+	token              atomic.Pointer[string]
+	mu                 sync.Mutex
+}
+
+// reauthTransport replays requests rejected with 401 after the reauthenticator renewed the token
+type reauthTransport struct {
+	base   http.RoundTripper
+	reauth *reauthenticator
+}
+
+func (c *Client) enableReauthentication(authenticationType AuthenticationType, credentials *AuthenticationRequestPayload, hook ReauthenticationHook) {
+	c.reauth = &reauthenticator{
+		client:             c,
+		authenticationType: authenticationType,
+		credentials:        credentials,
+		hook:               hook,
+	}
+	c.reauth.token.Store(&c.HTTPClient.Token)
+	c.HTTPClient.SetTransport(&reauthTransport{
+		base:   c.HTTPClient.GetClient().Transport,
+		reauth: c.reauth,
+	})
+}
+
+func (t *reauthTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if isTokenRequest(req) {
+		return t.base.RoundTrip(req)
+	}
+	scheme := t.reauth.client.HTTPClient.AuthScheme
+	if scheme == "" {
+		scheme = "Bearer"
+	}
+	// resty sets the token the client was created with, requests carry the renewed one
+	authorization := scheme + " " + t.reauth.currentToken()
+	if sent := req.Header.Get("Authorization"); strings.HasPrefix(sent, scheme+" ") && sent != authorization {
+		req = req.Clone(req.Context())
+		req.Header.Set("Authorization", authorization)
+	}
+
+	resp, err := t.base.RoundTrip(req)
+	if err != nil || resp.StatusCode != http.StatusUnauthorized {
+		return resp, err
+	}
+	if req.Body != nil && req.GetBody == nil {
+		return resp, nil
+	}
+
+	rejected := strings.TrimPrefix(req.Header.Get("Authorization"), scheme+" ")
+	token, err := t.reauth.renew(req.Context(), rejected)
+	if err != nil {
+		log.Error().Err(err).Str("url", req.URL.String()).Msg("Error renewing rejected token")
+		return resp, nil
+	}
+
+	replay := req.Clone(req.Context())
+	if req.GetBody != nil {
+		replay.Body, err = req.GetBody()
+		if err != nil {
+			return resp, nil
+		}
+	}
+	replay.Header.Set("Authorization", scheme+" "+token)
+	_, _ = io.Copy(io.Discard, resp.Body)
+	_ = resp.Body.Close()
+	return t.base.RoundTrip(replay)
+}
+
+// renew logs in again unless another request already replaced the rejected token
+func (r *reauthenticator) renew(ctx context.Context, rejected string) (string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if current := r.currentToken(); current != rejected {
+		return current, nil
+	}
+
+	log.Info().Str("authenticationType", r.authenticationType.Route()).Msg("Token rejected, authenticating API client again")
+	token, err := r.client.requestToken(ctx, r.authenticationType, r.credentials)
+	if err != nil {
+		return "", fmt.Errorf("error authenticating API client again: %w", err)
+	}
+	r.token.Store(&token)
+
+	if r.hook != nil {
+		r.hook(ctx, r.authenticationType)
+	}
+	return token, nil
+}
+
+// currentToken is the token requests are sent with, the last one renew obtained
+func (r *reauthenticator) currentToken() string {
+	return *r.token.Load()
+}
+
+func isTokenRequest(req *http.Request) bool {
+	return strings.HasSuffix(req.URL.Path, integrationAdminTokenService) || strings.HasSuffix(req.URL.Path, integrationCustomerTokenService)
+}
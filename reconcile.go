@@ -0,0 +1,76 @@
+package magento2
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+)
+
+// Magento REST has no delete events. The FindDeleted helpers reconcile the entities a downstream system
+// knows about against a search of the remote, so the system can purge what disappeared. Any failed search
+// fails the whole call: a partial answer would report existing entities as deleted
+
+// FindDeletedProducts returns the known SKUs for which no product exists anymore, in their given order.
+// SKUs are compared case-insensitively, as Magento does
+func FindDeletedProducts(ctx context.Context, knownSkus []string, apiClient *Client) ([]string, error) {
+	log.Debug().Int("count", len(knownSkus)).Msg("Finding deleted products")
+
+	existing, err := searchSkus(ctx, knownSkus, apiClient)
+	if err != nil {
+		return nil, err
+	}
+	deleted := []string{}
+	for _, sku := range knownSkus {
+		if len(existing[SkuKey(sku)]) == 0 {
+			deleted = append(deleted, sku)
+		}
+	}
+	return deleted, nil
+}
+
+// FindDeletedCategories returns the known category IDs that no longer exist
+func FindDeletedCategories(ctx context.Context, knownIDs []int, apiClient *Client) ([]int, error) {
+	return findDeletedIDs(ctx, categoriesList, knownIDs, func(c Category) int { return c.ID }, "find deleted categories", apiClient)
+}
+
+// FindDeletedCustomers returns the known customer IDs that no longer exist
+func FindDeletedCustomers(ctx context.Context, knownIDs []int, apiClient *Client) ([]int, error) {
+	return findDeletedIDs(ctx, customersSearch, knownIDs, func(c Customer) int { return c.ID }, "find deleted customers", apiClient)
+}
+
+// FindDeletedOrders returns the known order entity IDs that no longer exist
+func FindDeletedOrders(ctx context.Context, knownIDs []int, apiClient *Client) ([]int, error) {
+	return findDeletedIDs(ctx, Orders, knownIDs, func(o Order) int { return o.EntityID }, "find deleted orders", apiClient)
+}
+
+// findDeletedIDs searches the IDs in chunks with an "in" filter on entity_id and returns the ones not found
+func findDeletedIDs[T any](ctx context.Context, route string, knownIDs []int, id func(T) int, tryTo string, apiClient *Client) ([]int, error) {
+	log.Debug().Int("count", len(knownIDs)).Str("operation", tryTo).Msg("Finding deleted entities")
+
+	found := make(map[int]bool, len(knownIDs))
+	for start := 0; start < len(knownIDs); start += inFilterChunkSize {
+		chunk := knownIDs[start:min(start+inFilterChunkSize, len(knownIDs))]
+		values := make([]string, 0, len(chunk))
+		for _, knownID := range chunk {
+			values = append(values, strconv.Itoa(knownID))
+		}
+		byID := NewSearchCriteria(SearchFilter{Field: "entity_id", Value: strings.Join(values, ","), ConditionType: "in"})
+		items, err := searchAll[T](ctx, route, byID, tryTo, apiClient)
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range items {
+			found[id(item)] = true
+		}
+	}
+
+	deleted := []int{}
+	for _, knownID := range knownIDs {
+		if !found[knownID] {
+			deleted = append(deleted, knownID)
+		}
+	}
+	return deleted, nil
+}
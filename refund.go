@@ -0,0 +1,167 @@
+package magento2
+
+import (
+	"fmt"
+)
+
+// RefundOptions configures CalculateRefund. A nil ShippingAmount refunds shipping in proportion to the
+// refunded quantity, ReturnToStock puts the refunded items back to stock
+type RefundOptions struct {
+	ShippingAmount     *float64
+	AdjustmentPositive float64
+	AdjustmentNegative float64
+	ReturnToStock      bool
+	Notify             bool
+	Comment            string
+}
+
+// RefundLine holds the expected amounts of one refunded order item. DiscountAmount is positive and
+// Total is RowTotal plus TaxAmount minus DiscountAmount
+type RefundLine struct {
+	OrderItemID    int
+	Sku            string
+	Qty            Decimal
+	RowTotal       Decimal
+	TaxAmount      Decimal
+	DiscountAmount Decimal
+	Total          Decimal
+}
+
+// RefundCalculation is the outcome of CalculateRefund: the amounts the credit memo is expected to have,
+// and the request creating it
+type RefundCalculation struct {
+	Lines              []RefundLine
+	Subtotal           Decimal
+	TaxAmount          Decimal
+	DiscountAmount     Decimal
+	ShippingAmount     Decimal
+	ShippingTaxAmount  Decimal
+	AdjustmentPositive Decimal
+	AdjustmentNegative Decimal
+	GrandTotal         Decimal
+	Request            *RefundRequest
+}
+
+// CalculateRefund computes what refunding the given quantities, keyed by order item ID, refunds and builds
+// the RefundRequest for MOrder.Refund. It mirrors Magento's credit memo totals: row totals are prorated
+// by the ordered quantity, tax and discount by what is invoiced and not yet refunded, so the last refund
+// of an item picks up the rounding leftovers. Quantities above the invoiced and not yet refunded quantity
+// fail with ErrAlreadyRefunded, a grand total above the amount still refundable with a ValidationError
+func CalculateRefund(order *Order, quantities map[int]float64, opts RefundOptions) (*RefundCalculation, error) {
+	calculation := &RefundCalculation{
+		AdjustmentPositive: NewDecimalFromFloat(opts.AdjustmentPositive).Round(2),
+		AdjustmentNegative: NewDecimalFromFloat(opts.AdjustmentNegative).Round(2),
+	}
+	request := &RefundRequest{Notify: opts.Notify}
+
+	found := map[int]bool{}
+	var refundedQty, orderedQty, remainingQty Decimal
+	for i := range order.Items {
+		item := &order.Items[i]
+		itemID := int(item.ItemID)
+		if item.ParentItemID == 0 {
+			orderedQty = orderedQty.Add(NewDecimalFromFloat(item.QtyOrdered))
+			remainingQty = remainingQty.Add(NewDecimalFromFloat(qtyToRefund(item)))
+		}
+
+		requested, ok := quantities[itemID]
+		if !ok {
+			continue
+		}
+		found[itemID] = true
+
+		qty := NewDecimalFromFloat(requested)
+		if qty.Sign() <= 0 {
+			return nil, &ValidationError{Entity: fmt.Sprintf("refund of item %d", itemID), Field: "qty", Reason: "must be positive"}
+		}
+		left := NewDecimalFromFloat(qtyToRefund(item))
+		if qty.Cmp(left) > 0 {
+			return nil, fmt.Errorf("%w: item %d requested %v, remaining %v", ErrAlreadyRefunded, itemID, qty, left)
+		}
+		if item.ParentItemID == 0 {
+			refundedQty = refundedQty.Add(qty)
+		}
+
+		line := RefundLine{
+			OrderItemID:    itemID,
+			Sku:            item.Sku,
+			Qty:            qty,
+			RowTotal:       NewDecimalFromFloat(item.RowTotal).mulDiv(qty, NewDecimalFromFloat(item.QtyOrdered)).Round(2),
+			TaxAmount:      remainingAmount(item.TaxInvoiced, item.TaxRefunded).mulDiv(qty, left).Round(2),
+			DiscountAmount: remainingAmount(item.DiscountInvoiced, item.DiscountRefunded).mulDiv(qty, left).Round(2),
+		}
+		line.Total = line.RowTotal.Add(line.TaxAmount).Sub(line.DiscountAmount)
+		calculation.Lines = append(calculation.Lines, line)
+		calculation.Subtotal = calculation.Subtotal.Add(line.RowTotal)
+		calculation.TaxAmount = calculation.TaxAmount.Add(line.TaxAmount)
+		calculation.DiscountAmount = calculation.DiscountAmount.Add(line.DiscountAmount)
+
+		request.Items = append(request.Items, RefundItem{OrderItemID: itemID, Qty: requested})
+	}
+	for itemID := range quantities {
+		if !found[itemID] {
+			return nil, fmt.Errorf("%w: item %d is not part of order %d", ErrNotFound, itemID, order.EntityID)
+		}
+	}
+
+	shippingLeft := remainingAmount(order.ShippingAmount, order.ShippingRefunded)
+	if opts.ShippingAmount != nil {
+		calculation.ShippingAmount = NewDecimalFromFloat(*opts.ShippingAmount).Round(2)
+		if calculation.ShippingAmount.Sign() < 0 || calculation.ShippingAmount.Cmp(shippingLeft) > 0 {
+			return nil, &ValidationError{
+				Entity: fmt.Sprintf("refund of order %d", order.EntityID),
+				Field:  "shipping_amount",
+				Reason: fmt.Sprintf("must be between 0 and %s", shippingLeft.StringFixed(2)),
+			}
+		}
+	} else if refundedQty.Cmp(remainingQty) == 0 {
+		// the refund empties the order, so it takes whatever shipping is left
+		calculation.ShippingAmount = shippingLeft
+	} else {
+		calculation.ShippingAmount = NewDecimalFromFloat(order.ShippingAmount).mulDiv(refundedQty, orderedQty).Round(2)
+		if calculation.ShippingAmount.Cmp(shippingLeft) > 0 {
+			calculation.ShippingAmount = shippingLeft
+		}
+	}
+	calculation.ShippingTaxAmount = remainingAmount(order.ShippingTaxAmount, order.ShippingTaxRefunded).
+		mulDiv(calculation.ShippingAmount, shippingLeft).Round(2)
+
+	calculation.GrandTotal = calculation.Subtotal.
+		Add(calculation.TaxAmount).
+		Sub(calculation.DiscountAmount).
+		Add(calculation.ShippingAmount).
+		Add(calculation.ShippingTaxAmount).
+		Add(calculation.AdjustmentPositive).
+		Sub(calculation.AdjustmentNegative)
+	refundable := remainingAmount(order.TotalPaid, order.TotalRefunded)
+	if calculation.GrandTotal.Sign() < 0 || calculation.GrandTotal.Cmp(refundable) > 0 {
+		return nil, &ValidationError{
+			Entity: fmt.Sprintf("refund of order %d", order.EntityID),
+			Field:  "grand_total",
+			Reason: fmt.Sprintf("%s must be between 0 and the refundable %s", calculation.GrandTotal.StringFixed(2), refundable.StringFixed(2)),
+		}
+	}
+
+	request.Arguments = &RefundArguments{
+		ShippingAmount:     calculation.ShippingAmount.Float64(),
+		AdjustmentPositive: calculation.AdjustmentPositive.Float64(),
+		AdjustmentNegative: calculation.AdjustmentNegative.Float64(),
+	}
+	if opts.ReturnToStock && len(request.Items) > 0 {
+		extension := &RefundArgumentsExtension{}
+		for _, item := range request.Items {
+			extension.ReturnToStockItems = append(extension.ReturnToStockItems, item.OrderItemID)
+		}
+		request.Arguments.ExtensionAttributes = extension
+	}
+	if opts.Comment != "" {
+		request.AppendComment = true
+		request.Comment = &EntityComment{Comment: opts.Comment}
+	}
+	calculation.Request = request
+	return calculation, nil
+}
+
+func remainingAmount(total, done float64) Decimal {
+	return NewDecimalFromFloat(total).Sub(NewDecimalFromFloat(done))
+}
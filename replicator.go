@@ -0,0 +1,185 @@
+package magento2
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// ReplicaTarget is one store a Replicator writes to. CategoryIDs maps the category IDs of operations to
+// the IDs of this store, for installations that did not create their categories in the same order;
+// unmapped IDs are used as they are
+type ReplicaTarget struct {
+	Name        string
+	APIClient   *Client
+	CategoryIDs map[int]int
+}
+
+type ReplicatorConfig struct {
+	// MaxAttempts per operation and target, transient errors are retried with Backoff
+	MaxAttempts int
+	Backoff     Backoff
+	// OnResult is called for every target after an operation finished there, successfully or not
+	OnResult func(op *WriteOperation, result ReplicaResult)
+}
+
+var DefaultReplicatorConfig = ReplicatorConfig{
+	MaxAttempts: RetryAttempts,
+	Backoff:     DefaultBackoff,
+}
+
+// ReplicaResult is the outcome of an operation on one target
+type ReplicaResult struct {
+	Target   string
+	Attempts int
+	Err      error
+}
+
+// ReplicationResult holds the outcome of an operation on every target, in the order of the targets
+type ReplicationResult struct {
+	Operation *WriteOperation
+	Targets   []ReplicaResult
+}
+
+// Failed returns the names of the targets the operation could not be applied to
+func (r *ReplicationResult) Failed() []string {
+	var failed []string
+	for _, result := range r.Targets {
+		if result.Err != nil {
+			failed = append(failed, result.Target)
+		}
+	}
+	return failed
+}
+
+// Err joins the errors of the failed targets, nil when the operation reached every target
+func (r *ReplicationResult) Err() error {
+	var errs []error
+	for _, result := range r.Targets {
+		if result.Err != nil {
+			errs = append(errs, fmt.Errorf("target %s: %w", result.Target, result.Err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Replicator applies the same write operations (product, stock, price and category writes) to several
+// stores, e.g. parallel regional Magento instances. Targets are written concurrently and independently: a
+// failing target does not stop the others, and Retry sends the operation again to the failed ones only.
+// Operations are applied synchronously, so consecutive operations of a SKU arrive in order
+type Replicator struct {
+	targets []ReplicaTarget
+	config  ReplicatorConfig
+}
+
+func NewReplicator(targets []ReplicaTarget, config ReplicatorConfig) *Replicator {
+	if config.MaxAttempts <= 0 {
+		config.MaxAttempts = DefaultReplicatorConfig.MaxAttempts
+	}
+	if config.Backoff.Initial == 0 {
+		config.Backoff = DefaultReplicatorConfig.Backoff
+	}
+	return &Replicator{
+		targets: targets,
+		config:  config,
+	}
+}
+
+// Apply sends the operation to every target and waits for all of them. The error of the result tells
+// whether any target failed
+func (r *Replicator) Apply(ctx context.Context, op *WriteOperation) *ReplicationResult {
+	if op.ID == "" {
+		op.ID = newIdempotencyKey()
+	}
+	if op.EnqueuedAt.IsZero() {
+		op.EnqueuedAt = time.Now().UTC()
+	}
+	return r.apply(ctx, op, r.targets)
+}
+
+// Retry sends the operation of a previous result again to the targets that failed and returns the
+// combined result, targets that succeeded before keep their result
+func (r *Replicator) Retry(ctx context.Context, previous *ReplicationResult) *ReplicationResult {
+	failed := map[string]bool{}
+	for _, name := range previous.Failed() {
+		failed[name] = true
+	}
+	var targets []ReplicaTarget
+	for _, target := range r.targets {
+		if failed[target.Name] {
+			targets = append(targets, target)
+		}
+	}
+
+	retried := r.apply(ctx, previous.Operation, targets)
+	result := &ReplicationResult{Operation: previous.Operation, Targets: make([]ReplicaResult, len(previous.Targets))}
+	copy(result.Targets, previous.Targets)
+	for _, retriedResult := range retried.Targets {
+		for i := range result.Targets {
+			if result.Targets[i].Target == retriedResult.Target {
+				retriedResult.Attempts += result.Targets[i].Attempts
+				result.Targets[i] = retriedResult
+			}
+		}
+	}
+	return result
+}
+
+func (r *Replicator) apply(ctx context.Context, op *WriteOperation, targets []ReplicaTarget) *ReplicationResult {
+	log.Debug().
+		Str("operationID", op.ID).
+		Str("type", string(op.Type)).
+		Str("sku", op.Sku).
+		Int("targets", len(targets)).
+		Msg("Replicating write operation")
+
+	result := &ReplicationResult{Operation: op, Targets: make([]ReplicaResult, len(targets))}
+	var wg sync.WaitGroup
+	for i, target := range targets {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			result.Targets[i] = r.applyToTarget(ctx, op, target)
+			if r.config.OnResult != nil {
+				r.config.OnResult(op, result.Targets[i])
+			}
+		}()
+	}
+	wg.Wait()
+	return result
+}
+
+func (r *Replicator) applyToTarget(ctx context.Context, op *WriteOperation, target ReplicaTarget) ReplicaResult {
+	targetOp := *op
+	if mapped, ok := target.CategoryIDs[op.CategoryID]; ok && op.Type == WriteOperationCategory {
+		targetOp.CategoryID = mapped
+	}
+
+	result := ReplicaResult{Target: target.Name}
+	var wait time.Duration
+	for {
+		result.Attempts++
+		result.Err = applyWriteOperation(ctx, &targetOp, target.APIClient)
+		if result.Err == nil {
+			log.Debug().Str("operationID", op.ID).Str("target", target.Name).Msg("Write operation replicated")
+			return result
+		}
+		if !isTransientWriteError(result.Err) || result.Attempts >= r.config.MaxAttempts {
+			log.Error().Err(result.Err).Str("operationID", op.ID).Str("target", target.Name).Int("attempts", result.Attempts).Msg("Write operation replication failed")
+			return result
+		}
+
+		wait = r.config.Backoff.next(wait)
+		log.Warn().Err(result.Err).Str("operationID", op.ID).Str("target", target.Name).Int("attempt", result.Attempts).Dur("wait", wait).Msg("Retrying replicated write operation")
+		select {
+		case <-ctx.Done():
+			result.Err = ctx.Err()
+			return result
+		case <-time.After(wait):
+		}
+	}
+}
@@ -0,0 +1,106 @@
+package magento2
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+)
+
+// RequestOption adjusts a single call without changing the client it is issued from
+type RequestOption func(*requestOptions)
+
+type requestOptions struct {
+	storeCode      string
+	forceDuplicate bool
+	retries        *int
+	idempotencyKey string
+	timeout        time.Duration
+	responseLimit  *int
+}
+
+// WithStoreCode sends the request to the given store view instead of the client's StoreCode
+func WithStoreCode(storeCode string) RequestOption {
+	return func(o *requestOptions) {
+		o.storeCode = storeCode
+	}
+}
+
+// WithForceDuplicate skips the guards that refuse to invoice or ship quantities that already were
+func WithForceDuplicate() RequestOption {
+	return func(o *requestOptions) {
+		o.forceDuplicate = true
+	}
+}
+
+// WithRetries overrides the retry policy for the call: at most n retries (capped by the client's
+// retry count) are made regardless of the HTTP method. WithRetries(0) disables retrying
+func WithRetries(n int) RequestOption {
+	return func(o *requestOptions) {
+		o.retries = &n
+	}
+}
+
+// WithIdempotencyKey sends the given Idempotency-Key header with the call and makes a POST eligible
+// for retries. Magento itself ignores the header, retries are only deduplicated by a proxy or gateway
+// in front of it that honors the key
+func WithIdempotencyKey(key string) RequestOption {
+	return func(o *requestOptions) {
+		o.idempotencyKey = key
+	}
+}
+
+// WithTimeout overrides the client's default timeout for the call. The timeout covers all retries,
+// while a single attempt stays capped by the client's default timeout
+func WithTimeout(timeout time.Duration) RequestOption {
+	return func(o *requestOptions) {
+		o.timeout = timeout
+	}
+}
+
+// WithResponseLimit overrides the client's maximum response size for the call, e.g. for a large export
+func WithResponseLimit(bytes int) RequestOption {
+	return func(o *requestOptions) {
+		o.responseLimit = &bytes
+	}
+}
+
+func newRequestOptions(opts []RequestOption) *requestOptions {
+	o := &requestOptions{}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// endpoint resolves the route against the requested store view, if any
+func (o *requestOptions) endpoint(c *Client, route string) string {
+	if o.storeCode == "" {
+		return route
+	}
+	return storeScopedURL(c.HTTPClient.BaseURL, o.storeCode, route)
+}
+
+// newRequest builds a resty request carrying the per-call options. The returned cancel func releases
+// the call's deadline and must be called once the request is done
+func (o *requestOptions) newRequest(ctx context.Context, c *Client) (*resty.Request, context.CancelFunc) {
+	timeout := o.timeout
+	if timeout == 0 {
+		timeout = c.HTTPClient.GetClient().Timeout
+	}
+	cancel := func() {}
+	if timeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+	}
+	if o.retries != nil {
+		ctx = context.WithValue(ctx, retriesContextKey{}, *o.retries)
+	}
+	req := c.HTTPClient.R().SetContext(ctx)
+	if o.idempotencyKey != "" {
+		req.SetHeader(headerIdempotencyKey, o.idempotencyKey)
+	}
+	if o.responseLimit != nil {
+		req.SetResponseBodyLimit(*o.responseLimit)
+	}
+	return req, cancel
+}
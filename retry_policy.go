@@ -0,0 +1,106 @@
+package magento2
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	headerIdempotencyKey = "Idempotency-Key"
+)
+
+type retriesContextKey struct{}
+
+// callStartContextKey holds when the first attempt of a call was sent
+type callStartContextKey struct{}
+
+// markCallStart records the start of a call on its first attempt, the retries keep the context
+func markCallStart(_ *resty.Client, r *resty.Request) error {
+	if _, ok := r.Context().Value(callStartContextKey{}).(time.Time); !ok {
+		r.SetContext(context.WithValue(r.Context(), callStartContextKey{}, time.Now()))
+	}
+	return nil
+}
+
+// callDeadline is the deadline of the call's context or, for calls made without one, the end of the
+// client's default timeout counted from the first attempt
+func callDeadline(r *resty.Request, client *resty.Client) (time.Time, bool) {
+	if deadline, ok := r.Context().Deadline(); ok {
+		return deadline, true
+	}
+	start, ok := r.Context().Value(callStartContextKey{}).(time.Time)
+	timeout := client.GetClient().Timeout
+	if !ok || timeout <= 0 {
+		return time.Time{}, false
+	}
+	return start.Add(timeout), true
+}
+
+// isRetryAllowed applies the retry policy: idempotent methods are retried by default, POSTs only when
+// they carry an Idempotency-Key, and a WithRetries override on the call always wins
+func isRetryAllowed(req *resty.Request) bool {
+	if retries, ok := req.Context().Value(retriesContextKey{}).(int); ok {
+		return req.Attempt <= retries
+	}
+	if req.Header.Get(headerIdempotencyKey) != "" {
+		return true
+	}
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// retryCondition decides whether a failed attempt is retried. A retry is skipped when the call's deadline
+// would expire during the backoff wait, so the caller gets the last response instead of a bare timeout.
+// Calls without a context deadline are bound by the client's default timeout, see callDeadline
+func retryCondition(client *resty.Client) resty.RetryConditionFunc {
+	return func(r *resty.Response, err error) bool {
+		if r == nil || r.Request == nil {
+			return false
+		}
+		status := r.StatusCode()
+		if status != http.StatusServiceUnavailable && status != http.StatusInternalServerError {
+			return false
+		}
+		if !isRetryAllowed(r.Request) {
+			log.Debug().Str("method", r.Request.Method).Str("url", r.Request.URL).Int("status", status).Msg("Not retrying non-idempotent request")
+			return false
+		}
+		if deadline, ok := callDeadline(r.Request, client); ok && time.Until(deadline) < client.RetryWaitTime {
+			log.Debug().Str("url", r.Request.URL).Time("deadline", deadline).Msg("Not retrying, deadline would expire during backoff")
+			return false
+		}
+		return true
+	}
+}
+
+// EnableIdempotencyKeys adds a random Idempotency-Key header to every POST sent by the client. The key is kept
+// across retries of the same call, which also makes those POSTs eligible for retrying. Only enable it
+// behind a proxy or gateway that deduplicates by the key, Magento itself ignores the header
+func (c *Client) EnableIdempotencyKeys() *Client {
+	c.idempotencyKeys = true
+	c.HTTPClient.OnBeforeRequest(func(_ *resty.Client, r *resty.Request) error {
+		if r.Method == http.MethodPost && r.Header.Get(headerIdempotencyKey) == "" {
+			r.SetHeader(headerIdempotencyKey, newIdempotencyKey())
+		}
+		return nil
+	})
+	return c
+}
+
+func newIdempotencyKey() string {
+	b := make([]byte, 16)
+	_, err := rand.Read(b)
+	if err != nil {
+		log.Error().Err(err).Msg("Error generating idempotency key")
+	}
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,156 @@
+package magento2
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// RunTagAttributeCode is the product attribute carrying the run tag. meta_keyword is part of every
+	// default attribute set and searchable, so no custom attribute needs to be installed
+	RunTagAttributeCode = "meta_keyword"
+
+	categoryDescriptionAttributeCode = "description"
+	runTagPrefix                     = "m2rest-run:"
+)
+
+// WithRunID stamps the entities the client creates with the run ID: products in RunTagAttributeCode,
+// categories in their description and orders with a comment. FindRunEntities finds them again, e.g.
+// to clean up the data of a test run. Saves of existing products and categories aren't stamped, so
+// the cleanup leaves entities the run only changed alone
+func WithRunID(runID string) ClientOption {
+	return func(o *clientOptions) error {
+		o.runID = runID
+		return nil
+	}
+}
+
+// RunID returns the run ID set with WithRunID
+func (c *Client) RunID() string {
+	return c.runID
+}
+
+// RunTag returns the marker stamped into entities created by the run
+func RunTag(runID string) string {
+	return runTagPrefix + runID
+}
+
+// stampRunID returns a copy of the product carrying the run tag, the caller's attributes are left as
+// they are
+func (p Product) stampRunID(runID string) Product {
+	attributes := make([]map[string]any, 0, len(p.CustomAttributes)+1)
+	for _, attribute := range p.CustomAttributes {
+		if attribute["attribute_code"] != RunTagAttributeCode {
+			attributes = append(attributes, attribute)
+		}
+	}
+	p.CustomAttributes = append(attributes, map[string]any{"attribute_code": RunTagAttributeCode, "value": RunTag(runID)})
+	return p
+}
+
+// stampRunID returns a copy of the category with the tag appended to the description, so a
+// description set by the caller is kept
+func (c Category) stampRunID(runID string) Category {
+	tag := RunTag(runID)
+	attributes := append([]CustomAttributes(nil), c.CustomAttributes...)
+	c.CustomAttributes = attributes
+	for i := range attributes {
+		if attributes[i].AttributeCode == categoryDescriptionAttributeCode {
+			if !strings.Contains(attributes[i].Value, tag) {
+				attributes[i].Value = strings.TrimSpace(attributes[i].Value + " " + tag)
+			}
+			return c
+		}
+	}
+	c.CustomAttributes = append(attributes, CustomAttributes{AttributeCode: categoryDescriptionAttributeCode, Value: tag})
+	return c
+}
+
+// productExists tells whether a product with the SKU exists, i.e. whether saving it creates it
+func productExists(ctx context.Context, sku string, apiClient *Client) (bool, error) {
+	resp, err := apiClient.HTTPClient.R().SetContext(ctx).
+		SetQueryParam("fields", "sku").
+		Get(products + "/" + url.PathEscape(mayTrimSurroundingQuotes(sku)))
+	if err != nil {
+		return false, fmt.Errorf("error checking whether product '%s' exists: %w", sku, err)
+	}
+	if resp.StatusCode() == http.StatusNotFound {
+		return false, nil
+	}
+	if err := mayReturnErrorForHTTPResponse(resp, "check whether product exists"); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// TagRun adds a comment with the run tag to the order, which needs an admin token
+func (mo *MOrder) TagRun(ctx context.Context, runID string) error {
+	endpoint := mo.Route + "/" + OrderComments
+	payLoad := map[string]any{"statusHistory": &StatusHistory{Comment: RunTag(runID)}}
+
+	log.Debug().Int("orderID", mo.Order.EntityID).Str("runID", runID).Msg("Tagging order with run ID")
+
+	resp, err := mo.APIClient.HTTPClient.R().SetContext(ctx).SetBody(payLoad).Post(endpoint)
+	if err != nil {
+		return fmt.Errorf("error tagging order with run ID: %w", err)
+	}
+
+	return mayReturnErrorForHTTPResponse(resp, "tag order with run ID")
+}
+
+// RunEntities are the entities stamped with a run ID
+type RunEntities struct {
+	Products   []Product
+	Categories []Category
+	Orders     []Order
+}
+
+// FindRunEntities finds the products, categories and orders stamped with the run ID. Order comments
+// can't be searched, so the orders created since ordersSince are filtered locally. A zero ordersSince
+// skips orders
+func FindRunEntities(ctx context.Context, runID string, ordersSince time.Time, apiClient *Client) (*RunEntities, error) {
+	if runID == "" {
+		return nil, ErrEmptyRunID
+	}
+	tag := RunTag(runID)
+	entities := &RunEntities{}
+
+	log.Debug().Str("runID", runID).Time("ordersSince", ordersSince).Msg("Finding entities of run")
+
+	var err error
+	byTag := NewSearchCriteria(SearchFilter{Field: RunTagAttributeCode, Value: tag})
+	entities.Products, err = SearchProducts(ctx, byTag, apiClient)
+	if err != nil {
+		return nil, fmt.Errorf("error finding products of run '%s': %w", runID, err)
+	}
+
+	byDescription := NewSearchCriteria(SearchFilter{Field: categoryDescriptionAttributeCode, Value: "%" + tag + "%", ConditionType: "like"})
+	entities.Categories, err = SearchCategories(ctx, byDescription, apiClient)
+	if err != nil {
+		return nil, fmt.Errorf("error finding categories of run '%s': %w", runID, err)
+	}
+
+	if ordersSince.IsZero() {
+		return entities, nil
+	}
+	since := NewSearchCriteria(SearchFilter{Field: "created_at", Value: ordersSince.UTC().Format(DateTimeFormat), ConditionType: "gteq"})
+	orders, err := searchAll[Order](ctx, Orders, since, "search orders of run", apiClient)
+	if err != nil {
+		return nil, fmt.Errorf("error finding orders of run '%s': %w", runID, err)
+	}
+	for _, order := range orders {
+		for _, comment := range order.StatusHistories {
+			if comment.Comment == tag {
+				entities.Orders = append(entities.Orders, order)
+				break
+			}
+		}
+	}
+	return entities, nil
+}
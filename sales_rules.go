@@ -0,0 +1,132 @@
+package magento2
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// AppliedRuleIDs returns the IDs of the cart price rules applied to the order
+func (o *Order) AppliedRuleIDs() []int {
+	return parseRuleIDs(o.AppliedRuleIds)
+}
+
+// AppliedRuleIDs returns the IDs of the cart price rules applied to the order item
+func (item *Item) AppliedRuleIDs() []int {
+	return parseRuleIDs(item.AppliedRuleIds)
+}
+
+// HasCoupon reports whether a coupon was used for the order
+func (o *Order) HasCoupon() bool {
+	return strings.TrimSpace(o.CouponCode) != ""
+}
+
+// UsesCoupon reports whether the rule only applies with a coupon code
+func (r SalesRule) UsesCoupon() bool {
+	return r.CouponType == SalesRuleSpecificCoupon || r.CouponType == SalesRuleAutoCoupon
+}
+
+// parseRuleIDs parses the comma separated IDs Magento stores, skipping anything that is not a number
+func parseRuleIDs(value string) []int {
+	var ids []int
+	for _, part := range strings.Split(value, ",") {
+		id, err := strconv.Atoi(strings.TrimSpace(part))
+		if err == nil {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// SearchSalesRules returns all cart price rules matching the criteria, paging through the results
+func SearchSalesRules(ctx context.Context, criteria *SearchCriteria, apiClient *Client) ([]SalesRule, error) {
+	return searchAll[SalesRule](ctx, salesRulesSearch, criteria, "search sales rules", apiClient)
+}
+
+// GetSalesRuleNames resolves rule IDs to rule names with one search. IDs of deleted rules are missing
+// from the result
+func GetSalesRuleNames(ctx context.Context, ruleIDs []int, apiClient *Client) (map[int]string, error) {
+	rules, err := getSalesRulesByID(ctx, ruleIDs, apiClient)
+	if err != nil {
+		return nil, err
+	}
+	names := make(map[int]string, len(rules))
+	for id, rule := range rules {
+		names[id] = rule.Name
+	}
+	return names, nil
+}
+
+// getSalesRulesByID loads the rules with one search. IDs of deleted rules are missing from the result
+func getSalesRulesByID(ctx context.Context, ruleIDs []int, apiClient *Client) (map[int]SalesRule, error) {
+	rules := map[int]SalesRule{}
+	if len(ruleIDs) == 0 {
+		return rules, nil
+	}
+	values := make([]string, 0, len(ruleIDs))
+	for _, id := range ruleIDs {
+		values = append(values, strconv.Itoa(id))
+	}
+
+	byID := NewSearchCriteria(SearchFilter{Field: "rule_id", Value: strings.Join(values, ","), ConditionType: "in"})
+	found, err := SearchSalesRules(ctx, byID, apiClient)
+	if err != nil {
+		return nil, fmt.Errorf("error resolving sales rule names: %w", err)
+	}
+	for _, rule := range found {
+		rules[rule.RuleID] = rule
+	}
+	return rules, nil
+}
+
+// ReportPromotions sums up the orders per applied cart price rule, most used rules first. Rule names are
+// resolved with one search, deleted rules keep an empty name. The order's coupon code is only counted for
+// rules that take a coupon, as Magento applies automatic rules next to the coupon's rule. Canceled orders
+// should be filtered out by the caller
+func ReportPromotions(ctx context.Context, orders []Order, apiClient *Client) ([]PromotionUsage, error) {
+	usages := map[int]*PromotionUsage{}
+	for i := range orders {
+		order := &orders[i]
+		for _, ruleID := range order.AppliedRuleIDs() {
+			usage, ok := usages[ruleID]
+			if !ok {
+				usage = &PromotionUsage{RuleID: ruleID, CouponCodes: map[string]int{}}
+				usages[ruleID] = usage
+			}
+			usage.Orders++
+			// discount_amount is negative on orders
+			usage.DiscountAmount = usage.DiscountAmount.Sub(NewDecimalFromFloat(order.DiscountAmount))
+			usage.GrandTotal = usage.GrandTotal.Add(NewDecimalFromFloat(order.GrandTotal))
+		}
+	}
+
+	ruleIDs := sortedKeys(usages)
+	rules, err := getSalesRulesByID(ctx, ruleIDs, apiClient)
+	if err != nil {
+		return nil, err
+	}
+	for i := range orders {
+		order := &orders[i]
+		if !order.HasCoupon() {
+			continue
+		}
+		for _, ruleID := range order.AppliedRuleIDs() {
+			if rules[ruleID].UsesCoupon() {
+				usages[ruleID].CouponCodes[order.CouponCode]++
+			}
+		}
+	}
+
+	report := make([]PromotionUsage, 0, len(usages))
+	for _, ruleID := range ruleIDs {
+		usage := usages[ruleID]
+		usage.RuleName = rules[ruleID].Name
+		report = append(report, *usage)
+	}
+	sort.SliceStable(report, func(i, j int) bool {
+		return report[i].Orders > report[j].Orders
+	})
+	return report, nil
+}
@@ -0,0 +1,6 @@
+package magento2
+
+const (
+	salesRules       = "/salesRules"
+	salesRulesSearch = "/salesRules/search"
+)
@@ -0,0 +1,35 @@
+package magento2
+
+// SalesRule is a cart price rule. Only the fields needed for reporting are decoded
+type SalesRule struct {
+	RuleID           int     `json:"rule_id"`
+	Name             string  `json:"name"`
+	Description      string  `json:"description,omitempty"`
+	IsActive         bool    `json:"is_active"`
+	CouponType       string  `json:"coupon_type,omitempty"`
+	SimpleAction     string  `json:"simple_action,omitempty"`
+	DiscountAmount   float64 `json:"discount_amount"`
+	FromDate         string  `json:"from_date,omitempty"`
+	ToDate           string  `json:"to_date,omitempty"`
+	WebsiteIDs       []int   `json:"website_ids,omitempty"`
+	CustomerGroupIDs []int   `json:"customer_group_ids,omitempty"`
+	TimesUsed        int     `json:"times_used,omitempty"`
+}
+
+// Coupon types of a SalesRule
+const (
+	SalesRuleNoCoupon       = "NO_COUPON"
+	SalesRuleSpecificCoupon = "SPECIFIC_COUPON"
+	SalesRuleAutoCoupon     = "AUTO"
+)
+
+// PromotionUsage sums up the orders a rule was applied to. Magento does not split the discount of an order
+// by rule, so an order with two rules adds its whole discount to both
+type PromotionUsage struct {
+	RuleID         int
+	RuleName       string
+	Orders         int
+	CouponCodes    map[string]int
+	DiscountAmount Decimal
+	GrandTotal     Decimal
+}
@@ -0,0 +1,183 @@
+package magento2
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+)
+
+const schemaRoute = "/schema"
+
+// DefaultSchemaBindings maps the schema definitions of the core entities to the structs this package ships
+var DefaultSchemaBindings = map[string]any{
+	"catalog-data-product-interface":              Product{},
+	"catalog-data-category-interface":             Category{},
+	"catalog-data-product-attribute-interface":    Attribute{},
+	"eav-data-attribute-set-interface":            AttributeSet{},
+	"catalog-inventory-data-stock-item-interface": StockItem{},
+	"customer-data-customer-interface":            Customer{},
+	"quote-data-cart-interface":                   Cart{},
+	"quote-data-cart-item-interface":              CartItem{},
+	"sales-data-order-interface":                  Order{},
+	"sales-data-order-item-interface":             Item{},
+	"sales-data-invoice-interface":                Invoice{},
+}
+
+// schemaEndpoint derives the schema URL from the REST base URL, e.g. http://host/rest/default/V1 -> http://host/rest/default/schema
+func (c *Client) schemaEndpoint(storeCode string) string {
+	baseURL := c.HTTPClient.BaseURL
+	if storeCode != "" {
+		baseURL = storeScopedURL(baseURL, storeCode, "")
+	}
+	return strings.TrimSuffix(strings.TrimSuffix(baseURL, "/"), "/V1") + schemaRoute
+}
+
+// GetSchema downloads the Swagger schema of all services of the store, which needs an admin token
+func (c *Client) GetSchema(ctx context.Context, opts ...RequestOption) (*Schema, error) {
+	o := newRequestOptions(opts)
+	endpoint := c.schemaEndpoint(o.storeCode)
+	schema := &Schema{}
+
+	log.Debug().Str("endpoint", endpoint).Msg("Downloading REST schema")
+
+	req, cancel := o.newRequest(ctx, c)
+	defer cancel()
+	resp, err := req.SetQueryParam("services", "all").SetResult(schema).Get(endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("error downloading REST schema: %w", err)
+	}
+
+	httpErr := mayReturnErrorForHTTPResponse(resp, "download REST schema")
+	if httpErr != nil {
+		return nil, httpErr
+	}
+	return schema, nil
+}
+
+// CheckDrift compares the JSON fields of the bound structs, keyed by definition name, with the schema
+// definitions and reports the fields missing on either side. Pass DefaultSchemaBindings to check the
+// structs of this package, or your own bindings for extension types
+func (s *Schema) CheckDrift(bindings map[string]any) []SchemaDrift {
+	names := make([]string, 0, len(bindings))
+	for name := range bindings {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	drifts := []SchemaDrift{}
+	for _, name := range names {
+		t := reflect.TypeOf(bindings[name])
+		for t.Kind() == reflect.Pointer {
+			t = t.Elem()
+		}
+		definition, ok := s.Definitions[name]
+		if !ok {
+			drifts = append(drifts, SchemaDrift{Definition: name, Type: t.Name(), Kind: SchemaDriftMissingDefinition})
+			continue
+		}
+		drifts = append(drifts, definitionDrift(name, t, &definition)...)
+	}
+	return drifts
+}
+
+func definitionDrift(name string, t reflect.Type, definition *SchemaDefinition) []SchemaDrift {
+	fields := jsonFieldNames(t)
+	mapped := map[string]bool{}
+	for _, field := range fields {
+		mapped[field] = true
+	}
+	unmapped := []string{}
+	for property := range definition.Properties {
+		if !mapped[property] {
+			unmapped = append(unmapped, property)
+		}
+	}
+	sort.Strings(unmapped)
+
+	drifts := []SchemaDrift{}
+	for _, field := range fields {
+		if _, ok := definition.Properties[field]; ok {
+			continue
+		}
+		drifts = append(drifts, SchemaDrift{
+			Definition: name,
+			Type:       t.Name(),
+			Field:      field,
+			Kind:       SchemaDriftMissingField,
+			Suggestion: similarProperty(field, unmapped),
+		})
+	}
+	for _, property := range definition.Required {
+		if !mapped[property] {
+			drifts = append(drifts, SchemaDrift{Definition: name, Type: t.Name(), Field: property, Kind: SchemaDriftUnmappedField})
+		}
+	}
+	return drifts
+}
+
+// jsonFieldNames returns the names encoding/json uses for the fields of t, including embedded structs
+func jsonFieldNames(t reflect.Type) []string {
+	names := []string{}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, _, _ := strings.Cut(tag, ",")
+		if field.Anonymous && name == "" && field.Type.Kind() == reflect.Struct {
+			names = append(names, jsonFieldNames(field.Type)...)
+			continue
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		names = append(names, name)
+	}
+	return names
+}
+
+// similarProperty returns the candidate that equals field ignoring case and underscores or is
+// at most two edits away from it
+func similarProperty(field string, candidates []string) string {
+	normalize := func(s string) string {
+		return strings.ToLower(strings.ReplaceAll(s, "_", ""))
+	}
+	best, bestDistance := "", 3
+	for _, candidate := range candidates {
+		if normalize(candidate) == normalize(field) {
+			return candidate
+		}
+		if distance := editDistance(field, candidate); distance < bestDistance {
+			best, bestDistance = candidate, distance
+		}
+	}
+	return best
+}
+
+func editDistance(a, b string) int {
+	previous := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		current := make([]int, len(b)+1)
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous = current
+	}
+	return previous[len(b)]
+}
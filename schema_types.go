@@ -0,0 +1,47 @@
+package magento2
+
+// Schema is the Swagger 2.0 document Magento generates for its REST API
+type Schema struct {
+	Swagger string `json:"swagger"`
+	Info    struct {
+		Title   string `json:"title"`
+		Version string `json:"version"`
+	} `json:"info"`
+	Definitions map[string]SchemaDefinition `json:"definitions"`
+}
+
+type SchemaDefinition struct {
+	Type        string                    `json:"type"`
+	Description string                    `json:"description"`
+	Properties  map[string]SchemaProperty `json:"properties"`
+	Required    []string                  `json:"required"`
+}
+
+type SchemaProperty struct {
+	Type        string          `json:"type"`
+	Description string          `json:"description"`
+	Ref         string          `json:"$ref"`
+	Items       *SchemaProperty `json:"items"`
+}
+
+// SchemaDriftKind tells how a struct of this package differs from the schema of the store
+type SchemaDriftKind string
+
+const (
+	// SchemaDriftMissingDefinition means the schema has no definition for the struct at all
+	SchemaDriftMissingDefinition SchemaDriftKind = "missing_definition"
+	// SchemaDriftMissingField means a struct field is not a property of the definition, it was removed or renamed
+	SchemaDriftMissingField SchemaDriftKind = "missing_field"
+	// SchemaDriftUnmappedField means a required property of the definition has no struct field
+	SchemaDriftUnmappedField SchemaDriftKind = "unmapped_field"
+)
+
+// SchemaDrift is a difference between a struct and its schema definition. For a missing field Suggestion
+// names a similar, unmapped property the field was likely renamed to
+type SchemaDrift struct {
+	Definition string
+	Type       string
+	Field      string
+	Kind       SchemaDriftKind
+	Suggestion string
+}
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"encoding/csv"
 	"flag"
 	"fmt"
@@ -35,20 +36,8 @@ func main() {
 	output := zerolog.ConsoleWriter{Out: os.Stderr}
 	logger := zerolog.New(output).With().Timestamp().Logger()
 
-	// Load configuration
-	config, err := loadConfig()
-	if err != nil {
-		logger.Fatal().Err(err).Msg("Failed to load configuration")
-	}
-
-	// Create Magento client
-	storeConfig := &magento2.StoreConfig{
-		Scheme:    config.Scheme,
-		HostName:  config.Host,
-		StoreCode: config.StoreCode,
-	}
-
-	client, err := magento2.NewAPIClientFromIntegration(storeConfig, config.BearerToken)
+	// Create Magento client from MAGENTO_HOST, MAGENTO_BEARER_TOKEN and MAGENTO_STORE_CODE
+	client, err := magento2.NewAPIClientFromEnv(context.Background())
 	if err != nil {
 		logger.Fatal().Err(err).Msg("Failed to create API client")
 	}
@@ -83,47 +72,6 @@ func main() {
 	logger.Info().Msg("Bulk operations completed")
 }
 
-func loadConfig() (*Config, error) {
-	// Try to load from environment variables
-	host := os.Getenv("MAGENTO_HOST")
-	if host == "" {
-		return nil, fmt.Errorf("MAGENTO_HOST environment variable is required")
-	}
-
-	bearerToken := os.Getenv("MAGENTO_BEARER_TOKEN")
-	if bearerToken == "" {
-		return nil, fmt.Errorf("MAGENTO_BEARER_TOKEN environment variable is required")
-	}
-
-	storeCode := os.Getenv("MAGENTO_STORE_CODE")
-	if storeCode == "" {
-		storeCode = "all"
-	}
-
-	// Parse URL to get scheme and host
-	scheme := "https"
-	if len(host) > 7 && host[:7] == "http://" {
-		scheme = "http"
-		host = host[7:]
-	} else if len(host) > 8 && host[:8] == "https://" {
-		host = host[8:]
-	}
-
-	return &Config{
-		Scheme:      scheme,
-		Host:        host,
-		StoreCode:   storeCode,
-		BearerToken: bearerToken,
-	}, nil
-}
-
-type Config struct {
-	Scheme      string
-	Host        string
-	StoreCode   string
-	BearerToken string
-}
-
 func createBulkProducts(client *magento2.Client, count int, concurrent int, logger *zerolog.Logger) []string {
 	timestamp := time.Now().Unix()
 	products := make([]magento2.Product, count)
@@ -216,7 +164,7 @@ func updateBulkStock(client *magento2.Client, updates []StockUpdate, concurrent
 					if stockMap, ok := stockData.(map[string]any); ok {
 						if itemID, ok := stockMap["item_id"]; ok {
 							stockItemID := fmt.Sprintf("%v", itemID)
-							err = product.UpdateQuantityForStockItem(stockItemID, int(u.Qty), true)
+							err = product.UpdateDecimalQuantityForStockItem(context.Background(), stockItemID, magento2.NewDecimalFromFloat(u.Qty), true)
 							if err != nil {
 								logger.Error().Err(err).Str("sku", u.SKU).Msg("Failed to update stock")
 								errors <- err
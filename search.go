@@ -0,0 +1,96 @@
+package magento2
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	searchDocumentScore = "score"
+)
+
+// Search queries the /search endpoint, which returns matching document IDs together with the
+// layered-navigation facets (aggregations) that the /products endpoint does not provide
+func Search(ctx context.Context, request *SearchRequest, apiClient *Client) (*SearchResult, error) {
+	params := url.Values{}
+	params.Add("searchCriteria[requestName]", request.RequestName)
+	for i, filter := range request.Filters {
+		params.Add(fmt.Sprintf("searchCriteria[filter_groups][%d][filters][0][field]", i), filter.Field)
+		params.Add(fmt.Sprintf("searchCriteria[filter_groups][%d][filters][0][value]", i), filter.Value)
+		if filter.ConditionType != "" {
+			params.Add(fmt.Sprintf("searchCriteria[filter_groups][%d][filters][0][condition_type]", i), filter.ConditionType)
+		}
+	}
+	if request.PageSize > 0 {
+		params.Add("searchCriteria[page_size]", strconv.Itoa(request.PageSize))
+	}
+	if request.CurrentPage > 0 {
+		params.Add("searchCriteria[current_page]", strconv.Itoa(request.CurrentPage))
+	}
+
+	endpoint := search + "?" + params.Encode()
+	result := &SearchResult{}
+
+	log.Debug().
+		Str("requestName", request.RequestName).
+		Str("endpoint", endpoint).
+		Msg("Searching catalog")
+
+	resp, err := apiClient.HTTPClient.R().SetContext(ctx).SetResult(result).Get(endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("error searching catalog: %w", err)
+	}
+
+	httpErr := mayReturnErrorForHTTPResponse(resp, "search catalog")
+	if httpErr != nil {
+		return nil, httpErr
+	}
+
+	return result, nil
+}
+
+// Score returns the relevance score of the document, or 0 when Magento did not return one
+func (d *SearchDocument) Score() float64 {
+	for _, attribute := range d.CustomAttributes {
+		if attribute.AttributeCode != searchDocumentScore {
+			continue
+		}
+		switch v := attribute.Value.(type) {
+		case float64:
+			return v
+		case string:
+			score, _ := strconv.ParseFloat(v, 64)
+			return score
+		}
+	}
+	return 0
+}
+
+// Bucket returns the aggregation bucket with the given name, e.g. "price_bucket"
+func (a *SearchAggregations) Bucket(name string) (*SearchBucket, bool) {
+	for i := range a.Buckets {
+		if a.Buckets[i].Name == name {
+			return &a.Buckets[i], true
+		}
+	}
+	return nil, false
+}
+
+// Count returns the document count of the facet value, which Magento sends as the last metric
+func (v *SearchBucketValue) Count() int {
+	for i := len(v.Metrics) - 1; i >= 0; i-- {
+		switch m := v.Metrics[i].(type) {
+		case float64:
+			return int(m)
+		case string:
+			if count, err := strconv.Atoi(m); err == nil {
+				return count
+			}
+		}
+	}
+	return 0
+}
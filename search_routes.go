@@ -0,0 +1,5 @@
+package magento2
+
+const (
+	search = "/search"
+)
@@ -0,0 +1,54 @@
+package magento2
+
+const (
+	SearchRequestQuick       = "quick_search_container"
+	SearchRequestAdvanced    = "advanced_search_container"
+	SearchRequestCatalogView = "catalog_view_container"
+)
+
+// SearchFilter is a single condition of a /search request. Filters are ANDed together
+type SearchFilter struct {
+	Field         string
+	Value         string
+	ConditionType string
+}
+
+// SearchRequest describes a query against Magento's search engine, e.g. a quick search for a term:
+// SearchRequest{RequestName: SearchRequestQuick, Filters: []SearchFilter{{Field: "search_term", Value: "bag"}}}
+type SearchRequest struct {
+	RequestName string
+	Filters     []SearchFilter
+	PageSize    int
+	CurrentPage int
+}
+
+type SearchDocument struct {
+	ID               int                       `json:"id"`
+	CustomAttributes []SearchDocumentAttribute `json:"custom_attributes,omitempty"`
+}
+
+type SearchDocumentAttribute struct {
+	AttributeCode string `json:"attribute_code"`
+	Value         any    `json:"value"`
+}
+
+type SearchBucketValue struct {
+	Value   string `json:"value"`
+	Metrics []any  `json:"metrics"`
+}
+
+type SearchBucket struct {
+	Name   string              `json:"name"`
+	Values []SearchBucketValue `json:"values"`
+}
+
+type SearchAggregations struct {
+	Buckets     []SearchBucket `json:"buckets"`
+	BucketNames []string       `json:"bucket_names"`
+}
+
+type SearchResult struct {
+	Items        []SearchDocument   `json:"items"`
+	Aggregations SearchAggregations `json:"aggregations"`
+	TotalCount   int                `json:"total_count"`
+}
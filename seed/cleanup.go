@@ -0,0 +1,164 @@
+package seed
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	magento2 "github.com/florinel-chis/go-m2rest"
+	"github.com/rs/zerolog/log"
+)
+
+// CleanupOptions select the test data to delete. Pattern matches SKUs, category and attribute set names,
+// "*" matching any characters, e.g. "test-product-*" or "seed-*". Attribute codes and customer emails are
+// matched against the pattern in attribute code form, i.e. "seed-*" matches "seed_color"
+type CleanupOptions struct {
+	Pattern string
+	// CreatedBefore restricts products, categories and customers to those created before the time
+	CreatedBefore time.Time
+	// RunID restricts products and categories to those stamped with the run ID, see magento2.WithRunID
+	RunID string
+	// DryRun only reports what would be deleted
+	DryRun bool
+}
+
+// CleanupReport lists the deleted entities, or the ones that would be deleted on a dry run
+type CleanupReport struct {
+	Products      []string
+	Customers     []string
+	Categories    []int
+	Attributes    []string
+	AttributeSets []int
+}
+
+// Cleanup deletes the entities matching the options in dependency order: composite products before their
+// children, then simple products, customers, categories deepest first, attributes and finally attribute
+// sets. A failing deletion doesn't stop the cleanup, all errors are returned together
+func Cleanup(ctx context.Context, client *magento2.Client, opts CleanupOptions) (*CleanupReport, error) {
+	if strings.Trim(opts.Pattern, "*% ") == "" {
+		return nil, fmt.Errorf("refusing to clean up without a pattern: '%s'", opts.Pattern)
+	}
+	like := strings.ReplaceAll(opts.Pattern, "*", "%")
+	codeLike := codePattern(opts.Pattern)
+
+	c := &cleaner{client: client, dryRun: opts.DryRun, report: &CleanupReport{}}
+	byPattern := func(field, pattern string, timestamped bool) *magento2.SearchCriteria {
+		criteria := magento2.NewSearchCriteria(magento2.SearchFilter{Field: field, Value: pattern, ConditionType: "like"})
+		if timestamped && !opts.CreatedBefore.IsZero() {
+			criteria.And(magento2.SearchFilter{Field: "created_at", Value: opts.CreatedBefore.UTC().Format(magento2.DateTimeFormat), ConditionType: "lt"})
+		}
+		return criteria
+	}
+
+	productCriteria := byPattern("sku", like, true)
+	categoryCriteria := byPattern("name", like, true)
+	if opts.RunID != "" {
+		tag := magento2.RunTag(opts.RunID)
+		productCriteria.And(magento2.SearchFilter{Field: magento2.RunTagAttributeCode, Value: tag})
+		categoryCriteria.And(magento2.SearchFilter{Field: "description", Value: "%" + tag + "%", ConditionType: "like"})
+	}
+
+	products, err := magento2.SearchProducts(ctx, productCriteria, client)
+	if err != nil {
+		return c.report, fmt.Errorf("error searching products to clean up: %w", err)
+	}
+	// composite products first, Magento keeps children of configurable and bundle products otherwise linked
+	sort.SliceStable(products, func(i, j int) bool {
+		return isComposite(products[i].TypeID) && !isComposite(products[j].TypeID)
+	})
+	for _, product := range products {
+		c.delete("product", product.Sku, func() error {
+			return magento2.DeleteProduct(ctx, product.Sku, client)
+		}, func() { c.report.Products = append(c.report.Products, product.Sku) })
+	}
+
+	customers, err := magento2.SearchCustomers(ctx, byPattern("email", codeLike, true), client)
+	if err != nil {
+		c.errs = append(c.errs, fmt.Errorf("error searching customers to clean up: %w", err))
+	}
+	for _, customer := range customers {
+		c.delete("customer", customer.Email, func() error {
+			return magento2.DeleteCustomer(ctx, customer.ID, client)
+		}, func() { c.report.Customers = append(c.report.Customers, customer.Email) })
+	}
+
+	categories, err := magento2.SearchCategories(ctx, categoryCriteria, client)
+	if err != nil {
+		c.errs = append(c.errs, fmt.Errorf("error searching categories to clean up: %w", err))
+	}
+	sort.SliceStable(categories, func(i, j int) bool {
+		return categories[i].Level > categories[j].Level
+	})
+	for _, category := range categories {
+		mCategory := &magento2.MCategory{Route: fmt.Sprintf("/categories/%d", category.ID), Category: &category, APIClient: client}
+		c.delete("category", category.Name, func() error {
+			return mCategory.Delete(ctx)
+		}, func() { c.report.Categories = append(c.report.Categories, category.ID) })
+	}
+
+	attributes, err := magento2.SearchAttributes(ctx, byPattern("attribute_code", codeLike, false), client)
+	if err != nil {
+		c.errs = append(c.errs, fmt.Errorf("error searching attributes to clean up: %w", err))
+	}
+	for _, attribute := range attributes {
+		c.delete("attribute", attribute.AttributeCode, func() error {
+			return magento2.DeleteAttribute(ctx, attribute.AttributeCode, client)
+		}, func() { c.report.Attributes = append(c.report.Attributes, attribute.AttributeCode) })
+	}
+
+	attributeSets, err := magento2.SearchAttributeSets(ctx, byPattern("attribute_set_name", like, false), client)
+	if err != nil {
+		c.errs = append(c.errs, fmt.Errorf("error searching attribute sets to clean up: %w", err))
+	}
+	for _, attributeSet := range attributeSets {
+		c.delete("attribute set", attributeSet.AttributeSetName, func() error {
+			return magento2.DeleteAttributeSet(ctx, attributeSet.AttributeSetID, client)
+		}, func() { c.report.AttributeSets = append(c.report.AttributeSets, attributeSet.AttributeSetID) })
+	}
+
+	return c.report, errors.Join(c.errs...)
+}
+
+type cleaner struct {
+	client *magento2.Client
+	dryRun bool
+	report *CleanupReport
+	errs   []error
+}
+
+// delete runs the deletion unless on a dry run. Entities already gone, e.g. subcategories deleted with their
+// parent, count as deleted
+func (c *cleaner) delete(entity, name string, deleteFn func() error, record func()) {
+	if c.dryRun {
+		record()
+		return
+	}
+	err := deleteFn()
+	if err != nil && !errors.Is(err, magento2.ErrNotFound) {
+		log.Warn().Err(err).Str("entity", entity).Str("name", name).Msg("Error cleaning up")
+		c.errs = append(c.errs, fmt.Errorf("error deleting %s '%s': %w", entity, name, err))
+		return
+	}
+	log.Debug().Str("entity", entity).Str("name", name).Msg("Cleaned up")
+	record()
+}
+
+// codePattern converts the pattern the way Dataset.code converts the prefix, keeping the wildcards
+func codePattern(pattern string) string {
+	parts := strings.Split(strings.ToLower(pattern), "*")
+	for i := range parts {
+		parts[i] = nonCodeCharacters.ReplaceAllString(parts[i], "_")
+	}
+	return strings.Join(parts, "%")
+}
+
+func isComposite(typeID string) bool {
+	switch typeID {
+	case magento2.ProductTypeConfigurable, magento2.ProductTypeBundle, magento2.ProductTypeGrouped:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,329 @@
+// Package seed provisions a small, complete demo dataset against a fresh Magento instance, e.g. to set up
+// reproducible integration-test environments. All entities are named after Options.Prefix
+package seed
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	magento2 "github.com/florinel-chis/go-m2rest"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// DefaultRootCategoryID is the "Default Category" of a fresh installation
+	DefaultRootCategoryID = 2
+	DefaultPassword       = "Seed-Passw0rd!"
+	defaultStockItem      = "1"
+	defaultStockQty       = 100
+)
+
+var nonCodeCharacters = regexp.MustCompile(`[^a-z0-9_]+`)
+
+// Options configures the dataset. Zero values get defaults: a "seed-<unix time>" prefix, the root
+// category of a fresh installation, DefaultPassword, flat rate shipping and check / money order payment
+type Options struct {
+	Prefix          string
+	RootCategoryID  int
+	Password        string
+	Address         *magento2.Address
+	ShippingMethod  string
+	ShippingCarrier string
+	PaymentMethod   string
+}
+
+// Dataset holds what Run created. With a client created WithRunID all products, categories and the order
+// are stamped with the run ID. On error it holds the entities created before the failing step
+type Dataset struct {
+	Prefix           string
+	AttributeSet     *magento2.MAttributeSet
+	Attributes       []*magento2.MAttribute
+	Categories       []*magento2.MCategory
+	SimpleProducts   []*magento2.MProduct
+	Configurable     *magento2.MProduct
+	Bundle           *magento2.MProduct
+	Customer         *magento2.Customer
+	CustomerEmail    string
+	CustomerPassword string
+	Order            *magento2.MOrder
+}
+
+func (o *Options) withDefaults() *Options {
+	options := *o
+	if options.Prefix == "" {
+		options.Prefix = fmt.Sprintf("seed-%d", time.Now().Unix())
+	}
+	if options.RootCategoryID == 0 {
+		options.RootCategoryID = DefaultRootCategoryID
+	}
+	if options.Password == "" {
+		options.Password = DefaultPassword
+	}
+	if options.Address == nil {
+		options.Address = &magento2.Address{
+			CountryID: "US",
+			RegionID:  12,
+			Street:    []string{"6146 Honey Bluff Parkway"},
+			Telephone: "(555) 229-3326",
+			Postcode:  "49628-7978",
+			City:      "Calder",
+			Firstname: "Seed",
+			Lastname:  "Customer",
+		}
+	}
+	if options.ShippingMethod == "" {
+		options.ShippingMethod, options.ShippingCarrier = "flatrate", "flatrate"
+	}
+	if options.PaymentMethod == "" {
+		options.PaymentMethod = "checkmo"
+	}
+	return &options
+}
+
+// code turns the prefix into a valid attribute code part, e.g. "seed-1700000000" -> "seed_1700000000"
+func (d *Dataset) code(name string) string {
+	code := nonCodeCharacters.ReplaceAllString(strings.ToLower(d.Prefix), "_")
+	if code == "" || code[0] < 'a' || code[0] > 'z' {
+		code = "s" + code
+	}
+	return code + "_" + name
+}
+
+// Run creates, in order: an attribute set, a select and a text attribute, a category with a subcategory,
+// three simple products, a configurable and a bundle product, a customer and an order placed by them.
+// The client needs an admin token
+func Run(ctx context.Context, client *magento2.Client, opts Options) (*Dataset, error) {
+	o := opts.withDefaults()
+	dataset := &Dataset{Prefix: o.Prefix}
+	steps := []struct {
+		name string
+		run  func(context.Context, *magento2.Client, *Options) error
+	}{
+		{"attribute set", dataset.createAttributeSet},
+		{"attributes", dataset.createAttributes},
+		{"categories", dataset.createCategories},
+		{"products", dataset.createProducts},
+		{"customer", dataset.createCustomer},
+		{"order", dataset.placeOrder},
+	}
+	for _, step := range steps {
+		log.Info().Str("prefix", o.Prefix).Str("step", step.name).Msg("Seeding")
+		if err := step.run(ctx, client, o); err != nil {
+			return dataset, fmt.Errorf("error seeding %s: %w", step.name, err)
+		}
+	}
+	return dataset, nil
+}
+
+func (d *Dataset) createAttributeSet(ctx context.Context, client *magento2.Client, _ *Options) error {
+	skeletonID, err := magento2.DefaultAttributeSetSkeletonID(ctx, magento2.EntityTypeProduct, client)
+	if err != nil {
+		return err
+	}
+	attributeSet := magento2.AttributeSet{
+		AttributeSetName: d.Prefix + " Set",
+		EntityTypeID:     magento2.EntityTypeProduct,
+	}
+	d.AttributeSet, err = magento2.CreateAttributeSet(attributeSet, skeletonID, client)
+	return err
+}
+
+func (d *Dataset) createAttributes(ctx context.Context, client *magento2.Client, _ *Options) error {
+	attributes := []*magento2.Attribute{
+		{
+			AttributeCode:        d.code("color"),
+			FrontendInput:        magento2.FrontendInputSelect,
+			DefaultFrontendLabel: d.Prefix + " Color",
+			Scope:                "global",
+			Options: []magento2.Option{
+				{Label: "Red", SortOrder: 1},
+				{Label: "Blue", SortOrder: 2},
+			},
+		},
+		{
+			AttributeCode:        d.code("material"),
+			FrontendInput:        "text",
+			DefaultFrontendLabel: d.Prefix + " Material",
+			Scope:                "store",
+		},
+	}
+
+	group, err := d.AttributeSet.FindGroupByName("General")
+	if err != nil {
+		return fmt.Errorf("error finding group of attribute set: %w", err)
+	}
+	groupID, err := strconv.Atoi(group.AttributeGroupID)
+	if err != nil {
+		return fmt.Errorf("unexpected attribute group id '%s': %w", group.AttributeGroupID, err)
+	}
+
+	for i, attribute := range attributes {
+		mAttribute, err := magento2.CreateAttribute(attribute, client)
+		if err != nil {
+			return err
+		}
+		d.Attributes = append(d.Attributes, mAttribute)
+		err = d.AttributeSet.AssignAttribute(groupID, 100+i, attribute.AttributeCode)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (d *Dataset) createCategories(_ context.Context, client *magento2.Client, o *Options) error {
+	parent, err := magento2.CreateCategory(&magento2.Category{
+		ParentID:      o.RootCategoryID,
+		Name:          d.Prefix + " Gear",
+		IsActive:      true,
+		IncludeInMenu: true,
+	}, client)
+	if err != nil {
+		return err
+	}
+	d.Categories = append(d.Categories, parent)
+
+	child, err := magento2.CreateCategory(&magento2.Category{
+		ParentID:      parent.Category.ID,
+		Name:          d.Prefix + " Bags",
+		IsActive:      true,
+		IncludeInMenu: true,
+	}, client)
+	if err != nil {
+		return err
+	}
+	d.Categories = append(d.Categories, child)
+	return nil
+}
+
+func (d *Dataset) createProducts(ctx context.Context, client *magento2.Client, _ *Options) error {
+	setID := d.AttributeSet.AttributeSet.AttributeSetID
+	categoryIDs := []int{d.Categories[0].Category.ID, d.Categories[1].Category.ID}
+	colorCode := d.code("color")
+	cache := magento2.NewAttributeCache(client)
+
+	simples := []struct {
+		sku, name, color string
+		price            float64
+	}{
+		{d.Prefix + "-bag-red", d.Prefix + " Bag Red", "Red", 39},
+		{d.Prefix + "-bag-blue", d.Prefix + " Bag Blue", "Blue", 39},
+		{d.Prefix + "-strap", d.Prefix + " Strap", "", 9},
+	}
+	for _, simple := range simples {
+		product := magento2.NewSimpleProduct(simple.sku, simple.name, setID, simple.price, 1)
+		product.SetCategoryIDs(categoryIDs)
+		product.CustomAttributes = append(product.CustomAttributes, map[string]any{"attribute_code": d.code("material"), "value": "Canvas"})
+		if simple.color != "" {
+			value, err := cache.OptionValue(ctx, colorCode, simple.color)
+			if err != nil {
+				return err
+			}
+			product.Visibility = magento2.VisibilityNotVisible
+			product.CustomAttributes = append(product.CustomAttributes, map[string]any{"attribute_code": colorCode, "value": value})
+		}
+		mProduct, err := magento2.CreateOrReplaceProductContext(ctx, product, true, client)
+		if err != nil {
+			return err
+		}
+		d.SimpleProducts = append(d.SimpleProducts, mProduct)
+		err = mProduct.UpdateQuantityForStockItemContext(ctx, defaultStockItem, defaultStockQty, true)
+		if err != nil {
+			return err
+		}
+	}
+
+	configurable := magento2.NewConfigurableProduct(d.Prefix+"-bag", d.Prefix+" Bag", setID)
+	configurable.SetCategoryIDs(categoryIDs)
+	var err error
+	d.Configurable, err = magento2.CreateOrReplaceProductContext(ctx, configurable, true, client)
+	if err != nil {
+		return err
+	}
+	option, err := magento2.BuildConfigurableProductOption(ctx, colorCode, []string{"Red", "Blue"}, cache)
+	if err != nil {
+		return err
+	}
+	mConfigurable, err := magento2.SetOptionForExistingConfigurableProduct(configurable.Sku, option, client)
+	if err != nil {
+		return err
+	}
+	for _, child := range d.SimpleProducts[:2] {
+		if err := mConfigurable.AddChildBySKU(child.Product.Sku); err != nil {
+			return err
+		}
+	}
+
+	bundle := magento2.NewBundleProduct(d.Prefix+"-kit", d.Prefix+" Travel Kit", setID)
+	bundle.SetCategoryIDs(categoryIDs)
+	bundle.SetBundleOptions([]magento2.BundleOption{
+		{
+			Title:    "Strap",
+			Required: true,
+			Type:     magento2.BundleOptionTypeSelect,
+			ProductLinks: []magento2.BundleProductLink{
+				{Sku: d.SimpleProducts[2].Product.Sku, Qty: 1, IsDefault: true},
+			},
+		},
+	})
+	d.Bundle, err = magento2.CreateOrReplaceProductContext(ctx, bundle, true, client)
+	return err
+}
+
+func (d *Dataset) createCustomer(ctx context.Context, client *magento2.Client, o *Options) error {
+	address := *o.Address
+	d.CustomerEmail = d.code("customer") + "@example.com"
+	d.CustomerPassword = o.Password
+
+	customer := &magento2.Customer{
+		Email:     d.CustomerEmail,
+		Firstname: address.Firstname,
+		Lastname:  address.Lastname,
+		WebsiteID: 1,
+		Addresses: []magento2.Address{address},
+	}
+	var err error
+	d.Customer, err = magento2.CreateCustomer(ctx, customer, o.Password, client)
+	return err
+}
+
+func (d *Dataset) placeOrder(ctx context.Context, client *magento2.Client, o *Options) error {
+	guestCart, err := magento2.NewGuestCartFromAPIClient(client)
+	if err != nil {
+		return err
+	}
+	err = guestCart.AddItems([]magento2.CartItem{{Sku: d.SimpleProducts[2].Product.Sku, Qty: 2}})
+	if err != nil {
+		return err
+	}
+	cart, err := guestCart.LoginAsCustomer(ctx, d.CustomerEmail, d.CustomerPassword)
+	if err != nil {
+		return err
+	}
+
+	address := *o.Address
+	address.Email = d.CustomerEmail
+	err = cart.AddShippingInformation(&magento2.AddressInformation{
+		ShippingAddress:      &magento2.ShippingAddress{Address: address},
+		BillingAddress:       &magento2.BillingAddress{Address: address},
+		ShippingMethodCode:   o.ShippingMethod,
+		ShippingCarrierCodes: o.ShippingCarrier,
+	})
+	if err != nil {
+		return err
+	}
+	order, err := cart.CreateOrder(magento2.PaymentMethod{Code: o.PaymentMethod})
+	if err != nil {
+		return err
+	}
+	// the customer token can't comment on orders, so the run tag is added with the admin client
+	d.Order = &magento2.MOrder{Route: order.Route, Order: order.Order, APIClient: client}
+	if runID := client.RunID(); runID != "" {
+		return d.Order.TagRun(ctx, runID)
+	}
+	return nil
+}
@@ -0,0 +1,495 @@
+package magento2
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	SEOFieldMetaTitle       = "meta_title"
+	SEOFieldMetaKeyword     = "meta_keyword"
+	SEOFieldMetaDescription = "meta_description"
+	SEOFieldURLKey          = "url_key"
+)
+
+// SEOFields lists the fields SEO helpers read and write, in the order changes are planned
+var SEOFields = []string{SEOFieldMetaTitle, SEOFieldMetaKeyword, SEOFieldMetaDescription, SEOFieldURLKey}
+
+// categories store their keywords as meta_keywords, products as meta_keyword
+const categoryMetaKeywordsAttributeCode = "meta_keywords"
+
+// SEOMetadata holds the meta data and url key of a product or category
+type SEOMetadata struct {
+	MetaTitle       string `json:"meta_title,omitempty"`
+	MetaKeyword     string `json:"meta_keyword,omitempty"`
+	MetaDescription string `json:"meta_description,omitempty"`
+	URLKey          string `json:"url_key,omitempty"`
+}
+
+// Field returns the value of one of the SEOFields
+func (m SEOMetadata) Field(field string) string {
+	switch field {
+	case SEOFieldMetaTitle:
+		return m.MetaTitle
+	case SEOFieldMetaKeyword:
+		return m.MetaKeyword
+	case SEOFieldMetaDescription:
+		return m.MetaDescription
+	case SEOFieldURLKey:
+		return m.URLKey
+	}
+	return ""
+}
+
+// SEOMetadata reads the meta data and url key from the custom attributes of the product
+func (p *Product) SEOMetadata() SEOMetadata {
+	return SEOMetadata{
+		MetaTitle:       p.CustomAttributeString(SEOFieldMetaTitle),
+		MetaKeyword:     p.CustomAttributeString(SEOFieldMetaKeyword),
+		MetaDescription: p.CustomAttributeString(SEOFieldMetaDescription),
+		URLKey:          p.CustomAttributeString(SEOFieldURLKey),
+	}
+}
+
+// SEOMetadata reads the meta data and url key from the custom attributes of the category
+func (c *Category) SEOMetadata() SEOMetadata {
+	return SEOMetadata{
+		MetaTitle:       c.customAttribute(SEOFieldMetaTitle),
+		MetaKeyword:     c.customAttribute(categoryMetaKeywordsAttributeCode),
+		MetaDescription: c.customAttribute(SEOFieldMetaDescription),
+		URLKey:          c.customAttribute(categoryURLKeyAttributeCode),
+	}
+}
+
+func (c *Category) customAttribute(code string) string {
+	for _, attribute := range c.CustomAttributes {
+		if attribute.AttributeCode == code {
+			return attribute.Value
+		}
+	}
+	return ""
+}
+
+// SEOEntry is the SEO data of one product (Sku set) or category (CategoryID set)
+type SEOEntry struct {
+	Sku        string `json:"sku,omitempty"`
+	CategoryID int    `json:"category_id,omitempty"`
+	Name       string `json:"name"`
+	SEOMetadata
+}
+
+// ReadProductSEO returns the SEO data of the products matching criteria as seen by the given store view,
+// an empty storeCode reads through the client's store
+func ReadProductSEO(ctx context.Context, criteria *SearchCriteria, storeCode string, apiClient *Client) ([]SEOEntry, error) {
+	route := newRequestOptions([]RequestOption{WithStoreCode(storeCode)}).endpoint(apiClient, products)
+	items, err := searchAll[Product](ctx, route, criteria, "read product seo data", apiClient)
+	if err != nil {
+		return nil, err
+	}
+	entries := make([]SEOEntry, 0, len(items))
+	for i := range items {
+		entries = append(entries, SEOEntry{Sku: items[i].Sku, Name: items[i].Name, SEOMetadata: items[i].SEOMetadata()})
+	}
+	return entries, nil
+}
+
+// ReadCategorySEO returns the SEO data of the categories matching criteria as seen by the given store view,
+// an empty storeCode reads through the client's store
+func ReadCategorySEO(ctx context.Context, criteria *SearchCriteria, storeCode string, apiClient *Client) ([]SEOEntry, error) {
+	route := newRequestOptions([]RequestOption{WithStoreCode(storeCode)}).endpoint(apiClient, categoriesList)
+	items, err := searchAll[Category](ctx, route, criteria, "read category seo data", apiClient)
+	if err != nil {
+		return nil, err
+	}
+	entries := make([]SEOEntry, 0, len(items))
+	for i := range items {
+		entries = append(entries, SEOEntry{CategoryID: items[i].ID, Name: items[i].Name, SEOMetadata: items[i].SEOMetadata()})
+	}
+	return entries, nil
+}
+
+// SEOTemplate is a parsed template like "{name} | {brand}". Placeholders name an attribute code, or one of
+// sku, name and id
+type SEOTemplate struct {
+	source string
+	parts  []seoTemplatePart
+}
+
+type seoTemplatePart struct {
+	text        string
+	placeholder bool
+}
+
+// ParseSEOTemplate parses the template, rejecting unbalanced braces and empty placeholders
+func ParseSEOTemplate(template string) (*SEOTemplate, error) {
+	t := &SEOTemplate{source: template}
+	rest := template
+	for rest != "" {
+		open := strings.IndexAny(rest, "{}")
+		if open < 0 {
+			t.parts = append(t.parts, seoTemplatePart{text: rest})
+			break
+		}
+		if rest[open] == '}' {
+			return nil, &ValidationError{Entity: "seo template " + template, Field: "template", Reason: "unexpected '}'"}
+		}
+		if open > 0 {
+			t.parts = append(t.parts, seoTemplatePart{text: rest[:open]})
+		}
+		end := strings.IndexAny(rest[open+1:], "{}")
+		if end < 0 || rest[open+1+end] != '}' {
+			return nil, &ValidationError{Entity: "seo template " + template, Field: "template", Reason: "unclosed '{'"}
+		}
+		code := strings.TrimSpace(rest[open+1 : open+1+end])
+		if code == "" {
+			return nil, &ValidationError{Entity: "seo template " + template, Field: "template", Reason: "empty placeholder"}
+		}
+		t.parts = append(t.parts, seoTemplatePart{text: code, placeholder: true})
+		rest = rest[open+2+end:]
+	}
+	return t, nil
+}
+
+func (t *SEOTemplate) String() string {
+	return t.source
+}
+
+// Placeholders returns the codes the template refers to, without duplicates
+func (t *SEOTemplate) Placeholders() []string {
+	seen := map[string]bool{}
+	codes := []string{}
+	for _, part := range t.parts {
+		if part.placeholder && !seen[part.text] {
+			seen[part.text] = true
+			codes = append(codes, part.text)
+		}
+	}
+	return codes
+}
+
+// Render fills in the placeholders. Whitespace is collapsed and separators left dangling by empty values
+// are trimmed, so "{name} | {brand}" renders as "Tee" for a product without brand
+func (t *SEOTemplate) Render(value func(code string) string) string {
+	var b strings.Builder
+	for _, part := range t.parts {
+		if part.placeholder {
+			b.WriteString(value(part.text))
+		} else {
+			b.WriteString(part.text)
+		}
+	}
+	rendered := strings.Join(strings.Fields(b.String()), " ")
+	return strings.TrimFunc(rendered, func(r rune) bool {
+		return unicode.IsSpace(r) || strings.ContainsRune("|-–—,:;/", r)
+	})
+}
+
+// FormatURLKey turns a rendered value into a url key: lower case ASCII letters and digits, everything
+// else collapsed into single dashes
+func FormatURLKey(value string) string {
+	var b strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(value) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			if dash && b.Len() > 0 {
+				b.WriteByte('-')
+			}
+			b.WriteRune(r)
+			dash = false
+			continue
+		}
+		dash = true
+	}
+	return b.String()
+}
+
+// SEOTemplates holds a template per field, fields with an empty template are left alone
+type SEOTemplates struct {
+	MetaTitle       string `json:"meta_title,omitempty"`
+	MetaKeyword     string `json:"meta_keyword,omitempty"`
+	MetaDescription string `json:"meta_description,omitempty"`
+	URLKey          string `json:"url_key,omitempty"`
+}
+
+func (t SEOTemplates) parse() (map[string]*SEOTemplate, error) {
+	sources := map[string]string{
+		SEOFieldMetaTitle:       t.MetaTitle,
+		SEOFieldMetaKeyword:     t.MetaKeyword,
+		SEOFieldMetaDescription: t.MetaDescription,
+		SEOFieldURLKey:          t.URLKey,
+	}
+	parsed := map[string]*SEOTemplate{}
+	for _, field := range SEOFields {
+		if sources[field] == "" {
+			continue
+		}
+		template, err := ParseSEOTemplate(sources[field])
+		if err != nil {
+			return nil, err
+		}
+		parsed[field] = template
+	}
+	if len(parsed) == 0 {
+		return nil, &ValidationError{Entity: "seo templates", Field: "templates", Reason: "no template given"}
+	}
+	return parsed, nil
+}
+
+// SEOUpdateOptions configures PlanProductSEO and PlanCategorySEO. StoreCode is the store view values are
+// read from and written to, empty means the client's store and "all" the default scope. By default only
+// empty fields are filled, Overwrite replaces existing values as well. Workers fetches product pages in
+// parallel
+type SEOUpdateOptions struct {
+	StoreCode string
+	Overwrite bool
+	Workers   int
+}
+
+// SEOChange is one field of a product or category the plan changes. Field is the attribute code written,
+// which is meta_keywords for the keywords of a category
+type SEOChange struct {
+	Sku        string `json:"sku,omitempty"`
+	CategoryID int    `json:"category_id,omitempty"`
+	StoreCode  string `json:"store_code,omitempty"`
+	Field      string `json:"field"`
+	Old        string `json:"old"`
+	New        string `json:"new"`
+}
+
+func (c SEOChange) String() string {
+	target := c.Sku
+	if target == "" {
+		target = "category " + strconv.Itoa(c.CategoryID)
+	}
+	if c.StoreCode != "" {
+		target += " @" + c.StoreCode
+	}
+	return fmt.Sprintf("%s %s: %q -> %q", target, c.Field, c.Old, c.New)
+}
+
+// SEOPlan lists the SEO changes of a selection. Nothing is written while planning, so the plan doubles as
+// dry run, Enqueue hands it to a WriteQueue
+type SEOPlan struct {
+	Changes []SEOChange `json:"changes"`
+}
+
+// String lists the changes one per line, e.g. for the output of a dry run
+func (p *SEOPlan) String() string {
+	var b strings.Builder
+	for _, change := range p.Changes {
+		b.WriteString(change.String())
+		b.WriteByte('\n')
+	}
+	return b.String()
+}
+
+// seoPlanner renders the templates of one plan. Select and multiselect values are rendered as their option
+// labels, placeholders naming an unknown attribute fail the plan
+type seoPlanner struct {
+	templates  map[string]*SEOTemplate
+	opts       SEOUpdateOptions
+	attributes *AttributeCache
+	options    map[string]map[string]string
+	plan       *SEOPlan
+}
+
+func newSEOPlanner(ctx context.Context, templates SEOTemplates, opts SEOUpdateOptions, builtin []string, apiClient *Client) (*seoPlanner, error) {
+	parsed, err := templates.parse()
+	if err != nil {
+		return nil, err
+	}
+	p := &seoPlanner{
+		templates:  parsed,
+		opts:       opts,
+		attributes: NewAttributeCache(apiClient),
+		options:    map[string]map[string]string{},
+		plan:       &SEOPlan{Changes: []SEOChange{}},
+	}
+	for _, field := range SEOFields {
+		template, ok := parsed[field]
+		if !ok {
+			continue
+		}
+		for _, code := range template.Placeholders() {
+			if slices.Contains(builtin, code) {
+				continue
+			}
+			if _, known := p.options[code]; known {
+				continue
+			}
+			attribute, err := p.attributes.Get(ctx, code)
+			if errors.Is(err, ErrNotFound) {
+				return nil, &ValidationError{Entity: "seo template " + template.String(), Field: field, Reason: "unknown attribute " + code}
+			}
+			if err != nil {
+				return nil, err
+			}
+			labels := map[string]string{}
+			if attribute.FrontendInput == "select" || attribute.FrontendInput == "multiselect" {
+				for _, option := range attribute.Options {
+					labels[option.Value] = option.Label
+				}
+			}
+			p.options[code] = labels
+		}
+	}
+	return p, nil
+}
+
+// label maps option values of select attributes to their labels, other values are returned as they are
+func (p *seoPlanner) label(code, value string) string {
+	labels := p.options[code]
+	if len(labels) == 0 || value == "" {
+		return value
+	}
+	values := strings.Split(value, ",")
+	for i, v := range values {
+		if label, ok := labels[v]; ok {
+			values[i] = label
+		}
+	}
+	return strings.Join(values, ", ")
+}
+
+// changes renders the templates for one entity and returns the fields that differ. meta_keyword is
+// written as keywordsField, which differs between products and categories
+func (p *seoPlanner) changes(current SEOMetadata, keywordsField string, value func(code string) string) []SEOChange {
+	changes := []SEOChange{}
+	for _, field := range SEOFields {
+		template, ok := p.templates[field]
+		if !ok {
+			continue
+		}
+		old := current.Field(field)
+		if old != "" && !p.opts.Overwrite {
+			continue
+		}
+		rendered := template.Render(value)
+		if field == SEOFieldURLKey {
+			rendered = FormatURLKey(rendered)
+		}
+		if rendered == "" || rendered == old {
+			continue
+		}
+		code := field
+		if field == SEOFieldMetaKeyword {
+			code = keywordsField
+		}
+		changes = append(changes, SEOChange{StoreCode: p.opts.StoreCode, Field: code, Old: old, New: rendered})
+	}
+	return changes
+}
+
+// PlanProductSEO renders the templates for the products matching criteria and returns the resulting changes
+func PlanProductSEO(ctx context.Context, criteria *SearchCriteria, templates SEOTemplates, opts SEOUpdateOptions, apiClient *Client) (*SEOPlan, error) {
+	planner, err := newSEOPlanner(ctx, templates, opts, []string{"sku", "name", "id"}, apiClient)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Debug().Str("storeCode", opts.StoreCode).Int("templates", len(planner.templates)).Msg("Planning product seo changes")
+
+	route := newRequestOptions([]RequestOption{WithStoreCode(opts.StoreCode)}).endpoint(apiClient, products)
+	err = searchPagesParallel(ctx, route, criteria, opts.Workers, "search products for seo plan", apiClient, func(items []Product) error {
+		for i := range items {
+			product := &items[i]
+			value := func(code string) string {
+				switch code {
+				case "sku":
+					return product.Sku
+				case "name":
+					return product.Name
+				case "id":
+					return strconv.Itoa(product.ID)
+				}
+				return planner.label(code, product.CustomAttributeString(code))
+			}
+			for _, change := range planner.changes(product.SEOMetadata(), SEOFieldMetaKeyword, value) {
+				change.Sku = product.Sku
+				planner.plan.Changes = append(planner.plan.Changes, change)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error planning product seo changes: %w", err)
+	}
+	return planner.plan, nil
+}
+
+// PlanCategorySEO renders the templates for the categories matching criteria and returns the resulting
+// changes
+func PlanCategorySEO(ctx context.Context, criteria *SearchCriteria, templates SEOTemplates, opts SEOUpdateOptions, apiClient *Client) (*SEOPlan, error) {
+	planner, err := newSEOPlanner(ctx, templates, opts, []string{"name", "id"}, apiClient)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Debug().Str("storeCode", opts.StoreCode).Int("templates", len(planner.templates)).Msg("Planning category seo changes")
+
+	route := newRequestOptions([]RequestOption{WithStoreCode(opts.StoreCode)}).endpoint(apiClient, categoriesList)
+	items, err := searchAll[Category](ctx, route, criteria, "search categories for seo plan", apiClient)
+	if err != nil {
+		return nil, fmt.Errorf("error planning category seo changes: %w", err)
+	}
+	for i := range items {
+		category := &items[i]
+		value := func(code string) string {
+			switch code {
+			case "name":
+				return category.Name
+			case "id":
+				return strconv.Itoa(category.ID)
+			}
+			return planner.label(code, category.customAttribute(code))
+		}
+		for _, change := range planner.changes(category.SEOMetadata(), categoryMetaKeywordsAttributeCode, value) {
+			change.CategoryID = category.ID
+			planner.plan.Changes = append(planner.plan.Changes, change)
+		}
+	}
+	return planner.plan, nil
+}
+
+// Enqueue hands the changes to the queue, one write operation per product or category and store view, and
+// returns the number of operations enqueued. The queue must be started
+func (p *SEOPlan) Enqueue(queue *WriteQueue) (int, error) {
+	type target struct {
+		sku        string
+		categoryID int
+		storeCode  string
+	}
+	var order []target
+	attributes := map[target][]CustomAttributes{}
+	for _, change := range p.Changes {
+		key := target{sku: change.Sku, categoryID: change.CategoryID, storeCode: change.StoreCode}
+		if _, ok := attributes[key]; !ok {
+			order = append(order, key)
+		}
+		attributes[key] = append(attributes[key], CustomAttributes{AttributeCode: change.Field, Value: change.New})
+	}
+
+	for i, key := range order {
+		op := &WriteOperation{Sku: key.sku, StoreCode: key.storeCode}
+		fields := map[string]any{"custom_attributes": attributes[key]}
+		if key.sku == "" {
+			op.Type = WriteOperationCategory
+			op.CategoryID = key.categoryID
+			op.CategoryFields = fields
+		} else {
+			op.Type = WriteOperationProduct
+			op.ProductFields = fields
+		}
+		if err := queue.Enqueue(op); err != nil {
+			return i, fmt.Errorf("error enqueueing seo changes: %w", err)
+		}
+	}
+	return len(order), nil
+}
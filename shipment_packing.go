@@ -0,0 +1,110 @@
+package magento2
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/rs/zerolog/log"
+)
+
+// PackShipments splits the items left to ship across parcels. Items are placed heaviest first into
+// the first open parcel with room, a new parcel uses the first box in the given order that takes at
+// least one unit. Each request copies Notify, AppendComment and Comment from template
+func PackShipments(order *Order, boxes []Box, template ShipmentRequest) ([]PackedShipment, error) {
+	var items []*Item
+	for i := range order.Items {
+		if order.Items[i].ParentItemID == 0 && NewDecimalFromFloat(qtyToShip(&order.Items[i])).Sign() > 0 {
+			items = append(items, &order.Items[i])
+		}
+	}
+	sort.SliceStable(items, func(i, j int) bool {
+		return items[i].Weight > items[j].Weight
+	})
+
+	var packages []PackedShipment
+	for _, item := range items {
+		remaining := qtyToShip(item)
+		for i := range packages {
+			if remaining <= 0 {
+				break
+			}
+			remaining -= addToPackage(&packages[i], item, remaining)
+		}
+		for remaining > 0 {
+			packed := false
+			for _, box := range boxes {
+				parcel := PackedShipment{
+					Box: box,
+					Request: &ShipmentRequest{
+						Notify:        template.Notify,
+						AppendComment: template.AppendComment,
+						Comment:       template.Comment,
+					},
+				}
+				if qty := addToPackage(&parcel, item, remaining); qty > 0 {
+					remaining -= qty
+					packages = append(packages, parcel)
+					packed = true
+					break
+				}
+			}
+			if !packed {
+				return nil, fmt.Errorf("%w: item '%s' with weight %v", ErrItemDoesNotFit, item.Sku, item.Weight)
+			}
+		}
+	}
+	return packages, nil
+}
+
+// addToPackage puts as many units of the item into the parcel as the box allows and returns the quantity added
+func addToPackage(parcel *PackedShipment, item *Item, remaining float64) float64 {
+	qty := remaining
+	if parcel.Box.MaxWeight > 0 && item.Weight > 0 {
+		qty = math.Min(qty, math.Floor((parcel.Box.MaxWeight-parcel.Weight)/item.Weight+1e-9))
+	}
+	if parcel.Box.MaxQty > 0 {
+		qty = math.Min(qty, math.Floor(parcel.Box.MaxQty-parcel.Qty+1e-9))
+	}
+	if qty <= 0 {
+		return 0
+	}
+
+	parcel.Weight += qty * item.Weight
+	parcel.Qty += qty
+	parcel.Request.Items = append(parcel.Request.Items, ShipmentItem{
+		OrderItemID: int(item.ItemID),
+		Qty:         qty,
+	})
+	return qty
+}
+
+// ShipInPackages packs the items left to ship with PackShipments and creates one shipment per parcel,
+// one after the other. The IDs of the shipments created before an error are returned with it
+func (mo *MOrder) ShipInPackages(ctx context.Context, boxes []Box, template ShipmentRequest, opts ...RequestOption) ([]int, error) {
+	err := mo.UpdateFromRemote()
+	if err != nil {
+		return nil, fmt.Errorf("error refreshing order before packing: %w", err)
+	}
+
+	packages, err := PackShipments(mo.Order, boxes, template)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Debug().
+		Int("orderID", mo.Order.EntityID).
+		Int("packages", len(packages)).
+		Msg("Shipping order in packages")
+
+	shipmentIDs := make([]int, 0, len(packages))
+	for i := range packages {
+		shipmentID, err := mo.CreateShipment(ctx, packages[i].Request, opts...)
+		if err != nil {
+			return shipmentIDs, fmt.Errorf("error shipping package %d of %d: %w", i+1, len(packages), err)
+		}
+		shipmentIDs = append(shipmentIDs, shipmentID)
+	}
+	return shipmentIDs, nil
+}
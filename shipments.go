@@ -0,0 +1,116 @@
+package magento2
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/rs/zerolog/log"
+)
+
+type MShipment struct {
+	Route     string
+	Shipment  *Shipment
+	APIClient *Client
+}
+
+// TrackingLink is a shipment track with the carrier's tracking page. URL is empty for carriers
+// without a registered template
+type TrackingLink struct {
+	CarrierCode string
+	Title       string
+	TrackNumber string
+	URL         string
+}
+
+var (
+	trackingURLMu sync.RWMutex
+	// trackingURLs maps Magento carrier codes to tracking page templates, "%s" is replaced by the
+	// escaped tracking number
+	trackingURLs = map[string]string{
+		"ups":   "https://www.ups.com/track?tracknum=%s",
+		"usps":  "https://tools.usps.com/go/TrackConfirmAction?tLabels=%s",
+		"fedex": "https://www.fedex.com/fedextrack/?trknbr=%s",
+		"dhl":   "https://www.dhl.com/en/express/tracking.html?AWB=%s",
+	}
+)
+
+// RegisterTrackingURL adds or replaces the tracking page template of a carrier, e.g.
+// RegisterTrackingURL("dpd", "https://tracking.dpd.de/status/en_US/parcel/%s"). The template must
+// contain "%s" exactly once, other percent signs are kept as they are
+func RegisterTrackingURL(carrierCode, template string) error {
+	if strings.Count(template, "%s") != 1 {
+		return fmt.Errorf("%w: '%s'", ErrInvalidTrackingURL, template)
+	}
+	trackingURLMu.Lock()
+	trackingURLs[strings.ToLower(carrierCode)] = template
+	trackingURLMu.Unlock()
+	return nil
+}
+
+// UnregisterTrackingURL removes the tracking page template of a carrier
+func UnregisterTrackingURL(carrierCode string) {
+	trackingURLMu.Lock()
+	delete(trackingURLs, strings.ToLower(carrierCode))
+	trackingURLMu.Unlock()
+}
+
+// TrackingURL returns the tracking page of a tracking number, if a template is registered for the carrier.
+// The number is escaped for the position of "%s": as query value after a "?", as path segment before
+func TrackingURL(carrierCode, trackNumber string) (string, bool) {
+	trackingURLMu.RLock()
+	template, ok := trackingURLs[strings.ToLower(carrierCode)]
+	trackingURLMu.RUnlock()
+	trackNumber = strings.TrimSpace(trackNumber)
+	if !ok || trackNumber == "" {
+		return "", false
+	}
+	placeholder := strings.Index(template, "%s")
+	escaped := url.PathEscape(trackNumber)
+	if strings.Contains(template[:placeholder], "?") {
+		escaped = url.QueryEscape(trackNumber)
+	}
+	return template[:placeholder] + escaped + template[placeholder+len("%s"):], true
+}
+
+func GetShipmentByID(ctx context.Context, id int, apiClient *Client) (*MShipment, error) {
+	mShipment := &MShipment{
+		Route:     fmt.Sprintf("%s/%d", shipment, id),
+		Shipment:  &Shipment{},
+		APIClient: apiClient,
+	}
+
+	log.Debug().
+		Int("shipmentID", id).
+		Str("route", mShipment.Route).
+		Msg("Getting shipment by ID")
+
+	resp, err := apiClient.HTTPClient.R().SetContext(ctx).SetResult(mShipment.Shipment).Get(mShipment.Route)
+	if err != nil {
+		return nil, fmt.Errorf("error getting shipment by ID: %w", err)
+	}
+
+	httpErr := mayReturnErrorForHTTPResponse(resp, "get shipment by id from remote")
+	if httpErr != nil {
+		return nil, httpErr
+	}
+
+	return mShipment, nil
+}
+
+// TrackingLinks returns the tracks of the shipment with their tracking pages, e.g. for notification emails
+func (ms *MShipment) TrackingLinks() []TrackingLink {
+	links := make([]TrackingLink, 0, len(ms.Shipment.Tracks))
+	for _, track := range ms.Shipment.Tracks {
+		link, _ := TrackingURL(track.CarrierCode, track.TrackNumber)
+		links = append(links, TrackingLink{
+			CarrierCode: track.CarrierCode,
+			Title:       track.Title,
+			TrackNumber: track.TrackNumber,
+			URL:         link,
+		})
+	}
+	return links
+}
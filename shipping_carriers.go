@@ -0,0 +1,257 @@
+package magento2
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// DefaultCarrierTitles are the titles core carriers ship with, used when no estimate reported a title
+var DefaultCarrierTitles = map[string]string{
+	"flatrate":     "Flat Rate",
+	"tablerate":    "Best Way",
+	"freeshipping": "Free Shipping",
+	"ups":          "United Parcel Service",
+	"usps":         "United States Postal Service",
+	"fedex":        "Federal Express",
+	"dhl":          "DHL",
+	"instore":      "In-Store Pickup",
+}
+
+// defaultCarrierMethodCodes are the method codes of core carriers with a single method whose code
+// differs from the carrier code, e.g. tablerate_bestway
+var defaultCarrierMethodCodes = map[string]string{
+	"tablerate": "bestway",
+	"instore":   "pickup",
+}
+
+// ShippingDestination is the part of an address shipping rates depend on
+type ShippingDestination struct {
+	CountryID  string
+	RegionCode string
+	Postcode   string
+}
+
+// NewShippingDestination returns the destination of the address
+func NewShippingDestination(addr *ShippingAddress) ShippingDestination {
+	return ShippingDestination{CountryID: addr.CountryID, RegionCode: addr.RegionCode, Postcode: addr.Postcode}
+}
+
+// ShippingRate is what the last estimate for a destination returned for a method. Unavailable methods
+// carry the carrier's ErrorMessage, which is what shipping rule debugging usually needs
+type ShippingRate struct {
+	Destination  ShippingDestination
+	Amount       Decimal
+	PriceInclTax Decimal
+	Available    bool
+	ErrorMessage string
+	EstimatedAt  time.Time
+}
+
+// ShippingMethodInfo is a method of a carrier with its last rate per destination
+type ShippingMethodInfo struct {
+	Code  string
+	Title string
+	Rates map[ShippingDestination]ShippingRate
+}
+
+// ShippingCarrierInfo is a carrier with the methods seen for it
+type ShippingCarrierInfo struct {
+	Code    string
+	Title   string
+	Methods map[string]*ShippingMethodInfo
+}
+
+// ShippingCarrierRegistry collects the carriers and methods of store views from shipping estimates, with
+// the rate of the last estimate per destination. Carriers configured but never estimated can be added
+// with Register or from the configuration with RegisterConfig. It is safe for concurrent use, returned values are copies
+type ShippingCarrierRegistry struct {
+	mu     sync.RWMutex
+	stores map[string]map[string]*ShippingCarrierInfo
+	now    func() time.Time
+}
+
+func NewShippingCarrierRegistry() *ShippingCarrierRegistry {
+	return &ShippingCarrierRegistry{
+		stores: map[string]map[string]*ShippingCarrierInfo{},
+		now:    time.Now,
+	}
+}
+
+// Estimate estimates the shipping methods of the cart for the address and records the result for the
+// store view of the cart's client
+func (r *ShippingCarrierRegistry) Estimate(cart *MCart, addr *ShippingAddress) ([]Carrier, error) {
+	carriers, err := cart.EstimateShippingCarrier(addr)
+	if err != nil {
+		return nil, err
+	}
+	storeCode := ""
+	if cart.APIClient.storeConfig != nil {
+		storeCode = cart.APIClient.storeConfig.StoreCode
+	}
+	r.Record(storeCode, NewShippingDestination(addr), carriers)
+	return carriers, nil
+}
+
+// Record adds the result of an estimate made elsewhere, replacing the rates of the destination. Methods
+// the estimate no longer returns lose their rate for the destination
+func (r *ShippingCarrierRegistry) Record(storeCode string, destination ShippingDestination, carriers []Carrier) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, carrier := range r.stores[storeCode] {
+		for _, method := range carrier.Methods {
+			delete(method.Rates, destination)
+		}
+	}
+	now := r.now()
+	for _, carrier := range carriers {
+		method := r.method(storeCode, carrier.CarrierCode, carrier.CarrierTitle, carrier.MethodCode, carrier.MethodTitle)
+		method.Rates[destination] = ShippingRate{
+			Destination:  destination,
+			Amount:       NewDecimalFromFloat(carrier.Amount),
+			PriceInclTax: NewDecimalFromFloat(carrier.PriceInclTax),
+			Available:    carrier.Available,
+			ErrorMessage: carrier.ErrorMessage,
+			EstimatedAt:  now,
+		}
+	}
+	log.Debug().
+		Str("storeCode", storeCode).
+		Str("countryID", destination.CountryID).
+		Int("methods", len(carriers)).
+		Msg("Shipping estimate recorded")
+}
+
+// Register adds a carrier method without a rate, e.g. one known from the configuration
+func (r *ShippingCarrierRegistry) Register(storeCode, carrierCode, carrierTitle, methodCode, methodTitle string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.method(storeCode, carrierCode, carrierTitle, methodCode, methodTitle)
+}
+
+// RegisterConfig adds the carriers the store view's configuration enables. Magento exposes no carrier
+// configuration through its APIs, so config holds the values of the carriers/* paths, e.g. read with
+// ReadConfigShow from the output of bin/magento config:show carriers --scope=stores --scope-code=<store>.
+// A carrier's name is the title of its methods; carriers with allowed_methods register each of them
+// without a title
+func (r *ShippingCarrierRegistry) RegisterConfig(storeCode string, config map[string]string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for path, active := range config {
+		carrierCode, ok := strings.CutSuffix(strings.TrimPrefix(path, "carriers/"), "/active")
+		if !strings.HasPrefix(path, "carriers/") || !ok || strings.Contains(carrierCode, "/") || active != "1" {
+			continue
+		}
+		prefix := "carriers/" + carrierCode + "/"
+		title, name := config[prefix+"title"], config[prefix+"name"]
+		if allowed := config[prefix+"allowed_methods"]; allowed != "" {
+			for _, methodCode := range strings.Split(allowed, ",") {
+				r.method(storeCode, carrierCode, title, strings.TrimSpace(methodCode), "")
+			}
+			continue
+		}
+		methodCode, ok := defaultCarrierMethodCodes[carrierCode]
+		if !ok {
+			methodCode = carrierCode
+		}
+		r.method(storeCode, carrierCode, title, methodCode, name)
+	}
+}
+
+// ReadConfigShow reads the "path - value" lines bin/magento config:show prints into a map by path
+func ReadConfigShow(r io.Reader) (map[string]string, error) {
+	config := map[string]string{}
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		path, value, ok := strings.Cut(scanner.Text(), " - ")
+		if !ok {
+			continue
+		}
+		config[strings.TrimSpace(path)] = strings.TrimSpace(value)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("error reading config: %w", err)
+	}
+	return config, nil
+}
+
+// method returns the entry of the method, creating it as needed. Non-empty titles replace known ones
+func (r *ShippingCarrierRegistry) method(storeCode, carrierCode, carrierTitle, methodCode, methodTitle string) *ShippingMethodInfo {
+	carriers, ok := r.stores[storeCode]
+	if !ok {
+		carriers = map[string]*ShippingCarrierInfo{}
+		r.stores[storeCode] = carriers
+	}
+	carrier, ok := carriers[carrierCode]
+	if !ok {
+		carrier = &ShippingCarrierInfo{Code: carrierCode, Methods: map[string]*ShippingMethodInfo{}}
+		carriers[carrierCode] = carrier
+	}
+	if carrierTitle != "" {
+		carrier.Title = carrierTitle
+	}
+	method, ok := carrier.Methods[methodCode]
+	if !ok {
+		method = &ShippingMethodInfo{Code: methodCode, Rates: map[ShippingDestination]ShippingRate{}}
+		carrier.Methods[methodCode] = method
+	}
+	if methodTitle != "" {
+		method.Title = methodTitle
+	}
+	return method
+}
+
+// Carriers returns the carriers known for the store view sorted by code
+func (r *ShippingCarrierRegistry) Carriers(storeCode string) []ShippingCarrierInfo {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	carriers := r.stores[storeCode]
+	result := make([]ShippingCarrierInfo, 0, len(carriers))
+	for _, code := range sortedKeys(carriers) {
+		carrier := ShippingCarrierInfo{Code: code, Title: carriers[code].Title, Methods: map[string]*ShippingMethodInfo{}}
+		for methodCode, method := range carriers[code].Methods {
+			rates := make(map[ShippingDestination]ShippingRate, len(method.Rates))
+			for destination, rate := range method.Rates {
+				rates[destination] = rate
+			}
+			carrier.Methods[methodCode] = &ShippingMethodInfo{Code: method.Code, Title: method.Title, Rates: rates}
+		}
+		result = append(result, carrier)
+	}
+	return result
+}
+
+// Rate returns the rate the last estimate for the destination returned for the method
+func (r *ShippingCarrierRegistry) Rate(storeCode, carrierCode, methodCode string, destination ShippingDestination) (ShippingRate, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	carrier, ok := r.stores[storeCode][carrierCode]
+	if !ok {
+		return ShippingRate{}, false
+	}
+	method, ok := carrier.Methods[methodCode]
+	if !ok {
+		return ShippingRate{}, false
+	}
+	rate, ok := method.Rates[destination]
+	return rate, ok
+}
+
+// CarrierTitle returns the title of the carrier in the store view, falling back to DefaultCarrierTitles
+// and finally to the code itself
+func (r *ShippingCarrierRegistry) CarrierTitle(storeCode, carrierCode string) string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if carrier, ok := r.stores[storeCode][carrierCode]; ok && carrier.Title != "" {
+		return carrier.Title
+	}
+	if title, ok := DefaultCarrierTitles[carrierCode]; ok {
+		return title
+	}
+	return carrierCode
+}
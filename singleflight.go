@@ -0,0 +1,98 @@
+package magento2
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+)
+
+// WithSingleflight collapses identical GETs in flight at the same time into one upstream request, e.g.
+// when many goroutines of an import pipeline fetch the same product or attribute. Only routes matching
+// one of the path.Match patterns, relative to the REST prefix, are collapsed, e.g. "/products/*" or
+// "/products/attributes/*". Without patterns all GETs are
+func WithSingleflight(routePatterns ...string) ClientOption {
+	return func(o *clientOptions) error {
+		for _, pattern := range routePatterns {
+			if _, err := path.Match(pattern, ""); err != nil {
+				return fmt.Errorf("invalid singleflight route pattern '%s': %w", pattern, err)
+			}
+		}
+		o.singleflight = true
+		o.singleflightRoutes = routePatterns
+		return nil
+	}
+}
+
+type sharedResponse struct {
+	resp *http.Response
+	body []byte
+}
+
+// singleflightTransport shares the response of a GET with identical GETs issued while it is in flight.
+// Requests with a different token are never collapsed
+type singleflightTransport struct {
+	base          http.RoundTripper
+	routePatterns []string
+	flight        flightGroup[string, *sharedResponse]
+}
+
+func (t *singleflightTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Method != http.MethodGet || !t.matches(req.URL.Path) {
+		return t.base.RoundTrip(req)
+	}
+
+	key := req.URL.String() + "\n" + req.Header.Get("Authorization")
+	shared, isShared, err := t.flight.do(req.Context(), key, func() (*sharedResponse, error) {
+		resp, err := t.base.RoundTrip(req)
+		if err != nil {
+			return nil, err
+		}
+		defer resp.Body.Close()
+		body, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return nil, err
+		}
+		return &sharedResponse{resp: resp, body: body}, nil
+	})
+	if isShared && err != nil && req.Context().Err() == nil &&
+		(errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)) {
+		// the leading request gave up, which says nothing about this one
+		return t.base.RoundTrip(req)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if isShared {
+		log.Debug().Str("url", req.URL.String()).Msg("Shared response of identical in-flight GET")
+	}
+
+	resp := *shared.resp
+	resp.Header = shared.resp.Header.Clone()
+	resp.Body = io.NopCloser(bytes.NewReader(shared.body))
+	resp.ContentLength = int64(len(shared.body))
+	resp.Request = req
+	return &resp, nil
+}
+
+func (t *singleflightTransport) matches(urlPath string) bool {
+	if len(t.routePatterns) == 0 {
+		return true
+	}
+	route := urlPath
+	if i := strings.Index(urlPath, "/V1/"); i >= 0 {
+		route = urlPath[i+len("/V1"):]
+	}
+	for _, pattern := range t.routePatterns {
+		if ok, _ := path.Match(pattern, route); ok {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,155 @@
+package magento2
+
+import (
+	"context"
+	"strings"
+	"unicode"
+
+	"github.com/rs/zerolog/log"
+)
+
+// inFilterChunkSize keeps the "in" filters of SKU and ID searches within common URL length limits
+const inFilterChunkSize = 100
+
+// SkuCollisionKind tells how an import SKU collides
+type SkuCollisionKind string
+
+const (
+	// SkuCollisionBatch marks SKUs of the same import differing only in case
+	SkuCollisionBatch SkuCollisionKind = "batch"
+	// SkuCollisionExisting marks SKUs matching an existing product only case-insensitively. Magento treats
+	// them as the same product, so importing them overwrites the existing product
+	SkuCollisionExisting SkuCollisionKind = "existing"
+)
+
+// SkuCollision pairs an import SKU with the SKU it collides with
+type SkuCollision struct {
+	Sku          string
+	CollidesWith string
+	Kind         SkuCollisionKind
+}
+
+// ValidateSku checks the SKU against the rules Magento applies to product SKUs
+func ValidateSku(sku string) error {
+	return validateSku("product", sku)
+}
+
+// NormalizeSku trims the SKU, collapses whitespace runs into a single space, replaces the characters that
+// break REST routes with "-" and cuts it to the maximum length. Case is kept, compare with SkuKey
+func NormalizeSku(sku string) string {
+	var b strings.Builder
+	space := false
+	for _, r := range strings.TrimSpace(sku) {
+		switch {
+		case unicode.IsSpace(r):
+			space = true
+			continue
+		case unicode.IsControl(r):
+			continue
+		case r == '/' || r == '?' || r == '#':
+			r = '-'
+		}
+		if space {
+			b.WriteRune(' ')
+			space = false
+		}
+		b.WriteRune(r)
+	}
+
+	normalized := []rune(b.String())
+	if len(normalized) > maxSkuLength {
+		normalized = normalized[:maxSkuLength]
+	}
+	return strings.TrimSpace(string(normalized))
+}
+
+// SkuKey returns the form under which Magento considers SKUs equal: normalized and case-folded
+func SkuKey(sku string) string {
+	return strings.ToLower(NormalizeSku(sku))
+}
+
+// FindSkuCollisions checks SKUs before an import. It reports SKUs of the batch that differ only in case and
+// SKUs that match an existing product case-insensitively but not exactly. Exact matches are plain updates
+// and not reported
+func FindSkuCollisions(ctx context.Context, skus []string, apiClient *Client) ([]SkuCollision, error) {
+	collisions := []SkuCollision{}
+
+	firstByKey := make(map[string]string, len(skus))
+	unique := make([]string, 0, len(skus))
+	for _, sku := range skus {
+		key := SkuKey(sku)
+		first, seen := firstByKey[key]
+		if !seen {
+			firstByKey[key] = sku
+			unique = append(unique, sku)
+			continue
+		}
+		if first != sku {
+			collisions = append(collisions, SkuCollision{Sku: sku, CollidesWith: first, Kind: SkuCollisionBatch})
+		}
+	}
+
+	log.Debug().Int("count", len(unique)).Msg("Checking SKUs for case-insensitive collisions")
+
+	existing, err := searchSkus(ctx, unique, apiClient)
+	if err != nil {
+		return nil, err
+	}
+	for _, sku := range unique {
+		for _, existingSku := range existing[SkuKey(sku)] {
+			if existingSku != sku {
+				collisions = append(collisions, SkuCollision{Sku: sku, CollidesWith: existingSku, Kind: SkuCollisionExisting})
+			}
+		}
+	}
+	return collisions, nil
+}
+
+// searchSkus returns the existing SKUs matching the given ones in any case, keyed by SkuKey. The search asks
+// for the lower and upper case variants too, so it does not depend on a case-insensitive database collation
+func searchSkus(ctx context.Context, skus []string, apiClient *Client) (map[string][]string, error) {
+	existing := make(map[string][]string)
+	found := make(map[string]bool)
+
+	addResults := func(products []Product) {
+		for _, product := range products {
+			if found[product.Sku] {
+				continue
+			}
+			found[product.Sku] = true
+			key := SkuKey(product.Sku)
+			existing[key] = append(existing[key], product.Sku)
+		}
+	}
+
+	values := make([]string, 0, len(skus)*3)
+	for _, sku := range skus {
+		variants := []string{sku, strings.ToLower(sku), strings.ToUpper(sku)}
+		// a comma would split the value of an "in" filter
+		if strings.Contains(sku, ",") {
+			for _, variant := range variants {
+				products, err := SearchProducts(ctx, NewSearchCriteria(SearchFilter{Field: "sku", Value: variant, ConditionType: "eq"}), apiClient)
+				if err != nil {
+					return nil, err
+				}
+				addResults(products)
+			}
+			continue
+		}
+		for i, variant := range variants {
+			if i == 0 || variant != sku {
+				values = append(values, variant)
+			}
+		}
+	}
+
+	for start := 0; start < len(values); start += inFilterChunkSize {
+		chunk := values[start:min(start+inFilterChunkSize, len(values))]
+		products, err := SearchProducts(ctx, NewSearchCriteria(SearchFilter{Field: "sku", Value: strings.Join(chunk, ","), ConditionType: "in"}), apiClient)
+		if err != nil {
+			return nil, err
+		}
+		addResults(products)
+	}
+	return existing, nil
+}
@@ -0,0 +1,41 @@
+package magento2
+
+import (
+	"net/url"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+)
+
+// WithSlowRequestThreshold logs a warning with route, duration and response size for every request
+// attempt that takes longer than threshold
+func WithSlowRequestThreshold(threshold time.Duration) ClientOption {
+	return func(o *clientOptions) error {
+		o.slowRequestThreshold = threshold
+		return nil
+	}
+}
+
+func (c *Client) logSlowRequest(_ *resty.Client, resp *resty.Response) error {
+	duration := resp.Time()
+	if c.slowRequestThreshold <= 0 || duration < c.slowRequestThreshold {
+		return nil
+	}
+
+	route := resp.Request.URL
+	if parsed, err := url.Parse(resp.Request.URL); err == nil {
+		route = parsed.Path
+	}
+
+	logger := LoggerFromContext(resp.Request.Context())
+	logger.Warn().
+		Str("method", resp.Request.Method).
+		Str("route", route).
+		Int("status", resp.StatusCode()).
+		Dur("duration", duration).
+		Dur("threshold", c.slowRequestThreshold).
+		Int64("size", resp.Size()).
+		Int("attempt", resp.Request.Attempt).
+		Msg("Slow Magento request")
+	return nil
+}
@@ -0,0 +1,115 @@
+package magento2
+
+import (
+	"encoding/json"
+)
+
+// stockItemQuantities holds the quantities of a StockItem as Magento sent them. The int fields of
+// StockItem truncate the fractional quantities of products sold by kg or meter, the Decimal accessors
+// return the exact values
+type stockItemQuantities struct {
+	qty, minQty, minSaleQty, maxSaleQty, notifyStockQty, qtyIncrements *Decimal
+}
+
+// stockItemFields has the fields of StockItem without its JSON methods
+type stockItemFields StockItem
+
+// stockItemJSON decodes and encodes the quantities as decimals, its fields take precedence over the
+// int fields of the embedded StockItem
+type stockItemJSON struct {
+	*stockItemFields
+	Qty            *Decimal `json:"qty,omitempty"`
+	MinQty         *Decimal `json:"min_qty,omitempty"`
+	MinSaleQty     *Decimal `json:"min_sale_qty,omitempty"`
+	MaxSaleQty     *Decimal `json:"max_sale_qty,omitempty"`
+	NotifyStockQty *Decimal `json:"notify_stock_qty,omitempty"`
+	QtyIncrements  *Decimal `json:"qty_increments,omitempty"`
+}
+
+func (s *StockItem) UnmarshalJSON(data []byte) error {
+	decoded := stockItemJSON{stockItemFields: (*stockItemFields)(s)}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+	s.quantities = stockItemQuantities{
+		qty:            decoded.Qty,
+		minQty:         decoded.MinQty,
+		minSaleQty:     decoded.MinSaleQty,
+		maxSaleQty:     decoded.MaxSaleQty,
+		notifyStockQty: decoded.NotifyStockQty,
+		qtyIncrements:  decoded.QtyIncrements,
+	}
+	s.Qty = truncatedQuantity(decoded.Qty)
+	s.MinQty = truncatedQuantity(decoded.MinQty)
+	s.MinSaleQty = truncatedQuantity(decoded.MinSaleQty)
+	s.MaxSaleQty = truncatedQuantity(decoded.MaxSaleQty)
+	s.NotifyStockQty = truncatedQuantity(decoded.NotifyStockQty)
+	s.QtyIncrements = truncatedQuantity(decoded.QtyIncrements)
+	return nil
+}
+
+// MarshalJSON sends the exact quantities, unless the int field was changed since they were set
+func (s StockItem) MarshalJSON() ([]byte, error) {
+	return json.Marshal(stockItemJSON{
+		stockItemFields: (*stockItemFields)(&s),
+		Qty:             nonZeroQuantity(s.DecimalQty()),
+		MinQty:          nonZeroQuantity(s.DecimalMinQty()),
+		MinSaleQty:      nonZeroQuantity(s.DecimalMinSaleQty()),
+		MaxSaleQty:      nonZeroQuantity(s.DecimalMaxSaleQty()),
+		NotifyStockQty:  nonZeroQuantity(s.DecimalNotifyStockQty()),
+		QtyIncrements:   nonZeroQuantity(s.DecimalQtyIncrements()),
+	})
+}
+
+func (s *StockItem) DecimalQty() Decimal {
+	return exactQuantity(s.quantities.qty, s.Qty)
+}
+
+// SetDecimalQty sets a fractional quantity, Qty gets its integer part
+func (s *StockItem) SetDecimalQty(qty Decimal) {
+	s.quantities.qty = &qty
+	s.Qty = truncatedQuantity(&qty)
+}
+
+func (s *StockItem) DecimalMinQty() Decimal {
+	return exactQuantity(s.quantities.minQty, s.MinQty)
+}
+
+func (s *StockItem) DecimalMinSaleQty() Decimal {
+	return exactQuantity(s.quantities.minSaleQty, s.MinSaleQty)
+}
+
+func (s *StockItem) DecimalMaxSaleQty() Decimal {
+	return exactQuantity(s.quantities.maxSaleQty, s.MaxSaleQty)
+}
+
+func (s *StockItem) DecimalNotifyStockQty() Decimal {
+	return exactQuantity(s.quantities.notifyStockQty, s.NotifyStockQty)
+}
+
+func (s *StockItem) DecimalQtyIncrements() Decimal {
+	return exactQuantity(s.quantities.qtyIncrements, s.QtyIncrements)
+}
+
+// exactQuantity returns the exact value while the int field still holds its integer part, a caller
+// assigning the int field replaces it
+func exactQuantity(exact *Decimal, truncated int) Decimal {
+	if exact != nil && truncatedQuantity(exact) == truncated {
+		return *exact
+	}
+	return NewDecimal(int64(truncated), 0)
+}
+
+func truncatedQuantity(exact *Decimal) int {
+	if exact == nil {
+		return 0
+	}
+	return int(exact.Float64())
+}
+
+func nonZeroQuantity(quantity Decimal) *Decimal {
+	if quantity.IsZero() {
+		return nil
+	}
+	return &quantity
+}
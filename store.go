@@ -0,0 +1,49 @@
+package magento2
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/rs/zerolog/log"
+)
+
+func GetStoreViews(ctx context.Context, apiClient *Client) ([]StoreView, error) {
+	views := &[]StoreView{}
+
+	log.Debug().Str("endpoint", storeViews).Msg("Getting store views")
+
+	resp, err := apiClient.HTTPClient.R().SetContext(ctx).SetResult(views).Get(storeViews)
+	if err != nil {
+		return nil, fmt.Errorf("error getting store views: %w", err)
+	}
+
+	httpErr := mayReturnErrorForHTTPResponse(resp, "get store views")
+	if httpErr != nil {
+		return nil, httpErr
+	}
+
+	return *views, nil
+}
+
+// GetStoreConfigs returns the configuration of the given store views, or of all store views when no code is given
+func GetStoreConfigs(ctx context.Context, apiClient *Client, storeCodes ...string) ([]StoreConfiguration, error) {
+	configs := &[]StoreConfiguration{}
+
+	log.Debug().Str("endpoint", storeConfigs).Strs("storeCodes", storeCodes).Msg("Getting store configs")
+
+	req := apiClient.HTTPClient.R().SetContext(ctx).SetResult(configs)
+	if len(storeCodes) > 0 {
+		req.SetQueryParamsFromValues(map[string][]string{"storeCodes[]": storeCodes})
+	}
+	resp, err := req.Get(storeConfigs)
+	if err != nil {
+		return nil, fmt.Errorf("error getting store configs: %w", err)
+	}
+
+	httpErr := mayReturnErrorForHTTPResponse(resp, "get store configs")
+	if httpErr != nil {
+		return nil, httpErr
+	}
+
+	return *configs, nil
+}
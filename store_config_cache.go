@@ -0,0 +1,55 @@
+package magento2
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// StoreConfigCache keeps the configuration of all store views, which only changes with admin settings.
+// Configs expire after the TTL, call Invalidate after changing settings. Concurrent misses cause a single
+// request. It is safe for concurrent use
+type StoreConfigCache struct {
+	APIClient *Client
+	cache     ttlCache[struct{}, map[string]*StoreConfiguration]
+}
+
+// NewStoreConfigCache returns a cache keeping configs for ttl, a zero ttl keeps them until invalidated
+func NewStoreConfigCache(apiClient *Client, ttl time.Duration) *StoreConfigCache {
+	return &StoreConfigCache{
+		APIClient: apiClient,
+		cache:     ttlCache[struct{}, map[string]*StoreConfiguration]{ttl: ttl},
+	}
+}
+
+// Get returns the cached configuration of the store view. It is shared between callers and must not be modified
+func (scc *StoreConfigCache) Get(ctx context.Context, storeCode string) (*StoreConfiguration, error) {
+	configs, err := scc.cache.get(ctx, struct{}{}, func(ctx context.Context) (map[string]*StoreConfiguration, error) {
+		log.Debug().Msg("Store config cache miss")
+		list, err := GetStoreConfigs(ctx, scc.APIClient)
+		if err != nil {
+			return nil, fmt.Errorf("error loading store configs for cache: %w", err)
+		}
+		configs := make(map[string]*StoreConfiguration, len(list))
+		for i := range list {
+			configs[list[i].Code] = &list[i]
+		}
+		return configs, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	config, ok := configs[storeCode]
+	if !ok {
+		return nil, fmt.Errorf("%w: store config '%s'", ErrNotFound, storeCode)
+	}
+	return config, nil
+}
+
+// Invalidate drops the cached configs, the next lookup fetches them again
+func (scc *StoreConfigCache) Invalidate() {
+	scc.cache.invalidate()
+}
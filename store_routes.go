@@ -0,0 +1,6 @@
+package magento2
+
+const (
+	storeViews   = "/store/storeViews"
+	storeConfigs = "/store/storeConfigs"
+)
@@ -0,0 +1,33 @@
+package magento2
+
+type StoreView struct {
+	ID                  int            `json:"id"`
+	Code                string         `json:"code"`
+	Name                string         `json:"name"`
+	WebsiteID           int            `json:"website_id"`
+	StoreGroupID        int            `json:"store_group_id"`
+	IsActive            int            `json:"is_active"`
+	ExtensionAttributes map[string]any `json:"extension_attributes,omitempty"`
+}
+
+// StoreConfiguration holds the locale, currency and URL settings of a store view. It is not to be confused
+// with StoreConfig, which configures the client
+type StoreConfiguration struct {
+	ID                         int            `json:"id"`
+	Code                       string         `json:"code"`
+	WebsiteID                  int            `json:"website_id"`
+	Locale                     string         `json:"locale"`
+	BaseCurrencyCode           string         `json:"base_currency_code"`
+	DefaultDisplayCurrencyCode string         `json:"default_display_currency_code"`
+	Timezone                   string         `json:"timezone"`
+	WeightUnit                 string         `json:"weight_unit"`
+	BaseURL                    string         `json:"base_url"`
+	BaseLinkURL                string         `json:"base_link_url"`
+	BaseStaticURL              string         `json:"base_static_url"`
+	BaseMediaURL               string         `json:"base_media_url"`
+	SecureBaseURL              string         `json:"secure_base_url"`
+	SecureBaseLinkURL          string         `json:"secure_base_link_url"`
+	SecureBaseStaticURL        string         `json:"secure_base_static_url"`
+	SecureBaseMediaURL         string         `json:"secure_base_media_url"`
+	ExtensionAttributes        map[string]any `json:"extension_attributes,omitempty"`
+}
@@ -0,0 +1,65 @@
+package magento2
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+
+	magento2 "github.com/florinel-chis/go-m2rest"
+)
+
+func TestCheckACL(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.Query().Get("searchCriteria[pageSize]") != "1" {
+			t.Errorf("expected a GET of one row, got %s %s", r.Method, r.URL)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/rest/default/V1/products":
+			_, _ = w.Write([]byte(`{"items":[],"total_count":0}`))
+		case "/rest/default/V1/orders":
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"message":"The consumer isn't authorized to access %resources."}`))
+		case "/rest/default/V1/customers/search":
+			w.WriteHeader(http.StatusForbidden)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := magento2.NewClient(context.Background(), magento2.WithStoreURL(server.URL, "default"), magento2.WithBearerToken("token"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	report, err := client.CheckACL(context.Background(), "Magento_Catalog::products", "Magento_Sales::actions_view",
+		"Magento_Customer::customer", "Magento_InventoryApi::source", "Vendor_Module::custom")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := []magento2.ACLStatus{magento2.ACLGranted, magento2.ACLDenied, magento2.ACLDenied, magento2.ACLUnknown, magento2.ACLUnknown}
+	if len(report.Checks) != len(expected) {
+		t.Fatalf("expected a check per resource, got %+v", report.Checks)
+	}
+	for i, check := range report.Checks {
+		if check.Status != expected[i] {
+			t.Errorf("expected %s for %s, got %s (%s)", expected[i], check.Resource, check.Status, check.Detail)
+		}
+	}
+	if missing := report.Missing(); !slices.Equal(missing, []string{"Magento_Sales::actions_view", "Magento_Customer::customer"}) {
+		t.Errorf("unexpected missing resources %v", missing)
+	}
+	if err := report.Err(); !errors.Is(err, magento2.ErrMissingPermissions) {
+		t.Errorf("expected ErrMissingPermissions, got %v", err)
+	}
+
+	granted, err := client.CheckACL(context.Background(), "Magento_Catalog::products")
+	if err != nil || granted.Err() != nil {
+		t.Errorf("expected no missing permissions, got %v (%v)", granted, err)
+	}
+}
@@ -1,6 +1,7 @@
 package magento2
 
 import (
+	"context"
 	"fmt"
 	"testing"
 	"time"
@@ -401,9 +402,22 @@ func TestAdvancedProducts_GroupedProduct(t *testing.T) {
 			Msg("Grouped product created")
 
 		// Step 3: Link associated products to grouped product
-		// Note: This requires additional Magento API calls for product links
-		// For now, we'll log the successful creation
-		t.Logf("Created grouped product with %d associated products. Manual linking required in Magento admin.", len(groupedProducts))
+		var children []magento2.GroupedChild
+		for i, component := range groupedProducts {
+			children = append(children, magento2.GroupedChild{
+				Sku:      component.Product.Sku,
+				Qty:      1,
+				Position: i,
+			})
+		}
+
+		err = mGroup.SetGroupedChildren(context.Background(), children)
+		if err != nil {
+			t.Errorf("Failed to link grouped children: %v", err)
+			return
+		}
+
+		t.Logf("Created grouped product with %d associated products", len(groupedProducts))
 	})
 }
 
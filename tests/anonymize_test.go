@@ -0,0 +1,79 @@
+package magento2
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	magento2 "github.com/florinel-chis/go-m2rest"
+	"github.com/rs/zerolog"
+)
+
+func testOrder() *magento2.Order {
+	return &magento2.Order{
+		IncrementID:      "000000042",
+		CustomerEmail:    "roni_cost@example.com",
+		CustomerLastname: "Costello",
+		GrandTotal:       36.39,
+		BillingAddress: &magento2.BillingAddress{Address: magento2.Address{
+			Firstname: "Veronica",
+			Lastname:  "Costello",
+			Street:    []string{"6146 Honey Bluff Parkway"},
+			Telephone: "(555) 229-3326",
+			CountryID: "US",
+		}},
+	}
+}
+
+func TestAnonymize_Strip(t *testing.T) {
+	order := testOrder()
+	anonymized, err := magento2.AnonymizeOrder(order, magento2.NewAnonymizer(magento2.AnonymizeStrip, ""))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if anonymized.CustomerEmail != "" || anonymized.BillingAddress.Lastname != "" || anonymized.BillingAddress.Street[0] != "" {
+		t.Errorf("expected personal data to be stripped, got %+v", anonymized.BillingAddress)
+	}
+	if anonymized.IncrementID != order.IncrementID || anonymized.GrandTotal != order.GrandTotal || anonymized.BillingAddress.CountryID != "US" {
+		t.Errorf("expected non-personal data to be kept, got %+v", anonymized)
+	}
+	if order.CustomerEmail != "roni_cost@example.com" {
+		t.Errorf("expected original order to be untouched")
+	}
+}
+
+func TestAnonymize_HashCorrelates(t *testing.T) {
+	anonymizer := magento2.NewAnonymizer(magento2.AnonymizeHash, "salt")
+	first, err := magento2.AnonymizeOrder(testOrder(), anonymizer)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	second, _ := magento2.AnonymizeOrder(testOrder(), anonymizer)
+
+	if !strings.HasPrefix(first.CustomerEmail, "sha256:") || first.CustomerEmail != second.CustomerEmail {
+		t.Errorf("expected stable hashes, got %q and %q", first.CustomerEmail, second.CustomerEmail)
+	}
+	if first.CustomerLastname != first.BillingAddress.Lastname {
+		t.Errorf("expected same value to hash identically")
+	}
+}
+
+func TestAnonymize_Logs(t *testing.T) {
+	var buf bytes.Buffer
+	logger := zerolog.New(&buf)
+
+	magento2.EnableLogAnonymization(magento2.NewAnonymizer(magento2.AnonymizeStrip, ""))
+	logger.Info().Interface("order", testOrder()).Msg("order")
+	magento2.DisableLogAnonymization()
+
+	if strings.Contains(buf.String(), "roni_cost@example.com") || strings.Contains(buf.String(), "Honey Bluff") {
+		t.Errorf("expected anonymized log line, got %s", buf.String())
+	}
+
+	buf.Reset()
+	logger.Info().Interface("order", testOrder()).Msg("order")
+	if !strings.Contains(buf.String(), "roni_cost@example.com") {
+		t.Errorf("expected plain log line after disabling, got %s", buf.String())
+	}
+}
@@ -0,0 +1,96 @@
+package magento2
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	magento2 "github.com/florinel-chis/go-m2rest"
+)
+
+func TestCloneAttributeSet(t *testing.T) {
+	var createdGroups, assigned []string
+	var skeletonID int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		path := strings.TrimPrefix(r.URL.Path, "/rest/default/V1/products/attribute-sets")
+		setFilter := r.URL.RawQuery[strings.LastIndex(r.URL.RawQuery, "=")+1:]
+		switch {
+		case r.Method == http.MethodGet && path == "/10":
+			_, _ = w.Write([]byte(`{"attribute_set_id":10,"attribute_set_name":"Bags","sort_order":3,"entity_type_id":4}`))
+		case r.Method == http.MethodGet && path == "/11":
+			_, _ = w.Write([]byte(`{"attribute_set_id":11,"attribute_set_name":"Bags Brand B","sort_order":3,"entity_type_id":4}`))
+		case r.Method == http.MethodGet && path == "/groups/list" && setFilter == "10":
+			_, _ = w.Write([]byte(`{"items":[{"attribute_group_id":"20","attribute_group_name":"General","attribute_set_id":10},` +
+				`{"attribute_group_id":"21","attribute_group_name":"Material","attribute_set_id":10}]}`))
+		case r.Method == http.MethodGet && path == "/groups/list" && setFilter == "11":
+			groups := `{"attribute_group_id":"30","attribute_group_name":"General","attribute_set_id":11}`
+			if len(createdGroups) > 0 {
+				groups += `,{"attribute_group_id":"31","attribute_group_name":"Material","attribute_set_id":11}`
+			}
+			_, _ = w.Write([]byte(`{"items":[` + groups + `]}`))
+		case r.Method == http.MethodGet && path == "/10/attributes":
+			_, _ = w.Write([]byte(`[{"attribute_code":"name"},{"attribute_code":"strap_bags"}]`))
+		case r.Method == http.MethodGet && path == "/11/attributes":
+			attributes := `{"attribute_code":"name"}`
+			if len(assigned) > 0 {
+				attributes += `,{"attribute_code":"strap_bags"}`
+			}
+			_, _ = w.Write([]byte(`[` + attributes + `]`))
+		case r.Method == http.MethodPost && path == "":
+			var payload struct {
+				SkeletonID int `json:"skeletonId"`
+			}
+			_ = json.NewDecoder(r.Body).Decode(&payload)
+			skeletonID = payload.SkeletonID
+			_, _ = w.Write([]byte(`{"attribute_set_id":11,"attribute_set_name":"Bags Brand B"}`))
+		case r.Method == http.MethodPost && path == "/groups":
+			var payload struct {
+				Group magento2.Group `json:"group"`
+			}
+			_ = json.NewDecoder(r.Body).Decode(&payload)
+			createdGroups = append(createdGroups, payload.Group.AttributeGroupName)
+			_, _ = w.Write([]byte(`{}`))
+		case r.Method == http.MethodPost && path == "/attributes":
+			var payload struct {
+				AttributeCode    string `json:"attributeCode"`
+				AttributeGroupID int    `json:"attributeGroupId"`
+			}
+			_ = json.NewDecoder(r.Body).Decode(&payload)
+			if payload.AttributeGroupID != 30 {
+				t.Errorf("expected attribute to be assigned to the first group, got %d", payload.AttributeGroupID)
+			}
+			assigned = append(assigned, payload.AttributeCode)
+			_, _ = w.Write([]byte(`"1"`))
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := magento2.NewClient(context.Background(),
+		magento2.WithStoreURL(server.URL, "default"),
+		magento2.WithBearerToken("token"),
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	clone, err := magento2.CloneAttributeSet(context.Background(), 10, "Bags Brand B", client)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if skeletonID != 10 {
+		t.Errorf("expected the source set to be used as skeleton, got %d", skeletonID)
+	}
+	if strings.Join(createdGroups, ",") != "Material" || strings.Join(assigned, ",") != "strap_bags" {
+		t.Errorf("expected missing group and attribute to be replayed, got groups %v and attributes %v", createdGroups, assigned)
+	}
+	if clone.AttributeSet.AttributeSetID != 11 || len(clone.AttributeSetGroups) != 2 || len(*clone.AttributeSetAttributes) != 2 {
+		t.Errorf("unexpected clone: %+v", clone)
+	}
+}
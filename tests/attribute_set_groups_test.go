@@ -0,0 +1,79 @@
+package magento2
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	magento2 "github.com/florinel-chis/go-m2rest"
+)
+
+func TestAttributeSetGroups(t *testing.T) {
+	groups := map[string]string{"20": "General", "21": "Material"}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		path := strings.TrimPrefix(r.URL.Path, "/rest/default/V1/products/attribute-sets")
+		switch {
+		case r.Method == http.MethodGet && path == "/10":
+			_, _ = w.Write([]byte(`{"attribute_set_id":10,"attribute_set_name":"Bags","entity_type_id":4}`))
+		case r.Method == http.MethodGet && path == "/10/attributes":
+			_, _ = w.Write([]byte(`[]`))
+		case r.Method == http.MethodGet && path == "/groups/list":
+			var items []magento2.Group
+			for _, id := range []string{"20", "21"} {
+				if name, ok := groups[id]; ok {
+					items = append(items, magento2.Group{AttributeGroupID: id, AttributeGroupName: name, AttributeSetID: 10})
+				}
+			}
+			_ = json.NewEncoder(w).Encode(map[string]any{"items": items})
+		case r.Method == http.MethodPut && path == "/10/groups":
+			var payload struct {
+				Group magento2.Group `json:"group"`
+			}
+			_ = json.NewDecoder(r.Body).Decode(&payload)
+			if payload.Group.AttributeSetID != 10 {
+				t.Errorf("expected the group of set 10, got %+v", payload.Group)
+			}
+			groups[payload.Group.AttributeGroupID] = payload.Group.AttributeGroupName
+			_ = json.NewEncoder(w).Encode(payload.Group)
+		case r.Method == http.MethodDelete && path == "/groups/21":
+			delete(groups, "21")
+			_, _ = w.Write([]byte("true"))
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := magento2.NewClient(context.Background(), magento2.WithStoreURL(server.URL, "default"), magento2.WithBearerToken("token"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	mas := &magento2.MAttributeSet{Route: "/products/attribute-sets/10", AttributeSet: &magento2.AttributeSet{AttributeSetID: 10},
+		AttributeSetAttributes: &[]magento2.Attribute{}, APIClient: client}
+	if err := mas.UpdateAttributeSetFromRemote(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	material, err := mas.FindGroupByName("Material")
+	if err != nil || material.AttributeGroupID != "21" {
+		t.Fatalf("expected group 21, got %+v (%v)", material, err)
+	}
+	if err := mas.UpdateGroup(context.Background(), "21", "Materials"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := mas.FindGroupByName("Materials"); err != nil {
+		t.Errorf("expected the renamed group after the refresh, got %v", err)
+	}
+	if err := mas.DeleteGroup(context.Background(), "21"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := mas.FindGroupByName("Materials"); !errors.Is(err, magento2.ErrNotFound) {
+		t.Errorf("expected ErrNotFound for the deleted group, got %v", err)
+	}
+}
@@ -0,0 +1,52 @@
+package magento2
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	magento2 "github.com/florinel-chis/go-m2rest"
+)
+
+func TestAttributeSetSkeletons(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/rest/default/V1/products/attribute-sets/sets/list" {
+			t.Errorf("unexpected request: %s", r.URL)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		query := r.URL.RawQuery
+		switch {
+		case strings.Contains(query, "entity_type_id") && strings.HasSuffix(query, "=4"):
+			_, _ = w.Write([]byte(`{"items":[{"attribute_set_id":9,"attribute_set_name":"Bags","entity_type_id":4},` +
+				`{"attribute_set_id":4,"attribute_set_name":"Default","entity_type_id":4}]}`))
+		case strings.HasSuffix(query, "=3"):
+			_, _ = w.Write([]byte(`{"items":[{"attribute_set_id":12,"attribute_set_name":"Shoes","entity_type_id":3},` +
+				`{"attribute_set_id":11,"attribute_set_name":"Apparel","entity_type_id":3}]}`))
+		default:
+			_, _ = w.Write([]byte(`{"items":[]}`))
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := magento2.NewClient(context.Background(), magento2.WithStoreURL(server.URL, "default"), magento2.WithBearerToken("token"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	sets, err := magento2.ListAttributeSetSkeletons(context.Background(), magento2.EntityTypeProduct, client)
+	if err != nil || len(sets) != 2 || sets[0].AttributeSetName != "Bags" {
+		t.Fatalf("unexpected sets %+v (%v)", sets, err)
+	}
+	if id, err := magento2.DefaultAttributeSetSkeletonID(context.Background(), magento2.EntityTypeProduct, client); err != nil || id != 4 {
+		t.Errorf("expected the Default set 4, got %d (%v)", id, err)
+	}
+	if id, err := magento2.DefaultAttributeSetSkeletonID(context.Background(), magento2.EntityTypeCategory, client); err != nil || id != 11 {
+		t.Errorf("expected the lowest ID 11 without a Default set, got %d (%v)", id, err)
+	}
+	if _, err := magento2.DefaultAttributeSetSkeletonID(context.Background(), magento2.EntityTypeCustomer, client); !errors.Is(err, magento2.ErrNotFound) {
+		t.Errorf("expected ErrNotFound without sets, got %v", err)
+	}
+}
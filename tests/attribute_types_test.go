@@ -0,0 +1,61 @@
+package magento2
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	magento2 "github.com/florinel-chis/go-m2rest"
+)
+
+func TestGetAttributeTypes(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.Path != "/rest/default/V1/products/attributes/types" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`[{"value":"text","label":"Text Field"},{"value":"select","label":"Dropdown"},{"value":"swatch_visual","label":"Visual Swatch"}]`))
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := magento2.NewClient(context.Background(), magento2.WithStoreURL(server.URL, "default"), magento2.WithBearerToken("token"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	types, err := magento2.GetAttributeTypes(context.Background(), client)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(types) != 3 || types[1].Value != magento2.FrontendInputSelect || types[1].Label != "Dropdown" {
+		t.Errorf("unexpected attribute types %+v", types)
+	}
+}
+
+func TestAttribute_FrontendMetadata(t *testing.T) {
+	for input, expected := range map[string]struct {
+		hasOptions  bool
+		backendType string
+	}{
+		magento2.FrontendInputText:         {false, "varchar"},
+		magento2.FrontendInputTextarea:     {false, "text"},
+		magento2.FrontendInputSelect:       {true, "int"},
+		magento2.FrontendInputMultiselect:  {true, "varchar"},
+		magento2.FrontendInputBoolean:      {true, "int"},
+		magento2.FrontendInputSwatchVisual: {true, "int"},
+		magento2.FrontendInputPrice:        {false, "decimal"},
+	} {
+		attribute := &magento2.Attribute{FrontendInput: input}
+		if attribute.HasOptions() != expected.hasOptions {
+			t.Errorf("%s: expected HasOptions %v", input, expected.hasOptions)
+		}
+		if backendType, ok := attribute.ExpectedBackendType(); !ok || backendType != expected.backendType {
+			t.Errorf("%s: expected backend type %s, got %q", input, expected.backendType, backendType)
+		}
+	}
+
+	if _, ok := (&magento2.Attribute{FrontendInput: "gallery"}).ExpectedBackendType(); ok {
+		t.Error("expected no backend type for an unknown input")
+	}
+}
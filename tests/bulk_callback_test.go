@@ -0,0 +1,61 @@
+package magento2
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	magento2 "github.com/florinel-chis/go-m2rest"
+)
+
+const bulkCallbackBody = `{"bulk_id":"6f2e1a0c-8c1d-4b8e-9d55-0d8f2a6c1b11","operation_count":2,"operations_list":[
+	{"id":1,"status":1},
+	{"id":2,"status":3,"result_message":"Product with SKU 'x' not found"}]}`
+
+func TestBulkCallbackHandler(t *testing.T) {
+	var received *magento2.BulkEvent
+	handler := magento2.NewBulkCallbackHandler("secret").OnEvent(func(ctx context.Context, event *magento2.BulkEvent) error {
+		received = event
+		return nil
+	})
+
+	req := httptest.NewRequest(http.MethodPost, "/bulk-callback", strings.NewReader(bulkCallbackBody))
+	req.Header.Set(magento2.DefaultBulkSignatureHeader, magento2.SignBulkCallback("secret", []byte(bulkCallbackBody)))
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusNoContent {
+		t.Fatalf("expected 204, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if received == nil || !received.Completed || len(received.Failed) != 1 || received.Failed[0].ID != 2 {
+		t.Fatalf("unexpected event: %+v", received)
+	}
+}
+
+func TestBulkCallbackHandler_Rejects(t *testing.T) {
+	handler := magento2.NewBulkCallbackHandler("secret")
+
+	cases := map[string]struct {
+		method    string
+		signature string
+		want      int
+	}{
+		"wrong method":      {http.MethodGet, magento2.SignBulkCallback("secret", []byte(bulkCallbackBody)), http.StatusMethodNotAllowed},
+		"missing signature": {http.MethodPost, "", http.StatusUnauthorized},
+		"wrong secret":      {http.MethodPost, magento2.SignBulkCallback("other", []byte(bulkCallbackBody)), http.StatusUnauthorized},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			req := httptest.NewRequest(c.method, "/bulk-callback", strings.NewReader(bulkCallbackBody))
+			req.Header.Set(magento2.DefaultBulkSignatureHeader, c.signature)
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+			if rec.Code != c.want {
+				t.Errorf("expected %d, got %d", c.want, rec.Code)
+			}
+		})
+	}
+}
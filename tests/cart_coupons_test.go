@@ -0,0 +1,59 @@
+package magento2
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	magento2 "github.com/florinel-chis/go-m2rest"
+)
+
+func TestMCart_SimulateCoupon(t *testing.T) {
+	couponApplied := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.Method + " " + r.URL.Path {
+		case "POST /rest/default/V1/guest-carts":
+			_, _ = w.Write([]byte(`"temp-id"`))
+		case "GET /rest/default/V1/guest-carts/temp-id":
+			_, _ = w.Write([]byte(`{"id":4}`))
+		case "GET /rest/default/V1/guest-carts/real-id":
+			_, _ = w.Write([]byte(`{"id":3,"items":[{"sku":"shirt","qty":2}]}`))
+		case "POST /rest/default/V1/guest-carts/temp-id/items":
+			_, _ = w.Write([]byte(`{"sku":"shirt","qty":2}`))
+		case "PUT /rest/default/V1/guest-carts/temp-id/coupons/SAVE10":
+			couponApplied = true
+			_, _ = w.Write([]byte(`true`))
+		case "GET /rest/default/V1/guest-carts/temp-id/totals":
+			if couponApplied {
+				_, _ = w.Write([]byte(`{"grand_total":36,"discount_amount":-4}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"grand_total":40,"discount_amount":0}`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+	client, err := magento2.NewClient(context.Background(), magento2.WithStoreURL(server.URL, "default"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	guest := &magento2.MCart{Route: "/guest-carts/real-id", QuoteID: "real-id", Cart: &magento2.Cart{}, APIClient: client}
+	simulation, err := guest.SimulateCoupon(context.Background(), "SAVE10")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if simulation.DiscountDelta != -4 || simulation.GrandTotalDelta != -4 {
+		t.Errorf("unexpected simulation: %+v", simulation)
+	}
+
+	customer := &magento2.MCart{Route: "/carts/mine", Cart: &magento2.Cart{}, APIClient: client}
+	if _, err := customer.SimulateCoupon(context.Background(), "SAVE10"); !errors.Is(err, magento2.ErrNotGuestCart) {
+		t.Errorf("expected ErrNotGuestCart for a customer cart, got %v", err)
+	}
+}
@@ -0,0 +1,54 @@
+package magento2
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	magento2 "github.com/florinel-chis/go-m2rest"
+)
+
+func TestMCart_ItemTotals(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/rest/default/V1/carts/mine/totals" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"grand_total":52.2,"items":[
+			{"item_id":7,"qty":2,"price":22.5,"row_total":45,"discount_amount":4.5,"tax_amount":7.2},
+			{"item_id":8,"qty":1,"price":10,"row_total":10,"discount_amount":0,"tax_amount":0}
+		]}`))
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := magento2.NewClient(context.Background(), magento2.WithStoreURL(server.URL, "default"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	cart := &magento2.MCart{
+		Route:     "/carts/mine",
+		Cart:      &magento2.Cart{Items: []magento2.CartItem{{ItemID: 7, Sku: "24-MB01"}, {ItemID: 9, Sku: "24-MB02"}}},
+		APIClient: client,
+	}
+
+	items, err := cart.ItemTotals(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(items) != 2 {
+		t.Fatalf("expected 2 item totals, got %d", len(items))
+	}
+
+	totals := cart.Cart.Items[0].Totals
+	if totals == nil || totals.TaxAmount != 7.2 {
+		t.Fatalf("expected totals on item 7, got %+v", totals)
+	}
+	if net := totals.NetRowTotal().String(); net != "40.5" {
+		t.Errorf("expected net row total 40.5, got %s", net)
+	}
+	if cart.Cart.Items[1].Totals != nil {
+		t.Errorf("expected no totals for an item missing from the response")
+	}
+}
@@ -0,0 +1,60 @@
+package magento2
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	magento2 "github.com/florinel-chis/go-m2rest"
+)
+
+func TestMCart_LoginAsCustomer(t *testing.T) {
+	var assigned string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.URL.Path == "/rest/default/V1/integration/customer/token":
+			_, _ = w.Write([]byte(`"customer-token"`))
+		case r.Header.Get("Authorization") != "Bearer customer-token":
+			w.WriteHeader(http.StatusUnauthorized)
+		case r.URL.Path == "/rest/default/V1/customers/me":
+			_, _ = w.Write([]byte(`{"id":42,"store_id":1,"email":"roni_cost@example.com"}`))
+		case r.URL.Path == "/rest/default/V1/guest-carts/masked-id" && r.Method == http.MethodPut:
+			raw, _ := io.ReadAll(r.Body)
+			assigned = string(raw)
+			_, _ = w.Write([]byte(`true`))
+		case r.URL.Path == "/rest/default/V1/carts/mine":
+			_, _ = w.Write([]byte(`{"id":17,"items_count":3,"customer":{"id":42}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := magento2.NewClient(context.Background(), magento2.WithStoreURL(server.URL, "default"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	guest := &magento2.MCart{Route: "/guest-carts/masked-id", QuoteID: "masked-id", Cart: &magento2.Cart{}, APIClient: client}
+
+	customerCart, err := guest.LoginAsCustomer(context.Background(), "roni_cost@example.com", "secret")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if assigned != `{"customerId":42,"storeId":1}` {
+		t.Errorf("unexpected assign payload: %s", assigned)
+	}
+	if customerCart.Route != "/carts/mine" || customerCart.QuoteID != "17" || customerCart.Cart.ItemsCount != 3 {
+		t.Errorf("unexpected customer cart: %+v %+v", customerCart, customerCart.Cart)
+	}
+	if client.HTTPClient.Token != "" {
+		t.Errorf("expected the guest client to stay anonymous")
+	}
+
+	if _, err := customerCart.LoginAsCustomer(context.Background(), "roni_cost@example.com", "secret"); !errors.Is(err, magento2.ErrNotGuestCart) {
+		t.Errorf("expected ErrNotGuestCart, got: %v", err)
+	}
+}
@@ -0,0 +1,47 @@
+package magento2
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	magento2 "github.com/florinel-chis/go-m2rest"
+)
+
+func TestGetAbandonedCarts(t *testing.T) {
+	var queries []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/rest/default/V1/carts/search" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		query := r.URL.Query()
+		queries = append(queries, query.Get("searchCriteria[filter_groups][2][filters][0][value]"))
+		page := query.Get("searchCriteria[currentPage]")
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"items":[{"id":%s,"is_active":true,"items_count":1,"reserved_order_id":"000000042","customer":{"email":"roni_cost@example.com"}}],"total_count":2}`, page)
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := magento2.NewClient(context.Background(), magento2.WithStoreURL(server.URL, "default"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	since := time.Date(2024, 3, 1, 12, 0, 0, 0, time.FixedZone("CET", 3600))
+	criteria := &magento2.SearchCriteria{PageSize: 1}
+	carts, err := magento2.GetAbandonedCarts(context.Background(), since, criteria, client)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(carts) != 2 || carts[1].ID != 2 || carts[0].ReservedOrderID != "000000042" {
+		t.Errorf("unexpected carts: %+v", carts)
+	}
+	if len(queries) != 2 || queries[0] != "2024-03-01 11:00:00" {
+		t.Errorf("expected updated_at filter in UTC, got %v", queries)
+	}
+}
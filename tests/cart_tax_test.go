@@ -0,0 +1,70 @@
+package magento2
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	magento2 "github.com/florinel-chis/go-m2rest"
+)
+
+func TestCartTaxBreakdown(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/rest/default/V1/guest-carts/abc/totals" {
+			t.Errorf("unexpected request: %s", r.URL)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"grand_total":111.25,"tax_amount":11.25,"items":[
+			{"item_id":1,"name":"Strap","qty":1,"row_total":60,"tax_amount":6.75,"tax_percent":11.25},
+			{"item_id":2,"name":"Watch","qty":1,"row_total":40,"tax_amount":4.5,"tax_percent":11.25}],
+		"total_segments":[
+			{"code":"subtotal","value":100},
+			{"code":"tax","value":11.25,"extension_attributes":{"tax_grandtotal_details":[
+				{"amount":8.25,"rates":[{"percent":"6.25","title":"US-TX-*-State"},{"percent":"2","title":"US-TX-78701-City"}],"group_id":1},
+				{"amount":3,"rates":[{"percent":3,"title":"US-TX-*-Transit"}],"group_id":2}]}},
+			{"code":"grand_total","value":111.25}]}`))
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := magento2.NewClient(context.Background(),
+		magento2.WithStoreURL(server.URL, "default"),
+		magento2.WithBearerToken("token"),
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	cart := &magento2.MCart{Route: "/guest-carts/abc", APIClient: client}
+	breakdown, err := cart.TaxBreakdown(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(breakdown.AppliedTaxes) != 2 || breakdown.AppliedTaxes[0].Rates[0].Percent.String() != "6.25" {
+		t.Fatalf("unexpected applied taxes: %+v", breakdown.AppliedTaxes)
+	}
+	for code, amount := range map[string]string{"US-TX-*-State": "6.25", "US-TX-78701-City": "2", "US-TX-*-Transit": "3"} {
+		jurisdiction, ok := breakdown.Jurisdiction(code)
+		if !ok || jurisdiction.Amount.String() != amount {
+			t.Errorf("expected %s of %s, got %+v", amount, code, jurisdiction)
+		}
+	}
+	if !breakdown.Unexplained().IsZero() {
+		t.Errorf("expected the details to add up, %s unexplained", breakdown.Unexplained())
+	}
+	if len(breakdown.Items) != 2 || breakdown.Items[0].TaxAmount.String() != "6.75" {
+		t.Errorf("unexpected item taxes: %+v", breakdown.Items)
+	}
+}
+
+func TestCartTaxBreakdownWithoutDetails(t *testing.T) {
+	totals := &magento2.CartTotals{TaxAmount: 5, TotalSegments: []magento2.CartTotalSegment{{Code: "tax", Value: 5}}}
+	breakdown, err := totals.TaxBreakdown()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(breakdown.Jurisdictions) != 0 || breakdown.Unexplained().String() != "5" {
+		t.Errorf("expected the whole tax to be unexplained, got %+v", breakdown)
+	}
+}
@@ -0,0 +1,74 @@
+package magento2
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	magento2 "github.com/florinel-chis/go-m2rest"
+)
+
+func TestAuditCatalog(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/rest/default/V1/products":
+			_, _ = w.Write([]byte(`{"items":[
+				{"id":1,"sku":"shirt","type_id":"configurable","status":1,"attribute_set_id":4,
+				 "extension_attributes":{"website_ids":[1],"configurable_product_links":[2,3,99]},
+				 "custom_attributes":[{"attribute_code":"brand","value":"Acme"}]},
+				{"id":2,"sku":"shirt-s","type_id":"simple","status":2,"attribute_set_id":4,
+				 "extension_attributes":{"website_ids":[1],"stock_item":{"is_in_stock":true}},
+				 "custom_attributes":[{"attribute_code":"brand","value":"Acme"}]},
+				{"id":3,"sku":"shirt-m","type_id":"simple","status":1,"attribute_set_id":4,
+				 "extension_attributes":{"website_ids":[1],"stock_item":{"is_in_stock":false}},
+				 "custom_attributes":[{"attribute_code":"brand","value":"Acme"}]},
+				{"id":4,"sku":"mug","type_id":"simple","status":1,"attribute_set_id":4,
+				 "extension_attributes":{"stock_item":{"is_in_stock":true}}}
+			],"total_count":4}`))
+		case "/rest/default/V1/products/attribute-sets/4":
+			_, _ = w.Write([]byte(`{"attribute_set_id":4,"attribute_set_name":"Default","entity_type_id":4}`))
+		case "/rest/default/V1/products/attribute-sets/groups/list":
+			_, _ = w.Write([]byte(`{"items":[],"total_count":0}`))
+		case "/rest/default/V1/products/attribute-sets/4/attributes":
+			_, _ = w.Write([]byte(`[{"attribute_code":"name","is_required":true},{"attribute_code":"brand","is_required":true,"is_user_defined":true},` +
+				`{"attribute_code":"color","is_user_defined":true}]`))
+		default:
+			t.Errorf("unexpected request: %s", r.URL)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := magento2.NewClient(context.Background(),
+		magento2.WithStoreURL(server.URL, "default"),
+		magento2.WithBearerToken("token"),
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	report, err := magento2.AuditCatalog(context.Background(), 2, client)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if report.ProductsScanned != 4 {
+		t.Errorf("expected 4 products scanned, got %d", report.ProductsScanned)
+	}
+
+	expected := map[magento2.AuditIssueKind]string{
+		magento2.AuditMissingChild:             "shirt",
+		magento2.AuditNoSalableChildren:        "shirt",
+		magento2.AuditNoWebsites:               "mug",
+		magento2.AuditMissingRequiredAttribute: "mug",
+	}
+	for kind, sku := range expected {
+		issues := report.ByKind(kind)
+		if len(issues) != 1 || issues[0].Sku != sku {
+			t.Errorf("expected one %s issue for %s, got %+v", kind, sku, issues)
+		}
+	}
+	if len(report.Issues) != len(expected) {
+		t.Errorf("unexpected issues: %+v", report.Issues)
+	}
+}
@@ -0,0 +1,109 @@
+package magento2
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	magento2 "github.com/florinel-chis/go-m2rest"
+)
+
+// newCatalogDiffServer serves the given product search response and the categories of store
+func newCatalogDiffServer(t *testing.T, products string, store *fakeCategoryStore) *magento2.Client {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/rest/default/V1/products" {
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(products))
+			return
+		}
+		store.ServeHTTP(w, r)
+	}))
+	t.Cleanup(server.Close)
+	client, err := magento2.NewClient(context.Background(), magento2.WithStoreURL(server.URL, "default"), magento2.WithBearerToken("token"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return client
+}
+
+func TestDiffCatalogs(t *testing.T) {
+	source := newCatalogDiffServer(t, `{"items":[
+		{"sku":"Shirt","name":"Shirt","price":20,"status":1,"custom_attributes":[{"attribute_code":"color","value":"5"},{"attribute_code":"category_ids","value":["3"]}]},
+		{"sku":"mug","name":"Mug","price":8,"status":1},
+		{"sku":"cap","name":"Cap","price":12,"status":1}],"total_count":3}`, newFakeCategoryStore())
+
+	targetCategories := newFakeCategoryStore()
+	targetCategories.categories[4].Name = "Backpacks"
+	delete(targetCategories.categories, 6)
+	targetCategories.categories[10] = &magento2.Category{ID: 10, ParentID: 2, Name: "Sale", Position: 3,
+		CustomAttributes: []magento2.CustomAttributes{{AttributeCode: "url_key", Value: "sale"}}}
+	target := newCatalogDiffServer(t, `{"items":[
+		{"sku":"shirt","name":"Shirt","price":22.5,"status":1,"custom_attributes":[{"attribute_code":"color","value":"6"},{"attribute_code":"category_ids","value":["9"]}]},
+		{"sku":"mug","name":"Mug","price":8,"status":1},
+		{"sku":"socks","name":"Socks","price":4,"status":2}],"total_count":3}`, targetCategories)
+
+	diff, err := magento2.DiffCatalogs(context.Background(), source, target, magento2.CatalogDiffOptions{SourceCategoryRootID: 2})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if diff.SourceProducts != 3 || diff.TargetProducts != 3 || diff.Empty() {
+		t.Fatalf("unexpected diff %+v", diff)
+	}
+
+	want := strings.Join([]string{
+		"missing product cap",
+		"changed product Shirt",
+		`  color: "5" -> "6"`,
+		`  price: "20" -> "22.5"`,
+		"extra product socks",
+		"changed category gear/bags",
+		`  name: "Bags" -> "Backpacks"`,
+		"missing category old/watches",
+		"extra category sale",
+	}, "\n") + "\n"
+	if diff.String() != want {
+		t.Errorf("expected diff\n%s\ngot\n%s", want, diff.String())
+	}
+	if missing := diff.ProductsByKind(magento2.CatalogDiffMissing); len(missing) != 1 || missing[0].Sku != "cap" {
+		t.Errorf("expected cap to be missing, got %v", missing)
+	}
+}
+
+func TestDiffCatalogs_SameCatalog(t *testing.T) {
+	products := `{"items":[{"sku":"mug","name":"Mug","price":8,"status":1}],"total_count":1}`
+	source := newCatalogDiffServer(t, products, newFakeCategoryStore())
+	// the gear subtree below another root ID
+	targetCategories := newFakeCategoryStore()
+	gear, bags := targetCategories.categories[3], targetCategories.categories[4]
+	gear.ID, gear.ParentID = 11, 10
+	bags.ID, bags.ParentID = 12, 11
+	targetCategories.categories = map[int]*magento2.Category{
+		2:  targetCategories.categories[2],
+		10: {ID: 10, ParentID: 2, Name: "Main", IsActive: true},
+		11: gear,
+		12: bags,
+	}
+	target := newCatalogDiffServer(t, products, targetCategories)
+
+	diff, err := magento2.DiffCatalogs(context.Background(), source, target, magento2.CatalogDiffOptions{
+		SourceCategoryRootID: 3,
+		TargetCategoryRootID: 11,
+		Attributes:           []string{"color"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !diff.Empty() {
+		t.Errorf("expected no differences, got\n%s", diff.String())
+	}
+
+	diff, err = magento2.DiffCatalogs(context.Background(), source, target, magento2.CatalogDiffOptions{SourceCategoryRootID: 2, TargetCategoryRootID: 10})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if diff.String() != "missing category old\nmissing category old/watches\n" {
+		t.Errorf("expected only the old subtree to be missing below the roots, got\n%s", diff.String())
+	}
+}
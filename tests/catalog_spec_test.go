@@ -0,0 +1,100 @@
+package magento2
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	magento2 "github.com/florinel-chis/go-m2rest"
+)
+
+func newCatalogSpecTestClient(t *testing.T) *magento2.Client {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		path := strings.TrimPrefix(r.URL.Path, "/rest/default/V1")
+		lastValue := r.URL.RawQuery[strings.LastIndex(r.URL.RawQuery, "=")+1:]
+		switch {
+		case path == "/products/attributes/color":
+			_, _ = w.Write([]byte(`{"attribute_code":"color","frontend_input":"select","default_frontend_label":"Color",` +
+				`"options":[{"label":" ","value":""},{"label":"Red","value":"4"}]}`))
+		case strings.HasPrefix(path, "/products/attributes/"):
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"message":"The attribute with a \"%1\" attributeCode doesn't exist."}`))
+		case path == "/products/attribute-sets/sets/list" && lastValue == "Bags":
+			_, _ = w.Write([]byte(`{"items":[{"attribute_set_id":10,"attribute_set_name":"Bags"}]}`))
+		case path == "/products/attribute-sets/sets/list":
+			_, _ = w.Write([]byte(`{"items":[]}`))
+		case path == "/products/attribute-sets/10":
+			_, _ = w.Write([]byte(`{"attribute_set_id":10,"attribute_set_name":"Bags"}`))
+		case path == "/products/attribute-sets/groups/list":
+			_, _ = w.Write([]byte(`{"items":[{"attribute_group_id":"7","attribute_group_name":"General","attribute_set_id":10}]}`))
+		case path == "/products/attribute-sets/10/attributes":
+			_, _ = w.Write([]byte(`[{"attribute_code":"name"},{"attribute_code":"color"}]`))
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := magento2.NewClient(context.Background(),
+		magento2.WithStoreURL(server.URL, "default"),
+		magento2.WithBearerToken("token"),
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return client
+}
+
+func TestPlanCatalogSpec(t *testing.T) {
+	client := newCatalogSpecTestClient(t)
+
+	spec, err := magento2.ReadCatalogSpec(strings.NewReader(`{
+		"attributes": [
+			{"code": "color", "label": "Colour", "frontend_input": "select", "options": ["red", "Blue"]},
+			{"code": "material", "label": "Material", "frontend_input": "text"}
+		],
+		"attribute_sets": [
+			{"name": "Bags", "groups": [{"name": "general", "attributes": ["color", "material"]}, {"name": "Brand"}]},
+			{"name": "Shoes", "groups": [{"name": "General", "attributes": ["color"]}]}
+		]
+	}`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	plan, err := magento2.PlanCatalogSpec(context.Background(), spec, client)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := `update_attribute color (select, "Colour")
+add_option color "Blue"
+create_attribute material (text, "Material")
+assign_attribute material -> "Bags" / "general"
+create_group "Bags" / "Brand"
+create_attribute_set "Shoes"
+create_group "Shoes" / "General"
+assign_attribute color -> "Shoes" / "General"
+`
+	if plan.String() != expected {
+		t.Errorf("unexpected plan:\n%s", plan)
+	}
+	if plan.Changes[3].SortOrder != 1 {
+		t.Errorf("expected the sort order of the spec, got %d", plan.Changes[3].SortOrder)
+	}
+}
+
+func TestPlanCatalogSpec_RejectsInputChange(t *testing.T) {
+	client := newCatalogSpecTestClient(t)
+
+	spec := &magento2.CatalogSpec{Attributes: []magento2.AttributeSpec{{Code: "color", Label: "Color", FrontendInput: "multiselect"}}}
+	_, err := magento2.PlanCatalogSpec(context.Background(), spec, client)
+	if !errors.Is(err, magento2.ErrValidation) {
+		t.Errorf("expected ErrValidation for a frontend input change, got: %v", err)
+	}
+}
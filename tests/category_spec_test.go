@@ -0,0 +1,298 @@
+package magento2
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	magento2 "github.com/florinel-chis/go-m2rest"
+)
+
+// fakeCategoryStore serves the category list, save, move and delete routes from memory
+type fakeCategoryStore struct {
+	mu         sync.Mutex
+	categories map[int]*magento2.Category
+	storeNames map[string]map[int]string
+	writes     []string
+	nextID     int
+}
+
+func newFakeCategoryStore() *fakeCategoryStore {
+	category := func(id, parentID, position int, name, urlKey string) *magento2.Category {
+		return &magento2.Category{ID: id, ParentID: parentID, Name: name, IsActive: true, Position: position,
+			CustomAttributes: []magento2.CustomAttributes{{AttributeCode: "url_key", Value: urlKey}}}
+	}
+	return &fakeCategoryStore{
+		categories: map[int]*magento2.Category{
+			2: {ID: 2, ParentID: 1, Name: "Default Category", Path: "1/2", IsActive: true},
+			3: category(3, 2, 1, "Gear", "gear"),
+			4: category(4, 3, 1, "Bags", "bags"),
+			5: category(5, 2, 2, "Old", "old"),
+			6: category(6, 5, 1, "Watches", "watches"),
+		},
+		storeNames: map[string]map[int]string{"de": {3: "Ausrüstung"}},
+		nextID:     10,
+	}
+}
+
+func (s *fakeCategoryStore) path(id int) string {
+	if id == 1 {
+		return "1"
+	}
+	return s.path(s.categories[id].ParentID) + "/" + strconv.Itoa(id)
+}
+
+func (s *fakeCategoryStore) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	w.Header().Set("Content-Type", "application/json")
+
+	parts := strings.Split(strings.TrimPrefix(r.URL.Path, "/rest/"), "/")
+	storeCode, route := parts[0], "/"+strings.Join(parts[2:], "/")
+
+	switch {
+	case r.Method == http.MethodGet && route == "/categories/list":
+		query := r.URL.Query()
+		field := query.Get("searchCriteria[filter_groups][0][filters][0][field]")
+		value := query.Get("searchCriteria[filter_groups][0][filters][0][value]")
+		items := []magento2.Category{}
+		for _, id := range []int{2, 3, 4, 5, 6, 10, 11, 12} {
+			category, ok := s.categories[id]
+			if !ok {
+				continue
+			}
+			c := *category
+			c.Path = s.path(id)
+			if name, ok := s.storeNames[storeCode][id]; ok {
+				c.Name = name
+			}
+			if (field == "entity_id" && strconv.Itoa(id) == value) ||
+				(field == "path" && strings.HasPrefix(c.Path, strings.TrimSuffix(value, "%"))) {
+				items = append(items, c)
+			}
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"items": items, "total_count": len(items)})
+		return
+	case r.Method == http.MethodPost && route == "/categories":
+		var payload struct {
+			Category magento2.Category `json:"category"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&payload)
+		payload.Category.ID = s.nextID
+		s.nextID++
+		s.categories[payload.Category.ID] = &payload.Category
+		s.writes = append(s.writes, fmt.Sprintf("%s POST %s %s", storeCode, route, payload.Category.Name))
+		_ = json.NewEncoder(w).Encode(payload.Category)
+		return
+	case r.Method == http.MethodPut && strings.HasSuffix(route, "/move"):
+		id, _ := strconv.Atoi(parts[3])
+		var payload struct {
+			ParentID int `json:"parentId"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&payload)
+		s.categories[id].ParentID = payload.ParentID
+		s.writes = append(s.writes, fmt.Sprintf("%s PUT %s %d", storeCode, route, payload.ParentID))
+		_, _ = w.Write([]byte(`true`))
+		return
+	case r.Method == http.MethodPut:
+		var payload struct {
+			Category map[string]any `json:"category"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&payload)
+		delete(payload.Category, "id")
+		keys := make([]string, 0, len(payload.Category))
+		for key := range payload.Category {
+			keys = append(keys, key)
+		}
+		s.writes = append(s.writes, fmt.Sprintf("%s PUT %s %d fields", storeCode, route, len(keys)))
+		_, _ = w.Write([]byte(`{}`))
+		return
+	case r.Method == http.MethodDelete:
+		id, _ := strconv.Atoi(parts[3])
+		delete(s.categories, id)
+		s.writes = append(s.writes, fmt.Sprintf("%s DELETE %s", storeCode, route))
+		_, _ = w.Write([]byte(`true`))
+		return
+	}
+	w.WriteHeader(http.StatusNotFound)
+}
+
+func newCategorySpecTestClient(t *testing.T) (*magento2.Client, *fakeCategoryStore) {
+	t.Helper()
+	store := newFakeCategoryStore()
+	server := httptest.NewServer(store)
+	t.Cleanup(server.Close)
+
+	client, err := magento2.NewClient(context.Background(),
+		magento2.WithStoreURL(server.URL, "default"),
+		magento2.WithBearerToken("token"),
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return client, store
+}
+
+func TestExportCategoryTree(t *testing.T) {
+	client, _ := newCategorySpecTestClient(t)
+
+	spec, err := magento2.ExportCategoryTree(context.Background(), 2, []string{"de"}, client)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(spec.Children) != 2 || spec.Children[0].URLKey != "gear" || spec.Children[1].Children[0].Name != "Watches" {
+		t.Fatalf("unexpected spec: %+v", spec)
+	}
+	if spec.Children[0].StoreViews["de"].Name != "Ausrüstung" || len(spec.Children[1].StoreViews) != 0 {
+		t.Errorf("expected only the overridden store view name, got %+v", spec.Children[0].StoreViews)
+	}
+
+	var buf bytes.Buffer
+	if err := magento2.WriteCategorySpec(&buf, spec); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	read, err := magento2.ReadCategorySpec(&buf)
+	if err != nil || read.Children[0].Children[0].Name != "Bags" {
+		t.Errorf("expected spec to survive a round trip, got %+v (%v)", read, err)
+	}
+
+	plan, err := magento2.ApplyCategoryTree(context.Background(), read, 2, magento2.ApplyCategoryTreeOptions{Delete: true}, client)
+	if err != nil || len(plan.Changes) != 0 {
+		t.Errorf("expected applying the export to change nothing, got %v (%v)", plan, err)
+	}
+}
+
+func TestApplyCategoryTree(t *testing.T) {
+	spec := &magento2.CategorySpec{
+		Name: "Default Category",
+		Children: []magento2.CategorySpec{
+			{Name: "Gear", URLKey: "gear", IsActive: false, Position: 1,
+				StoreViews: map[string]magento2.CategoryStoreSpec{"de": {Name: "Ausrüstung & Zubehör"}},
+				Children: []magento2.CategorySpec{
+					{Name: "Bags", URLKey: "bags", IsActive: true, Position: 1},
+					{Name: "Watches", URLKey: "watches", IsActive: true, Position: 1},
+				}},
+			{Name: "New Arrivals", URLKey: "new", IsActive: true, Position: 3},
+		},
+	}
+
+	client, store := newCategorySpecTestClient(t)
+	plan, err := magento2.ApplyCategoryTree(context.Background(), spec, 2, magento2.ApplyCategoryTreeOptions{Delete: true, DryRun: true}, client)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := "update Gear (is_active)\n" +
+		"update Gear [de] (name)\n" +
+		"move   Gear/Watches\n" +
+		"create New Arrivals\n" +
+		"delete Old\n"
+	if plan.String() != expected {
+		t.Errorf("unexpected plan:\n%s", plan)
+	}
+	if len(store.writes) != 0 {
+		t.Fatalf("expected a dry run to write nothing, got %v", store.writes)
+	}
+
+	applied, err := magento2.ApplyCategoryTree(context.Background(), spec, 2, magento2.ApplyCategoryTreeOptions{Delete: true}, client)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if applied.String() != expected {
+		t.Errorf("expected the applied changes to match the dry run, got:\n%s", applied)
+	}
+	expectedWrites := []string{
+		"all PUT /categories/3 1 fields",
+		"de PUT /categories/3 1 fields",
+		"all PUT /categories/6/move 3",
+		"all POST /categories New Arrivals",
+		"all DELETE /categories/5",
+	}
+	if strings.Join(store.writes, "\n") != strings.Join(expectedWrites, "\n") {
+		t.Errorf("unexpected writes:\n%s", strings.Join(store.writes, "\n"))
+	}
+}
+
+func TestCategorySpecYAML_RoundTrip(t *testing.T) {
+	spec := &magento2.CategorySpec{
+		Name:     "Default Category",
+		IsActive: true,
+		Children: []magento2.CategorySpec{
+			{Name: "Gear: Bags & More", URLKey: "gear", IsActive: true, Position: 1,
+				Attributes: map[string]string{"description": "Line one\nLine two", "is_anchor": "1"},
+				StoreViews: map[string]magento2.CategoryStoreSpec{"de": {Name: "Ausrüstung"}},
+				Children:   []magento2.CategorySpec{{Name: "Bags", URLKey: "bags", Position: 1}}},
+			{Name: "# Sale", URLKey: "true", IncludeInMenu: true, Position: 2},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := magento2.WriteCategorySpecYAML(&buf, spec); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(buf.String(), "children:\n  - name: 'Gear: Bags & More'\n    url_key: gear\n") {
+		t.Errorf("expected children as a block sequence, got:\n%s", buf.String())
+	}
+	read, err := magento2.ReadCategorySpecYAML(&buf)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(read, spec) {
+		t.Errorf("expected spec to survive a round trip, got %+v", read)
+	}
+}
+
+func TestReadCategorySpecYAML(t *testing.T) {
+	input := `# category tree of the default store
+name: Default Category
+is_active: true
+children:
+- name: Gear   # top level
+  url_key: 'gear'
+  position: 1
+  attributes:
+    description: |
+      Bags and
+      fitness equipment
+    meta_title: "Gear – Shop"
+  store_views:
+    de: {name: Ausrüstung}
+  children: []
+`
+	spec, err := magento2.ReadCategorySpecYAML(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	gear := spec.Children[0]
+	if spec.Name != "Default Category" || !spec.IsActive || gear.Name != "Gear" || gear.URLKey != "gear" || gear.Position != 1 {
+		t.Errorf("unexpected spec: %+v", spec)
+	}
+	if gear.Attributes["description"] != "Bags and\nfitness equipment\n" || gear.Attributes["meta_title"] != "Gear – Shop" {
+		t.Errorf("unexpected attributes: %q", gear.Attributes)
+	}
+	if gear.StoreViews["de"].Name != "Ausrüstung" {
+		t.Errorf("unexpected store views: %+v", gear.StoreViews)
+	}
+
+	spec, err = magento2.ReadCategorySpecYAML(strings.NewReader("name: \"\\x41ccessories\"\nis_active: yes\nposition: 1e3\n"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if spec.Name != "Accessories" || !spec.IsActive || spec.Position != 1000 {
+		t.Errorf("unexpected spec: %+v", spec)
+	}
+
+	for _, invalid := range []string{"name: Gear\n  position: 1\n", "name: Gear\nname: Bags\n", "position: first\n", "children:\n\t- name: Gear\n"} {
+		if _, err := magento2.ReadCategorySpecYAML(strings.NewReader(invalid)); !errors.Is(err, magento2.ErrInvalidYAML) {
+			t.Errorf("expected ErrInvalidYAML for %q, got %v", invalid, err)
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package magento2
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	magento2 "github.com/florinel-chis/go-m2rest"
+)
+
+func TestClient_Clone(t *testing.T) {
+	requests := map[string]string{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests[r.URL.Path] = r.Header.Get("Authorization")
+		_, _ = w.Write([]byte(`[]`))
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := magento2.NewClient(context.Background(),
+		magento2.WithStoreURL(server.URL, "default"),
+		magento2.WithBearerToken("tenant-a"),
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	client.ValidatePayloads = true
+
+	clone, err := client.Clone(magento2.WithStoreView("de"), magento2.WithBearerToken("tenant-b"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !clone.ValidatePayloads {
+		t.Errorf("expected client settings to be kept")
+	}
+	if clone.HTTPClient.GetClient().Transport != client.HTTPClient.GetClient().Transport {
+		t.Errorf("expected the transport to be shared")
+	}
+
+	_, _ = magento2.GetCountries(context.Background(), client)
+	_, _ = magento2.GetCountries(context.Background(), clone)
+
+	if requests["/rest/default/V1/directory/countries"] != "Bearer tenant-a" {
+		t.Errorf("unexpected original request: %v", requests)
+	}
+	if requests["/rest/de/V1/directory/countries"] != "Bearer tenant-b" {
+		t.Errorf("unexpected clone request: %v", requests)
+	}
+}
+
+func TestClientRegistry(t *testing.T) {
+	registry := magento2.NewClientRegistry()
+	if _, err := registry.Get("shop-a"); !errors.Is(err, magento2.ErrUnknownTenant) {
+		t.Fatalf("expected ErrUnknownTenant, got: %v", err)
+	}
+
+	created := 0
+	create := func() (*magento2.Client, error) {
+		created++
+		return magento2.NewClient(context.Background(), magento2.WithStoreURL("https://shop-a.example.com", "default"))
+	}
+	first, err := registry.GetOrCreate("shop-a", create)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	second, _ := registry.GetOrCreate("shop-a", create)
+	if first != second || created != 1 {
+		t.Errorf("expected the client to be created once, created %d", created)
+	}
+
+	registry.Remove("shop-a")
+	if len(registry.Keys()) != 0 {
+		t.Errorf("expected empty registry, got %v", registry.Keys())
+	}
+}
@@ -0,0 +1,163 @@
+package magento2
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	magento2 "github.com/florinel-chis/go-m2rest"
+)
+
+func TestNewClient_AdminCredentials(t *testing.T) {
+	var authorization string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/rest/default/V1/integration/admin/token":
+			_, _ = w.Write([]byte(`"admin-token"`))
+		default:
+			authorization = r.Header.Get("Authorization")
+			_, _ = w.Write([]byte(`[]`))
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := magento2.NewClient(context.Background(),
+		magento2.WithStoreURL(server.URL, "default"),
+		magento2.WithAdminCredentials("admin", "secret"),
+		magento2.WithRetry(0, 0, 0),
+		magento2.WithDefaultTimeout(5*time.Second),
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, err = magento2.GetCountries(context.Background(), client); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if authorization != "Bearer admin-token" {
+		t.Errorf("expected admin token to be sent, got %q", authorization)
+	}
+}
+
+func TestNewClient_FailedLogin(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte(`{"message":"The account sign-in was incorrect"}`))
+	}))
+	t.Cleanup(server.Close)
+
+	_, err := magento2.NewClient(context.Background(),
+		magento2.WithStoreURL(server.URL, "default"),
+		magento2.WithCustomerCredentials("roni_cost@example.com", "wrong"),
+	)
+	if !errors.Is(err, magento2.ErrBadRequest) {
+		t.Fatalf("expected failed login to be reported, got: %v", err)
+	}
+}
+
+func TestNewClient_RequiresStore(t *testing.T) {
+	if _, err := magento2.NewClient(context.Background(), magento2.WithBearerToken("token")); !errors.Is(err, magento2.ErrNoStoreConfig) {
+		t.Fatalf("expected ErrNoStoreConfig, got: %v", err)
+	}
+}
+
+func TestNewClient_ConnectionPool(t *testing.T) {
+	client, err := magento2.NewClient(context.Background(),
+		magento2.WithStoreURL("https://shop.example.com", "default"),
+		magento2.WithMaxIdleConnsPerHost(64),
+		magento2.WithIdleConnTimeout(30*time.Second),
+		magento2.WithHTTP2(false),
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	transport, ok := client.HTTPClient.GetClient().Transport.(*http.Transport)
+	if !ok {
+		t.Fatalf("expected *http.Transport, got %T", client.HTTPClient.GetClient().Transport)
+	}
+	if transport.MaxIdleConnsPerHost != 64 || transport.IdleConnTimeout != 30*time.Second {
+		t.Errorf("unexpected pool settings: %d, %s", transport.MaxIdleConnsPerHost, transport.IdleConnTimeout)
+	}
+	if transport.ForceAttemptHTTP2 || transport.TLSNextProto == nil {
+		t.Errorf("expected HTTP/2 to be disabled")
+	}
+}
+
+func TestNewClient_Headers(t *testing.T) {
+	var received http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = r.Header.Clone()
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`[]`))
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := magento2.NewClient(context.Background(),
+		magento2.WithStoreURL(server.URL, "default"),
+		magento2.WithUserAgent("catalog-sync/1.2"),
+		magento2.WithHeaders(map[string]string{"x-cdn-bypass": "secret"}),
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	clone, err := client.Clone(magento2.WithHeader("X-Forwarded-For", "10.0.0.1"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, err := clone.HTTPClient.R().Get("/store/storeViews"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := received.Get("User-Agent"); got != "catalog-sync/1.2" {
+		t.Errorf("expected custom User-Agent, got %q", got)
+	}
+	if received.Get("X-Cdn-Bypass") != "secret" || received.Get("X-Forwarded-For") != "10.0.0.1" {
+		t.Errorf("expected default headers to be sent, got %v", received)
+	}
+}
+
+func TestNewClient_MaxResponseSize(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id":2,"name":"Default Category","children_data":[{"id":3,"name":"Gear","children_data":[]}]}`))
+	}))
+	t.Cleanup(server.Close)
+
+	unlimited, err := magento2.NewClient(context.Background(), magento2.WithStoreURL(server.URL, "default"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if limit := unlimited.HTTPClient.ResponseBodyLimit; limit != 0 {
+		t.Errorf("expected responses not to be capped by default, got a limit of %d bytes", limit)
+	}
+
+	client, err := magento2.NewClient(context.Background(),
+		magento2.WithStoreURL(server.URL, "default"),
+		magento2.WithMaxResponseSize(16),
+		magento2.WithRetry(0, 0, 0),
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	_, err = magento2.GetCategoryTree(context.Background(), 2, 2, client)
+	if !errors.Is(err, magento2.ErrResponseTooLarge) {
+		t.Fatalf("expected ErrResponseTooLarge, got: %v", err)
+	}
+
+	tree, err := magento2.GetCategoryTree(context.Background(), 2, 2, client, magento2.WithResponseLimit(1024))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(tree.ChildrenData) != 1 || tree.ChildrenData[0].Name != "Gear" {
+		t.Errorf("unexpected tree: %+v", tree)
+	}
+
+	if _, err := magento2.GetCategoryTree(context.Background(), 2, 0, client); !errors.Is(err, magento2.ErrValidation) {
+		t.Errorf("expected unbounded depth to be rejected, got: %v", err)
+	}
+}
@@ -0,0 +1,69 @@
+package magento2
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	magento2 "github.com/florinel-chis/go-m2rest"
+)
+
+func TestClient_Stats(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/rest/default/V1/directory/countries":
+			if calls.Add(1) <= 2 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			_, _ = w.Write([]byte(`[]`))
+		case "/rest/default/V1/store/websites":
+			w.WriteHeader(http.StatusServiceUnavailable)
+		default:
+			w.WriteHeader(http.StatusTooManyRequests)
+		}
+	}))
+
+	client, err := magento2.NewClient(context.Background(),
+		magento2.WithStoreURL(server.URL, "default"),
+		magento2.WithRetry(2, time.Millisecond, time.Millisecond),
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, err = magento2.GetCountries(context.Background(), client); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err = client.HTTPClient.R().Get("/store/storeViews"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err = client.HTTPClient.R().Get("/store/websites"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	server.Close()
+	if _, err = client.HTTPClient.R().SetContext(context.Background()).Get("/store/storeViews"); err == nil {
+		t.Fatalf("expected a transport error")
+	}
+
+	stats := client.Stats()
+	// the countries call succeeded on its third attempt, the websites call failed on all three
+	expected := magento2.ClientStats{
+		Requests:        8,
+		Retries:         4,
+		ClientErrors:    1,
+		ServerErrors:    1,
+		RateLimited:     1,
+		TransportErrors: 1,
+	}
+	if stats != expected {
+		t.Errorf("expected %+v, got %+v", expected, stats)
+	}
+	if stats.Failures() != 3 {
+		t.Errorf("expected 3 failed calls, got %d", stats.Failures())
+	}
+}
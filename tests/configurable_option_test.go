@@ -0,0 +1,56 @@
+package magento2
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	magento2 "github.com/florinel-chis/go-m2rest"
+)
+
+func TestBuildConfigurableProductOption(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		if r.URL.Path != "/rest/default/V1/products/attributes/color" {
+			t.Errorf("unexpected request: %s", r.URL)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"attribute_id":93,"attribute_code":"color","default_frontend_label":"Color",` +
+			`"options":[{"label":" ","value":""},{"label":"Red","value":"58"},{"label":"Blue","value":"59"}]}`))
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := magento2.NewClient(context.Background(), magento2.WithStoreURL(server.URL, "default"), magento2.WithBearerToken("token"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	cache := magento2.NewAttributeCache(client)
+
+	option, err := magento2.BuildConfigurableProductOption(context.Background(), "color", []string{"Blue", "Red"}, cache)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if option.AttributeID != "93" || option.Label != "Color" || len(option.Values) != 2 ||
+		option.Values[0].ValueIndex != 59 || option.Values[1].ValueIndex != 58 {
+		t.Errorf("unexpected option %+v", option)
+	}
+	if n := requests.Load(); n != 1 {
+		t.Errorf("expected the attribute to be fetched once, got %d requests", n)
+	}
+
+	if _, err := magento2.BuildConfigurableProductOption(context.Background(), "color", []string{"Green"}, cache); !errors.Is(err, magento2.ErrNotFound) {
+		t.Errorf("expected ErrNotFound for an unknown label, got %v", err)
+	}
+
+	cache.Invalidate("color")
+	if _, err := cache.OptionValue(context.Background(), "color", "Red"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if n := requests.Load(); n != 2 {
+		t.Errorf("expected the invalidated attribute to be fetched again, got %d requests", n)
+	}
+}
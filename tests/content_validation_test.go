@@ -0,0 +1,70 @@
+package magento2
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	magento2 "github.com/florinel-chis/go-m2rest"
+)
+
+func TestContentValidator_Check(t *testing.T) {
+	validator := magento2.NewContentValidator(
+		magento2.MinLengthRule{Attribute: "description", Min: 20},
+		magento2.BannedWordsRule{Words: []string{"cheap", "best ever"}},
+		magento2.RequiredAttributesRule{BySet: map[int][]string{4: {"brand", "color"}}},
+	)
+
+	product := magento2.NewSimpleProduct("tee", "Cheap Tee", 4, 10, 1)
+	product.CustomAttributes = append(product.CustomAttributes,
+		map[string]any{"attribute_code": "description", "value": "<p>The <b>best   ever</b> tee</p>"},
+		map[string]any{"attribute_code": "brand", "value": "7"},
+	)
+
+	violations := validator.Check(product)
+	want := []magento2.ContentViolation{
+		{Sku: "tee", Rule: magento2.ContentRuleMinLength, Field: "description", Message: "has 17 characters, at least 20 required"},
+		{Sku: "tee", Rule: magento2.ContentRuleBannedWords, Field: "name", Message: `contains banned word "cheap"`},
+		{Sku: "tee", Rule: magento2.ContentRuleBannedWords, Field: "description", Message: `contains banned word "best ever"`},
+		{Sku: "tee", Rule: magento2.ContentRuleRequiredAttributes, Field: "color", Message: "is required for attribute set 4"},
+	}
+	if len(violations) != len(want) {
+		t.Fatalf("expected %d violations, got %v", len(want), violations)
+	}
+	for i := range want {
+		if violations[i] != want[i] {
+			t.Errorf("violation %d: expected %+v, got %+v", i, want[i], violations[i])
+		}
+	}
+
+	clean := magento2.NewSimpleProduct("mug", "Mug", 9, 5, 1)
+	clean.CustomAttributes = append(clean.CustomAttributes, map[string]any{"attribute_code": "description", "value": "A sturdy mug for coffee and tea"})
+	if violations := validator.Check(clean); violations != nil {
+		t.Errorf("expected no violations, got %v", violations)
+	}
+}
+
+func TestCreateOrReplaceProduct_RejectsContentViolations(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := magento2.NewClient(context.Background(), magento2.WithStoreURL(server.URL, "default"), magento2.WithBearerToken("token"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	client.SetContentValidator(magento2.NewContentValidator(magento2.MinLengthRule{Attribute: "description", Min: 10}))
+
+	_, err = magento2.CreateOrReplaceProduct(magento2.NewSimpleProduct("tee", "Tee", 4, 10, 1), false, client)
+	var violations *magento2.ContentViolationsError
+	if !errors.As(err, &violations) || !errors.Is(err, magento2.ErrValidation) {
+		t.Fatalf("expected content violations, got %v", err)
+	}
+	if len(violations.Violations) != 1 || violations.Violations[0].Field != "description" {
+		t.Errorf("unexpected violations %v", violations.Violations)
+	}
+}
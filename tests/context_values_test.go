@@ -0,0 +1,113 @@
+package magento2
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	magento2 "github.com/florinel-chis/go-m2rest"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+)
+
+func TestContextValues_PropagatedToHeaderAndLogs(t *testing.T) {
+	var received string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = r.Header.Get(magento2.HeaderRequestID)
+		_, _ = w.Write([]byte(`[]`))
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := magento2.NewClient(context.Background(), magento2.WithStoreURL(server.URL, "default"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var buf bytes.Buffer
+	previous := log.Logger
+	previousLevel := zerolog.GlobalLevel()
+	magento2.SetZeroLogger(zerolog.New(&buf))
+	zerolog.SetGlobalLevel(zerolog.DebugLevel)
+	t.Cleanup(func() {
+		magento2.SetZeroLogger(previous)
+		zerolog.SetGlobalLevel(previousLevel)
+	})
+
+	ctx := magento2.ContextWithTenantID(magento2.ContextWithRequestID(context.Background(), "req-123"), "tenant-a")
+	if _, err = magento2.GetCountries(ctx, client); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if received != "req-123" {
+		t.Errorf("expected X-Request-ID header, got %q", received)
+	}
+	if !strings.Contains(buf.String(), `"requestID":"req-123"`) || !strings.Contains(buf.String(), `"tenantID":"tenant-a"`) {
+		t.Errorf("expected correlated log event, got %s", buf.String())
+	}
+}
+
+func TestLoggerFromContext_UsesTheContextLogger(t *testing.T) {
+	var buf bytes.Buffer
+	ctx := zerolog.New(&buf).With().Str("service", "orders").Logger().WithContext(context.Background())
+	ctx = magento2.ContextWithTenantID(magento2.ContextWithRequestID(ctx, "req-9"), "tenant-b")
+
+	logger := magento2.LoggerFromContext(ctx)
+	logger.Info().Msg("hello")
+	for _, field := range []string{`"service":"orders"`, `"requestID":"req-9"`, `"tenantID":"tenant-b"`} {
+		if !strings.Contains(buf.String(), field) {
+			t.Errorf("expected %s in the context logger's event, got %s", field, buf.String())
+		}
+	}
+
+	var global bytes.Buffer
+	previous := log.Logger
+	magento2.SetZeroLogger(zerolog.New(&global))
+	t.Cleanup(func() { magento2.SetZeroLogger(previous) })
+	logger = magento2.LoggerFromContext(magento2.ContextWithRequestID(context.Background(), "req-10"))
+	logger.Info().Msg("hello")
+	if !strings.Contains(global.String(), `"requestID":"req-10"`) {
+		t.Errorf("expected the package logger without a context logger, got %s", global.String())
+	}
+}
+
+func TestSlowRequestThreshold(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/rest/default/V1/directory/countries" {
+			time.Sleep(30 * time.Millisecond)
+		}
+		_, _ = w.Write([]byte(`[]`))
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := magento2.NewClient(context.Background(),
+		magento2.WithStoreURL(server.URL, "default"),
+		magento2.WithSlowRequestThreshold(20*time.Millisecond),
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var buf bytes.Buffer
+	previous := log.Logger
+	magento2.SetZeroLogger(zerolog.New(&buf))
+	t.Cleanup(func() { magento2.SetZeroLogger(previous) })
+
+	if _, err = magento2.GetCountries(context.Background(), client); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(buf.String(), `"route":"/rest/default/V1/directory/countries"`) {
+		t.Fatalf("expected slow request warning, got %s", buf.String())
+	}
+
+	buf.Reset()
+	if _, err = client.HTTPClient.R().Get("/store/storeViews"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if strings.Contains(buf.String(), "Slow Magento request") {
+		t.Errorf("expected no warning for a fast request, got %s", buf.String())
+	}
+}
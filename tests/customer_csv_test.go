@@ -0,0 +1,124 @@
+package magento2
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	magento2 "github.com/florinel-chis/go-m2rest"
+)
+
+func newCustomerCSVServer(t *testing.T, created *[]magento2.Customer) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.URL.Path == "/rest/default/V1/customerGroups/search":
+			_, _ = w.Write([]byte(`{"items":[{"id":1,"code":"General"},{"id":2,"code":"Wholesale"}],"total_count":2}`))
+		case r.URL.Path == "/rest/default/V1/customers/search":
+			_, _ = w.Write([]byte(`{"items":[{"id":9,"email":"ann@example.com","firstname":"Ann","lastname":"Lee","group_id":2,"website_id":1,
+				"default_billing":"3","default_shipping":"3",
+				"addresses":[{"id":3,"firstname":"Ann","lastname":"Lee","street":["Main St 1","Apt 2"],"city":"Berlin","region":{"region_code":"BE","region":"Berlin","region_id":82},"region_id":82,"postcode":"10115","country_id":"DE","telephone":"123"}]}],"total_count":1}`))
+		case r.URL.Path == "/rest/default/V1/customers" && r.Method == http.MethodPost:
+			var payload struct {
+				Customer magento2.Customer `json:"customer"`
+			}
+			_ = json.NewDecoder(r.Body).Decode(&payload)
+			*created = append(*created, payload.Customer)
+			_, _ = w.Write([]byte(`{"id":10}`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestExportCustomersCSV(t *testing.T) {
+	server := newCustomerCSVServer(t, nil)
+	ctx := context.Background()
+	client, err := magento2.NewClient(ctx, magento2.WithStoreURL(server.URL, "default"), magento2.WithBearerToken("token"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var buf bytes.Buffer
+	rows, err := magento2.ExportCustomersCSV(ctx, &buf, nil, client)
+	if err != nil || rows != 1 {
+		t.Fatalf("expected one row, got %d, %v", rows, err)
+	}
+	records, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	row := map[string]string{}
+	for i, column := range records[0] {
+		row[column] = records[1][i]
+	}
+	for column, want := range map[string]string{
+		"email":               "ann@example.com",
+		"group":               "Wholesale",
+		"website_id":          "1",
+		"billing_street":      "Main St 1\nApt 2",
+		"shipping_city":       "Berlin",
+		"shipping_country_id": "DE",
+		"billing_region":      "Berlin",
+		"billing_region_code": "BE",
+		"billing_region_id":   "82",
+	} {
+		if row[column] != want {
+			t.Errorf("%s: expected %q, got %q", column, want, row[column])
+		}
+	}
+}
+
+func TestImportCustomersCSV(t *testing.T) {
+	var created []magento2.Customer
+	server := newCustomerCSVServer(t, &created)
+	ctx := context.Background()
+	client, err := magento2.NewClient(ctx, magento2.WithStoreURL(server.URL, "default"), magento2.WithBearerToken("token"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	input := "email,firstname,lastname,group,billing_street,billing_city,billing_region_code,billing_region_id,billing_country_id,shipping_street,shipping_city,shipping_country_id\n" +
+		"ann@example.com,Ann,Lee,Wholesale,,,,,,,,\n" +
+		"bob@example.com,Bob,Ray,wholesale,Elm 2,Paris,75,182,FR,Elm 2,Paris,FR\n" +
+		"eve@example.com,Eve,Doe,VIP,,,,,,,,\n"
+	results, err := magento2.ImportCustomersCSV(ctx, strings.NewReader(input), magento2.CustomerCSVImportOptions{WebsiteID: 1}, client)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	statuses := []magento2.ImportRowStatus{magento2.ImportRowSkipped, magento2.ImportRowCreated, magento2.ImportRowFailed}
+	if len(results) != len(statuses) {
+		t.Fatalf("expected %d results, got %v", len(statuses), results)
+	}
+	for i, status := range statuses {
+		if results[i].Row != i || results[i].Status != status {
+			t.Errorf("row %d: expected %s, got %+v", i, status, results[i])
+		}
+	}
+
+	if len(created) != 1 {
+		t.Fatalf("expected one created customer, got %v", created)
+	}
+	bob := created[0]
+	if bob.GroupID != 2 || bob.WebsiteID != 1 {
+		t.Fatalf("unexpected customer %+v", bob)
+	}
+	if len(bob.Addresses) != 2 {
+		t.Fatalf("expected separate billing and shipping addresses, got %+v", bob.Addresses)
+	}
+	billing := bob.Addresses[0]
+	if !billing.DefaultBilling || billing.Firstname != "Bob" || billing.City != "Paris" || billing.RegionCode != "" {
+		t.Errorf("unexpected billing address %+v", billing)
+	}
+	if billing.Region == nil || billing.Region.RegionCode != "75" || billing.Region.RegionID != 182 {
+		t.Errorf("expected the region object on the billing address, got %+v", billing.Region)
+	}
+}
@@ -0,0 +1,84 @@
+package magento2
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	magento2 "github.com/florinel-chis/go-m2rest"
+)
+
+func TestExportCustomerData(t *testing.T) {
+	var paths []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		paths = append(paths, r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/rest/default/V1/customers/7":
+			_, _ = w.Write([]byte(`{"id":7,"email":"jane@example.com","firstname":"Jane",` +
+				`"addresses":[{"id":3,"country_id":"US","city":"Austin","street":["1 Main St"],"telephone":"555"}]}`))
+		case "/rest/default/V1/customers/8":
+			_, _ = w.Write([]byte(`{"id":8,"email":"john@example.com"}`))
+		case "/rest/default/V1/orders":
+			if query.Get("searchCriteria[filter_groups][0][filters][0][field]") != "customer_id" ||
+				query.Get("searchCriteria[currentPage]") != "1" {
+				t.Errorf("unexpected request: %s", r.URL)
+			}
+			if query.Get("searchCriteria[filter_groups][0][filters][0][value]") == "8" {
+				_, _ = w.Write([]byte(`{"items":[],"total_count":0}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"items":[{"entity_id":100,"increment_id":"000000100"},` +
+				`{"entity_id":101,"increment_id":"000000101"}],"total_count":2}`))
+		case "/rest/default/V1/invoices", "/rest/default/V1/shipments", "/rest/default/V1/creditmemos":
+			if query.Get("searchCriteria[filter_groups][0][filters][0][field]") != "order_id" ||
+				query.Get("searchCriteria[filter_groups][0][filters][0][value]") != "100,101" ||
+				query.Get("searchCriteria[filter_groups][0][filters][0][condition_type]") != "in" {
+				t.Errorf("unexpected request: %s", r.URL)
+			}
+			if r.URL.Path == "/rest/default/V1/creditmemos" {
+				_, _ = w.Write([]byte(`{"items":[],"total_count":0}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"items":[{"entity_id":1,"order_id":100},{"entity_id":2,"order_id":101}],"total_count":2}`))
+		default:
+			t.Errorf("unexpected request: %s", r.URL)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := magento2.NewClient(context.Background(), magento2.WithStoreURL(server.URL, "default"), magento2.WithBearerToken("token"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	export, err := magento2.ExportCustomerData(context.Background(), 7, client)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if export.Customer.Email != "jane@example.com" || export.ExportedAt.IsZero() {
+		t.Errorf("unexpected export %+v", export)
+	}
+	if len(export.Addresses) != 1 || export.Addresses[0].City != "Austin" {
+		t.Errorf("unexpected addresses %+v", export.Addresses)
+	}
+	if len(export.Orders) != 2 || export.Orders[1].EntityID != 101 {
+		t.Errorf("unexpected orders %+v", export.Orders)
+	}
+	if len(export.Invoices) != 2 || len(export.Shipments) != 2 || export.Shipments[1].OrderID != 101 || len(export.CreditMemos) != 0 {
+		t.Errorf("unexpected order documents: %d invoices, %d shipments, %d credit memos",
+			len(export.Invoices), len(export.Shipments), len(export.CreditMemos))
+	}
+
+	paths = nil
+	export, err = magento2.ExportCustomerData(context.Background(), 8, client)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(export.Orders) != 0 || len(paths) != 2 {
+		t.Errorf("expected no document lookups without orders, got requests %v", paths)
+	}
+}
@@ -0,0 +1,59 @@
+package magento2
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	magento2 "github.com/florinel-chis/go-m2rest"
+)
+
+func TestGetCustomerPrices(t *testing.T) {
+	today := time.Now().UTC().Format("2006-01-02") + " 00:00:00"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/rest/default/V1/customers/42":
+			_, _ = w.Write([]byte(`{"id":42,"group_id":2,"store_id":1,"website_id":1}`))
+		case "/rest/default/V1/customerGroups/2":
+			_, _ = w.Write([]byte(`{"id":2,"code":"Wholesale"}`))
+		case "/rest/default/V1/products/base-prices-information":
+			_, _ = w.Write([]byte(`[{"sku":"24-MB01","price":40,"store_id":0},{"sku":"24-MB01","price":34,"store_id":1},{"sku":"24-MB02","price":20,"store_id":0}]`))
+		case "/rest/default/V1/products/special-price-information":
+			_, _ = w.Write([]byte(`[{"sku":"24-MB02","price":18,"store_id":0,"price_from":"2000-01-01 00:00:00","price_to":""},
+				{"sku":"24-MB02","price":1,"store_id":0,"price_from":"2000-01-01 00:00:00","price_to":"2000-02-01 00:00:00"},
+				{"sku":"24-MB02","price":17,"store_id":0,"price_from":"2000-01-01 00:00:00","price_to":"` + today + `"}]`))
+		case "/rest/default/V1/products/tier-prices-information":
+			_, _ = w.Write([]byte(`[{"sku":"24-MB01","price":10,"price_type":"discount","website_id":0,"customer_group":"Wholesale","quantity":5},
+				{"sku":"24-MB01","price":25,"price_type":"fixed","website_id":0,"customer_group":"Retailer","quantity":1},
+				{"sku":"24-MB02","price":15,"price_type":"fixed","website_id":1,"customer_group":"ALL GROUPS","quantity":20}]`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := magento2.NewClient(context.Background(), magento2.WithStoreURL(server.URL, "default"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	report, err := magento2.GetCustomerPrices(context.Background(), 42, []string{"24-MB01", "24-MB02"}, 10, client)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if report.Group.Code != "Wholesale" || len(report.Prices) != 2 {
+		t.Fatalf("unexpected report: %+v", report)
+	}
+
+	bag := report.Prices[0]
+	if bag.Price != 30.6 || bag.Source != magento2.CustomerPriceSourceTier || bag.BasePrice.Price != 34 {
+		t.Errorf("expected 10%% wholesale discount on the store price, got %+v", bag)
+	}
+	second := report.Prices[1]
+	if second.Price != 17 || second.Source != magento2.CustomerPriceSourceSpecial {
+		t.Errorf("expected the special price ending today to be active, got %+v", second)
+	}
+}
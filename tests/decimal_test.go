@@ -0,0 +1,101 @@
+package magento2
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	magento2 "github.com/florinel-chis/go-m2rest"
+)
+
+func TestDecimal_NoFloatDrift(t *testing.T) {
+	sum := magento2.Decimal{}
+	for i := 0; i < 10; i++ {
+		sum = sum.Add(magento2.NewDecimalFromFloat(0.1))
+	}
+	if sum.Cmp(magento2.NewDecimal(1, 0)) != 0 {
+		t.Fatalf("expected 1, got %s", sum)
+	}
+}
+
+func TestDecimal_ParseAndFormat(t *testing.T) {
+	cases := map[string]string{
+		"12.5":    "12.5",
+		"-0.125":  "-0.125",
+		"3":       "3",
+		"1.00005": "1.0001",
+		".5":      "0.5",
+	}
+	for in, want := range cases {
+		d, err := magento2.ParseDecimal(in)
+		if err != nil {
+			t.Fatalf("unexpected error parsing %q: %v", in, err)
+		}
+		if d.String() != want {
+			t.Errorf("expected %q for %q, got %q", want, in, d.String())
+		}
+	}
+
+	for _, in := range []string{"", "abc", "1.2.3", "-"} {
+		if _, err := magento2.ParseDecimal(in); err == nil {
+			t.Errorf("expected error parsing %q", in)
+		}
+	}
+
+	if got := magento2.NewDecimal(1999, 2).StringFixed(2); got != "19.99" {
+		t.Errorf("expected 19.99, got %s", got)
+	}
+	if got := magento2.NewDecimal(-12345, 4).Round(2).String(); got != "-1.23" {
+		t.Errorf("expected -1.23, got %s", got)
+	}
+}
+
+func TestDecimal_Mul(t *testing.T) {
+	price := magento2.NewDecimal(1999, 2)
+	qty := magento2.NewDecimal(25, 1)
+	if got := price.Mul(qty).String(); got != "49.975" {
+		t.Errorf("expected 49.975, got %s", got)
+	}
+	if got := magento2.NewDecimal(3333, 4).Mul(magento2.NewDecimal(5, 1)).String(); got != "0.1667" {
+		t.Errorf("expected 0.1667, got %s", got)
+	}
+}
+
+func TestDecimal_JSON(t *testing.T) {
+	var v struct {
+		Qty   magento2.Decimal `json:"qty"`
+		Price magento2.Decimal `json:"price"`
+	}
+	if err := json.Unmarshal([]byte(`{"qty": 1.5, "price": "19.90"}`), &v); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if v.Qty.String() != "1.5" || v.Price.String() != "19.9" {
+		t.Errorf("unexpected values %s, %s", v.Qty, v.Price)
+	}
+	out, err := json.Marshal(v)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(out) != `{"qty":1.5,"price":19.9}` {
+		t.Errorf("unexpected json %s", out)
+	}
+}
+
+func TestMoney_CurrencyMismatch(t *testing.T) {
+	eur := magento2.NewMoney(10, "EUR")
+	if _, err := eur.Add(magento2.NewMoney(1, "USD")); !errors.Is(err, magento2.ErrCurrencyMismatch) {
+		t.Fatalf("expected ErrCurrencyMismatch, got: %v", err)
+	}
+	sum, err := eur.Add(magento2.NewMoney(0.2, "EUR"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if sum.String() != "10.20 EUR" {
+		t.Errorf("expected 10.20 EUR, got %s", sum)
+	}
+
+	order := &magento2.Order{OrderCurrencyCode: "USD", BaseCurrencyCode: "EUR", GrandTotal: 11, BaseGrandTotal: 10}
+	if order.OrderAmounts().GrandTotal.String() != "11.00 USD" || order.BaseAmounts().GrandTotal.String() != "10.00 EUR" {
+		t.Errorf("unexpected order amounts %v / %v", order.OrderAmounts().GrandTotal, order.BaseAmounts().GrandTotal)
+	}
+}
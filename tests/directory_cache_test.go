@@ -0,0 +1,80 @@
+package magento2
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	magento2 "github.com/florinel-chis/go-m2rest"
+)
+
+func TestDirectoryCache(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/rest/default/V1/directory/countries" {
+			t.Errorf("unexpected request: %s", r.URL)
+		}
+		requests++
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`[
+			{"id":"US","two_letter_abbreviation":"US","full_name_english":"United States","available_regions":[
+				{"id":"12","code":"CA","name":"California"},{"id":"43","code":"NY","name":"New York"}]},
+			{"id":"GB","two_letter_abbreviation":"GB","full_name_english":"United Kingdom"}]`))
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := magento2.NewClient(context.Background(), magento2.WithStoreURL(server.URL, "default"), magento2.WithBearerToken("token"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	cache := magento2.NewDirectoryCache(client)
+	ctx := context.Background()
+
+	country, err := cache.Country(ctx, "us")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if country.FullNameEnglish != "United States" {
+		t.Errorf("unexpected country %+v", country)
+	}
+	if _, err := cache.Country(ctx, "FR"); !errors.Is(err, magento2.ErrNotFound) {
+		t.Errorf("expected ErrNotFound for an unknown country, got %v", err)
+	}
+
+	region, err := cache.ResolveRegion(ctx, "US", " new york ")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if region.ID != "43" || region.Code != "NY" {
+		t.Errorf("unexpected region %+v", region)
+	}
+	if _, err := cache.ResolveRegion(ctx, "US", "Ontario"); !errors.Is(err, magento2.ErrNotFound) {
+		t.Errorf("expected ErrNotFound for an unknown region, got %v", err)
+	}
+
+	address := &magento2.Address{CountryID: "US", RegionCode: "ca"}
+	if err := cache.FillRegion(ctx, address, ""); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if address.RegionID != 12 || address.RegionCode != "CA" {
+		t.Errorf("unexpected address region %d/%s", address.RegionID, address.RegionCode)
+	}
+
+	ukAddress := &magento2.Address{CountryID: "GB"}
+	if err := cache.FillRegion(ctx, ukAddress, "Greater London"); err != nil || ukAddress.RegionID != 0 {
+		t.Errorf("expected countries without regions to be left alone, got %d (%v)", ukAddress.RegionID, err)
+	}
+
+	if requests != 1 {
+		t.Errorf("expected the countries to be loaded once, got %d requests", requests)
+	}
+	cache.Invalidate()
+	if _, err := cache.Country(ctx, "US"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if requests != 2 {
+		t.Errorf("expected Invalidate to reload the countries, got %d requests", requests)
+	}
+}
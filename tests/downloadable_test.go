@@ -0,0 +1,84 @@
+package magento2
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	magento2 "github.com/florinel-chis/go-m2rest"
+)
+
+func TestNewDownloadableFileContent(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "manual.pdf")
+	if err := os.WriteFile(path, []byte("%PDF-1.4"), 0o600); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	content, err := magento2.NewDownloadableFileContent(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if content.Name != "manual.pdf" || content.FileData != "JVBERi0xLjQ=" {
+		t.Errorf("unexpected content %+v", content)
+	}
+	encoded, _ := json.Marshal(content)
+	if string(encoded) != `{"file_data":"JVBERi0xLjQ=","name":"manual.pdf"}` {
+		t.Errorf("expected only the fields Magento accepts, got %s", encoded)
+	}
+
+	empty := filepath.Join(dir, "empty.pdf")
+	if err := os.WriteFile(empty, nil, 0o600); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := magento2.NewDownloadableFileContent(empty); err == nil {
+		t.Error("expected an error for an empty file")
+	}
+	if _, err := magento2.NewDownloadableFileContent(filepath.Join(dir, "missing.pdf")); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("expected a not exist error, got %v", err)
+	}
+}
+
+func TestAddDownloadableLink(t *testing.T) {
+	var payload struct {
+		Link                 magento2.DownloadableLink `json:"link"`
+		IsGlobalScopeContent bool                      `json:"isGlobalScopeContent"`
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/rest/default/V1/products/ebook/downloadable-links" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		_ = json.NewDecoder(r.Body).Decode(&payload)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`"7"`))
+	}))
+	t.Cleanup(server.Close)
+	client, err := magento2.NewClient(context.Background(), magento2.WithStoreURL(server.URL, "default"), magento2.WithBearerToken("token"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	path := filepath.Join(t.TempDir(), "ebook.epub")
+	if err := os.WriteFile(path, []byte("book"), 0o600); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	link, err := magento2.NewFileDownloadableLink("E-Book", path, 4.99)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	mProduct := &magento2.MProduct{Route: "/products/ebook", Product: &magento2.Product{Sku: "ebook"}, APIClient: client}
+	id, err := mProduct.AddDownloadableLink(context.Background(), link)
+	if err != nil || id != 7 {
+		t.Fatalf("expected link 7, got %d, %v", id, err)
+	}
+	if !payload.IsGlobalScopeContent || payload.Link.LinkType != magento2.DownloadableTypeFile || payload.Link.LinkFileContent == nil ||
+		payload.Link.LinkFileContent.Name != "ebook.epub" || payload.Link.LinkFileContent.FileData != "Ym9vaw==" {
+		t.Errorf("unexpected payload %+v", payload)
+	}
+}
@@ -0,0 +1,161 @@
+package magento2
+
+import (
+	"bytes"
+	"context"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	magento2 "github.com/florinel-chis/go-m2rest"
+)
+
+// recordingEncoder keeps the rows it receives as column=value pairs
+type recordingEncoder struct {
+	columns []magento2.ExportColumn
+	rows    []string
+	closed  bool
+}
+
+func (e *recordingEncoder) Begin(columns []magento2.ExportColumn) error {
+	e.columns = columns
+	return nil
+}
+
+func (e *recordingEncoder) Encode(columns, values []string) error {
+	pairs := make([]string, len(columns))
+	for i := range columns {
+		pairs[i] = columns[i] + "=" + values[i]
+	}
+	e.rows = append(e.rows, strings.Join(pairs, " "))
+	return nil
+}
+
+func (e *recordingEncoder) Close() error {
+	e.closed = true
+	return nil
+}
+
+func TestExportOrders_Encoder(t *testing.T) {
+	server := newOrderExportServer(t)
+	ctx := context.Background()
+	client, err := magento2.NewClient(ctx, magento2.WithStoreURL(server.URL, "default"), magento2.WithBearerToken("token"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	enc := &recordingEncoder{}
+	var buf bytes.Buffer
+	records, err := magento2.ExportOrders(ctx, &buf, magento2.OrderExportOptions{
+		Format:  "parquet",
+		Encoder: enc,
+		From:    time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+		Fields: map[magento2.OrderRecordType][]string{
+			magento2.OrderRecordOrder:   {"order_increment_id", "grand_total"},
+			magento2.OrderRecordItem:    {"order_increment_id", "sku"},
+			magento2.OrderRecordTax:     {},
+			magento2.OrderRecordPayment: {},
+			magento2.OrderRecordRefund:  {},
+		},
+	}, client)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if records != 2 || !enc.closed || buf.Len() != 0 {
+		t.Fatalf("expected 2 records to the closed encoder only, got %d, closed %v, output %q", records, enc.closed, buf.String())
+	}
+	want := []magento2.ExportColumn{
+		{Name: "record_type", Type: magento2.ColumnString},
+		{Name: "order_increment_id", Type: magento2.ColumnString},
+		{Name: "grand_total", Type: magento2.ColumnNumber},
+		{Name: "sku", Type: magento2.ColumnString},
+	}
+	if !slices.Equal(enc.columns, want) {
+		t.Errorf("expected columns %v, got %v", want, enc.columns)
+	}
+	rows := []string{
+		"record_type=order order_increment_id=000000005 grand_total=119.00",
+		"record_type=item order_increment_id=000000005 sku=shirt",
+	}
+	if strings.Join(enc.rows, "\n") != strings.Join(rows, "\n") {
+		t.Errorf("expected rows %q, got %q", rows, enc.rows)
+	}
+}
+
+func TestCSVEncoder_PlacesValuesByColumn(t *testing.T) {
+	var buf bytes.Buffer
+	enc := magento2.NewCSVEncoder(&buf)
+	if err := enc.Begin(magento2.StringColumns("a", "b", "c")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := enc.Encode([]string{"a", "c"}, []string{"1", "3"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := enc.Close(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if buf.String() != "a,b,c\n1,,3\n" {
+		t.Errorf("unexpected csv %q", buf.String())
+	}
+}
+
+func TestJSONLEncoder_KeepsColumnOrder(t *testing.T) {
+	var buf bytes.Buffer
+	enc := magento2.NewJSONLEncoder(&buf)
+	if err := enc.Encode([]string{"z", "a"}, []string{"1", `"q"`}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if buf.String() != `{"z":"1","a":"\"q\""}`+"\n" {
+		t.Errorf("unexpected jsonl %q", buf.String())
+	}
+}
+
+func TestJSONLEncoder_WritesTypedValues(t *testing.T) {
+	var buf bytes.Buffer
+	enc := magento2.NewJSONLEncoder(&buf)
+	err := enc.Begin([]magento2.ExportColumn{
+		{Name: "sku", Type: magento2.ColumnString},
+		{Name: "price", Type: magento2.ColumnNumber},
+		{Name: "special_price", Type: magento2.ColumnNumber},
+		{Name: "salable", Type: magento2.ColumnBool},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := enc.Encode([]string{"sku", "price", "special_price", "salable"}, []string{"007", "19.90", "", "true"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := enc.Encode([]string{"sku", "price"}, []string{"shirt", "n/a"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := `{"sku":"007","price":19.90,"special_price":null,"salable":true}` + "\n" + `{"sku":"shirt","price":"n/a"}` + "\n"
+	if buf.String() != want {
+		t.Errorf("expected %q, got %q", want, buf.String())
+	}
+}
+
+func TestJSONEncoder_WritesAnArray(t *testing.T) {
+	var buf bytes.Buffer
+	enc := magento2.NewJSONEncoder(&buf)
+	if err := enc.Close(); err != nil || buf.String() != "[]\n" {
+		t.Fatalf("expected an empty array, got %q, %v", buf.String(), err)
+	}
+
+	buf.Reset()
+	enc = magento2.NewJSONEncoder(&buf)
+	if err := enc.Begin([]magento2.ExportColumn{{Name: "sku", Type: magento2.ColumnString}, {Name: "qty", Type: magento2.ColumnNumber}}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, values := range [][]string{{"a", "1"}, {"b", "2.5"}} {
+		if err := enc.Encode([]string{"sku", "qty"}, values); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if err := enc.Close(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := "[\n{\"sku\":\"a\",\"qty\":1},\n{\"sku\":\"b\",\"qty\":2.5}\n]\n"; buf.String() != want {
+		t.Errorf("expected %q, got %q", want, buf.String())
+	}
+}
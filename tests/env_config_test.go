@@ -0,0 +1,92 @@
+package magento2
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	magento2 "github.com/florinel-chis/go-m2rest"
+)
+
+func TestLoadTestConfigFromEnv_SharesTheLibraryParser(t *testing.T) {
+	t.Setenv(magento2.EnvHost, "shop.example.com")
+	t.Setenv(magento2.EnvBearerToken, "token")
+	t.Setenv(magento2.EnvStoreCode, "")
+	t.Setenv(magento2.EnvTimeout, "90s")
+
+	config, err := LoadTestConfigFromEnv()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	envConfig, err := magento2.LoadEnvConfig()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if config.StoreCode != "default" || envConfig.StoreCode != "all" {
+		t.Errorf("expected the store code to default to default for tests and all otherwise, got %q and %q", config.StoreCode, envConfig.StoreCode)
+	}
+	if config.Timeout != 90*time.Second || envConfig.Timeout != 90*time.Second {
+		t.Errorf("expected the timeout from %s, got %v and %v", magento2.EnvTimeout, config.Timeout, envConfig.Timeout)
+	}
+
+	t.Setenv(magento2.EnvTimeout, "soon")
+	if _, err := LoadTestConfigFromEnv(); err == nil {
+		t.Error("expected an invalid timeout to be rejected")
+	}
+	t.Setenv(magento2.EnvTimeout, "")
+	t.Setenv(magento2.EnvHost, "")
+	config, err = LoadTestConfigFromEnv()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if config.Host != "http://localhost" || config.Timeout != 60*time.Second {
+		t.Errorf("expected the test defaults for host and timeout, got %q and %v", config.Host, config.Timeout)
+	}
+	if _, err := magento2.LoadEnvConfig(); err == nil {
+		t.Errorf("expected %s to be required outside the tests", magento2.EnvHost)
+	}
+	t.Setenv(magento2.EnvBearerToken, "")
+	if _, err := LoadTestConfigFromEnv(); err == nil {
+		t.Error("expected a missing token to be rejected")
+	}
+}
+
+func TestStoreConfigFromURL_KeepsTheBasePath(t *testing.T) {
+	for host, want := range map[string]magento2.StoreConfig{
+		"shop.example.com":               {Scheme: "https", HostName: "shop.example.com", StoreCode: "default"},
+		"http://example.com:8080/shop/":  {Scheme: "http", HostName: "example.com:8080", BasePath: "/shop", StoreCode: "default"},
+		"https://example.com/eu/magento": {Scheme: "https", HostName: "example.com", BasePath: "/eu/magento", StoreCode: "default"},
+	} {
+		storeConfig, err := magento2.StoreConfigFromURL(host, "default")
+		if err != nil || *storeConfig != want {
+			t.Errorf("%s: expected %+v, got %+v, %v", host, want, storeConfig, err)
+		}
+	}
+
+	var path string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"sku":"shirt"}`))
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := magento2.NewClient(context.Background(), magento2.WithStoreURL(server.URL+"/shop", "default"), magento2.WithBearerToken("token"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := magento2.GetProductBySKU("shirt", client); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if path != "/shop/rest/default/V1/products/shirt" {
+		t.Errorf("expected the request below the base path, got %s", path)
+	}
+	if _, err := magento2.GetProductBySKU("shirt", client, magento2.WithStoreCode("de")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if path != "/shop/rest/de/V1/products/shirt" {
+		t.Errorf("expected the store scoped request below the base path, got %s", path)
+	}
+}
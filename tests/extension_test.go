@@ -0,0 +1,90 @@
+package magento2
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	magento2 "github.com/florinel-chis/go-m2rest"
+)
+
+type loyaltyBalance struct {
+	CustomerID int     `json:"customer_id"`
+	Points     float64 `json:"points"`
+}
+
+type loyaltyRedeem struct {
+	Points float64 `json:"points"`
+}
+
+func TestExtension_RegisteredEndpoints(t *testing.T) {
+	var calls []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		raw, _ := io.ReadAll(r.Body)
+		calls = append(calls, r.Method+" "+r.URL.EscapedPath()+" "+string(raw))
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.EscapedPath() {
+		case "/rest/default/V1/loyalty/balance/7":
+			_, _ = w.Write([]byte(`{"customer_id":7,"points":120}`))
+		case "/rest/de/V1/loyalty/redeem/a%2Fb":
+			_, _ = w.Write([]byte(`{"customer_id":7,"points":20}`))
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"message":"unknown route"}`))
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := magento2.NewClient(context.Background(),
+		magento2.WithStoreURL(server.URL, "default"),
+		magento2.WithBearerToken("token"),
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	client.RegisterExtension("loyalty", "/loyalty")
+
+	if _, err := client.RegisteredExtension("rewards"); !errors.Is(err, magento2.ErrUnknownExtension) {
+		t.Errorf("expected ErrUnknownExtension, got: %v", err)
+	}
+
+	// registrations survive cloning
+	clone, err := client.Clone()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	loyalty, err := clone.RegisteredExtension("loyalty")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	balance := magento2.NewEndpoint[struct{}, loyaltyBalance](http.MethodGet, "/balance/%d")
+	got, err := balance.Path(7).Call(context.Background(), loyalty, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.CustomerID != 7 || got.Points != 120 {
+		t.Errorf("unexpected balance: %+v", got)
+	}
+
+	redeem := magento2.NewEndpoint[loyaltyRedeem, loyaltyBalance](http.MethodPost, "/redeem/%s")
+	got, err = redeem.Path("a/b").Call(context.Background(), loyalty, &loyaltyRedeem{Points: 100}, magento2.WithStoreCode("de"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.Points != 20 {
+		t.Errorf("unexpected balance after redeem: %+v", got)
+	}
+	if calls[1] != `POST /rest/de/V1/loyalty/redeem/a%2Fb {"points":100}` {
+		t.Errorf("unexpected request: %s", calls[1])
+	}
+
+	_, err = redeem.Path("c").Call(context.Background(), loyalty, &loyaltyRedeem{})
+	var httpErr *magento2.HTTPStatusError
+	if !errors.As(err, &httpErr) || httpErr.StatusCode != http.StatusBadRequest {
+		t.Errorf("expected HTTPStatusError with status 400, got: %v", err)
+	}
+}
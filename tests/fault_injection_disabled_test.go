@@ -0,0 +1,21 @@
+//go:build !faultinjection
+
+package magento2
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	magento2 "github.com/florinel-chis/go-m2rest"
+)
+
+func TestWithFaultInjection_DisabledWithoutTag(t *testing.T) {
+	_, err := magento2.NewClient(context.Background(),
+		magento2.WithStoreURL("https://shop.example.com", "default"),
+		magento2.WithFaultInjection(magento2.FaultPolicy{ServerErrorRate: 1}),
+	)
+	if !errors.Is(err, magento2.ErrFaultInjectionDisabled) {
+		t.Errorf("expected fault injection to be disabled without the faultinjection tag, got %v", err)
+	}
+}
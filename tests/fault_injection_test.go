@@ -0,0 +1,125 @@
+//go:build faultinjection
+
+package magento2
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	magento2 "github.com/florinel-chis/go-m2rest"
+)
+
+func newFaultInjectionClient(t *testing.T, policy magento2.FaultPolicy) (*magento2.Client, *atomic.Int64) {
+	var served atomic.Int64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		served.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"sku":"shirt"}`))
+	}))
+	t.Cleanup(server.Close)
+	client, err := magento2.NewClient(context.Background(),
+		magento2.WithStoreURL(server.URL, "default"),
+		magento2.WithBearerToken("token"),
+		magento2.WithRetry(2, time.Millisecond, time.Millisecond),
+		magento2.WithFaultInjection(policy),
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return client, &served
+}
+
+func TestWithFaultInjection_ServerErrorsAreRetried(t *testing.T) {
+	client, served := newFaultInjectionClient(t, magento2.FaultPolicy{ServerErrorRate: 1, Routes: []string{"/products/*"}})
+
+	resp, err := client.HTTPClient.R().Get("/products/shirt")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.StatusCode() != http.StatusServiceUnavailable || resp.Header().Get(magento2.HeaderInjectedFault) != "503" {
+		t.Errorf("expected an injected 503, got %d %v", resp.StatusCode(), resp.Header())
+	}
+	if stats := client.Stats(); stats.Requests != 3 || stats.Retries != 2 || stats.ServerErrors != 1 {
+		t.Errorf("expected 3 attempts of one failed call, got %+v", stats)
+	}
+
+	if _, err := client.HTTPClient.R().Get("/store/storeConfigs"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if served.Load() != 1 {
+		t.Errorf("expected only the unmatched route to reach the server, got %d requests", served.Load())
+	}
+}
+
+func TestWithFaultInjection_RateLimitsAndTimeouts(t *testing.T) {
+	client, served := newFaultInjectionClient(t, magento2.FaultPolicy{RateLimitRate: 1, RetryAfter: 30 * time.Second})
+	resp, err := client.HTTPClient.R().Get("/products/shirt")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.StatusCode() != http.StatusTooManyRequests || resp.Header().Get("Retry-After") != "30" {
+		t.Errorf("expected an injected 429 with Retry-After, got %d %v", resp.StatusCode(), resp.Header())
+	}
+
+	client, _ = newFaultInjectionClient(t, magento2.FaultPolicy{RateLimitRate: 1, RetryAfter: 200 * time.Millisecond})
+	resp, err = client.HTTPClient.R().Get("/products/shirt")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.Header().Get("Retry-After") != "1" {
+		t.Errorf("expected a sub-second Retry-After to be rounded up, got %q", resp.Header().Get("Retry-After"))
+	}
+
+	client, _ = newFaultInjectionClient(t, magento2.FaultPolicy{TimeoutRate: 1})
+	if _, err := client.HTTPClient.R().Get("/products/shirt"); !errors.Is(err, magento2.ErrInjectedFault) {
+		t.Errorf("expected an injected timeout, got %v", err)
+	}
+	if served.Load() != 0 {
+		t.Errorf("expected no request to reach the server, got %d", served.Load())
+	}
+}
+
+func TestWithFaultInjection_InvalidPolicy(t *testing.T) {
+	_, err := magento2.NewClient(context.Background(),
+		magento2.WithStoreURL("https://shop.example.com", "default"),
+		magento2.WithFaultInjection(magento2.FaultPolicy{TimeoutRate: 0.6, ServerErrorRate: 0.6}),
+	)
+	if err == nil {
+		t.Error("expected rates adding up to more than 1 to be rejected")
+	}
+}
+
+func TestWithFaultInjection_Clone(t *testing.T) {
+	client, served := newFaultInjectionClient(t, magento2.FaultPolicy{ServerErrorRate: 1, Routes: []string{"/products/*"}})
+
+	clone, err := client.Clone(magento2.WithMaxIdleConnsPerHost(4))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	resp, err := clone.HTTPClient.R().Get("/products/shirt")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.StatusCode() != http.StatusServiceUnavailable || served.Load() != 0 {
+		t.Errorf("expected the clone to keep the fault policy, got %d with %d requests served", resp.StatusCode(), served.Load())
+	}
+
+	clone, err = client.Clone(magento2.WithFaultInjection(magento2.FaultPolicy{RateLimitRate: 1, Routes: []string{"/store/*"}}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp, err = clone.HTTPClient.R().Get("/products/shirt"); err != nil || resp.StatusCode() != http.StatusOK {
+		t.Errorf("expected the clone's policy to replace the original one, got %v, %v", resp, err)
+	}
+	if resp, err = clone.HTTPClient.R().Get("/store/storeViews"); err != nil || resp.StatusCode() != http.StatusTooManyRequests {
+		t.Errorf("expected an injected 429, got %v, %v", resp, err)
+	}
+	if served.Load() != 1 {
+		t.Errorf("expected only the products request to reach the server, got %d requests", served.Load())
+	}
+}
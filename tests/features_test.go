@@ -0,0 +1,79 @@
+package magento2
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	magento2 "github.com/florinel-chis/go-m2rest"
+	"github.com/florinel-chis/go-m2rest/features"
+)
+
+func TestMagentoVersion(t *testing.T) {
+	version, err := magento2.ParseMagentoVersion("Magento/2.4 (Enterprise)\n")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if version.Version != "2.4" || version.Edition != magento2.EditionEnterprise {
+		t.Errorf("unexpected version: %+v", version)
+	}
+	for required, expected := range map[string]bool{"2": true, "2.3": true, "2.4": true, "2.4.6": false, "2.10": false} {
+		if got := version.AtLeast(required); got != expected {
+			t.Errorf("AtLeast(%s): expected %v, got %v", required, expected, got)
+		}
+	}
+
+	if _, err := magento2.ParseMagentoVersion("<html>"); !errors.Is(err, magento2.ErrUnexpectedVersion) {
+		t.Errorf("expected ErrUnexpectedVersion, got: %v", err)
+	}
+}
+
+func TestFeatures_Supported(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/magento_version" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		requests.Add(1)
+		_, _ = w.Write([]byte("Magento/2.3 (Community)"))
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := magento2.NewClient(context.Background(),
+		magento2.WithStoreURL(server.URL, "default"),
+		magento2.WithBearerToken("token"),
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := map[features.Feature]bool{
+		features.MSI:           true,
+		features.AsyncBulk:     true,
+		features.ReviewsREST:   false,
+		features.TwoFactorAuth: false,
+	}
+	for feature, want := range expected {
+		got, err := features.Supported(context.Background(), client, feature)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got != want {
+			t.Errorf("feature %s: expected %v, got %v", feature, want, got)
+		}
+	}
+	if requests.Load() != 1 {
+		t.Errorf("expected the version to be detected once, got %d requests", requests.Load())
+	}
+
+	community := &magento2.MagentoVersion{Version: "2.4", Edition: magento2.EditionCommunity}
+	features.Matrix["b2b"] = features.Requirement{MinVersion: "2.2", Editions: []string{magento2.EditionEnterprise}}
+	t.Cleanup(func() { delete(features.Matrix, "b2b") })
+	if features.SupportedBy(community, "b2b") {
+		t.Errorf("expected edition restricted feature to be unsupported on community")
+	}
+}
@@ -0,0 +1,81 @@
+package magento2
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	magento2 "github.com/florinel-chis/go-m2rest"
+	"github.com/florinel-chis/go-m2rest/feeds"
+)
+
+func TestMapping_Row(t *testing.T) {
+	product := &magento2.Product{
+		Sku:    "24-MB01",
+		Name:   "Joust <b>Duffle</b> Bag",
+		Price:  34,
+		Status: magento2.ProductStatusEnabled,
+		CustomAttributes: []map[string]any{
+			{"attribute_code": "description", "value": "<p>The sporty Joust&nbsp;Duffle Bag</p>"},
+			{"attribute_code": "url_key", "value": "joust-duffle-bag"},
+			{"attribute_code": "special_price", "value": "29.5"},
+		},
+		ExtensionAttributes: map[string]any{"stock_item": map[string]any{"qty": 0, "is_in_stock": false}},
+	}
+
+	mapping := feeds.GoogleShoppingMapping("USD", "https://example.com/", ".html")
+	values, err := mapping.Row(product, time.Now())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := []string{"24-MB01", "Joust Duffle Bag", "The sporty Joust Duffle Bag",
+		"https://example.com/joust-duffle-bag.html", "34.00 USD", "29.50 USD", "out_of_stock", "new"}
+	if strings.Join(values, "|") != strings.Join(expected, "|") {
+		t.Errorf("unexpected values:\n%q\nexpected:\n%q", values, expected)
+	}
+
+	product.Name = ""
+	if _, err := mapping.Row(product, time.Now()); !errors.Is(err, feeds.ErrMissingValue) {
+		t.Errorf("expected ErrMissingValue for empty title, got: %v", err)
+	}
+}
+
+func TestMapping_Validate(t *testing.T) {
+	cases := map[string]feeds.Mapping{
+		"empty":     {},
+		"no source": {{Name: "id"}},
+		"duplicate": {{Name: "id", Source: "sku"}, {Name: "id", Source: "name"}},
+	}
+	for name, mapping := range cases {
+		if err := mapping.Validate(); !errors.Is(err, feeds.ErrInvalidMapping) {
+			t.Errorf("%s: expected ErrInvalidMapping, got: %v", name, err)
+		}
+	}
+}
+
+func TestWriteMapped_JSON(t *testing.T) {
+	client, _ := newFeedTestClient(t, 2)
+
+	mapping := feeds.Mapping{
+		{Name: "id", Source: "sku"},
+		{Name: "availability", Source: feeds.SourceSalable, Transforms: []feeds.Transform{
+			feeds.MapValues(map[string]string{"true": "in stock", "false": "out of stock"}),
+		}},
+		{Name: "price", Source: "price", Transforms: []feeds.Transform{feeds.FormatPrice("EUR")}},
+	}
+
+	var buf bytes.Buffer
+	rows, err := feeds.WriteMapped(context.Background(), client, &buf, mapping, feeds.Options{Format: feeds.FormatJSON})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := "[\n" +
+		`{"id":"SKU-1","availability":"in stock","price":"10.00 EUR"},` + "\n" +
+		`{"id":"SKU-2","availability":"out of stock","price":"20.00 EUR"}` + "\n]\n"
+	if rows != 2 || buf.String() != expected {
+		t.Errorf("unexpected feed with %d rows:\n%s", rows, buf.String())
+	}
+}
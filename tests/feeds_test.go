@@ -0,0 +1,111 @@
+package magento2
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	magento2 "github.com/florinel-chis/go-m2rest"
+	"github.com/florinel-chis/go-m2rest/feeds"
+)
+
+func newFeedTestClient(t *testing.T, totalCount int) (*magento2.Client, *atomic.Int32) {
+	t.Helper()
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		if r.URL.Path != "/rest/default/V1/products" {
+			t.Errorf("unexpected request: %s", r.URL)
+		}
+		pageSize, _ := strconv.Atoi(r.URL.Query().Get("searchCriteria[pageSize]"))
+		currentPage, _ := strconv.Atoi(r.URL.Query().Get("searchCriteria[currentPage]"))
+		items := []string{}
+		for i := (currentPage-1)*pageSize + 1; i <= min(currentPage*pageSize, totalCount); i++ {
+			items = append(items, fmt.Sprintf(`{"sku":"SKU-%d","price":%d,"status":1,`+
+				`"custom_attributes":[{"attribute_code":"special_price","value":"%d.5"},`+
+				`{"attribute_code":"special_to_date","value":"2026-01-01 00:00:00"}],`+
+				`"extension_attributes":{"stock_item":{"qty":%d,"is_in_stock":%t}}}`, i, i*10, i, i, i%2 == 1))
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprintf(w, `{"items":[%s],"total_count":%d}`, strings.Join(items, ","), totalCount)
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := magento2.NewClient(context.Background(),
+		magento2.WithStoreURL(server.URL, "default"),
+		magento2.WithBearerToken("token"),
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return client, &requests
+}
+
+func TestWritePriceStock_CSV(t *testing.T) {
+	client, requests := newFeedTestClient(t, 5)
+
+	var buf bytes.Buffer
+	rows, err := feeds.WritePriceStock(context.Background(), client, &buf, feeds.Options{
+		Criteria: &magento2.SearchCriteria{PageSize: 2},
+		Workers:  2,
+		Now:      time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if rows != 5 || requests.Load() != 3 {
+		t.Fatalf("expected 5 rows from 3 pages, got %d rows from %d requests", rows, requests.Load())
+	}
+
+	expected := "sku,price,special_price,qty,salable\n" +
+		"SKU-1,10,1.5,1,true\n" +
+		"SKU-2,20,2.5,2,false\n" +
+		"SKU-3,30,3.5,3,true\n" +
+		"SKU-4,40,4.5,4,false\n" +
+		"SKU-5,50,5.5,5,true\n"
+	if buf.String() != expected {
+		t.Errorf("unexpected feed:\n%s", buf.String())
+	}
+}
+
+func TestWritePriceStock_JSON(t *testing.T) {
+	client, _ := newFeedTestClient(t, 3)
+
+	var buf bytes.Buffer
+	_, err := feeds.WritePriceStock(context.Background(), client, &buf, feeds.Options{
+		Format: feeds.FormatJSON,
+		Now:    time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var rows []feeds.PriceStockRow
+	if err := json.Unmarshal(buf.Bytes(), &rows); err != nil {
+		t.Fatalf("expected a JSON array, got %q: %v", buf.String(), err)
+	}
+	if len(rows) != 3 || rows[2].Sku != "SKU-3" || rows[2].Qty != 3 || !rows[2].Salable {
+		t.Errorf("unexpected rows: %+v", rows)
+	}
+	if rows[0].SpecialPrice != nil {
+		t.Errorf("expected expired special price to be omitted, got %v", *rows[0].SpecialPrice)
+	}
+}
+
+func TestWritePriceStock_UnknownFormat(t *testing.T) {
+	client, requests := newFeedTestClient(t, 1)
+
+	_, err := feeds.WritePriceStock(context.Background(), client, &bytes.Buffer{}, feeds.Options{Format: "xml"})
+	if !errors.Is(err, feeds.ErrUnknownFormat) || requests.Load() != 0 {
+		t.Errorf("expected ErrUnknownFormat before any request, got %v after %d requests", err, requests.Load())
+	}
+}
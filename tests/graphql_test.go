@@ -0,0 +1,86 @@
+package magento2
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	magento2 "github.com/florinel-chis/go-m2rest"
+)
+
+func TestGraphQL_QueryProducts(t *testing.T) {
+	var attempts atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request struct {
+			Query     string         `json:"query"`
+			Variables map[string]any `json:"variables"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&request)
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case strings.HasPrefix(request.Query, "mutation"):
+			w.WriteHeader(http.StatusServiceUnavailable)
+		case strings.Contains(request.Query, "products("):
+			// the first attempt fails, queries are retried
+			if attempts.Add(1) == 1 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			if r.Header.Get("Store") != "de" || request.Variables["search"] != "bag" || request.Variables["pageSize"] != float64(2) {
+				t.Errorf("unexpected request: store %q, variables %v", r.Header.Get("Store"), request.Variables)
+			}
+			_, _ = w.Write([]byte(`{"data":{"products":{"total_count":1,` +
+				`"items":[{"__typename":"SimpleProduct","uid":"MQ==","sku":"24-MB01","name":"Joust Duffle Bag",` +
+				`"price_range":{"minimum_price":{"final_price":{"value":34,"currency":"EUR"}}}}],` +
+				`"aggregations":[{"attribute_code":"price","label":"Price","count":1,"options":[{"label":"30-40","value":"30_40","count":1}]}]}}}`))
+		default:
+			_, _ = w.Write([]byte(`{"data":{"customerCart":null},"errors":[{"message":"The current customer isn't authorized.","extensions":{"category":"graphql-authorization"}}]}`))
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := magento2.NewClient(context.Background(),
+		magento2.WithStoreURL(server.URL, "default"),
+		magento2.WithBearerToken("token"),
+		magento2.WithRetry(2, time.Millisecond, time.Millisecond),
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	result, err := magento2.QueryProducts(context.Background(), &magento2.ProductQuery{Search: "bag", PageSize: 2}, client, magento2.WithStoreCode("de"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.TotalCount != 1 || result.Items[0].Sku != "24-MB01" || result.Items[0].PriceRange.MinimumPrice.FinalPrice.Value.String() != "34" {
+		t.Errorf("unexpected products: %+v", result)
+	}
+	if len(result.Aggregations) != 1 || result.Aggregations[0].Options[0].Value != "30_40" {
+		t.Errorf("unexpected aggregations: %+v", result.Aggregations)
+	}
+
+	_, err = magento2.GetCustomerCartSummary(context.Background(), client)
+	var graphQLErrs magento2.GraphQLErrors
+	if !errors.Is(err, magento2.ErrGraphQL) || !errors.As(err, &graphQLErrs) {
+		t.Fatalf("expected GraphQLErrors, got: %v", err)
+	}
+	if graphQLErrs[0].Extensions["category"] != "graphql-authorization" {
+		t.Errorf("unexpected error extensions: %+v", graphQLErrs[0])
+	}
+
+	before := client.Stats().Requests
+	err = client.GraphQL(context.Background(), `mutation { createEmptyCart }`, nil, nil)
+	var httpErr *magento2.HTTPStatusError
+	if !errors.As(err, &httpErr) || httpErr.StatusCode != http.StatusServiceUnavailable {
+		t.Errorf("expected HTTPStatusError with status 503, got: %v", err)
+	}
+	if requests := client.Stats().Requests - before; requests != 1 {
+		t.Errorf("expected mutation not to be retried, got %d requests", requests)
+	}
+}
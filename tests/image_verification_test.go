@@ -0,0 +1,76 @@
+package magento2
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	magento2 "github.com/florinel-chis/go-m2rest"
+)
+
+func TestImageVerifier(t *testing.T) {
+	var imageRequests atomic.Int32
+	var mediaURL string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/V1/store/storeConfigs"):
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`[{"code":"default","base_media_url":"` + mediaURL + `","secure_base_media_url":"` + mediaURL + `"}]`))
+		case r.URL.Path == "/rest/default/V1/products":
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"items":[
+				{"id":1,"sku":"mug","media_gallery_entries":[{"file":"/m/u/mug.jpg","media_type":"image"},{"file":"/s/h/shared.jpg","media_type":"image"}]},
+				{"id":2,"sku":"plate","media_gallery_entries":[{"file":"/p/l/plate.jpg","media_type":"image"},{"file":"/s/h/shared.jpg","media_type":"image"}]},
+				{"id":3,"sku":"bowl","media_gallery_entries":[{"file":"/b/o/bowl.jpg","media_type":"image"}]}
+			],"total_count":3}`))
+		case strings.HasPrefix(r.URL.Path, "/media/catalog/product/"):
+			imageRequests.Add(1)
+			if r.Header.Get("Authorization") != "" {
+				t.Error("expected image requests without the API token")
+			}
+			switch r.URL.Path {
+			case "/media/catalog/product/p/l/plate.jpg", "/media/catalog/product/s/h/shared.jpg":
+				w.WriteHeader(http.StatusNotFound)
+			case "/media/catalog/product/b/o/bowl.jpg":
+				if r.Method == http.MethodHead {
+					w.WriteHeader(http.StatusMethodNotAllowed)
+				}
+			}
+		default:
+			t.Errorf("unexpected request: %s", r.URL)
+		}
+	}))
+	t.Cleanup(server.Close)
+	mediaURL = server.URL + "/media/"
+
+	client, err := magento2.NewClient(context.Background(),
+		magento2.WithStoreURL(server.URL, "default"),
+		magento2.WithBearerToken("token"),
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	verifier := magento2.NewImageVerifier(magento2.NewMediaURLResolver(client, time.Hour))
+	verifier.Concurrency = 2
+	report, err := verifier.Verify(context.Background(), nil, 1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if report.ProductsScanned != 3 || report.ImagesChecked != 4 {
+		t.Errorf("expected 4 distinct images of 3 products, got %+v", report)
+	}
+	// the HEAD and the GET fallback for the bowl
+	if imageRequests.Load() != 5 {
+		t.Errorf("expected 5 image requests, got %d", imageRequests.Load())
+	}
+	notFound := report.NotFound()
+	if len(notFound) != 3 || notFound[0].Sku != "mug" || notFound[1].Sku != "plate" || notFound[2].Sku != "plate" {
+		t.Errorf("expected the shared image for both products and the plate image, got %+v", notFound)
+	}
+}
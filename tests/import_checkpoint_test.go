@@ -0,0 +1,65 @@
+package magento2
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	magento2 "github.com/florinel-chis/go-m2rest"
+)
+
+func TestImportCheckpoint_Resume(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "import.state")
+
+	checkpoint, err := magento2.OpenImportCheckpoint(path, "products.csv:1024")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	checkpoint.SaveEvery = 2
+	for _, result := range []magento2.ImportRowResult{
+		{Row: 0, Key: "a", Status: magento2.ImportRowCreated},
+		{Row: 2, Key: "c", Status: magento2.ImportRowFailed, Error: "rejected"},
+		{Row: 1, Key: "b", Status: magento2.ImportRowUpdated},
+		{Row: 4, Key: "e", Status: magento2.ImportRowUnchanged},
+		// recorded after the last save, so it is lost on a crash
+		{Row: 3, Key: "d", Status: magento2.ImportRowCreated},
+	} {
+		if err := checkpoint.Record(result); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	resumed, err := magento2.OpenImportCheckpoint(path, "products.csv:1024")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resumed.Offset != 3 {
+		t.Errorf("expected offset 3, got %d", resumed.Offset)
+	}
+	for row, done := range []bool{true, true, true, false, true, false} {
+		if resumed.Done(row) != done {
+			t.Errorf("row %d: expected done %v", row, done)
+		}
+	}
+	if resumed.Counts[magento2.ImportRowCreated] != 1 || resumed.Counts[magento2.ImportRowFailed] != 1 || len(resumed.Failures) != 1 || resumed.Failures[0].Key != "c" {
+		t.Errorf("unexpected results %v %v", resumed.Counts, resumed.Failures)
+	}
+
+	if err := resumed.Record(magento2.ImportRowResult{Row: 3, Key: "d", Status: magento2.ImportRowCreated}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resumed.Offset != 5 {
+		t.Errorf("expected the gap to close at offset 5, got %d", resumed.Offset)
+	}
+
+	if _, err := magento2.OpenImportCheckpoint(path, "other.csv:1"); !errors.Is(err, magento2.ErrCheckpointMismatch) {
+		t.Errorf("expected ErrCheckpointMismatch, got %v", err)
+	}
+	if err := resumed.Remove(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	fresh, err := magento2.OpenImportCheckpoint(path, "other.csv:1")
+	if err != nil || fresh.Offset != 0 {
+		t.Errorf("expected a fresh checkpoint, got %v, %v", fresh, err)
+	}
+}
@@ -0,0 +1,158 @@
+package magento2
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	magento2 "github.com/florinel-chis/go-m2rest"
+)
+
+const testImportProfile = `{
+	"name": "supplier-a",
+	"store_code": "de",
+	"columns": [
+		{"column": "Article", "target": "sku", "transforms": [{"type": "trim"}, {"type": "upper"}], "required": true},
+		{"column": "Title", "target": "name", "required": true},
+		{"column": "Price", "target": "price", "transforms": [{"type": "replace", "from": ",", "to": "."}]},
+		{"column": "Title", "target": "url_key", "transforms": [{"type": "url_key"}]},
+		{"column": "Brand", "target": "brand", "transforms": [{"type": "map", "values": {"ACME Inc": "Acme"}}, {"type": "option"}]},
+		{"column": "Text", "target": "description", "transforms": [{"type": "strip_html"}]}
+	],
+	"defaults": {"attribute_set_id": "4", "type_id": "simple", "description": "No description"}
+}`
+
+func TestImportProfile_Product(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path != "/rest/default/V1/products/attributes/brand" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(`{"attribute_code":"brand","frontend_input":"select","options":[{"label":"Acme","value":"7"}]}`))
+	}))
+	t.Cleanup(server.Close)
+
+	ctx := context.Background()
+	client, err := magento2.NewClient(ctx, magento2.WithStoreURL(server.URL, "default"), magento2.WithBearerToken("token"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	profile, err := magento2.ReadImportProfile(strings.NewReader(testImportProfile))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(profile.RequestOptions()) != 1 {
+		t.Errorf("expected a store scope option")
+	}
+
+	product, err := profile.Product(ctx, map[string]string{
+		"Article": " tee-1 ",
+		"Title":   "Blue Tee",
+		"Price":   "12,50",
+		"Brand":   "ACME Inc",
+		"Ignored": "x",
+	}, magento2.NewAttributeCache(client))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if product.Sku != "TEE-1" || product.Name != "Blue Tee" || product.Price != 12.5 || product.AttributeSetID != 4 || product.TypeID != "simple" {
+		t.Errorf("unexpected product %+v", product)
+	}
+	for code, want := range map[string]string{"url_key": "blue-tee", "brand": "7", "description": "No description"} {
+		if got := product.CustomAttributeString(code); got != want {
+			t.Errorf("%s: expected %q, got %q", code, want, got)
+		}
+	}
+
+	_, err = profile.Product(ctx, map[string]string{"Article": "tee-2", "Title": "Tee", "Price": "cheap"}, nil)
+	if !errors.Is(err, magento2.ErrValidation) {
+		t.Errorf("expected validation error for the price, got %v", err)
+	}
+	_, err = profile.Product(ctx, map[string]string{"Article": "tee-3"}, nil)
+	if !errors.Is(err, magento2.ErrValidation) {
+		t.Errorf("expected validation error for the missing title, got %v", err)
+	}
+}
+
+func TestReadImportProfile_RejectsInvalidProfiles(t *testing.T) {
+	for name, profile := range map[string]string{
+		"no sku":               `{"columns": [{"column": "Title", "target": "name"}]}`,
+		"duplicate target":     `{"columns": [{"column": "A", "target": "sku"}, {"column": "B", "target": "sku"}]}`,
+		"unknown transform":    `{"columns": [{"column": "A", "target": "sku", "transforms": [{"type": "reverse"}]}]}`,
+		"prefix without value": `{"columns": [{"column": "A", "target": "sku", "transforms": [{"type": "prefix"}]}]}`,
+	} {
+		if _, err := magento2.ReadImportProfile(strings.NewReader(profile)); !errors.Is(err, magento2.ErrValidation) {
+			t.Errorf("%s: expected validation error, got %v", name, err)
+		}
+	}
+}
+
+func TestLoadImportProfile_YAML(t *testing.T) {
+	input := `# supplier A delivers semicolon separated files
+name: supplier-a
+store_code: de
+columns:
+  - column: Article
+    target: sku
+    transforms:
+      - type: trim
+      - type: upper
+    required: yes
+  - {column: Title, target: name, required: true}
+  - column: Price
+    target: price
+    transforms:
+      - {type: replace, from: ",", to: "."}
+  - column: Title
+    target: url_key
+    transforms: [{type: url_key}]
+  - column: Brand
+    target: brand
+    transforms:
+      - type: map
+        values:
+          ACME Inc: Acme
+      - type: option
+  - column: Text
+    target: description
+    transforms:
+      - type: strip_html
+defaults:
+  attribute_set_id: "4"
+  type_id: simple
+  description: No description
+`
+	path := filepath.Join(t.TempDir(), "supplier-a.yaml")
+	if err := os.WriteFile(path, []byte(input), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	profile, err := magento2.LoadImportProfile(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected, err := magento2.ReadImportProfile(strings.NewReader(testImportProfile))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(profile, expected) {
+		t.Errorf("expected the YAML profile to match the JSON one, got %+v", profile)
+	}
+
+	invalid := "name: broken\ncolumns:\n  - column: Title\n    target: name\n"
+	if _, err := magento2.ReadImportProfileYAML(strings.NewReader(invalid)); !errors.Is(err, magento2.ErrValidation) {
+		t.Errorf("expected validation error, got %v", err)
+	}
+	if _, err := magento2.ReadImportProfileYAML(strings.NewReader("name: broken\ncolumns: [\n")); !errors.Is(err, magento2.ErrInvalidYAML) {
+		t.Errorf("expected ErrInvalidYAML, got %v", err)
+	}
+}
@@ -0,0 +1,76 @@
+package magento2
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	magento2 "github.com/florinel-chis/go-m2rest"
+)
+
+func TestBulkSourceAssignments(t *testing.T) {
+	bodies := map[string]map[string]any{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf("unexpected method %s", r.Method)
+		}
+		body := map[string]any{}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("invalid body: %v", err)
+		}
+		bodies[r.URL.Path] = body
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/rest/default/V1/inventory/bulk-product-source-transfer":
+			_, _ = w.Write([]byte(`true`))
+		case "/rest/default/V1/inventory/bulk-product-source-assign":
+			_, _ = w.Write([]byte(`4`))
+		case "/rest/default/V1/inventory/bulk-product-source-unassign":
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"message":"Source \"us\" does not exist."}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := magento2.NewClient(context.Background(), magento2.WithStoreURL(server.URL, "default"), magento2.WithBearerToken("token"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := magento2.TransferSourceItems(context.Background(), []string{"mug", "cap"}, "default", "eu", true, client); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expectedTransfer := map[string]any{
+		"skus":               []any{"mug", "cap"},
+		"originSource":       "default",
+		"destinationSource":  "eu",
+		"unassignFromOrigin": true,
+	}
+	if transfer := bodies["/rest/default/V1/inventory/bulk-product-source-transfer"]; !reflect.DeepEqual(transfer, expectedTransfer) {
+		t.Errorf("unexpected transfer payload %v", transfer)
+	}
+
+	count, err := magento2.AssignSources(context.Background(), []string{"mug", "cap"}, []string{"eu", "us"}, client)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if count != 4 {
+		t.Errorf("expected 4 assignments, got %d", count)
+	}
+	expectedAssign := map[string]any{"skus": []any{"mug", "cap"}, "sourceCodes": []any{"eu", "us"}}
+	if assign := bodies["/rest/default/V1/inventory/bulk-product-source-assign"]; !reflect.DeepEqual(assign, expectedAssign) {
+		t.Errorf("unexpected assign payload %v", assign)
+	}
+
+	if _, err := magento2.UnassignSources(context.Background(), []string{"mug"}, []string{"us"}, client); err == nil {
+		t.Error("expected an error when unassigning fails")
+	}
+	if _, ok := bodies["/rest/default/V1/inventory/bulk-product-source-unassign"]; !ok {
+		t.Error("expected the unassign endpoint to be called")
+	}
+}
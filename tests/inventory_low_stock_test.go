@@ -0,0 +1,67 @@
+package magento2
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	magento2 "github.com/florinel-chis/go-m2rest"
+)
+
+func TestGetLowStockItems(t *testing.T) {
+	var pages []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		if r.URL.Path != "/rest/default/V1/inventory/source-items" ||
+			query.Get("searchCriteria[filter_groups][0][filters][0][field]") != "source_code" ||
+			query.Get("searchCriteria[filter_groups][1][filters][0][field]") != "quantity" ||
+			query.Get("searchCriteria[filter_groups][1][filters][0][value]") != "2.5" ||
+			query.Get("searchCriteria[filter_groups][1][filters][0][condition_type]") != "lteq" ||
+			query.Get("searchCriteria[pageSize]") != "2" {
+			t.Errorf("unexpected request: %s", r.URL)
+		}
+		page := query.Get("searchCriteria[currentPage]")
+		pages = append(pages, page)
+		w.Header().Set("Content-Type", "application/json")
+		switch page {
+		case "1":
+			_, _ = w.Write([]byte(`{"items":[{"sku":"mug","source_code":"eu","quantity":0,"status":0},` +
+				`{"sku":"cap","source_code":"eu","quantity":1,"status":1}],"total_count":3}`))
+		default:
+			_, _ = w.Write([]byte(`{"items":[{"sku":"tee","source_code":"eu","quantity":2.5,"status":1}],"total_count":3}`))
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := magento2.NewClient(context.Background(), magento2.WithStoreURL(server.URL, "default"), magento2.WithBearerToken("token"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	criteria := magento2.NewSearchCriteria(magento2.SearchFilter{Field: "source_code", Value: "eu", ConditionType: "eq"})
+	criteria.PageSize = 2
+	items, err := magento2.GetLowStockItems(context.Background(), 2.5, criteria, client)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(pages) != 2 || pages[0] != "1" || pages[1] != "2" {
+		t.Errorf("expected pages 1 and 2 to be fetched, got %v", pages)
+	}
+	if len(items) != 3 || items[0].Sku != "mug" || items[2].Sku != "tee" || items[2].Qty != 2.5 || items[1].SourceCode != "eu" {
+		t.Errorf("unexpected low stock items %+v", items)
+	}
+	if len(criteria.FilterGroups) != 1 || criteria.CurrentPage != 0 {
+		t.Errorf("expected the caller's criteria to be left untouched, got %+v", criteria)
+	}
+
+	pages = nil
+	criteria.CurrentPage = 2
+	if _, err := magento2.GetLowStockItems(context.Background(), 2.5, criteria, client); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(pages) != 1 || pages[0] != "2" {
+		t.Errorf("expected only the requested page to be fetched, got %v", pages)
+	}
+}
@@ -0,0 +1,68 @@
+package magento2
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	magento2 "github.com/florinel-chis/go-m2rest"
+)
+
+func TestGetStockReservations(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/rest/default/V1/inventory/stock-source-links":
+			if query.Get("searchCriteria[filter_groups][0][filters][0][field]") != "stock_id" ||
+				query.Get("searchCriteria[filter_groups][0][filters][0][value]") != "2" {
+				t.Errorf("unexpected request: %s", r.URL)
+			}
+			_, _ = w.Write([]byte(`{"items":[{"stock_id":2,"source_code":"eu","priority":1},` +
+				`{"stock_id":2,"source_code":"uk","priority":2}],"total_count":2}`))
+		case "/rest/default/V1/inventory/source-items":
+			if query.Get("searchCriteria[filter_groups][0][filters][0][value]") != "mug,cap" ||
+				query.Get("searchCriteria[filter_groups][1][filters][0][field]") != "source_code" ||
+				query.Get("searchCriteria[filter_groups][1][filters][0][value]") != "eu,uk" ||
+				query.Get("searchCriteria[filter_groups][1][filters][0][condition_type]") != "in" {
+				t.Errorf("unexpected request: %s", r.URL)
+			}
+			_, _ = w.Write([]byte(`{"items":[
+				{"sku":"mug","source_code":"eu","quantity":10,"status":1},
+				{"sku":"mug","source_code":"uk","quantity":5,"status":1},
+				{"sku":"cap","source_code":"eu","quantity":3,"status":0}],"total_count":3}`))
+		case "/rest/default/V1/inventory/get-product-salable-quantity/mug/2":
+			_, _ = w.Write([]byte(`12`))
+		case "/rest/default/V1/inventory/get-product-salable-quantity/cap/2":
+			_, _ = w.Write([]byte(`0`))
+		default:
+			t.Errorf("unexpected request: %s", r.URL)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := magento2.NewClient(context.Background(), magento2.WithStoreURL(server.URL, "default"), magento2.WithBearerToken("token"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	reservations, err := magento2.GetStockReservations(context.Background(), []string{"mug", "cap"}, 2, client)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(reservations) != 2 {
+		t.Fatalf("expected 2 reservations, got %+v", reservations)
+	}
+
+	mug := reservations[0]
+	if mug.Sku != "mug" || mug.StockID != 2 || mug.PhysicalQty != 15 || mug.SalableQty != 12 || mug.ReservedQty != 3 {
+		t.Errorf("unexpected reservation %+v", mug)
+	}
+	// out-of-stock source items are not physically available to the stock
+	capReservation := reservations[1]
+	if capReservation.Sku != "cap" || capReservation.PhysicalQty != 0 || capReservation.SalableQty != 0 || capReservation.ReservedQty != 0 {
+		t.Errorf("unexpected reservation %+v", capReservation)
+	}
+}
@@ -0,0 +1,63 @@
+package magento2
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	magento2 "github.com/florinel-chis/go-m2rest"
+)
+
+func TestGetInvoiceWithDocument(t *testing.T) {
+	pdf := []byte("%PDF-1.4 invoice 100000001")
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/rest/default/V1/invoices/5":
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"entity_id":5,"order_id":42,"increment_id":"100000001","grand_total":59,` +
+				`"items":[{"order_item_id":1,"sku":"shirt-M","qty":2}]}`))
+		case "/rest/default/V1/invoices/5/pdf":
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`"` + base64.StdEncoding.EncodeToString(pdf) + `"`))
+		case "/rest/default/V1/invoices/5/raw":
+			w.Header().Set("Content-Type", "application/pdf")
+			_, _ = w.Write(pdf)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := magento2.NewClient(context.Background(), magento2.WithStoreURL(server.URL, "default"), magento2.WithBearerToken("token"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, _, err := magento2.GetInvoiceWithDocument(context.Background(), 5, client); !errors.Is(err, magento2.ErrNoInvoiceDocumentSource) {
+		t.Errorf("expected ErrNoInvoiceDocumentSource without a source, got %v", err)
+	}
+
+	client.SetInvoiceDocumentSource(&magento2.RESTInvoiceDocumentSource{RouteTemplate: "/invoices/%d/pdf"})
+	mInvoice, document, err := magento2.GetInvoiceWithDocument(context.Background(), 5, client)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if mInvoice.Invoice.IncrementID != "100000001" || mInvoice.Invoice.OrderID != 42 || len(mInvoice.Invoice.Items) != 1 {
+		t.Errorf("unexpected invoice %+v", mInvoice.Invoice)
+	}
+	if string(document) != string(pdf) {
+		t.Errorf("expected the decoded document, got %q", document)
+	}
+
+	client.SetInvoiceDocumentSource(&magento2.RESTInvoiceDocumentSource{RouteTemplate: "/invoices/%d/raw", Raw: true})
+	if document, err := mInvoice.Document(context.Background()); err != nil || string(document) != string(pdf) {
+		t.Errorf("expected the raw document, got %q (%v)", document, err)
+	}
+
+	if _, err := magento2.GetInvoiceByID(context.Background(), 6, client); !errors.Is(err, magento2.ErrNotFound) {
+		t.Errorf("expected ErrNotFound for an unknown invoice, got %v", err)
+	}
+}
@@ -0,0 +1,127 @@
+package magento2
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	magento2 "github.com/florinel-chis/go-m2rest"
+)
+
+var replayEntries = []magento2.JournalEntry{
+	{ID: "1", Method: http.MethodPost, StoreCode: "default", Route: "/products", Status: 200, Created: true, Payload: json.RawMessage(`{"product":{"sku":"shirt"}}`)},
+	{ID: "2", Method: http.MethodPut, StoreCode: "default", Route: "/products/shirt", Status: 503, Payload: json.RawMessage(`{"product":{"price":20}}`)},
+	{ID: "3", Method: http.MethodPut, StoreCode: "de", Route: "/products/shirt", Status: 200, Payload: json.RawMessage(`{"product":{"name":"Hemd"}}`)},
+	{ID: "4", Method: http.MethodPut, StoreCode: "default", Route: "/categories/7/products", Status: 200, Payload: json.RawMessage(`{"productLink":{"sku":"shirt","category_id":"7"}}`)},
+	{ID: "5", Method: http.MethodDelete, StoreCode: "default", Route: "/products/old", Status: 200},
+	{ID: "6", Method: http.MethodPost, StoreCode: "default", Route: "/products", Status: 200, Payload: json.RawMessage(`{"product":{"sku":"mug","price":9}}`)},
+}
+
+func newReplayClient(t *testing.T) (*magento2.Client, *[]string) {
+	var (
+		mu       sync.Mutex
+		requests []string
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		mu.Lock()
+		requests = append(requests, r.Method+" "+r.URL.Path+" "+string(body))
+		mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`true`))
+	}))
+	t.Cleanup(server.Close)
+	client, err := magento2.NewClient(context.Background(), magento2.WithStoreURL(server.URL, "default"), magento2.WithBearerToken("token"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return client, &requests
+}
+
+func TestReplayJournal_Apply(t *testing.T) {
+	client, requests := newReplayClient(t)
+
+	results, err := magento2.ReplayJournal(context.Background(), replayEntries, magento2.ReplayOptions{}, client)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []string{
+		`POST /rest/default/V1/products {"product":{"sku":"shirt"}}`,
+		`PUT /rest/de/V1/products/shirt {"product":{"name":"Hemd"}}`,
+		`PUT /rest/default/V1/categories/7/products {"productLink":{"sku":"shirt","category_id":"7"}}`,
+		`DELETE /rest/default/V1/products/old `,
+		`POST /rest/default/V1/products {"product":{"sku":"mug","price":9}}`,
+	}
+	if !slices.Equal(*requests, want) {
+		t.Errorf("expected requests\n%q\ngot\n%q", want, *requests)
+	}
+	if len(results) != 6 || results[1].Action != magento2.ReplaySkipped || results[1].Reason != "not accepted originally (status 503)" {
+		t.Errorf("expected entry 2 to be skipped, got %+v", results)
+	}
+}
+
+func TestReplayJournal_InvertDryRun(t *testing.T) {
+	client, requests := newReplayClient(t)
+
+	results, err := magento2.ReplayJournal(context.Background(), replayEntries, magento2.ReplayOptions{Invert: true, DryRun: true}, client)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(*requests) != 0 {
+		t.Errorf("expected a dry run to send nothing, got %v", *requests)
+	}
+	got := []string{}
+	for _, result := range results {
+		got = append(got, strings.Join(strings.Fields(result.EntryID+" "+string(result.Action)+" "+result.Method+" "+result.Route+" "+result.Reason), " "))
+	}
+	want := []string{
+		"6 skipped updated an existing entity, the previous state is unknown",
+		"5 skipped DELETE /products/old cannot be inverted",
+		"4 planned DELETE /categories/7/products/shirt",
+		"3 skipped PUT /products/shirt cannot be inverted",
+		"2 skipped not accepted originally (status 503)",
+		"1 planned DELETE /products/shirt",
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("expected\n%q\ngot\n%q", want, got)
+	}
+}
+
+func TestReplayJournal_SkipsRedactedPayloads(t *testing.T) {
+	recording, _ := newReplayClient(t)
+	store := magento2.NewFileJournalStore(filepath.Join(t.TempDir(), "journal.jsonl"))
+	recording.SetJournal(&magento2.Journal{Store: store, KeepPayloads: true})
+	customer := map[string]any{"customer": map[string]any{"email": "roni_cost@example.com"}, "password": "secret-1"}
+	if _, err := recording.HTTPClient.R().SetBody(customer).Post("/customers"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := recording.HTTPClient.R().SetBody(map[string]any{"customer": map[string]any{"id": 5, "firstname": "Roni"}}).Put("/customers/5"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	entries, err := store.Entries(time.Time{}, time.Time{})
+	if err != nil || len(entries) != 2 {
+		t.Fatalf("expected 2 entries, got %v, %v", entries, err)
+	}
+
+	client, requests := newReplayClient(t)
+	results, err := magento2.ReplayJournal(context.Background(), entries, magento2.ReplayOptions{}, client)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(results) != 2 || results[0].Action != magento2.ReplaySkipped || results[0].Reason != "payload redacted" ||
+		results[1].Action != magento2.ReplayApplied {
+		t.Errorf("expected the customer creation to be skipped, got %+v", results)
+	}
+	want := []string{`PUT /rest/default/V1/customers/5 {"customer":{"firstname":"Roni","id":5}}`}
+	if !slices.Equal(*requests, want) {
+		t.Errorf("expected requests\n%q\ngot\n%q", want, *requests)
+	}
+}
@@ -0,0 +1,156 @@
+package magento2
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	magento2 "github.com/florinel-chis/go-m2rest"
+)
+
+func TestJournal_RecordsMutatingRequests(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method == http.MethodDelete {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"message":"missing"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"sku":"shirt"}`))
+	}))
+	t.Cleanup(server.Close)
+
+	ctx := magento2.ContextWithRequestID(context.Background(), "req-1")
+	client, err := magento2.NewClient(ctx, magento2.WithStoreURL(server.URL, "default"), magento2.WithBearerToken("token"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	client.HTTPClient.SetRetryCount(0)
+	store := magento2.NewFileJournalStore(filepath.Join(t.TempDir(), "journal.jsonl"))
+	client.SetJournal(&magento2.Journal{Store: store, KeepPayloads: true})
+
+	start := time.Now().Add(-time.Second)
+	body := `{"product":{"sku":"shirt","price":20}}`
+	if _, err := client.HTTPClient.R().SetContext(ctx).SetBody(body).Put("/products/shirt"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := magento2.GetProductBySKU("shirt", client); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := magento2.DeleteProduct(ctx, "shirt", client); err == nil {
+		t.Fatal("expected the delete to fail")
+	}
+
+	entries, err := store.Entries(start, time.Time{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("expected the PUT and the DELETE, got %+v", entries)
+	}
+
+	put, del := entries[0], entries[1]
+	hash := sha256.Sum256([]byte(body))
+	if put.Method != http.MethodPut || put.StoreCode != "default" || put.Route != "/products/shirt" || !put.Succeeded() {
+		t.Errorf("unexpected put entry %+v", put)
+	}
+	if put.PayloadHash != hex.EncodeToString(hash[:]) || string(put.Payload) != body || put.RequestID != "req-1" {
+		t.Errorf("unexpected payload %s (%s) or request ID %s", put.Payload, put.PayloadHash, put.RequestID)
+	}
+	if del.Method != http.MethodDelete || del.Status != http.StatusNotFound || del.Succeeded() {
+		t.Errorf("unexpected delete entry %+v", del)
+	}
+
+	later, err := store.Entries(time.Now().Add(time.Hour), time.Time{})
+	if err != nil || len(later) != 0 {
+		t.Errorf("expected no entries in the future, got %v, %v", later, err)
+	}
+}
+
+func TestJournal_MarksProductCreations(t *testing.T) {
+	updatedAt := map[string]string{"new": "2026-01-01 10:00:00", "old": "2026-01-02 08:00:00"}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload struct {
+			Product struct {
+				Sku string `json:"sku"`
+			} `json:"product"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&payload)
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]string{
+			"sku":        payload.Product.Sku,
+			"created_at": "2026-01-01 10:00:00",
+			"updated_at": updatedAt[payload.Product.Sku],
+		})
+	}))
+	t.Cleanup(server.Close)
+
+	ctx := context.Background()
+	client, err := magento2.NewClient(ctx, magento2.WithStoreURL(server.URL, "default"), magento2.WithBearerToken("token"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	store := magento2.NewFileJournalStore(filepath.Join(t.TempDir(), "journal.jsonl"))
+	client.SetJournal(&magento2.Journal{Store: store})
+
+	for _, sku := range []string{"new", "old"} {
+		if _, err := magento2.CreateOrReplaceProduct(&magento2.Product{Sku: sku, Name: sku}, false, client); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	entries, err := store.Entries(time.Time{}, time.Time{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(entries) != 2 || !entries[0].Created || entries[1].Created {
+		t.Errorf("expected only the first save to be a creation, got %+v", entries)
+	}
+}
+
+func TestJournal_RedactsPasswords(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`true`))
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := magento2.NewClient(context.Background(), magento2.WithStoreURL(server.URL, "default"), magento2.WithBearerToken("token"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	store := magento2.NewFileJournalStore(filepath.Join(t.TempDir(), "journal.jsonl"))
+	client.SetJournal(&magento2.Journal{Store: store, KeepPayloads: true})
+
+	bodies := []any{
+		map[string]any{"customer": map[string]any{"email": "roni_cost@example.com"}, "password": "secret-1"},
+		`{"currentPassword":"secret-2","newPassword":"secret-3"}`,
+		map[string]any{"customer": map[string]any{"id": 5}, "new_password": "secret-4"},
+	}
+	for _, body := range bodies {
+		if _, err := client.HTTPClient.R().SetBody(body).Put("/customers/me/password"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	entries, err := store.Entries(time.Time{}, time.Time{})
+	if err != nil || len(entries) != 3 {
+		t.Fatalf("expected 3 entries, got %v, %v", entries, err)
+	}
+	want := []string{
+		`{"customer":{"email":"roni_cost@example.com"},"password":"[redacted]"}`,
+		`{"currentPassword":"[redacted]","newPassword":"[redacted]"}`,
+		`{"customer":{"id":5},"new_password":"[redacted]"}`,
+	}
+	for i, entry := range entries {
+		hash := sha256.Sum256([]byte(want[i]))
+		if string(entry.Payload) != want[i] || entry.PayloadHash != hex.EncodeToString(hash[:]) || !entry.Redacted {
+			t.Errorf("expected redacted payload %s, got %s (%s)", want[i], entry.Payload, entry.PayloadHash)
+		}
+	}
+}
@@ -0,0 +1,64 @@
+package magento2
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+
+	magento2 "github.com/florinel-chis/go-m2rest"
+)
+
+func TestClient_MaintenanceHooks(t *testing.T) {
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload map[string][]string
+		_ = json.NewDecoder(r.Body).Decode(&payload)
+		for key, values := range payload {
+			requests = append(requests, r.Method+" "+r.URL.Path+" "+key+"="+values[0])
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte("true"))
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := magento2.NewClient(context.Background(), magento2.WithStoreURL(server.URL, "default"), magento2.WithBearerToken("token"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := client.Reindex(context.Background(), magento2.IndexerProductPrice); !errors.Is(err, magento2.ErrNoMaintenanceHook) {
+		t.Errorf("expected ErrNoMaintenanceHook without a hook, got %v", err)
+	}
+
+	client.SetMaintenanceHook(&magento2.RESTMaintenanceHook{APIClient: client, ReindexRoute: "/indexer/reindex"})
+	if err := client.Reindex(context.Background(), magento2.IndexerProductPrice); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := client.FlushCache(context.Background(), magento2.CacheTypeFullPage); !errors.Is(err, magento2.ErrNoMaintenanceHook) {
+		t.Errorf("expected ErrNoMaintenanceHook without a cache route, got %v", err)
+	}
+	if !slices.Equal(requests, []string{"POST /rest/default/V1/indexer/reindex indexerIds=catalog_product_price"}) {
+		t.Errorf("unexpected requests %v", requests)
+	}
+
+	var flushed []string
+	failure := errors.New("pipeline unavailable")
+	client.SetMaintenanceHook(magento2.MaintenanceHookFuncs{
+		FlushCacheFunc: func(ctx context.Context, cacheTypes ...string) error {
+			flushed = append(flushed, cacheTypes...)
+			return failure
+		},
+	})
+	if err := client.Reindex(context.Background(), magento2.IndexerStock); err != nil {
+		t.Errorf("expected a nil func to be a no-op, got %v", err)
+	}
+	if err := client.FlushCache(context.Background(), magento2.CacheTypeConfig, magento2.CacheTypeEAV); !errors.Is(err, failure) {
+		t.Errorf("expected the hook's error, got %v", err)
+	}
+	if !slices.Equal(flushed, []string{"config", "eav"}) {
+		t.Errorf("unexpected flushed cache types %v", flushed)
+	}
+}
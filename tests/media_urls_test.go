@@ -0,0 +1,77 @@
+package magento2
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	magento2 "github.com/florinel-chis/go-m2rest"
+)
+
+func TestMediaURLResolver(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		if r.URL.Path != "/rest/all/V1/store/storeConfigs" {
+			t.Errorf("unexpected request: %s", r.URL)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`[` +
+			`{"code":"default","base_media_url":"http://shop.test/media/","secure_base_media_url":"https://shop.test/media/"},` +
+			`{"code":"de","base_media_url":"http://cdn.test/de/media/","secure_base_media_url":""}]`))
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := magento2.NewClient(context.Background(),
+		magento2.WithStoreURL(server.URL, "all"),
+		magento2.WithBearerToken("token"),
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	resolver := magento2.NewMediaURLResolver(client, time.Hour)
+	ctx := context.Background()
+
+	product := &magento2.Product{
+		MediaGalleryEntries: []magento2.MediaGalleryEntries{
+			{File: "/m/b/back.jpg", MediaType: "image", Position: 2},
+			{File: "/m/b/hidden.jpg", MediaType: "image", Position: 0, Disabled: true},
+			{File: "/m/b/front.jpg", MediaType: "image", Position: 1, Types: []string{"image", "thumbnail"}},
+			{File: "/m/b/video.jpg", MediaType: "external-video", Position: 3},
+		},
+	}
+
+	urls, err := resolver.ProductImageURLs(ctx, "", product)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := "https://shop.test/media/catalog/product/m/b/front.jpg https://shop.test/media/catalog/product/m/b/back.jpg"
+	if strings.Join(urls, " ") != expected {
+		t.Errorf("unexpected urls: %v", urls)
+	}
+
+	main, err := resolver.MainImageURL(ctx, "de", product)
+	if err != nil || main != "http://cdn.test/de/media/catalog/product/m/b/front.jpg" {
+		t.Errorf("expected store-scoped main image, got %q (%v)", main, err)
+	}
+
+	fromAttribute := &magento2.Product{CustomAttributes: []map[string]any{
+		{"attribute_code": "image", "value": "no_selection"},
+		{"attribute_code": "small_image", "value": "/s/m/small.jpg"},
+	}}
+	if main, _ := resolver.MainImageURL(ctx, "default", fromAttribute); main != "https://shop.test/media/catalog/product/s/m/small.jpg" {
+		t.Errorf("expected small image fallback, got %q", main)
+	}
+
+	if _, err := resolver.ImageURL(ctx, "fr", "/a.jpg"); !errors.Is(err, magento2.ErrNotFound) {
+		t.Errorf("expected ErrNotFound for unknown store, got: %v", err)
+	}
+	if requests.Load() != 1 {
+		t.Errorf("expected store configs to be fetched once, got %d requests", requests.Load())
+	}
+}
@@ -0,0 +1,75 @@
+package magento2
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+
+	magento2 "github.com/florinel-chis/go-m2rest"
+)
+
+func TestMOrder_DocumentData(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/rest/default/V1/products/attributes/93":
+			_, _ = w.Write([]byte(`{"attribute_id":93,"attribute_code":"color","default_frontend_label":"Color","options":[{"label":"Red","value":"58"}]}`))
+		case "/rest/default/V1/products/MH01-M-Red/options":
+			_, _ = w.Write([]byte(`[{"option_id":4,"title":"Engraving"},{"option_id":5,"title":"Gift Wrap","type":"checkbox",` +
+				`"values":[{"title":"Paper","option_type_id":12},{"title":"Ribbon","option_type_id":13}]}]`))
+		case "/rest/default/V1/store/storeViews":
+			_, _ = w.Write([]byte(`[{"id":0,"code":"admin"},{"id":1,"code":"default","name":"Default Store View"}]`))
+		case "/rest/default/V1/store/storeConfigs":
+			_, _ = w.Write([]byte(`[{"id":1,"code":"default","locale":"en_US","base_currency_code":"USD"}]`))
+		case "/rest/default/V1/invoices":
+			_, _ = w.Write([]byte(`{"items":[{"entity_id":3,"order_id":7,"increment_id":"000000003"}],"total_count":1}`))
+		case "/rest/default/V1/shipments":
+			_, _ = w.Write([]byte(`{"items":[],"total_count":0}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := magento2.NewClient(context.Background(), magento2.WithStoreURL(server.URL, "default"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	order := &magento2.Order{}
+	withSelect := strings.Replace(orderWithOptions, `"option_value":"Happy birthday"}`,
+		`"option_value":"Happy birthday"},{"option_id":"5","option_value":"12,13"}`, 1)
+	if err := json.Unmarshal([]byte(withSelect), order); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	order.EntityID = 7
+	order.StoreID = 1
+	order.OrderCurrencyCode = "USD"
+	order.GrandTotal = 99.5
+
+	data, err := (&magento2.MOrder{Order: order, APIClient: client}).DocumentData(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(data.Items) != 2 || len(data.Items[1].Children) != 2 {
+		t.Fatalf("expected 2 top-level items with bundle children, got %+v", data.Items)
+	}
+	expected := []magento2.DocumentItemOption{
+		{Label: "Color", Value: "Red"},
+		{Label: "Engraving", Value: "Happy birthday"},
+		{Label: "Gift Wrap", Value: "Paper, Ribbon"},
+	}
+	if !slices.Equal(data.Items[0].Options, expected) {
+		t.Errorf("unexpected options: %+v", data.Items[0].Options)
+	}
+	if data.Store == nil || data.Store.Code != "default" || data.StoreConfig == nil || data.StoreConfig.Locale != "en_US" {
+		t.Errorf("unexpected store: %+v %+v", data.Store, data.StoreConfig)
+	}
+	if len(data.Invoices) != 1 || data.Totals.GrandTotal.String() != "99.50 USD" {
+		t.Errorf("unexpected invoices or totals: %+v %s", data.Invoices, data.Totals.GrandTotal)
+	}
+}
@@ -0,0 +1,95 @@
+package magento2
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	magento2 "github.com/florinel-chis/go-m2rest"
+)
+
+// fakeOrderDocuments serves order 7 and books the invoices and shipments posted for it
+type fakeOrderDocuments struct {
+	mu    sync.Mutex
+	order magento2.Order
+	posts int
+}
+
+func (s *fakeOrderDocuments) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	w.Header().Set("Content-Type", "application/json")
+	switch {
+	case r.Method == http.MethodGet && r.URL.Path == "/rest/default/V1/orders/7":
+		_ = json.NewEncoder(w).Encode(s.order)
+	case r.Method == http.MethodPost && (r.URL.Path == "/rest/default/V1/order/7/invoice" || r.URL.Path == "/rest/default/V1/order/7/ship"):
+		s.posts++
+		var payload struct {
+			Items []magento2.InvoiceItem `json:"items"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&payload)
+		for i := range s.order.Items {
+			item := &s.order.Items[i]
+			for _, requested := range payload.Items {
+				if int(item.ItemID) != requested.OrderItemID {
+					continue
+				}
+				if r.URL.Path == "/rest/default/V1/order/7/invoice" {
+					item.QtyInvoiced += requested.Qty
+				} else {
+					item.QtyShipped += requested.Qty
+				}
+			}
+		}
+		_, _ = w.Write([]byte(`"100"`))
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func TestOrderDocuments_GuardAgainstDuplicates(t *testing.T) {
+	store := &fakeOrderDocuments{order: magento2.Order{EntityID: 7, Items: []magento2.Item{
+		{ItemID: 1, Sku: "strap", QtyOrdered: 2},
+		{ItemID: 2, Sku: "gift-card", QtyOrdered: 1, IsVirtual: 1},
+	}}}
+	server := httptest.NewServer(store)
+	t.Cleanup(server.Close)
+
+	client, err := magento2.NewClient(context.Background(), magento2.WithStoreURL(server.URL, "default"), magento2.WithBearerToken("token"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	mOrder := &magento2.MOrder{Route: "/orders/7", Order: &magento2.Order{}, APIClient: client}
+
+	invoice := &magento2.InvoiceRequest{Items: []magento2.InvoiceItem{{OrderItemID: 1, Qty: 2}}}
+	if id, err := mOrder.CreateInvoice(context.Background(), invoice); err != nil || id != 100 {
+		t.Fatalf("expected invoice 100, got %d (%v)", id, err)
+	}
+	if _, err := mOrder.CreateInvoice(context.Background(), invoice); !errors.Is(err, magento2.ErrAlreadyInvoiced) {
+		t.Errorf("expected ErrAlreadyInvoiced for the repeated invoice, got %v", err)
+	}
+	if store.posts != 1 {
+		t.Errorf("expected the duplicate not to be posted, got %d posts", store.posts)
+	}
+	if _, err := mOrder.CreateInvoice(context.Background(), invoice, magento2.WithForceDuplicate()); err != nil {
+		t.Errorf("expected WithForceDuplicate to skip the check, got %v", err)
+	}
+
+	ship := &magento2.ShipmentRequest{Items: []magento2.ShipmentItem{{OrderItemID: 1, Qty: 1}}}
+	for range 2 {
+		if _, err := mOrder.CreateShipment(context.Background(), ship); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	// the virtual item is never shipped, so nothing is left to ship
+	if _, err := mOrder.CreateShipment(context.Background(), &magento2.ShipmentRequest{}); !errors.Is(err, magento2.ErrAlreadyShipped) {
+		t.Errorf("expected ErrAlreadyShipped once everything shipped, got %v", err)
+	}
+	if store.posts != 4 {
+		t.Errorf("expected 4 posts, got %d", store.posts)
+	}
+}
@@ -0,0 +1,117 @@
+package magento2
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	magento2 "github.com/florinel-chis/go-m2rest"
+)
+
+func newOrderExportServer(t *testing.T) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/rest/default/V1/orders":
+			if query := r.URL.Query(); query.Get("searchCriteria[filter_groups][0][filters][0][value]") != "2026-01-01 00:00:00" {
+				t.Errorf("expected date range filter, got %v", query)
+			}
+			_, _ = w.Write([]byte(`{"items":[{"entity_id":5,"increment_id":"000000005","created_at":"2026-01-02 10:00:00","status":"complete",
+				"customer_firstname":"Ann","customer_lastname":"Lee","order_currency_code":"EUR","subtotal":100,"tax_amount":19,"grand_total":119,
+				"items":[{"item_id":1,"sku":"shirt","product_type":"configurable","qty_ordered":2,"price":50,"row_total":100,"tax_amount":19},
+					{"item_id":2,"parent_item_id":1,"sku":"shirt-m","qty_ordered":2}],
+				"payment":{"method":"checkmo","amount_ordered":119,"amount_paid":119},
+				"extension_attributes":{"applied_taxes":[{"code":"DE","title":"VAT","percent":19,"amount":19}]}}],"total_count":1}`))
+		case "/rest/default/V1/creditmemos":
+			_, _ = w.Write([]byte(`{"items":[{"entity_id":8,"order_id":5,"increment_id":"000000008","grand_total":59.5,"order_currency_code":"EUR"}],"total_count":1}`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestExportOrders_CSV(t *testing.T) {
+	server := newOrderExportServer(t)
+	ctx := context.Background()
+	client, err := magento2.NewClient(ctx, magento2.WithStoreURL(server.URL, "default"), magento2.WithBearerToken("token"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var buf bytes.Buffer
+	records, err := magento2.ExportOrders(ctx, &buf, magento2.OrderExportOptions{
+		From: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+		Fields: map[magento2.OrderRecordType][]string{
+			magento2.OrderRecordOrder:   {"order_increment_id", "grand_total"},
+			magento2.OrderRecordItem:    {"order_increment_id", "sku", "row_total"},
+			magento2.OrderRecordTax:     {"order_increment_id", "title", "amount"},
+			magento2.OrderRecordPayment: {},
+		},
+	}, client)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	rows, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := [][]string{
+		{"record_type", "order_increment_id", "grand_total", "sku", "row_total", "title", "amount", "creditmemo_increment_id", "created_at", "currency", "subtotal", "shipping_amount", "discount_amount", "adjustment_positive", "adjustment_negative", "tax_amount"},
+		{"order", "000000005", "119.00", "", "", "", "", "", "", "", "", "", "", "", "", ""},
+		{"item", "000000005", "", "shirt", "100.00", "", "", "", "", "", "", "", "", "", "", ""},
+		{"tax", "000000005", "", "", "", "VAT", "19.00", "", "", "", "", "", "", "", "", ""},
+		{"refund", "000000005", "59.50", "", "", "", "", "000000008", "", "EUR", "0.00", "0.00", "0.00", "0.00", "0.00", "0.00"},
+	}
+	if records != 4 || len(rows) != len(want) {
+		t.Fatalf("expected 4 records, got %d: %v", records, rows)
+	}
+	for i := range want {
+		if strings.Join(rows[i], "|") != strings.Join(want[i], "|") {
+			t.Errorf("row %d:\nexpected %v\ngot      %v", i, want[i], rows[i])
+		}
+	}
+}
+
+func TestExportOrders_JSONL(t *testing.T) {
+	server := newOrderExportServer(t)
+	ctx := context.Background()
+	client, err := magento2.NewClient(ctx, magento2.WithStoreURL(server.URL, "default"), magento2.WithBearerToken("token"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var buf bytes.Buffer
+	_, err = magento2.ExportOrders(ctx, &buf, magento2.OrderExportOptions{
+		Format: magento2.OrderExportJSONL,
+		From:   time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+		Fields: map[magento2.OrderRecordType][]string{
+			magento2.OrderRecordOrder:   {},
+			magento2.OrderRecordItem:    {},
+			magento2.OrderRecordTax:     {},
+			magento2.OrderRecordRefund:  {},
+			magento2.OrderRecordPayment: {"order_increment_id", "method", "amount_paid"},
+		},
+	}, client)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := buf.String(); got != `{"record_type":"payment","order_increment_id":"000000005","method":"checkmo","amount_paid":119.00}`+"\n" {
+		t.Errorf("unexpected output %s", got)
+	}
+
+	_, err = magento2.ExportOrders(ctx, &buf, magento2.OrderExportOptions{
+		Fields: map[magento2.OrderRecordType][]string{magento2.OrderRecordOrder: {"margin"}},
+	}, client)
+	if !errors.Is(err, magento2.ErrValidation) {
+		t.Errorf("expected validation error for an unknown field, got %v", err)
+	}
+}
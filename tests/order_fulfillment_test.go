@@ -0,0 +1,50 @@
+package magento2
+
+import (
+	"testing"
+
+	magento2 "github.com/florinel-chis/go-m2rest"
+)
+
+func TestOrderFulfillment(t *testing.T) {
+	mOrder := &magento2.MOrder{
+		Order: &magento2.Order{
+			EntityID: 7,
+			Items: []magento2.Item{
+				{ItemID: 1, Sku: "strap", QtyOrdered: 5, QtyInvoiced: 5, QtyShipped: 1, QtyRefunded: 1, QtyBackordered: 2},
+				{ItemID: 2, Sku: "shirt", QtyOrdered: 2, QtyInvoiced: 1, QtyCanceled: 1, ProductType: "configurable"},
+				{ItemID: 3, Sku: "shirt-m", QtyOrdered: 2, ParentItemID: 2, ProductType: "simple"},
+				{ItemID: 4, Sku: "warranty", QtyOrdered: 1, QtyInvoiced: 1, IsVirtual: 1},
+			},
+		},
+	}
+
+	items := mOrder.Fulfillment()
+	if len(items) != 3 {
+		t.Fatalf("expected the 3 top-level items, got %+v", items)
+	}
+	strap := items[0]
+	if strap.ToInvoice.String() != "0" || strap.ToShip.String() != "3" || strap.ToRefund.String() != "4" || strap.Backordered.String() != "2" {
+		t.Errorf("unexpected strap fulfillment: %+v", strap)
+	}
+	if shirt := items[1]; shirt.ToInvoice.String() != "0" || shirt.ToShip.String() != "1" {
+		t.Errorf("unexpected shirt fulfillment: %+v", shirt)
+	}
+	if warranty := items[2]; warranty.ToShip.String() != "0" {
+		t.Errorf("expected nothing to ship for a virtual item, got %+v", warranty)
+	}
+
+	toShip := mOrder.ItemsToShip(false)
+	if len(toShip) != 2 || toShip[0].Qty != 3 || toShip[1].OrderItemID != 2 {
+		t.Errorf("unexpected items to ship: %+v", toShip)
+	}
+	inStock := mOrder.ItemsToShip(true)
+	if len(inStock) != 2 || inStock[0].Qty != 1 {
+		t.Errorf("expected the backordered units to be left out, got %+v", inStock)
+	}
+
+	if !mOrder.IsFullyInvoiced() || mOrder.IsFullyShipped() || !mOrder.HasBackorders() {
+		t.Errorf("unexpected order state: invoiced %v, shipped %v, backorders %v",
+			mOrder.IsFullyInvoiced(), mOrder.IsFullyShipped(), mOrder.HasBackorders())
+	}
+}
@@ -0,0 +1,124 @@
+package magento2
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	magento2 "github.com/florinel-chis/go-m2rest"
+)
+
+func newHoldTestClient(t *testing.T, handler http.HandlerFunc) *magento2.Client {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	client, err := magento2.NewClient(context.Background(),
+		magento2.WithStoreURL(server.URL, "default"),
+		magento2.WithBearerToken("token"),
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return client
+}
+
+func TestHoldWithComment(t *testing.T) {
+	var calls []string
+	var comment magento2.StatusHistory
+	client := newHoldTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		calls = append(calls, r.Method+" "+strings.TrimPrefix(r.URL.Path, "/rest/default/V1"))
+		w.Header().Set("Content-Type", "application/json")
+		if strings.HasSuffix(r.URL.Path, "/comments") {
+			var payLoad struct {
+				StatusHistory magento2.StatusHistory `json:"statusHistory"`
+			}
+			_ = json.NewDecoder(r.Body).Decode(&payLoad)
+			comment = payLoad.StatusHistory
+		}
+		_, _ = w.Write([]byte(`true`))
+	})
+
+	mOrder := &magento2.MOrder{Route: "/orders/7", Order: &magento2.Order{EntityID: 7, State: "processing"}, APIClient: client}
+	err := mOrder.HoldWithComment(context.Background(), magento2.HoldReason{Code: magento2.HoldReasonFraudReview, Detail: "AVS mismatch"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if strings.Join(calls, ",") != "POST /orders/7/hold,POST /orders/7/comments" {
+		t.Errorf("unexpected calls: %v", calls)
+	}
+	if comment.Comment != "m2rest-hold:fraud_review AVS mismatch" || comment.Status != magento2.OrderStateHolded {
+		t.Errorf("unexpected comment: %+v", comment)
+	}
+	if mOrder.Order.State != magento2.OrderStateHolded {
+		t.Errorf("expected the order to be held, got state %s", mOrder.Order.State)
+	}
+
+	err = mOrder.HoldWithComment(context.Background(), magento2.HoldReason{Code: "Fraud Review"})
+	if !errors.Is(err, magento2.ErrValidation) {
+		t.Errorf("expected ErrValidation, got %v", err)
+	}
+}
+
+func TestHoldWithCommentReleasesOnFailure(t *testing.T) {
+	var calls []string
+	client := newHoldTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		calls = append(calls, strings.TrimPrefix(r.URL.Path, "/rest/default/V1"))
+		w.Header().Set("Content-Type", "application/json")
+		if strings.HasSuffix(r.URL.Path, "/comments") {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"message":"invalid status"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`true`))
+	})
+
+	mOrder := &magento2.MOrder{Route: "/orders/7", Order: &magento2.Order{EntityID: 7, State: "processing"}, APIClient: client}
+	err := mOrder.HoldWithComment(context.Background(), magento2.HoldReason{Code: magento2.HoldReasonPaymentReview})
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	if strings.Join(calls, ",") != "/orders/7/hold,/orders/7/comments,/orders/7/unhold" {
+		t.Errorf("expected the order to be released, got calls %v", calls)
+	}
+	if mOrder.Order.State != "processing" {
+		t.Errorf("expected the state to be kept, got %s", mOrder.Order.State)
+	}
+}
+
+func TestSearchHeldOrders(t *testing.T) {
+	client := newHoldTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("searchCriteria[filter_groups][0][filters][0][value]") != magento2.OrderStateHolded {
+			t.Errorf("expected a search for held orders, got %s", r.URL)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"items":[
+			{"entity_id":1,"state":"holded","status_histories":[
+				{"entity_id":10,"comment":"m2rest-hold:fraud_review AVS mismatch"},
+				{"entity_id":12,"comment":"m2rest-hold:payment_review"}]},
+			{"entity_id":2,"state":"holded","status_histories":[{"entity_id":20,"comment":"m2rest-hold:fraud_review"}]},
+			{"entity_id":3,"state":"holded","status_histories":[{"entity_id":30,"comment":"Held by hand"}]}
+		],"total_count":3}`))
+	})
+
+	orders, err := magento2.SearchHeldOrders(context.Background(), magento2.HoldReasonFraudReview, client)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// order 1 was held for fraud review first, its latest reason is the payment review
+	if len(orders) != 1 || orders[0].EntityID != 2 {
+		t.Errorf("expected order 2, got %+v", orders)
+	}
+
+	orders, err = magento2.SearchHeldOrders(context.Background(), "", client)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(orders) != 2 {
+		t.Errorf("expected the 2 orders held with a reason, got %d", len(orders))
+	}
+}
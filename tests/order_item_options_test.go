@@ -0,0 +1,68 @@
+package magento2
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	magento2 "github.com/florinel-chis/go-m2rest"
+)
+
+const orderWithOptions = `{"items":[
+	{"item_id":1,"sku":"MH01-M-Red","product_type":"configurable","product_option":{"extension_attributes":{
+		"configurable_item_options":[{"option_id":"93","option_value":58}],
+		"custom_options":[{"option_id":"4","option_value":"Happy birthday"}]}}},
+	{"item_id":2,"parent_item_id":1,"sku":"MH01-M-Red","product_type":"simple"},
+	{"item_id":3,"sku":"24-WG080","product_type":"bundle","product_option":{"extension_attributes":{
+		"bundle_options":[{"option_id":1,"option_qty":1,"option_selections":[2,4]}]}}},
+	{"item_id":4,"parent_item_id":3,"sku":"24-WG081-blue","product_type":"simple"},
+	{"item_id":5,"parent_item_id":3,"sku":"24-WG084","product_type":"simple"}
+]}`
+
+func TestOrderItemOptions(t *testing.T) {
+	order := &magento2.Order{}
+	if err := json.Unmarshal([]byte(orderWithOptions), order); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	configurable := &order.Items[0]
+	if custom := configurable.CustomOptions(); len(custom) != 1 || custom[0].OptionValue != "Happy birthday" {
+		t.Errorf("unexpected custom options: %+v", custom)
+	}
+	if children := order.ChildItems(configurable); len(children) != 1 || children[0].ItemID != 2 {
+		t.Errorf("expected the simple product as child, got %+v", children)
+	}
+
+	bundle := &order.Items[2]
+	options := bundle.BundleOptions()
+	if len(options) != 1 || len(options[0].SelectionIDs()) != 2 || options[0].SelectionIDs()[1] != 4 {
+		t.Errorf("unexpected bundle options: %+v", options)
+	}
+	if children := order.ChildItems(bundle); len(children) != 2 {
+		t.Errorf("expected 2 bundle components, got %d", len(children))
+	}
+	if bundle.ConfigurableOptions() != nil || order.Items[1].CustomOptions() != nil {
+		t.Errorf("expected no options on items without product_option")
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"attribute_id":93,"attribute_code":"color","default_frontend_label":"Color",
+			"options":[{"label":"Blue","value":"50"},{"label":"Red","value":"58"}]}`))
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := magento2.NewClient(context.Background(), magento2.WithStoreURL(server.URL, "default"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	selected, err := magento2.ResolveConfigurableOptions(context.Background(), configurable, magento2.NewAttributeCache(client))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(selected) != 1 || selected[0].AttributeCode != "color" || selected[0].ValueLabel != "Red" {
+		t.Errorf("unexpected selected attributes: %+v", selected)
+	}
+}
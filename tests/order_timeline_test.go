@@ -0,0 +1,77 @@
+package magento2
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	magento2 "github.com/florinel-chis/go-m2rest"
+)
+
+func TestOrderTimeline(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("searchCriteria[filter_groups][0][filters][0][value]") != "7" {
+			t.Errorf("expected documents of order 7, got %s", r.URL)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/rest/default/V1/invoices":
+			_, _ = w.Write([]byte(`{"items":[{"entity_id":3,"order_id":7,"increment_id":"INV-1","grand_total":40,"created_at":"2026-03-01 10:05:00"}],"total_count":1}`))
+		case "/rest/default/V1/shipments":
+			_, _ = w.Write([]byte(`{"items":[{"entity_id":5,"order_id":7,"created_at":"2026-03-02 08:00:00",` +
+				`"tracks":[{"track_number":"1Z999","carrier_code":"ups"}],"comments":[{"comment":"Left at door"}]}],"total_count":1}`))
+		case "/rest/default/V1/creditmemos":
+			_, _ = w.Write([]byte(`{"items":[{"entity_id":9,"order_id":7,"grand_total":15,"created_at":"2026-03-05 12:00:00"}],"total_count":1}`))
+		default:
+			t.Errorf("unexpected request: %s", r.URL)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := magento2.NewClient(context.Background(),
+		magento2.WithStoreURL(server.URL, "default"),
+		magento2.WithBearerToken("token"),
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	mOrder := &magento2.MOrder{
+		Route: "/orders/7",
+		Order: &magento2.Order{
+			EntityID:    7,
+			IncrementID: "000000007",
+			GrandTotal:  40,
+			CreatedAt:   "2026-03-01 10:00:00",
+			StatusHistories: []magento2.StatusHistory{
+				{EntityID: 12, Comment: "Refunded the strap", Status: "processing", CreatedAt: "2026-03-05 12:00:00"},
+				{EntityID: 11, Comment: "Captured amount of $40.00", Status: "processing", CreatedAt: "2026-03-01 10:05:00", IsCustomerNotified: 1},
+			},
+		},
+		APIClient: client,
+	}
+
+	events, err := mOrder.Timeline(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var summary []string
+	for _, event := range events {
+		summary = append(summary, fmt.Sprintf("%s %s %d", event.Time.Format("01-02 15:04"), event.Type, event.EntityID))
+	}
+	expected := "03-01 10:00 placed 7|03-01 10:05 invoice 3|03-01 10:05 status_comment 11|" +
+		"03-02 08:00 shipment 5|03-05 12:00 credit_memo 9|03-05 12:00 status_comment 12"
+	if strings.Join(summary, "|") != expected {
+		t.Errorf("unexpected timeline:\n%s", strings.Join(summary, "\n"))
+	}
+	if shipment := events[3]; shipment.Comment != "Left at door" || len(shipment.TrackNumbers) != 1 || shipment.TrackNumbers[0] != "1Z999" {
+		t.Errorf("unexpected shipment event: %+v", shipment)
+	}
+	if !events[2].CustomerNotified || events[4].Amount != 15 {
+		t.Errorf("unexpected event details: %+v %+v", events[2], events[4])
+	}
+}
@@ -0,0 +1,51 @@
+package magento2
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	magento2 "github.com/florinel-chis/go-m2rest"
+)
+
+func TestGetOrdersByIncrementIDs(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		query := r.URL.Query()
+		if r.URL.Path != "/rest/default/V1/orders" ||
+			query.Get("searchCriteria[filter_groups][0][filters][0][value]") != "000000001,000000002,000000404" ||
+			query.Get("searchCriteria[filter_groups][0][filters][0][condition_type]") != "in" {
+			t.Errorf("unexpected request: %s", r.URL)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"items":[` +
+			`{"entity_id":1,"increment_id":"000000001","grand_total":36.39,"items":[{"sku":"24-MB01"}]},` +
+			`{"entity_id":2,"increment_id":"000000002","grand_total":10}],"total_count":2}`))
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := magento2.NewClient(context.Background(),
+		magento2.WithStoreURL(server.URL, "default"),
+		magento2.WithBearerToken("token"),
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	orders, err := magento2.GetOrdersByIncrementIDs(context.Background(), []string{"000000001", "000000002", "000000404"}, client)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if requests != 1 || len(orders) != 2 {
+		t.Fatalf("expected 2 orders from one request, got %d orders from %d requests", len(orders), requests)
+	}
+	order := orders["000000001"]
+	if order.Route != "/orders/1" || order.Order.GrandTotal != 36.39 || len(order.Order.Items) != 1 {
+		t.Errorf("unexpected hydrated order: %+v", order)
+	}
+	if _, ok := orders["000000404"]; ok {
+		t.Errorf("expected missing order to be absent")
+	}
+}
@@ -0,0 +1,57 @@
+package magento2
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	magento2 "github.com/florinel-chis/go-m2rest"
+)
+
+func TestLookupGuestOrderStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		if r.URL.Path != "/rest/default/V1/orders" || query.Get("searchCriteria[filter_groups][0][filters][0][field]") != "increment_id" {
+			t.Errorf("unexpected request: %s", r.URL)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if query.Get("searchCriteria[filter_groups][0][filters][0][value]") != "000000042" {
+			_, _ = w.Write([]byte(`{"items":[],"total_count":0}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"items":[{"entity_id":42,"increment_id":"000000042","state":"processing","status":"processing",
+			"customer_email":"Jane@Example.com","customer_lastname":"Roe","grand_total":59,"order_currency_code":"EUR",
+			"billing_address":{"firstname":"Jane","lastname":"Doe"},
+			"items":[{"item_id":1,"sku":"shirt-M","name":"Shirt","qty_ordered":2,"qty_shipped":1},
+				{"item_id":2,"parent_item_id":1,"sku":"shirt-M","name":"Shirt M","qty_ordered":2}]}],"total_count":1}`))
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := magento2.NewClient(context.Background(), magento2.WithStoreURL(server.URL, "default"), magento2.WithBearerToken("token"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	status, err := magento2.LookupGuestOrderStatus(context.Background(), "000000042", " jane@example.com", "doe", client)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if status.IncrementID != "000000042" || status.State != "processing" || status.GrandTotal != 59 || status.OrderCurrencyCode != "EUR" {
+		t.Errorf("unexpected status %+v", status)
+	}
+	if len(status.Items) != 1 || status.Items[0].QtyOrdered != 2 || status.Items[0].QtyShipped != 1 {
+		t.Errorf("expected only the parent item, got %+v", status.Items)
+	}
+
+	for name, lookup := range map[string][3]string{
+		"wrong email":                          {"000000042", "john@example.com", "Doe"},
+		"customer instead of billing lastname": {"000000042", "jane@example.com", "Roe"},
+		"unknown order":                        {"000000043", "jane@example.com", "Doe"},
+	} {
+		if _, err := magento2.LookupGuestOrderStatus(context.Background(), lookup[0], lookup[1], lookup[2], client); !errors.Is(err, magento2.ErrNotFound) {
+			t.Errorf("%s: expected ErrNotFound, got %v", name, err)
+		}
+	}
+}
@@ -0,0 +1,65 @@
+package magento2
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	magento2 "github.com/florinel-chis/go-m2rest"
+)
+
+func TestPaymentMethodCatalog(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/graphql":
+			switch r.Header.Get("Store") {
+			case "de":
+				_, _ = w.Write([]byte(`{"data":{"storeConfig":{"check_money_order_enable":true,"check_money_order_title":"Scheck / Zahlungsanweisung",` +
+					`"zero_subtotal_enabled":false,"zero_subtotal_title":"Keine Zahlung"}}}`))
+			default:
+				t.Errorf("unexpected store: %s", r.Header.Get("Store"))
+			}
+		case "/rest/default/V1/guest-carts/abc/payment-methods":
+			_, _ = w.Write([]byte(`[{"code":"checkmo","title":"Check / Money order"},{"code":"banktransfer","title":"Wire Transfer"}]`))
+		default:
+			t.Errorf("unexpected request: %s", r.URL)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := magento2.NewClient(context.Background(),
+		magento2.WithStoreURL(server.URL, "default"),
+		magento2.WithBearerToken("token"),
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	catalog := magento2.NewPaymentMethodCatalog(client)
+	if err := catalog.Load(context.Background(), "de"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := catalog.AddCartEstimate(&magento2.MCart{Route: "/guest-carts/abc", APIClient: client}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if methods := catalog.Methods("de"); len(methods) != 1 || methods[0].Code != "checkmo" {
+		t.Errorf("expected only check / money order to be enabled for de, got %+v", methods)
+	}
+	if methods := catalog.Methods("default"); len(methods) != 2 || methods[0].Code != "banktransfer" {
+		t.Errorf("expected the estimated methods for default, got %+v", methods)
+	}
+
+	for _, tc := range []struct{ storeCode, code, title string }{
+		{"de", "checkmo", "Scheck / Zahlungsanweisung"},
+		{"de", "banktransfer", "Wire Transfer"},
+		{"default", "cashondelivery", "Cash On Delivery"},
+		{"default", "custom_gateway", "custom_gateway"},
+	} {
+		if title := catalog.Title(tc.storeCode, tc.code); title != tc.title {
+			t.Errorf("expected title %q for %s in %s, got %q", tc.title, tc.code, tc.storeCode, title)
+		}
+	}
+}
@@ -0,0 +1,64 @@
+package magento2
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	magento2 "github.com/florinel-chis/go-m2rest"
+)
+
+func TestPaymentTokens(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/graphql" || r.Header.Get("Store") != "default" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		var request struct {
+			Query     string         `json:"query"`
+			Variables map[string]any `json:"variables"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&request)
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case strings.Contains(request.Query, "customerPaymentTokens"):
+			_, _ = w.Write([]byte(`{"data":{"customerPaymentTokens":{"items":[{"public_hash":"abc","payment_method_code":"braintree","type":"card","details":"{\"type\":\"VI\",\"maskedCC\":\"1111\",\"expirationDate\":\"12/2027\"}"}]}}}`))
+		case request.Variables["publicHash"] == "abc":
+			_, _ = w.Write([]byte(`{"data":{"deletePaymentToken":{"result":true}}}`))
+		default:
+			_, _ = w.Write([]byte(`{"errors":[{"message":"Could not find a token using public hash: missing"}]}`))
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := magento2.NewClient(context.Background(),
+		magento2.WithStoreURL(server.URL, "default"),
+		magento2.WithBearerToken("customer-token"),
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tokens, err := magento2.GetPaymentTokens(context.Background(), client)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(tokens) != 1 || tokens[0].Type != magento2.PaymentTokenTypeCard {
+		t.Fatalf("unexpected tokens: %+v", tokens)
+	}
+	details, err := tokens[0].CardDetails()
+	if err != nil || details.MaskedCC != "1111" || details.ExpirationDate != "12/2027" {
+		t.Errorf("unexpected card details: %+v, %v", details, err)
+	}
+
+	if err := magento2.DeletePaymentToken(context.Background(), "abc", client); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := magento2.DeletePaymentToken(context.Background(), "missing", client); !errors.Is(err, magento2.ErrGraphQL) {
+		t.Errorf("expected ErrGraphQL, got: %v", err)
+	}
+}
@@ -0,0 +1,81 @@
+package magento2
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	magento2 "github.com/florinel-chis/go-m2rest"
+)
+
+func TestAuditPrices(t *testing.T) {
+	var (
+		mu       sync.Mutex
+		searches []string
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/rest/default/V1/products/base-prices-information":
+			_, _ = w.Write([]byte(`[{"sku":"bag","price":40,"store_id":0},{"sku":"bag","price":38,"store_id":1},` +
+				`{"sku":"watch","price":100,"store_id":0}]`))
+		case "/rest/default/V1/products/special-price-information":
+			_, _ = w.Write([]byte(`[{"sku":"watch","price":120,"store_id":0}]`))
+		case "/rest/default/V1/products/tier-prices-information":
+			_, _ = w.Write([]byte(`[{"sku":"bag","price":30,"price_type":"fixed","quantity":5},{"sku":"watch","price":10,"price_type":"discount","quantity":2}]`))
+		case "/rest/default/V1/products-render-info":
+			_, _ = w.Write([]byte(`{"items":[{"id":1,"price_info":{"final_price":38}},{"id":2,"price_info":{"final_price":110}}],"total_count":2}`))
+		case "/rest/default/V1/products":
+			query := r.URL.Query()
+			filter := query.Get("searchCriteria[filter_groups][0][filters][0][condition_type]") + " " +
+				query.Get("searchCriteria[filter_groups][0][filters][0][value]")
+			mu.Lock()
+			searches = append(searches, filter)
+			mu.Unlock()
+			switch filter {
+			case "in bag,watch,shirt":
+				_, _ = w.Write([]byte(`{"items":[{"id":1,"sku":"bag"},{"id":2,"sku":"watch"}],"total_count":2}`))
+			case "eq tee,white":
+				_, _ = w.Write([]byte(`{"items":[{"id":3,"sku":"tee,white"}],"total_count":1}`))
+			default:
+				t.Errorf("unexpected product search %s", r.URL)
+			}
+		default:
+			t.Errorf("unexpected request: %s", r.URL)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := magento2.NewClient(context.Background(), magento2.WithStoreURL(server.URL, "default"), magento2.WithBearerToken("token"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	report, err := magento2.AuditPrices(context.Background(), []string{"bag", "watch", "shirt", "tee,white"}, 1, "EUR", client)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(report.Entries) != 4 {
+		t.Fatalf("expected an entry per SKU, got %+v", report.Entries)
+	}
+
+	bag := report.Entries[0]
+	if bag.BasePrice.Price != 38 || *bag.FinalPrice != 38 || len(bag.TierPrices) != 1 || len(bag.Issues) != 0 {
+		t.Errorf("expected the store's base price and no issues for bag, got %+v", bag)
+	}
+	watch := report.Entries[1]
+	if watch.BasePrice.Price != 100 || len(watch.Issues) != 2 ||
+		!strings.Contains(watch.Issues[0], "final price 110 exceeds") || !strings.Contains(watch.Issues[1], "special price 120") {
+		t.Errorf("expected the default base price and two issues for watch, got %+v", watch)
+	}
+	if shirt := report.Entries[2]; shirt.BasePrice != nil || len(shirt.Issues) != 1 || shirt.Issues[0] != "no base price" {
+		t.Errorf("expected a missing base price for shirt, got %+v", shirt)
+	}
+	if len(searches) != 2 || searches[0] != "in bag,watch,shirt" || searches[1] != "eq tee,white" {
+		t.Errorf("expected SKUs with a comma to be searched apart, got %q", searches)
+	}
+}
@@ -0,0 +1,49 @@
+package magento2
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+
+	magento2 "github.com/florinel-chis/go-m2rest"
+)
+
+func TestMProduct_SetCategories(t *testing.T) {
+	var payload struct {
+		Product map[string]any `json:"product"`
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut || r.URL.Path != "/rest/default/V1/products/24-MB01" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL)
+		}
+		_ = json.NewDecoder(r.Body).Decode(&payload)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"sku":"24-MB01"}`))
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := magento2.NewClient(context.Background(), magento2.WithStoreURL(server.URL, "default"), magento2.WithBearerToken("token"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	product := &magento2.Product{Sku: "24-MB01", Name: "Joust Duffle Bag", Price: 34}
+	mProduct := &magento2.MProduct{Route: "/products/24-MB01", Product: product, APIClient: client}
+	if err := mProduct.SetCategories(context.Background(), []int{4, 3}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(payload.Product) != 2 || payload.Product["sku"] != "24-MB01" {
+		t.Errorf("expected only the sku and the category links, got %v", payload.Product)
+	}
+	links, _ := json.Marshal(payload.Product["extension_attributes"])
+	if string(links) != `{"category_links":[{"category_id":"4","position":0},{"category_id":"3","position":1}]}` {
+		t.Errorf("unexpected category links %s", links)
+	}
+	if ids := product.CategoryIDs(); !slices.Equal(ids, []int{4, 3}) {
+		t.Errorf("expected the local product to carry the categories, got %v", ids)
+	}
+}
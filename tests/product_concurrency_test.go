@@ -0,0 +1,185 @@
+package magento2
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	magento2 "github.com/florinel-chis/go-m2rest"
+)
+
+func TestConcurrencyCheck_DetectsRemoteChange(t *testing.T) {
+	var (
+		mu        sync.Mutex
+		updatedAt = "2024-01-01 10:00:00"
+		puts      int
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		if r.Method == http.MethodPut {
+			puts++
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"sku":"concurrency-sku","name":"Product","updated_at":%q}`, updatedAt)
+	}))
+	t.Cleanup(server.Close)
+
+	parsed, _ := url.Parse(server.URL)
+	client := magento2.NewAPIClientWithoutAuthentication(&magento2.StoreConfig{
+		Scheme:    parsed.Scheme,
+		HostName:  parsed.Host,
+		StoreCode: "default",
+	})
+	client.ConcurrencyCheck = magento2.ConcurrencyCheckUpdatedAt
+
+	mProduct, err := magento2.GetProductBySKU("concurrency-sku", client)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err = mProduct.SetCategories(context.Background(), []int{3}); err != nil {
+		t.Fatalf("expected unchanged product to be written, got: %v", err)
+	}
+
+	mu.Lock()
+	updatedAt = "2024-01-01 10:05:00"
+	mu.Unlock()
+
+	err = mProduct.SetCategories(context.Background(), []int{4})
+	if !errors.Is(err, magento2.ErrConflict) {
+		t.Fatalf("expected ErrConflict, got: %v", err)
+	}
+	if puts != 1 {
+		t.Errorf("expected the conflicting write not to be sent, got %d PUTs", puts)
+	}
+}
+
+func TestConcurrencyCheck_VersionFromWriteResponse(t *testing.T) {
+	var (
+		mu      sync.Mutex
+		methods []string
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		methods = append(methods, r.Method)
+		updatedAt := "2024-01-01 10:00:00"
+		if r.Method == http.MethodPut {
+			updatedAt = "2024-01-01 10:01:00"
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"sku":"concurrency-sku","name":"Product","updated_at":%q}`, updatedAt)
+	}))
+	t.Cleanup(server.Close)
+
+	parsed, _ := url.Parse(server.URL)
+	client := magento2.NewAPIClientWithoutAuthentication(&magento2.StoreConfig{
+		Scheme:    parsed.Scheme,
+		HostName:  parsed.Host,
+		StoreCode: "default",
+	})
+	client.ConcurrencyCheck = magento2.ConcurrencyCheckUpdatedAt
+
+	mProduct, err := magento2.GetProductBySKU("concurrency-sku", client)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err = mProduct.SetCategories(context.Background(), []int{3}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if fmt.Sprint(methods) != "[GET GET PUT]" {
+		t.Errorf("expected the version to be taken from the PUT response, got requests %v", methods)
+	}
+
+	// the server's GETs don't report the updated_at the PUT returned, so the next write conflicts
+	err = mProduct.SetCategories(context.Background(), []int{4})
+	if !errors.Is(err, magento2.ErrConflict) {
+		t.Errorf("expected the version of the PUT response to be compared, got: %v", err)
+	}
+}
+
+func TestConcurrencyCheck_HashReadFromStoreView(t *testing.T) {
+	var (
+		mu       sync.Mutex
+		requests []string
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		name := "Shirt"
+		if strings.HasPrefix(r.URL.Path, "/rest/de/") {
+			name = "Hemd"
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"sku":"concurrency-sku","name":%q,"updated_at":"2024-01-01 10:00:00"}`, name)
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := magento2.NewClient(context.Background(), magento2.WithStoreURL(server.URL, "default"), magento2.WithBearerToken("token"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	client.ConcurrencyCheck = magento2.ConcurrencyCheckHash
+
+	mProduct, err := magento2.GetProductBySKU("concurrency-sku", client, magento2.WithStoreCode("de"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, categoryID := range []int{3, 4} {
+		if err := mProduct.SetCategories(context.Background(), []int{categoryID}); err != nil {
+			t.Fatalf("expected a product read through a store view not to conflict, got: %v", err)
+		}
+	}
+
+	want := []string{
+		"GET /rest/de/V1/products/concurrency-sku",
+		"GET /rest/de/V1/products/concurrency-sku",
+		"PUT /rest/default/V1/products/concurrency-sku",
+		"GET /rest/de/V1/products/concurrency-sku",
+		"GET /rest/de/V1/products/concurrency-sku",
+		"PUT /rest/default/V1/products/concurrency-sku",
+		"GET /rest/de/V1/products/concurrency-sku",
+	}
+	if !slices.Equal(requests, want) {
+		t.Errorf("expected requests\n%q\ngot\n%q", want, requests)
+	}
+}
+
+func TestCreateOrReplaceProductContext_Canceled(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"sku":"concurrency-sku"}`))
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := magento2.NewClient(context.Background(), magento2.WithStoreURL(server.URL, "default"), magento2.WithBearerToken("token"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	client.ConcurrencyCheck = magento2.ConcurrencyCheckUpdatedAt
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	mProduct, err := magento2.CreateOrReplaceProductContext(ctx, &magento2.Product{Sku: "concurrency-sku", Name: "Shirt"}, false, client)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+	if err := mProduct.UpdateQuantityForStockItemContext(ctx, "1", 5, true); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+	if n := requests.Load(); n != 0 {
+		t.Errorf("expected no requests with a canceled context, got %d", n)
+	}
+}
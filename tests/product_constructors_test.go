@@ -0,0 +1,81 @@
+package magento2
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	magento2 "github.com/florinel-chis/go-m2rest"
+)
+
+func TestProductConstructors(t *testing.T) {
+	var (
+		mu       sync.Mutex
+		payloads = map[string]map[string]any{}
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/rest/default/V1/products" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL)
+		}
+		var payload struct {
+			Product map[string]any `json:"product"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&payload)
+		mu.Lock()
+		payloads[payload.Product["sku"].(string)] = payload.Product
+		mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(payload.Product)
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := magento2.NewClient(context.Background(), magento2.WithStoreURL(server.URL, "default"), magento2.WithBearerToken("token"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	client.ValidatePayloads = true
+
+	for _, product := range []*magento2.Product{
+		magento2.NewSimpleProduct("simple-1", "Simple", 4, 10, 1.5),
+		magento2.NewVirtualProduct("virtual-1", "Virtual", 4, 5),
+		magento2.NewConfigurableProduct("config-1", "Configurable", 4),
+		magento2.NewBundleProduct("bundle-1", "Bundle", 4),
+	} {
+		if _, err := magento2.CreateOrReplaceProduct(product, true, client); err != nil {
+			t.Fatalf("unexpected error for %s: %v", product.Sku, err)
+		}
+	}
+
+	for sku, typeID := range map[string]string{"simple-1": "simple", "virtual-1": "virtual", "config-1": "configurable", "bundle-1": "bundle"} {
+		payload := payloads[sku]
+		if payload["type_id"] != typeID || payload["status"] != float64(1) || payload["visibility"] != float64(4) {
+			t.Errorf("unexpected payload for %s: %v", sku, payload)
+		}
+	}
+	if payloads["simple-1"]["weight"] != 1.5 || payloads["simple-1"]["price"] != float64(10) {
+		t.Errorf("expected price and weight of the simple product, got %v", payloads["simple-1"])
+	}
+	if _, ok := payloads["virtual-1"]["weight"]; ok {
+		t.Errorf("expected no weight for the virtual product, got %v", payloads["virtual-1"])
+	}
+	if _, ok := payloads["config-1"]["weight"]; ok || payloads["config-1"]["price"] != float64(0) {
+		t.Errorf("expected no price or weight for the configurable product, got %v", payloads["config-1"])
+	}
+	attributes, _ := payloads["bundle-1"]["custom_attributes"].([]any)
+	if len(attributes) != 4 || attributes[0].(map[string]any)["attribute_code"] != "price_type" {
+		t.Errorf("expected the dynamic bundle attributes, got %v", payloads["bundle-1"]["custom_attributes"])
+	}
+
+	heavy := magento2.NewVirtualProduct("virtual-2", "Virtual", 4, 5)
+	heavy.Weight = 2
+	if _, err := magento2.CreateOrReplaceProduct(heavy, true, client); !errors.Is(err, magento2.ErrValidation) {
+		t.Errorf("expected a virtual product with weight to be rejected, got %v", err)
+	}
+	if _, ok := payloads["virtual-2"]; ok {
+		t.Error("expected the invalid product not to be sent")
+	}
+}
@@ -0,0 +1,68 @@
+package magento2
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	magento2 "github.com/florinel-chis/go-m2rest"
+)
+
+func TestProductFingerprint(t *testing.T) {
+	a := &magento2.Product{Name: "Acme  Mug, Blue", CustomAttributes: []map[string]any{{"attribute_code": "manufacturer", "value": "12"}}}
+	b := &magento2.Product{Name: "acme mug blue", CustomAttributes: []map[string]any{{"attribute_code": "manufacturer", "value": "12"}}}
+	c := &magento2.Product{Name: "acme mug blue", CustomAttributes: []map[string]any{{"attribute_code": "manufacturer", "value": "13"}}}
+
+	attributes := []string{"name", "manufacturer"}
+	if magento2.ProductFingerprint(a, attributes) != magento2.ProductFingerprint(b, attributes) {
+		t.Error("expected names differing in case, spacing and punctuation to match")
+	}
+	if magento2.ProductFingerprint(b, attributes) == magento2.ProductFingerprint(c, attributes) {
+		t.Error("expected different brands not to match")
+	}
+	if fingerprint := magento2.ProductFingerprint(&magento2.Product{}, attributes); fingerprint != "" {
+		t.Errorf("expected no fingerprint without values, got %s", fingerprint)
+	}
+}
+
+func TestFindDuplicateProducts(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/rest/default/V1/products" {
+			t.Errorf("unexpected request: %s", r.URL)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"items":[
+			{"id":1,"sku":"mug-1","name":"Acme Mug"},
+			{"id":2,"sku":"mug-2","name":"ACME mug!"},
+			{"id":3,"sku":"mug-3","name":"Acme Mug"},
+			{"id":4,"sku":"plate-1","name":"Plate"},
+			{"id":5,"sku":"plate-2","name":"Plate"},
+			{"id":6,"sku":"bowl","name":"Bowl"},
+			{"id":7,"sku":"unnamed","name":""}
+		],"total_count":7}`))
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := magento2.NewClient(context.Background(),
+		magento2.WithStoreURL(server.URL, "default"),
+		magento2.WithBearerToken("token"),
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	groups, err := magento2.FindDuplicateProducts(context.Background(), nil, []string{"name"}, 2, client)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(groups) != 2 {
+		t.Fatalf("expected 2 groups, got %+v", groups)
+	}
+	if len(groups[0].Products) != 3 || groups[0].Products[1].Sku != "mug-2" {
+		t.Errorf("expected the mugs first, got %+v", groups[0])
+	}
+	if len(groups[1].Products) != 2 || groups[1].Products[0].Sku != "plate-1" {
+		t.Errorf("expected the plates second, got %+v", groups[1])
+	}
+}
@@ -0,0 +1,103 @@
+package magento2
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"testing"
+	"time"
+
+	magento2 "github.com/florinel-chis/go-m2rest"
+)
+
+func TestExportProductsUpdatedSince(t *testing.T) {
+	// 120 products of one import share a timestamp, more than fit on a page
+	var catalog []magento2.Product
+	for id := 1; id <= 250; id++ {
+		updatedAt := "2026-05-01 08:00:00"
+		switch {
+		case id <= 10:
+			updatedAt = "2026-04-01 08:00:00"
+		case id > 130:
+			updatedAt = fmt.Sprintf("2026-05-02 08:%02d:%02d", (id-130)/60, (id-130)%60)
+		}
+		catalog = append(catalog, magento2.Product{ID: id, Sku: fmt.Sprintf("sku-%d", id), UpdatedAt: updatedAt})
+	}
+
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		q := r.URL.Query()
+		since := q.Get("searchCriteria[filter_groups][0][filters][0][value]")
+		afterID, _ := strconv.Atoi(q.Get("searchCriteria[filter_groups][1][filters][0][value]"))
+		after := q.Get("searchCriteria[filter_groups][1][filters][1][value]")
+		pageSize, _ := strconv.Atoi(q.Get("searchCriteria[pageSize]"))
+		if q.Get("searchCriteria[sortOrders][0][field]") != "updated_at" || q.Get("searchCriteria[sortOrders][1][field]") != "entity_id" {
+			t.Errorf("expected sorting by updated_at and entity_id, got %s", r.URL.RawQuery)
+		}
+
+		var matches []magento2.Product
+		for _, product := range catalog {
+			if product.UpdatedAt >= since && (product.ID > afterID || product.UpdatedAt > after) {
+				matches = append(matches, product)
+			}
+		}
+		sort.SliceStable(matches, func(i, j int) bool {
+			if matches[i].UpdatedAt != matches[j].UpdatedAt {
+				return matches[i].UpdatedAt < matches[j].UpdatedAt
+			}
+			return matches[i].ID < matches[j].ID
+		})
+		total := len(matches)
+		if len(matches) > pageSize {
+			matches = matches[:pageSize]
+		}
+		if requests == 1 {
+			// a product of the first page is saved while the export runs
+			catalog[20].UpdatedAt = "2026-05-03 00:00:00"
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{"items": matches, "total_count": total})
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := magento2.NewClient(context.Background(),
+		magento2.WithStoreURL(server.URL, "default"),
+		magento2.WithBearerToken("token"),
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	delivered := map[int]int{}
+	since := time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC)
+	checkpoint, err := magento2.ExportProductsUpdatedSince(context.Background(), since, client, func(products []magento2.Product) error {
+		for _, product := range products {
+			delivered[product.ID]++
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for id := 11; id <= 250; id++ {
+		if delivered[id] == 0 {
+			t.Errorf("product %d was not delivered", id)
+		}
+	}
+	if delivered[5] != 0 {
+		t.Error("expected products updated before since to be skipped")
+	}
+	if delivered[21] != 2 {
+		t.Errorf("expected the product saved during the export to be delivered again, got %d", delivered[21])
+	}
+	if want := time.Date(2026, 5, 3, 0, 0, 0, 0, time.UTC); !checkpoint.Equal(want) {
+		t.Errorf("expected checkpoint %s, got %s", want, checkpoint)
+	}
+}
@@ -0,0 +1,62 @@
+package magento2
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+
+	magento2 "github.com/florinel-chis/go-m2rest"
+)
+
+func TestSetLinkedProducts_RemovesStaleLinks(t *testing.T) {
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		requests = append(requests, r.Method+" "+r.URL.Path+" "+string(body))
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method == http.MethodGet {
+			_, _ = w.Write([]byte(`[{"sku":"shirt","link_type":"related","linked_product_sku":"belt","position":1,"extension_attributes":null},` +
+				`{"sku":"shirt","link_type":"related","linked_product_sku":"hat","position":2}]`))
+			return
+		}
+		_, _ = w.Write([]byte(`true`))
+	}))
+	t.Cleanup(server.Close)
+	client, err := magento2.NewClient(context.Background(), magento2.WithStoreURL(server.URL, "default"), magento2.WithBearerToken("token"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	mProduct := &magento2.MProduct{
+		Route: "/products/shirt",
+		Product: &magento2.Product{Sku: "shirt", ProductLinks: []magento2.ProductLinks{
+			{Sku: "shirt", LinkType: "related", LinkedProductSku: "belt", Position: 1},
+			{Sku: "shirt", LinkType: "related", LinkedProductSku: "hat", Position: 2},
+		}},
+		APIClient: client,
+	}
+	if err := mProduct.SetLinkedProducts(context.Background(), "related", []string{"scarf", "belt"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []string{
+		"GET /rest/default/V1/products/shirt/links/related ",
+		"DELETE /rest/default/V1/products/shirt/links/related/hat ",
+		`POST /rest/default/V1/products/shirt/links {"items":[` +
+			`{"sku":"shirt","link_type":"related","linked_product_sku":"scarf","linked_product_type":"","position":1,"extension_attributes":null},` +
+			`{"sku":"shirt","link_type":"related","linked_product_sku":"belt","linked_product_type":"","position":2,"extension_attributes":null}]}`,
+	}
+	if !slices.Equal(requests, want) {
+		t.Errorf("expected requests\n%q\ngot\n%q", want, requests)
+	}
+	got := []string{}
+	for _, link := range mProduct.Product.LinksByType("related") {
+		got = append(got, link.LinkedProductSku)
+	}
+	if !slices.Equal(got, []string{"scarf", "belt"}) {
+		t.Errorf("expected the local links to be replaced, got %v", got)
+	}
+}
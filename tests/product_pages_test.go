@@ -0,0 +1,62 @@
+package magento2
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+
+	magento2 "github.com/florinel-chis/go-m2rest"
+)
+
+func TestForEachProductPage_SortsByEntityID(t *testing.T) {
+	var (
+		mu    sync.Mutex
+		sorts = map[string]int{}
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		mu.Lock()
+		sorts[query.Get("searchCriteria[sortOrders][0][field]")+" "+query.Get("searchCriteria[sortOrders][0][direction]")]++
+		mu.Unlock()
+		page, _ := strconv.Atoi(query.Get("searchCriteria[currentPage]"))
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprintf(w, `{"items":[{"sku":"sku-%d"}],"total_count":3}`, page)
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := magento2.NewClient(context.Background(), magento2.WithStoreURL(server.URL, "default"), magento2.WithBearerToken("token"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	collect := func(criteria *magento2.SearchCriteria) []string {
+		skus := []string{}
+		err := magento2.ForEachProductPage(context.Background(), criteria, 2, client, func(products []magento2.Product) error {
+			for _, product := range products {
+				skus = append(skus, product.Sku)
+			}
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		return skus
+	}
+
+	criteria := &magento2.SearchCriteria{PageSize: 1}
+	if skus := collect(criteria); len(skus) != 3 || skus[2] != "sku-3" {
+		t.Errorf("expected the pages in order, got %v", skus)
+	}
+	if sorts["entity_id ASC"] != 3 || len(criteria.SortOrders) != 0 {
+		t.Errorf("expected every page sorted by entity_id without changing the criteria, got %v", sorts)
+	}
+
+	sorts = map[string]int{}
+	collect(&magento2.SearchCriteria{PageSize: 1, SortOrders: []magento2.SortOrder{{Field: "name", Direction: magento2.SortDescending}}})
+	if sorts["name DESC"] != 3 || len(sorts) != 1 {
+		t.Errorf("expected the caller's sort order to be kept, got %v", sorts)
+	}
+}
@@ -0,0 +1,41 @@
+package magento2
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	magento2 "github.com/florinel-chis/go-m2rest"
+)
+
+func TestMProduct_UpdateForStore(t *testing.T) {
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		var payload struct {
+			Product map[string]any `json:"product"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&payload)
+		if payload.Product["name"] != "Hemd" || payload.Product["sku"] != "shirt" {
+			t.Errorf("expected the localized name, got %v", payload.Product)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"sku":"shirt","name":"Hemd"}`))
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := magento2.NewClient(context.Background(), magento2.WithStoreURL(server.URL+"/shop", "default"), magento2.WithBearerToken("token"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	mProduct := &magento2.MProduct{Route: "/products/shirt", Product: &magento2.Product{Sku: "shirt", Name: "Hemd"}, APIClient: client}
+	if err := mProduct.UpdateForStore(context.Background(), "de"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(requests) != 1 || requests[0] != "PUT /shop/rest/de/V1/products/shirt" {
+		t.Errorf("expected a PUT to the de store view, got %v", requests)
+	}
+}
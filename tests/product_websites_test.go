@@ -0,0 +1,59 @@
+package magento2
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+
+	magento2 "github.com/florinel-chis/go-m2rest"
+)
+
+func TestProductWebsites(t *testing.T) {
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		if r.Method == http.MethodPost {
+			var payload struct {
+				ProductWebsiteLink struct {
+					Sku       string `json:"sku"`
+					WebsiteID int    `json:"website_id"`
+				} `json:"productWebsiteLink"`
+			}
+			_ = json.NewDecoder(r.Body).Decode(&payload)
+			if payload.ProductWebsiteLink.Sku != "24-MB01" || payload.ProductWebsiteLink.WebsiteID != 2 {
+				t.Errorf("unexpected website link %+v", payload.ProductWebsiteLink)
+			}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte("true"))
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := magento2.NewClient(context.Background(), magento2.WithStoreURL(server.URL, "default"), magento2.WithBearerToken("token"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	product := &magento2.Product{Sku: "24-MB01", ExtensionAttributes: map[string]any{"website_ids": []any{float64(1)}}}
+	mProduct := &magento2.MProduct{Route: "/products/24-MB01", Product: product, APIClient: client}
+	if err := mProduct.AssignWebsite(context.Background(), 2); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if ids := product.WebsiteIDs(); !slices.Equal(ids, []int{1, 2}) {
+		t.Errorf("expected website 2 to be added, got %v", ids)
+	}
+	if err := mProduct.RemoveWebsite(context.Background(), 1); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if ids := product.WebsiteIDs(); !slices.Equal(ids, []int{2}) {
+		t.Errorf("expected website 1 to be removed, got %v", ids)
+	}
+
+	expected := []string{"POST /rest/default/V1/products/24-MB01/websites", "DELETE /rest/default/V1/products/24-MB01/websites/1"}
+	if !slices.Equal(requests, expected) {
+		t.Errorf("unexpected requests %v", requests)
+	}
+}
@@ -0,0 +1,32 @@
+package magento2
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	magento2 "github.com/florinel-chis/go-m2rest"
+)
+
+func TestGetProductsRenderInfo_EscapesTheQuery(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		if r.URL.Path != "/rest/default/V1/products-render-info" || query.Get("searchCriteria[filter_groups][0][filters][0][value]") != "a&b,c+d" ||
+			query.Get("storeId") != "2" || query.Get("currencyCode") != "EUR&x=1" || query.Has("x") {
+			t.Errorf("unexpected request %s", r.URL)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"items":[{"id":1,"name":"Shirt","store_id":2,"currency_code":"EUR"}],"total_count":1}`))
+	}))
+	t.Cleanup(server.Close)
+	client, err := magento2.NewClient(context.Background(), magento2.WithStoreURL(server.URL, "default"), magento2.WithBearerToken("token"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	items, err := magento2.GetProductsRenderInfo(context.Background(), []string{"a&b", "c+d"}, 2, "EUR&x=1", client)
+	if err != nil || len(items) != 1 || items[0].Name != "Shirt" {
+		t.Fatalf("unexpected items %+v, %v", items, err)
+	}
+}
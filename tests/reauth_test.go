@@ -0,0 +1,118 @@
+package magento2
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	magento2 "github.com/florinel-chis/go-m2rest"
+)
+
+func TestNewClient_CustomerReauthentication(t *testing.T) {
+	var logins atomic.Int32
+	var body string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/rest/default/V1/integration/customer/token" {
+			fmt.Fprintf(w, `"customer-token-%d"`, logins.Add(1))
+			return
+		}
+		if r.Header.Get("Authorization") != "Bearer customer-token-2" {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"message":"The consumer isn't authorized to access %resources."}`))
+			return
+		}
+		raw, _ := io.ReadAll(r.Body)
+		body = string(raw)
+		_, _ = w.Write([]byte(`"5"`))
+	}))
+	t.Cleanup(server.Close)
+
+	var hookCalls []magento2.AuthenticationType
+	client, err := magento2.NewClient(context.Background(),
+		magento2.WithStoreURL(server.URL, "default"),
+		magento2.WithCustomerCredentials("roni_cost@example.com", "secret"),
+		magento2.WithReauthenticationHook(func(_ context.Context, authenticationType magento2.AuthenticationType) {
+			hookCalls = append(hookCalls, authenticationType)
+		}),
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	resp, err := client.HTTPClient.R().SetBody(map[string]string{"cartId": "abc"}).Post("/carts/mine")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.StatusCode() != http.StatusOK || body != `{"cartId":"abc"}` {
+		t.Fatalf("expected replayed request with body, got %d %q", resp.StatusCode(), body)
+	}
+	if logins.Load() != 2 || len(hookCalls) != 1 || hookCalls[0] != magento2.CustomerAuth {
+		t.Errorf("expected one re-login with hook call, got %d logins and hooks %v", logins.Load(), hookCalls)
+	}
+
+	clone, err := client.Clone()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := clone.HTTPClient.R().Get("/carts/mine"); err != nil || logins.Load() != 2 {
+		t.Errorf("expected clone to reuse the renewed token, got %v after %d logins", err, logins.Load())
+	}
+}
+
+func TestNewClient_ConcurrentReauthentication(t *testing.T) {
+	var logins atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/rest/default/V1/integration/admin/token" {
+			time.Sleep(5 * time.Millisecond)
+			fmt.Fprintf(w, `"admin-token-%d"`, logins.Add(1))
+			return
+		}
+		if r.Header.Get("Authorization") != "Bearer admin-token-2" {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"message":"The consumer isn't authorized to access %resources."}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"sku":"shirt"}`))
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := magento2.NewClient(context.Background(),
+		magento2.WithStoreURL(server.URL, "default"),
+		magento2.WithAdminCredentials("admin", "secret"),
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var wg sync.WaitGroup
+	statuses := make([]int, 16)
+	for i := range statuses {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			resp, err := client.HTTPClient.R().Get("/products/shirt")
+			if err == nil {
+				statuses[i] = resp.StatusCode()
+			}
+		}(i)
+		time.Sleep(time.Millisecond)
+	}
+	wg.Wait()
+
+	for i, status := range statuses {
+		if status != http.StatusOK {
+			t.Errorf("expected request %d to be replayed with the renewed token, got %d", i, status)
+		}
+	}
+	if logins.Load() != 2 {
+		t.Errorf("expected a single re-login, got %d logins", logins.Load())
+	}
+}
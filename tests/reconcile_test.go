@@ -0,0 +1,79 @@
+package magento2
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	magento2 "github.com/florinel-chis/go-m2rest"
+)
+
+func TestFindDeletedEntities(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		value := r.URL.Query().Get("searchCriteria[filter_groups][0][filters][0][value]")
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/rest/default/V1/products":
+			if !strings.Contains(value, "WATCH-1") || !strings.Contains(value, "strap-2") {
+				t.Errorf("expected a search for all SKUs, got %s", value)
+			}
+			_, _ = w.Write([]byte(`{"items":[{"id":1,"sku":"watch-1"}],"total_count":1}`))
+		case "/rest/default/V1/categories/list":
+			if value != "3,4,5" {
+				t.Errorf("expected a search for categories 3, 4 and 5, got %s", value)
+			}
+			_, _ = w.Write([]byte(`{"items":[{"id":3},{"id":5}],"total_count":2}`))
+		default:
+			t.Errorf("unexpected request: %s", r.URL)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := magento2.NewClient(context.Background(),
+		magento2.WithStoreURL(server.URL, "default"),
+		magento2.WithBearerToken("token"),
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	skus, err := magento2.FindDeletedProducts(context.Background(), []string{"WATCH-1", "strap-2"}, client)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(skus) != 1 || skus[0] != "strap-2" {
+		t.Errorf("expected strap-2 to be deleted, got %v", skus)
+	}
+
+	ids, err := magento2.FindDeletedCategories(context.Background(), []int{3, 4, 5}, client)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(ids) != 1 || ids[0] != 4 {
+		t.Errorf("expected category 4 to be deleted, got %v", ids)
+	}
+}
+
+func TestFindDeletedEntitiesFailsOnSearchError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte(`{"message":"The consumer isn't authorized to access %resources."}`))
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := magento2.NewClient(context.Background(),
+		magento2.WithStoreURL(server.URL, "default"),
+		magento2.WithBearerToken("token"),
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	ids, err := magento2.FindDeletedCustomers(context.Background(), []int{1, 2}, client)
+	if err == nil || ids != nil {
+		t.Errorf("expected an error instead of deleted customers %v", ids)
+	}
+}
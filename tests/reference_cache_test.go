@@ -0,0 +1,155 @@
+package magento2
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	magento2 "github.com/florinel-chis/go-m2rest"
+)
+
+func TestCategoryTreeCache(t *testing.T) {
+	var treeRequests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/rest/default/V1/categories":
+			treeRequests.Add(1)
+			time.Sleep(20 * time.Millisecond)
+			_, _ = w.Write([]byte(`{"id":2,"name":"Default Category","children_data":[{"id":3,"name":"Gear"}]}`))
+		case r.Method == http.MethodPut && r.URL.Path == "/rest/default/V1/categories/3":
+			_, _ = w.Write([]byte(`{"id":3,"name":"Gear & Bags"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := magento2.NewClient(context.Background(),
+		magento2.WithStoreURL(server.URL, "default"),
+		magento2.WithBearerToken("token"),
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	cache := magento2.NewCategoryTreeCache(client, time.Hour)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			tree, err := cache.Get(context.Background(), 2, 3)
+			if err != nil || len(tree.ChildrenData) != 1 {
+				t.Errorf("unexpected tree %+v, error: %v", tree, err)
+			}
+		}()
+	}
+	wg.Wait()
+	if _, err := cache.Get(context.Background(), 2, 3); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := treeRequests.Load(); got != 1 {
+		t.Errorf("expected concurrent misses to cause one request, got %d", got)
+	}
+
+	category := &magento2.MCategory{Route: "/categories/3", Category: &magento2.Category{ID: 3, Name: "Gear & Bags"}, APIClient: client}
+	if err := category.UpdateCategoryOnRemote(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := cache.Get(context.Background(), 2, 3); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := treeRequests.Load(); got != 2 {
+		t.Errorf("expected a category write to invalidate the cache, got %d requests", got)
+	}
+
+	expiring := magento2.NewCategoryTreeCache(client, time.Millisecond)
+	_, _ = expiring.Get(context.Background(), 2, 3)
+	time.Sleep(5 * time.Millisecond)
+	_, _ = expiring.Get(context.Background(), 2, 3)
+	if got := treeRequests.Load(); got != 4 {
+		t.Errorf("expected expired trees to be fetched again, got %d requests", got)
+	}
+}
+
+func TestCategoryTreeCache_LoadOutlivesTheFirstCaller(t *testing.T) {
+	var treeRequests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		treeRequests.Add(1)
+		time.Sleep(30 * time.Millisecond)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id":2,"name":"Default Category","children_data":[{"id":3,"name":"Gear"}]}`))
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := magento2.NewClient(context.Background(),
+		magento2.WithStoreURL(server.URL, "default"),
+		magento2.WithBearerToken("token"),
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	cache := magento2.NewCategoryTreeCache(client, time.Hour)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		_, _ = cache.Get(ctx, 2, 3)
+	}()
+	time.Sleep(5 * time.Millisecond)
+	tree, err := cache.Get(context.Background(), 2, 3)
+	wg.Wait()
+	if err != nil || len(tree.ChildrenData) != 1 {
+		t.Fatalf("expected the waiter to get the tree after the first caller gave up, got %+v, %v", tree, err)
+	}
+	if got := treeRequests.Load(); got != 1 {
+		t.Errorf("expected one request, got %d", got)
+	}
+}
+
+func TestStoreConfigCache(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`[{"id":1,"code":"default","base_currency_code":"USD"},{"id":2,"code":"de","base_currency_code":"EUR"}]`))
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := magento2.NewClient(context.Background(),
+		magento2.WithStoreURL(server.URL, "default"),
+		magento2.WithBearerToken("token"),
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	cache := magento2.NewStoreConfigCache(client, 0)
+
+	config, err := cache.Get(context.Background(), "de")
+	if err != nil || config.BaseCurrencyCode != "EUR" {
+		t.Fatalf("unexpected config %+v, error: %v", config, err)
+	}
+	if _, err := cache.Get(context.Background(), "fr"); !errors.Is(err, magento2.ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got: %v", err)
+	}
+	if requests.Load() != 1 {
+		t.Errorf("expected one request, got %d", requests.Load())
+	}
+
+	cache.Invalidate()
+	if _, err := cache.Get(context.Background(), "default"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if requests.Load() != 2 {
+		t.Errorf("expected invalidation to reload configs, got %d requests", requests.Load())
+	}
+}
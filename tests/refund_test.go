@@ -0,0 +1,99 @@
+package magento2
+
+import (
+	"errors"
+	"testing"
+
+	magento2 "github.com/florinel-chis/go-m2rest"
+)
+
+func newRefundTestOrder() *magento2.Order {
+	return &magento2.Order{
+		EntityID:          7,
+		ShippingAmount:    10,
+		ShippingTaxAmount: 2,
+		TotalPaid:         57,
+		Items: []magento2.Item{
+			{ItemID: 1, Sku: "strap", QtyOrdered: 3, QtyInvoiced: 3, RowTotal: 30, TaxInvoiced: 6, DiscountInvoiced: 3},
+			{ItemID: 2, Sku: "watch", QtyOrdered: 1, QtyInvoiced: 1, RowTotal: 10, TaxInvoiced: 2},
+		},
+	}
+}
+
+func TestCalculateRefundPartial(t *testing.T) {
+	calculation, err := magento2.CalculateRefund(newRefundTestOrder(), map[int]float64{1: 1}, magento2.RefundOptions{ReturnToStock: true, Comment: "Damaged"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	line := calculation.Lines[0]
+	if line.RowTotal.String() != "10" || line.TaxAmount.String() != "2" || line.DiscountAmount.String() != "1" || line.Total.String() != "11" {
+		t.Errorf("unexpected line: %+v", line)
+	}
+	// a quarter of the ordered quantity takes a quarter of the shipping and its tax
+	if calculation.ShippingAmount.String() != "2.5" || calculation.ShippingTaxAmount.String() != "0.5" {
+		t.Errorf("expected shipping 2.5 + 0.5, got %s + %s", calculation.ShippingAmount, calculation.ShippingTaxAmount)
+	}
+	if calculation.GrandTotal.String() != "14" {
+		t.Errorf("expected grand total 14, got %s", calculation.GrandTotal)
+	}
+
+	request := calculation.Request
+	if len(request.Items) != 1 || request.Items[0].OrderItemID != 1 || request.Items[0].Qty != 1 {
+		t.Errorf("unexpected request items: %+v", request.Items)
+	}
+	if request.Arguments.ShippingAmount != 2.5 || request.Arguments.ExtensionAttributes.ReturnToStockItems[0] != 1 {
+		t.Errorf("unexpected request arguments: %+v", request.Arguments)
+	}
+	if !request.AppendComment || request.Comment.Comment != "Damaged" {
+		t.Errorf("expected the comment to be appended, got %+v", request.Comment)
+	}
+}
+
+func TestCalculateRefundRemainder(t *testing.T) {
+	order := newRefundTestOrder()
+	order.Items[0].QtyRefunded = 1
+	order.Items[0].TaxRefunded = 2
+	order.Items[0].DiscountRefunded = 1
+	order.ShippingRefunded = 2.5
+	order.ShippingTaxRefunded = 0.5
+	order.TotalRefunded = 14
+
+	calculation, err := magento2.CalculateRefund(order, map[int]float64{1: 2, 2: 1}, magento2.RefundOptions{AdjustmentNegative: 3})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// emptying the order refunds all shipping that is left
+	if calculation.ShippingAmount.String() != "7.5" || calculation.ShippingTaxAmount.String() != "1.5" {
+		t.Errorf("expected shipping 7.5 + 1.5, got %s + %s", calculation.ShippingAmount, calculation.ShippingTaxAmount)
+	}
+	if calculation.GrandTotal.String() != "40" {
+		t.Errorf("expected grand total 40, got %s", calculation.GrandTotal)
+	}
+	if calculation.Request.Arguments.AdjustmentNegative != 3 {
+		t.Errorf("expected adjustment negative 3, got %v", calculation.Request.Arguments.AdjustmentNegative)
+	}
+}
+
+func TestCalculateRefundValidation(t *testing.T) {
+	order := newRefundTestOrder()
+	order.Items[1].QtyRefunded = 1
+
+	_, err := magento2.CalculateRefund(order, map[int]float64{2: 1}, magento2.RefundOptions{})
+	if !errors.Is(err, magento2.ErrAlreadyRefunded) {
+		t.Errorf("expected ErrAlreadyRefunded, got %v", err)
+	}
+	_, err = magento2.CalculateRefund(order, map[int]float64{3: 1}, magento2.RefundOptions{})
+	if !errors.Is(err, magento2.ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+	_, err = magento2.CalculateRefund(order, map[int]float64{1: 1}, magento2.RefundOptions{AdjustmentPositive: 100})
+	if !errors.Is(err, magento2.ErrValidation) {
+		t.Errorf("expected ErrValidation, got %v", err)
+	}
+	shipping := 11.0
+	_, err = magento2.CalculateRefund(order, map[int]float64{1: 1}, magento2.RefundOptions{ShippingAmount: &shipping})
+	if !errors.Is(err, magento2.ErrValidation) {
+		t.Errorf("expected ErrValidation, got %v", err)
+	}
+}
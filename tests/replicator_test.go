@@ -0,0 +1,101 @@
+package magento2
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	magento2 "github.com/florinel-chis/go-m2rest"
+)
+
+// replicaServer records the paths written to it and answers 503 while down is set
+type replicaServer struct {
+	mu       sync.Mutex
+	down     bool
+	paths    []string
+	requests int
+}
+
+func (s *replicaServer) client(t *testing.T) *magento2.Client {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		s.requests++
+		if s.down {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		s.paths = append(s.paths, r.Method+" "+r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	t.Cleanup(server.Close)
+	client, err := magento2.NewClient(context.Background(), magento2.WithStoreURL(server.URL, "default"), magento2.WithBearerToken("token"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	client.HTTPClient.SetRetryWaitTime(time.Millisecond).SetRetryMaxWaitTime(time.Millisecond)
+	return client
+}
+
+func TestReplicator_TracksTargetsAndRetriesFailed(t *testing.T) {
+	eu, us := &replicaServer{}, &replicaServer{down: true}
+	var (
+		mu       sync.Mutex
+		reported []string
+	)
+	replicator := magento2.NewReplicator([]magento2.ReplicaTarget{
+		{Name: "eu", APIClient: eu.client(t)},
+		{Name: "us", APIClient: us.client(t), CategoryIDs: map[int]int{12: 40}},
+	}, magento2.ReplicatorConfig{
+		MaxAttempts: 2,
+		Backoff:     magento2.Backoff{Initial: time.Millisecond, Max: time.Millisecond, Multiplier: 1},
+		OnResult: func(op *magento2.WriteOperation, result magento2.ReplicaResult) {
+			mu.Lock()
+			defer mu.Unlock()
+			reported = append(reported, result.Target)
+		},
+	})
+
+	op := &magento2.WriteOperation{Type: magento2.WriteOperationCategory, CategoryID: 12, CategoryFields: map[string]any{"name": "Sale"}}
+	result := replicator.Apply(context.Background(), op)
+	if op.ID == "" {
+		t.Error("expected the operation to get an ID")
+	}
+	if failed := result.Failed(); !slices.Equal(failed, []string{"us"}) {
+		t.Fatalf("expected us to fail, got %v", failed)
+	}
+	var statusErr *magento2.HTTPStatusError
+	if err := result.Err(); !errors.As(err, &statusErr) || result.Targets[1].Attempts != 2 {
+		t.Errorf("expected a status error after 2 attempts, got %v after %d", err, result.Targets[1].Attempts)
+	}
+	if us.requests != 2 {
+		t.Errorf("expected one request per replicator attempt, got %d", us.requests)
+	}
+	slices.Sort(reported)
+	if !slices.Equal(reported, []string{"eu", "us"}) {
+		t.Errorf("expected a result per target, got %v", reported)
+	}
+
+	us.mu.Lock()
+	us.down = false
+	us.mu.Unlock()
+	retried := replicator.Retry(context.Background(), result)
+	if err := retried.Err(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if retried.Targets[0].Attempts != 1 || retried.Targets[1].Attempts != 3 {
+		t.Errorf("unexpected attempts %+v", retried.Targets)
+	}
+	if !slices.Equal(eu.paths, []string{"PUT /rest/default/V1/categories/12"}) {
+		t.Errorf("expected eu to be written once, got %v", eu.paths)
+	}
+	if !slices.Equal(us.paths, []string{"PUT /rest/default/V1/categories/40"}) {
+		t.Errorf("expected us to get the mapped category, got %v", us.paths)
+	}
+}
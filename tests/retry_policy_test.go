@@ -0,0 +1,122 @@
+package magento2
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	magento2 "github.com/florinel-chis/go-m2rest"
+)
+
+type attemptRecorder struct {
+	mu       sync.Mutex
+	attempts int
+	keys     []string
+}
+
+func newFailingServer(t *testing.T, rec *attemptRecorder) *magento2.Client {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rec.mu.Lock()
+		rec.attempts++
+		rec.keys = append(rec.keys, r.Header.Get("Idempotency-Key"))
+		rec.mu.Unlock()
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	t.Cleanup(server.Close)
+
+	parsed, _ := url.Parse(server.URL)
+	client := magento2.NewAPIClientWithoutAuthentication(&magento2.StoreConfig{
+		Scheme:    parsed.Scheme,
+		HostName:  parsed.Host,
+		StoreCode: "default",
+	})
+	client.HTTPClient.SetRetryWaitTime(time.Millisecond).SetRetryMaxWaitTime(time.Millisecond)
+	return client
+}
+
+func TestRetryPolicy_PostNotRetriedByDefault(t *testing.T) {
+	rec := &attemptRecorder{}
+	client := newFailingServer(t, rec)
+
+	_, _ = magento2.CreateOrReplaceProduct(&magento2.Product{Sku: "retry-sku"}, true, client)
+	if rec.attempts != 1 {
+		t.Errorf("expected a single POST attempt, got %d", rec.attempts)
+	}
+}
+
+func TestRetryPolicy_GetRetried(t *testing.T) {
+	rec := &attemptRecorder{}
+	client := newFailingServer(t, rec)
+
+	_, _ = magento2.GetProductBySKU("retry-sku", client)
+	if rec.attempts != magento2.RetryAttempts+1 {
+		t.Errorf("expected %d GET attempts, got %d", magento2.RetryAttempts+1, rec.attempts)
+	}
+}
+
+func TestRetryPolicy_PostWithIdempotencyKeyRetried(t *testing.T) {
+	rec := &attemptRecorder{}
+	client := newFailingServer(t, rec).EnableIdempotencyKeys()
+
+	_, _ = magento2.CreateOrReplaceProduct(&magento2.Product{Sku: "retry-sku"}, true, client)
+	if rec.attempts != magento2.RetryAttempts+1 {
+		t.Fatalf("expected %d POST attempts, got %d", magento2.RetryAttempts+1, rec.attempts)
+	}
+	for _, key := range rec.keys {
+		if key == "" || key != rec.keys[0] {
+			t.Fatalf("expected the same idempotency key on every attempt, got %v", rec.keys)
+		}
+	}
+}
+
+func TestRetryPolicy_WithRetriesOverride(t *testing.T) {
+	rec := &attemptRecorder{}
+	client := newFailingServer(t, rec)
+
+	_, _ = magento2.GetCategoryByID(context.Background(), 3, client, magento2.WithRetries(0))
+	if rec.attempts != 1 {
+		t.Errorf("expected retries to be disabled, got %d attempts", rec.attempts)
+	}
+}
+
+func TestRetryPolicy_RetriesStayWithinTheDeadline(t *testing.T) {
+	rec := &attemptRecorder{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rec.mu.Lock()
+		rec.attempts++
+		rec.mu.Unlock()
+		time.Sleep(20 * time.Millisecond)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	t.Cleanup(server.Close)
+	client, err := magento2.NewClient(context.Background(),
+		magento2.WithStoreURL(server.URL, "default"),
+		magento2.WithRetry(3, 40*time.Millisecond, 40*time.Millisecond),
+		magento2.WithDefaultTimeout(50*time.Millisecond),
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	_, _ = magento2.GetProductBySKU("retry-sku", client)
+	if rec.attempts != 1 {
+		t.Errorf("expected no retry past the default timeout of a call without context, got %d attempts", rec.attempts)
+	}
+
+	rec.attempts = 0
+	_, _ = magento2.GetCategoryByID(context.Background(), 3, client, magento2.WithTimeout(50*time.Millisecond))
+	if rec.attempts != 1 {
+		t.Errorf("expected no retry past the call's deadline, got %d attempts", rec.attempts)
+	}
+
+	rec.attempts = 0
+	client.SetDefaultTimeout(time.Second)
+	_, _ = magento2.GetProductBySKU("retry-sku", client)
+	if rec.attempts != 4 {
+		t.Errorf("expected all retries within the budget, got %d attempts", rec.attempts)
+	}
+}
@@ -0,0 +1,121 @@
+package magento2
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	magento2 "github.com/florinel-chis/go-m2rest"
+)
+
+func TestRunTags(t *testing.T) {
+	bodies := map[string]string{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		raw, _ := io.ReadAll(r.Body)
+		path := strings.TrimPrefix(r.URL.Path, "/rest/default/V1")
+		bodies[r.Method+" "+path] = string(raw)
+		query := r.URL.Query()
+		w.Header().Set("Content-Type", "application/json")
+		switch path {
+		case "/products":
+			if r.Method == http.MethodGet {
+				if query.Get("searchCriteria[filter_groups][0][filters][0][field]") != "meta_keyword" ||
+					query.Get("searchCriteria[filter_groups][0][filters][0][value]") != "m2rest-run:ci-42" {
+					t.Errorf("unexpected product search: %v", query)
+				}
+				_, _ = w.Write([]byte(`{"items":[{"sku":"run-sku"}],"total_count":1}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"sku":"run-sku"}`))
+		case "/products/kept-sku":
+			_, _ = w.Write([]byte(`{"sku":"kept-sku"}`))
+		case "/categories":
+			_, _ = w.Write([]byte(`{"id":40,"name":"Run Category"}`))
+		case "/categories/list":
+			if query.Get("searchCriteria[filter_groups][0][filters][0][value]") != "%m2rest-run:ci-42%" {
+				t.Errorf("unexpected category search: %v", query)
+			}
+			_, _ = w.Write([]byte(`{"items":[{"id":40,"name":"Run Category"}],"total_count":1}`))
+		case "/orders":
+			if query.Get("searchCriteria[filter_groups][0][filters][0][value]") != "2026-01-02 00:00:00" {
+				t.Errorf("unexpected order search: %v", query)
+			}
+			_, _ = w.Write([]byte(`{"items":[` +
+				`{"entity_id":1,"status_histories":[{"comment":"m2rest-run:ci-41"}]},` +
+				`{"entity_id":2,"status_histories":[{"comment":"Shipped"},{"comment":"m2rest-run:ci-42"}]}],"total_count":2}`))
+		case "/orders/2/comments":
+			_, _ = w.Write([]byte(`true`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := magento2.NewClient(context.Background(),
+		magento2.WithStoreURL(server.URL, "default"),
+		magento2.WithBearerToken("admin-token"),
+		magento2.WithRunID("ci-42"),
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	clone, err := client.Clone()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if clone.RunID() != "ci-42" {
+		t.Errorf("expected the clone to keep the run ID, got %q", clone.RunID())
+	}
+
+	product := magento2.NewSimpleProduct("run-sku", "Run Product", 4, 10, 1)
+	if _, err := magento2.CreateOrReplaceProduct(product, true, client); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(bodies["POST /products"], `{"attribute_code":"meta_keyword","value":"m2rest-run:ci-42"}`) {
+		t.Errorf("expected product to be stamped, got %s", bodies["POST /products"])
+	}
+	for _, attribute := range product.CustomAttributes {
+		if attribute["attribute_code"] == "meta_keyword" {
+			t.Errorf("expected the caller's product to be left unstamped, got %v", product.CustomAttributes)
+		}
+	}
+
+	existing := magento2.NewSimpleProduct("kept-sku", "Kept Product", 4, 10, 1)
+	if _, err := magento2.CreateOrReplaceProduct(existing, true, client); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if strings.Contains(bodies["POST /products"], "m2rest-run") {
+		t.Errorf("expected an existing product not to be stamped, got %s", bodies["POST /products"])
+	}
+
+	category := &magento2.Category{Name: "Run Category", ParentID: 2, CustomAttributes: []magento2.CustomAttributes{{AttributeCode: "description", Value: "Bags"}}}
+	if _, err := magento2.CreateCategory(category, client); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(bodies["POST /categories"], `{"attribute_code":"description","value":"Bags m2rest-run:ci-42"}`) {
+		t.Errorf("expected category description to be stamped, got %s", bodies["POST /categories"])
+	}
+	if category.CustomAttributes[0].Value != "Bags" {
+		t.Errorf("expected the caller's category to be left unstamped, got %v", category.CustomAttributes)
+	}
+
+	order := &magento2.MOrder{Route: "/orders/2", Order: &magento2.Order{EntityID: 2}, APIClient: client}
+	if err := order.TagRun(context.Background(), client.RunID()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(bodies["POST /orders/2/comments"], `"comment":"m2rest-run:ci-42"`) {
+		t.Errorf("expected run comment, got %s", bodies["POST /orders/2/comments"])
+	}
+
+	entities, err := magento2.FindRunEntities(context.Background(), "ci-42", time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC), client)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(entities.Products) != 1 || len(entities.Categories) != 1 || len(entities.Orders) != 1 || entities.Orders[0].EntityID != 2 {
+		t.Errorf("unexpected run entities: %+v", entities)
+	}
+}
@@ -0,0 +1,70 @@
+package magento2
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	magento2 "github.com/florinel-chis/go-m2rest"
+)
+
+func TestOrderAppliedRuleIDs(t *testing.T) {
+	order := &magento2.Order{AppliedRuleIds: "3, 12,,x"}
+	ids := order.AppliedRuleIDs()
+	if len(ids) != 2 || ids[0] != 3 || ids[1] != 12 {
+		t.Errorf("expected rules 3 and 12, got %v", ids)
+	}
+	if order.HasCoupon() {
+		t.Error("expected no coupon")
+	}
+}
+
+func TestReportPromotions(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/rest/default/V1/salesRules/search" {
+			t.Errorf("unexpected request: %s", r.URL)
+		}
+		if !strings.Contains(r.URL.RawQuery, "3%2C12%2C40") {
+			t.Errorf("expected a search for rules 3, 12 and 40, got %s", r.URL.RawQuery)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"items":[{"rule_id":3,"name":"Summer Sale","is_active":true,"coupon_type":"SPECIFIC_COUPON"},{"rule_id":12,"name":"Free Shipping over 50","is_active":true,"coupon_type":"NO_COUPON"}],"total_count":2}`))
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := magento2.NewClient(context.Background(),
+		magento2.WithStoreURL(server.URL, "default"),
+		magento2.WithBearerToken("token"),
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	orders := []magento2.Order{
+		{EntityID: 1, AppliedRuleIds: "3,12", CouponCode: "SUMMER", DiscountAmount: -10, GrandTotal: 90},
+		{EntityID: 2, AppliedRuleIds: "3", CouponCode: "SUMMER", DiscountAmount: -5.5, GrandTotal: 44.5},
+		{EntityID: 3, AppliedRuleIds: "40", DiscountAmount: -1, GrandTotal: 9},
+		{EntityID: 4, GrandTotal: 20},
+	}
+	report, err := magento2.ReportPromotions(context.Background(), orders, client)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(report) != 3 {
+		t.Fatalf("expected 3 rules, got %+v", report)
+	}
+	summer := report[0]
+	if summer.RuleID != 3 || summer.RuleName != "Summer Sale" || summer.Orders != 2 || summer.CouponCodes["SUMMER"] != 2 ||
+		summer.DiscountAmount.String() != "15.5" || summer.GrandTotal.String() != "134.5" {
+		t.Errorf("unexpected usage of rule 3: %+v", summer)
+	}
+	if report[1].RuleID != 12 || report[1].RuleName != "Free Shipping over 50" || len(report[1].CouponCodes) != 0 {
+		t.Errorf("unexpected usage of rule 12: %+v", report[1])
+	}
+	if report[2].RuleID != 40 || report[2].RuleName != "" {
+		t.Errorf("expected the deleted rule 40 without name, got %+v", report[2])
+	}
+}
@@ -0,0 +1,70 @@
+package magento2
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	magento2 "github.com/florinel-chis/go-m2rest"
+)
+
+type schemaTestBrand struct {
+	ID        int    `json:"id"`
+	Name      string `json:"name"`
+	URLKey    string `json:"urlKey,omitempty"`
+	Logo      string `json:"logo_image"`
+	Internal  string `json:"-"`
+	SortOrder int    `json:"sort_order"`
+}
+
+func TestSchema_CheckDrift(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/rest/de/schema" || r.URL.Query().Get("services") != "all" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"swagger":"2.0","info":{"version":"2.4","title":"Magento Enterprise"},"definitions":{
+			"brand-data-brand-interface":{"type":"object","required":["id","name","store_ids"],"properties":{
+				"id":{"type":"integer"},"name":{"type":"string"},"url_key":{"type":"string"},
+				"logo":{"type":"string"},"sort_order":{"type":"integer"},
+				"store_ids":{"type":"array","items":{"type":"integer"}}}}}}`))
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := magento2.NewClient(context.Background(),
+		magento2.WithStoreURL(server.URL, "default"),
+		magento2.WithBearerToken("token"),
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	schema, err := client.GetSchema(context.Background(), magento2.WithStoreCode("de"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if schema.Info.Version != "2.4" || schema.Definitions["brand-data-brand-interface"].Properties["store_ids"].Items.Type != "integer" {
+		t.Errorf("unexpected schema: %+v", schema)
+	}
+
+	drifts := schema.CheckDrift(map[string]any{
+		"brand-data-brand-interface": &schemaTestBrand{},
+		"brand-data-missing":         schemaTestBrand{},
+	})
+	expected := []magento2.SchemaDrift{
+		{Definition: "brand-data-brand-interface", Type: "schemaTestBrand", Field: "urlKey", Kind: magento2.SchemaDriftMissingField, Suggestion: "url_key"},
+		{Definition: "brand-data-brand-interface", Type: "schemaTestBrand", Field: "logo_image", Kind: magento2.SchemaDriftMissingField},
+		{Definition: "brand-data-brand-interface", Type: "schemaTestBrand", Field: "store_ids", Kind: magento2.SchemaDriftUnmappedField},
+		{Definition: "brand-data-missing", Type: "schemaTestBrand", Kind: magento2.SchemaDriftMissingDefinition},
+	}
+	if len(drifts) != len(expected) {
+		t.Fatalf("expected %d drifts, got: %+v", len(expected), drifts)
+	}
+	for i := range expected {
+		if drifts[i] != expected[i] {
+			t.Errorf("drift %d: expected %+v, got %+v", i, expected[i], drifts[i])
+		}
+	}
+}
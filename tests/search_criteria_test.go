@@ -0,0 +1,51 @@
+package magento2
+
+import (
+	"net/url"
+	"testing"
+
+	magento2 "github.com/florinel-chis/go-m2rest"
+)
+
+func TestSearchCriteria_Query(t *testing.T) {
+	criteria := magento2.NewSearchCriteria(magento2.SearchFilter{Field: "status", Value: "pending"})
+	criteria.And(
+		magento2.SearchFilter{Field: "customer_email", Value: "a@example.com"},
+		magento2.SearchFilter{Field: "customer_id", Value: "7"},
+	)
+	criteria.SortOrders = []magento2.SortOrder{{Field: "created_at", Direction: magento2.SortDescending}}
+	criteria.PageSize = 20
+
+	values, err := url.ParseQuery(criteria.Query())
+	if err != nil {
+		t.Fatalf("query does not parse: %v", err)
+	}
+
+	expected := map[string]string{
+		"searchCriteria[filter_groups][0][filters][0][field]":          "status",
+		"searchCriteria[filter_groups][0][filters][0][condition_type]": "eq",
+		"searchCriteria[filter_groups][1][filters][1][field]":          "customer_id",
+		"searchCriteria[filter_groups][1][filters][1][value]":          "7",
+		"searchCriteria[sortOrders][0][direction]":                     "DESC",
+		"searchCriteria[pageSize]":                                     "20",
+	}
+	for key, value := range expected {
+		if got := values.Get(key); got != value {
+			t.Errorf("%s: expected %q, got %q", key, value, got)
+		}
+	}
+}
+
+func TestSearchCriteria_CloneDoesNotShareGroups(t *testing.T) {
+	criteria := magento2.NewSearchCriteria(magento2.SearchFilter{Field: "status", Value: "pending"})
+	clone := criteria.Clone().And(magento2.SearchFilter{Field: "customer_id", Value: "1"})
+
+	if len(criteria.FilterGroups) != 1 || len(clone.FilterGroups) != 2 {
+		t.Fatalf("expected clone to be independent, got %d and %d groups", len(criteria.FilterGroups), len(clone.FilterGroups))
+	}
+
+	var empty *magento2.SearchCriteria
+	if values, _ := url.ParseQuery(empty.Clone().Query()); !values.Has("searchCriteria") {
+		t.Errorf("expected empty criteria to still send searchCriteria")
+	}
+}
@@ -0,0 +1,61 @@
+package magento2
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	magento2 "github.com/florinel-chis/go-m2rest"
+)
+
+func TestSearch(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		if r.URL.Path != "/rest/default/V1/search" || query.Get("searchCriteria[requestName]") != magento2.SearchRequestQuick ||
+			query.Get("searchCriteria[filter_groups][0][filters][0][field]") != "search_term" ||
+			query.Get("searchCriteria[filter_groups][0][filters][0][value]") != "duffle bag" ||
+			query.Get("searchCriteria[page_size]") != "10" || query.Get("searchCriteria[current_page]") != "" {
+			t.Errorf("unexpected request: %s", r.URL)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"items":[
+			{"id":1,"custom_attributes":[{"attribute_code":"score","value":"12.5"}]},
+			{"id":7,"custom_attributes":[{"attribute_code":"score","value":3}]},
+			{"id":9}],
+		"aggregations":{"buckets":[
+			{"name":"price_bucket","values":[{"value":"0_50","metrics":["0_50","2"]},{"value":"50_100","metrics":["50_100",1]}]},
+			{"name":"color_bucket","values":[]}],
+		"bucket_names":["price_bucket","color_bucket"]},
+		"total_count":3}`))
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := magento2.NewClient(context.Background(), magento2.WithStoreURL(server.URL, "default"), magento2.WithBearerToken("token"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	result, err := magento2.Search(context.Background(), &magento2.SearchRequest{
+		RequestName: magento2.SearchRequestQuick,
+		Filters:     []magento2.SearchFilter{{Field: "search_term", Value: "duffle bag"}},
+		PageSize:    10,
+	}, client)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if result.TotalCount != 3 || len(result.Items) != 3 {
+		t.Fatalf("unexpected result %+v", result)
+	}
+	if result.Items[0].Score() != 12.5 || result.Items[1].Score() != 3 || result.Items[2].Score() != 0 {
+		t.Errorf("unexpected scores %v, %v, %v", result.Items[0].Score(), result.Items[1].Score(), result.Items[2].Score())
+	}
+	price, ok := result.Aggregations.Bucket("price_bucket")
+	if !ok || len(price.Values) != 2 || price.Values[0].Count() != 2 || price.Values[1].Count() != 1 {
+		t.Errorf("unexpected price bucket %+v", price)
+	}
+	if _, ok := result.Aggregations.Bucket("size_bucket"); ok {
+		t.Error("expected no size bucket")
+	}
+}
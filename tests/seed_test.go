@@ -0,0 +1,219 @@
+package magento2
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	magento2 "github.com/florinel-chis/go-m2rest"
+	"github.com/florinel-chis/go-m2rest/seed"
+)
+
+// fakeSeedStore answers the requests of seed.Run like a fresh Magento installation and records them
+type fakeSeedStore struct {
+	mu    sync.Mutex
+	calls []string
+}
+
+func (s *fakeSeedStore) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	raw, _ := io.ReadAll(r.Body)
+	path := strings.TrimPrefix(r.URL.Path, "/rest/default/V1")
+	s.mu.Lock()
+	s.calls = append(s.calls, r.Method+" "+path)
+	s.mu.Unlock()
+
+	w.Header().Set("Content-Type", "application/json")
+	write := func(body string) { _, _ = w.Write([]byte(body)) }
+	switch {
+	case path == "/products/attribute-sets/sets/list":
+		write(`{"items":[{"attribute_set_id":4,"attribute_set_name":"Default","entity_type_id":4}]}`)
+	case path == "/products/attribute-sets" || path == "/products/attribute-sets/9":
+		write(`{"attribute_set_id":9,"attribute_set_name":"seed Set","entity_type_id":4}`)
+	case path == "/products/attribute-sets/groups/list":
+		write(`{"items":[{"attribute_group_id":"20","attribute_group_name":"General","attribute_set_id":9}]}`)
+	case path == "/products/attribute-sets/9/attributes":
+		write(`[]`)
+	case path == "/products/attribute-sets/attributes":
+		write(`"150"`)
+	case r.Method == http.MethodPost && path == "/products/attributes":
+		var payload struct {
+			Attribute map[string]any `json:"attribute"`
+		}
+		_ = json.Unmarshal(raw, &payload)
+		payload.Attribute["attribute_id"] = 150
+		body, _ := json.Marshal(payload.Attribute)
+		_, _ = w.Write(body)
+	case path == "/products/attributes/seed_color":
+		write(`{"attribute_id":150,"attribute_code":"seed_color","frontend_input":"select","default_frontend_label":"seed Color",` +
+			`"options":[{"label":" ","value":""},{"label":"Red","value":"11"},{"label":"Blue","value":"12"}]}`)
+	case path == "/categories":
+		if strings.Contains(string(raw), `"parent_id":2`) {
+			write(`{"id":40,"parent_id":2,"name":"seed Gear"}`)
+		} else {
+			write(`{"id":41,"parent_id":40,"name":"seed Bags"}`)
+		}
+	case path == "/products" || strings.HasPrefix(path, "/products/seed-"):
+		var payload struct {
+			Product map[string]any `json:"product"`
+		}
+		_ = json.Unmarshal(raw, &payload)
+		if payload.Product == nil {
+			payload.Product = map[string]any{"sku": strings.Split(strings.TrimPrefix(path, "/products/"), "/")[0]}
+		}
+		body, _ := json.Marshal(payload.Product)
+		_, _ = w.Write(body)
+	case strings.HasPrefix(path, "/configurable-products/seed-bag/options/all"):
+		write(`[{"id":1,"attribute_id":"150","label":"seed Color","values":[{"value_index":11},{"value_index":12}]}]`)
+	case strings.HasPrefix(path, "/configurable-products/"):
+		write(`true`)
+	case path == "/customers":
+		write(`{"id":7,"email":"seed_customer@example.com","store_id":1}`)
+	case path == "/guest-carts":
+		write(`"quote-1"`)
+	case path == "/guest-carts/quote-1":
+		if r.Method == http.MethodPut {
+			write(`true`)
+		} else {
+			write(`{"id":1,"items":[]}`)
+		}
+	case path == "/guest-carts/quote-1/items":
+		write(`{"item_id":1,"sku":"seed-strap","qty":2}`)
+	case path == "/integration/customer/token":
+		write(`"customer-token"`)
+	case path == "/customers/me":
+		write(`{"id":7,"email":"seed_customer@example.com","store_id":1}`)
+	case path == "/carts/mine":
+		write(`{"id":1,"items":[{"item_id":1,"sku":"seed-strap","qty":2}]}`)
+	case path == "/carts/mine/shipping-information":
+		write(`{}`)
+	case path == "/carts/mine/order":
+		write(`"42"`)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+		write(`{"message":"unexpected request"}`)
+	}
+}
+
+func TestSeed_Run(t *testing.T) {
+	store := &fakeSeedStore{}
+	server := httptest.NewServer(store)
+	t.Cleanup(server.Close)
+
+	client, err := magento2.NewClient(context.Background(),
+		magento2.WithStoreURL(server.URL, "default"),
+		magento2.WithBearerToken("admin-token"),
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	dataset, err := seed.Run(context.Background(), client, seed.Options{Prefix: "seed"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v\ncalls: %s", err, strings.Join(store.calls, "\n"))
+	}
+
+	if dataset.AttributeSet.AttributeSet.AttributeSetID != 9 || len(dataset.Attributes) != 2 || len(dataset.Categories) != 2 {
+		t.Errorf("unexpected catalog structure: %+v", dataset)
+	}
+	if len(dataset.SimpleProducts) != 3 || dataset.Configurable.Product.Sku != "seed-bag" || dataset.Bundle.Product.Sku != "seed-kit" {
+		t.Errorf("unexpected products: %+v", dataset)
+	}
+	if dataset.Customer.ID != 7 || dataset.Order.Order.EntityID != 42 {
+		t.Errorf("unexpected customer or order: %+v %+v", dataset.Customer, dataset.Order)
+	}
+}
+
+func TestSeed_Cleanup(t *testing.T) {
+	var mu sync.Mutex
+	var deletes []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path := strings.TrimPrefix(r.URL.Path, "/rest/default/V1")
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method == http.MethodDelete {
+			mu.Lock()
+			deletes = append(deletes, path)
+			mu.Unlock()
+			if path == "/categories/41" {
+				// deleted together with its parent
+				w.WriteHeader(http.StatusNotFound)
+				_, _ = w.Write([]byte(`{"message":"No such entity with id = 41"}`))
+				return
+			}
+			_, _ = w.Write([]byte(`true`))
+			return
+		}
+
+		value := r.URL.Query().Get("searchCriteria[filter_groups][0][filters][0][value]")
+		createdBefore := r.URL.Query().Get("searchCriteria[filter_groups][1][filters][0][value]")
+		if r.URL.Query().Get("searchCriteria[filter_groups][0][filters][0][condition_type]") != "like" {
+			t.Errorf("expected like condition for %s", path)
+		}
+		switch path {
+		case "/products":
+			if value != "seed-%" || createdBefore != "2026-01-02 03:04:05" {
+				t.Errorf("unexpected product filter %q, %q", value, createdBefore)
+			}
+			_, _ = w.Write([]byte(`{"items":[{"sku":"seed-strap","type_id":"simple"},{"sku":"seed-bag","type_id":"configurable"},{"sku":"seed-kit","type_id":"bundle"}],"total_count":3}`))
+		case "/customers/search":
+			_, _ = w.Write([]byte(`{"items":[{"id":7,"email":"seed_customer@example.com"}],"total_count":1}`))
+		case "/categories/list":
+			_, _ = w.Write([]byte(`{"items":[{"id":40,"name":"seed Gear","level":2},{"id":41,"name":"seed Bags","level":3}],"total_count":2}`))
+		case "/products/attributes":
+			if value != "seed_%" {
+				t.Errorf("unexpected attribute filter %q", value)
+			}
+			_, _ = w.Write([]byte(`{"items":[{"attribute_code":"seed_color"}],"total_count":1}`))
+		case "/products/attribute-sets/sets/list":
+			_, _ = w.Write([]byte(`{"items":[{"attribute_set_id":9,"attribute_set_name":"seed Set"}],"total_count":1}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := magento2.NewClient(context.Background(),
+		magento2.WithStoreURL(server.URL, "default"),
+		magento2.WithBearerToken("admin-token"),
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, err := seed.Cleanup(context.Background(), client, seed.CleanupOptions{Pattern: "*"}); err == nil {
+		t.Errorf("expected an error for a pattern matching everything")
+	}
+
+	opts := seed.CleanupOptions{Pattern: "seed-*", CreatedBefore: time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC), DryRun: true}
+	report, err := seed.Cleanup(context.Background(), client, opts)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(deletes) != 0 || len(report.Products) != 3 {
+		t.Errorf("expected a dry run to only report, got deletes %v and report %+v", deletes, report)
+	}
+
+	opts.DryRun = false
+	report, err = seed.Cleanup(context.Background(), client, opts)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := []string{
+		"/products/seed-bag", "/products/seed-kit", "/products/seed-strap",
+		"/customers/7",
+		"/categories/41", "/categories/40",
+		"/products/attributes/seed_color",
+		"/products/attribute-sets/9",
+	}
+	if strings.Join(deletes, ",") != strings.Join(expected, ",") {
+		t.Errorf("unexpected deletion order:\n%v\nexpected:\n%v", deletes, expected)
+	}
+	if len(report.Categories) != 2 || report.Attributes[0] != "seed_color" || report.AttributeSets[0] != 9 {
+		t.Errorf("unexpected report: %+v", report)
+	}
+}
@@ -0,0 +1,152 @@
+package magento2
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	magento2 "github.com/florinel-chis/go-m2rest"
+)
+
+func TestSEOTemplate_Render(t *testing.T) {
+	template, err := magento2.ParseSEOTemplate("{name} | {brand}")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	values := map[string]string{"name": "Tee"}
+	if got := template.Render(func(code string) string { return values[code] }); got != "Tee" {
+		t.Errorf("expected dangling separator to be trimmed, got %q", got)
+	}
+	values["brand"] = "Acme"
+	if got := template.Render(func(code string) string { return values[code] }); got != "Tee | Acme" {
+		t.Errorf("unexpected render %q", got)
+	}
+
+	for _, invalid := range []string{"{name", "name}", "{}", "{na{me}"} {
+		if _, err := magento2.ParseSEOTemplate(invalid); !errors.Is(err, magento2.ErrValidation) {
+			t.Errorf("expected validation error for %q, got %v", invalid, err)
+		}
+	}
+
+	if got := magento2.FormatURLKey("  Blue Tee & Co. -- XL "); got != "blue-tee-co-xl" {
+		t.Errorf("unexpected url key %q", got)
+	}
+}
+
+func TestPlanProductSEO_FillsEmptyFieldsAndEnqueues(t *testing.T) {
+	var (
+		mu      sync.Mutex
+		written = map[string]string{}
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.URL.Path == "/rest/default/V1/products/attributes/brand":
+			_, _ = w.Write([]byte(`{"attribute_code":"brand","frontend_input":"select","options":[{"label":"Acme","value":"7"}]}`))
+		case r.URL.Path == "/rest/de/V1/products" && r.Method == http.MethodGet:
+			_, _ = w.Write([]byte(`{"items":[
+				{"sku":"tee","name":"Blue Tee","custom_attributes":[{"attribute_code":"brand","value":"7"}]},
+				{"sku":"mug","name":"Mug","custom_attributes":[{"attribute_code":"meta_title","value":"Kept"},{"attribute_code":"url_key","value":"mug"}]}
+			],"total_count":2}`))
+		case strings.HasPrefix(r.URL.Path, "/rest/de/V1/products/") && r.Method == http.MethodPut:
+			body, _ := io.ReadAll(r.Body)
+			mu.Lock()
+			written[strings.TrimPrefix(r.URL.Path, "/rest/de/V1/products/")] = string(body)
+			mu.Unlock()
+			_, _ = w.Write([]byte(`{}`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	ctx := context.Background()
+	client, err := magento2.NewClient(ctx, magento2.WithStoreURL(server.URL, "default"), magento2.WithBearerToken("token"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	plan, err := magento2.PlanProductSEO(ctx, nil, magento2.SEOTemplates{
+		MetaTitle: "{name} | {brand}",
+		URLKey:    "{name}",
+	}, magento2.SEOUpdateOptions{StoreCode: "de"}, client)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []magento2.SEOChange{
+		{Sku: "tee", StoreCode: "de", Field: "meta_title", New: "Blue Tee | Acme"},
+		{Sku: "tee", StoreCode: "de", Field: "url_key", New: "blue-tee"},
+	}
+	if len(plan.Changes) != len(want) {
+		t.Fatalf("expected %d changes, got %v", len(want), plan.Changes)
+	}
+	for i := range want {
+		if plan.Changes[i] != want[i] {
+			t.Errorf("change %d: expected %+v, got %+v", i, want[i], plan.Changes[i])
+		}
+	}
+
+	var results sync.WaitGroup
+	queue := magento2.NewWriteQueue(client, magento2.WriteQueueConfig{
+		OnResult: func(op *magento2.WriteOperation, err error) {
+			if err != nil {
+				t.Errorf("unexpected error for %s: %v", op.Sku, err)
+			}
+			results.Done()
+		},
+	})
+	if err := queue.Start(ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	results.Add(1)
+	enqueued, err := plan.Enqueue(queue)
+	if err != nil || enqueued != 1 {
+		t.Fatalf("expected one operation, got %d, %v", enqueued, err)
+	}
+	results.Wait()
+	if err := queue.Close(ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	body := written["tee"]
+	if !strings.Contains(body, `"attribute_code":"meta_title","value":"Blue Tee | Acme"`) || !strings.Contains(body, `"attribute_code":"url_key","value":"blue-tee"`) {
+		t.Errorf("unexpected payload %s", body)
+	}
+}
+
+func TestPlanCategorySEO_WritesMetaKeywords(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path != "/rest/default/V1/categories/list" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(`{"items":[{"id":5,"name":"Shirts","custom_attributes":[{"attribute_code":"meta_keywords","value":"old"}]}],"total_count":1}`))
+	}))
+	t.Cleanup(server.Close)
+
+	ctx := context.Background()
+	client, err := magento2.NewClient(ctx, magento2.WithStoreURL(server.URL, "default"), magento2.WithBearerToken("token"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	plan, err := magento2.PlanCategorySEO(ctx, nil, magento2.SEOTemplates{MetaKeyword: "{name}, shop"}, magento2.SEOUpdateOptions{Overwrite: true}, client)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(plan.Changes) != 1 {
+		t.Fatalf("expected one change, got %v", plan.Changes)
+	}
+	change := plan.Changes[0]
+	if change.CategoryID != 5 || change.Field != "meta_keywords" || change.Old != "old" || change.New != "Shirts, shop" {
+		t.Errorf("unexpected change %+v", change)
+	}
+}
@@ -0,0 +1,37 @@
+package magento2
+
+import (
+	"errors"
+	"testing"
+
+	magento2 "github.com/florinel-chis/go-m2rest"
+)
+
+func TestPackShipments(t *testing.T) {
+	order := &magento2.Order{Items: []magento2.Item{
+		{ItemID: 1, Sku: "24-MB01", Weight: 1, QtyOrdered: 2},
+		{ItemID: 2, Sku: "24-WG080", Weight: 4, QtyOrdered: 4, QtyShipped: 1},
+		{ItemID: 3, ParentItemID: 2, Sku: "24-WG081", Weight: 4, QtyOrdered: 4},
+	}}
+	boxes := []magento2.Box{{Name: "small", MaxWeight: 10}}
+	template := magento2.ShipmentRequest{Notify: true}
+
+	packages, err := magento2.PackShipments(order, boxes, template)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(packages) != 2 {
+		t.Fatalf("expected 2 packages, got %d: %+v", len(packages), packages)
+	}
+	if packages[0].Weight != 10 || len(packages[0].Request.Items) != 2 || !packages[0].Request.Notify {
+		t.Errorf("unexpected first package: %+v %+v", packages[0], packages[0].Request)
+	}
+	if packages[1].Weight != 4 || packages[1].Request.Items[0] != (magento2.ShipmentItem{OrderItemID: 2, Qty: 1}) {
+		t.Errorf("unexpected second package: %+v %+v", packages[1], packages[1].Request)
+	}
+
+	order.Items[0].Weight = 12
+	if _, err := magento2.PackShipments(order, boxes, template); !errors.Is(err, magento2.ErrItemDoesNotFit) {
+		t.Errorf("expected ErrItemDoesNotFit, got: %v", err)
+	}
+}
@@ -0,0 +1,69 @@
+package magento2
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	magento2 "github.com/florinel-chis/go-m2rest"
+)
+
+func TestMShipment_TrackingLinks(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/rest/default/V1/shipment/12" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"entity_id":12,"order_id":5,"tracks":[
+			{"carrier_code":"ups","title":"United Parcel Service","track_number":"1Z 999"},
+			{"carrier_code":"dpd","title":"DPD","track_number":"0123"},
+			{"carrier_code":"custom","title":"Courier","track_number":"ABC"},
+			{"carrier_code":"gls","title":"GLS","track_number":"AB 12/3"}
+		]}`))
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := magento2.NewClient(context.Background(), magento2.WithStoreURL(server.URL, "default"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	mShipment, err := magento2.GetShipmentByID(context.Background(), 12, client)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for carrierCode, template := range map[string]string{
+		"DPD": "https://tracking.dpd.de/status/en_US/parcel/%s",
+		"gls": "https://gls-group.eu/track/%s?lang=en%20US",
+	} {
+		if err := magento2.RegisterTrackingURL(carrierCode, template); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		t.Cleanup(func() { magento2.UnregisterTrackingURL(carrierCode) })
+	}
+	for _, template := range []string{"https://example.com/track", "https://example.com/%s/%s", "https://example.com/%d"} {
+		if err := magento2.RegisterTrackingURL("custom", template); !errors.Is(err, magento2.ErrInvalidTrackingURL) {
+			t.Errorf("expected %q to be rejected, got %v", template, err)
+		}
+	}
+
+	links := mShipment.TrackingLinks()
+	if len(links) != 4 {
+		t.Fatalf("expected 4 links, got %d", len(links))
+	}
+	if links[0].URL != "https://www.ups.com/track?tracknum=1Z+999" {
+		t.Errorf("unexpected UPS link: %s", links[0].URL)
+	}
+	if links[1].URL != "https://tracking.dpd.de/status/en_US/parcel/0123" {
+		t.Errorf("unexpected registered link: %s", links[1].URL)
+	}
+	if links[2].URL != "" || links[2].TrackNumber != "ABC" {
+		t.Errorf("expected no link for an unknown carrier, got %+v", links[2])
+	}
+	if links[3].URL != "https://gls-group.eu/track/AB%2012%2F3?lang=en%20US" {
+		t.Errorf("expected a path escaped number, got %s", links[3].URL)
+	}
+}
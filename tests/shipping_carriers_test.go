@@ -0,0 +1,132 @@
+package magento2
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	magento2 "github.com/florinel-chis/go-m2rest"
+)
+
+func TestShippingCarrierRegistry(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/rest/default/V1/guest-carts/abc/estimate-shipping-methods" {
+			t.Errorf("unexpected request: %s", r.URL)
+		}
+		var payLoad struct {
+			Address magento2.ShippingAddress `json:"address"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&payLoad)
+
+		w.Header().Set("Content-Type", "application/json")
+		if payLoad.Address.CountryID == "US" {
+			_, _ = w.Write([]byte(`[{"carrier_code":"flatrate","method_code":"flatrate","carrier_title":"Flat Rate","method_title":"Fixed","amount":5,"price_incl_tax":5,"available":true},` +
+				`{"carrier_code":"ups","method_code":"GND","carrier_title":"UPS","method_title":"Ground","amount":12.5,"price_incl_tax":12.5,"available":true}]`))
+			return
+		}
+		_, _ = w.Write([]byte(`[{"carrier_code":"flatrate","method_code":"flatrate","carrier_title":"Flat Rate","method_title":"Fixed","amount":15,"price_incl_tax":15,"available":true},` +
+			`{"carrier_code":"ups","method_code":"","carrier_title":"UPS","method_title":"","available":false,"error_message":"This shipping method is not available."}]`))
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := magento2.NewClient(context.Background(),
+		magento2.WithStoreURL(server.URL, "default"),
+		magento2.WithBearerToken("token"),
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	registry := magento2.NewShippingCarrierRegistry()
+	cart := &magento2.MCart{Route: "/guest-carts/abc", APIClient: client}
+	us := &magento2.ShippingAddress{Address: magento2.Address{CountryID: "US", RegionCode: "TX", Postcode: "78701"}}
+	de := &magento2.ShippingAddress{Address: magento2.Address{CountryID: "DE", Postcode: "10115"}}
+	for _, addr := range []*magento2.ShippingAddress{us, de} {
+		if _, err := registry.Estimate(cart, addr); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	registry.Register("default", "tablerate", "", "bestway", "Table Rate")
+
+	carriers := registry.Carriers("default")
+	if len(carriers) != 3 || carriers[0].Code != "flatrate" || carriers[2].Code != "ups" {
+		t.Fatalf("unexpected carriers: %+v", carriers)
+	}
+
+	rate, ok := registry.Rate("default", "flatrate", "flatrate", magento2.NewShippingDestination(de))
+	if !ok || rate.Amount.String() != "15" || !rate.Available {
+		t.Errorf("unexpected flat rate to DE: %+v", rate)
+	}
+	rate, ok = registry.Rate("default", "ups", "", magento2.NewShippingDestination(de))
+	if !ok || rate.Available || rate.ErrorMessage == "" {
+		t.Errorf("expected UPS to be unavailable for DE, got %+v", rate)
+	}
+	if _, ok := registry.Rate("default", "ups", "GND", magento2.NewShippingDestination(de)); ok {
+		t.Error("expected no UPS ground rate for DE")
+	}
+
+	if title := registry.CarrierTitle("default", "ups"); title != "UPS" {
+		t.Errorf("expected the estimated title, got %q", title)
+	}
+	if title := registry.CarrierTitle("default", "tablerate"); title != "Best Way" {
+		t.Errorf("expected the default title, got %q", title)
+	}
+}
+
+func TestShippingCarrierRegistry_RecordReplacesTheDestinationsRates(t *testing.T) {
+	registry := magento2.NewShippingCarrierRegistry()
+	us := magento2.ShippingDestination{CountryID: "US", Postcode: "78701"}
+	de := magento2.ShippingDestination{CountryID: "DE", Postcode: "10115"}
+	flatRate := magento2.Carrier{CarrierCode: "flatrate", MethodCode: "flatrate", Amount: 5, Available: true}
+	ground := magento2.Carrier{CarrierCode: "ups", MethodCode: "GND", Amount: 12.5, Available: true}
+
+	registry.Record("default", us, []magento2.Carrier{flatRate, ground})
+	registry.Record("default", de, []magento2.Carrier{ground})
+	registry.Record("default", us, []magento2.Carrier{flatRate})
+
+	if _, ok := registry.Rate("default", "ups", "GND", us); ok {
+		t.Error("expected the stale UPS rate for US to be dropped")
+	}
+	if _, ok := registry.Rate("default", "flatrate", "flatrate", us); !ok {
+		t.Error("expected the flat rate for US")
+	}
+	if _, ok := registry.Rate("default", "ups", "GND", de); !ok {
+		t.Error("expected the UPS rate for DE to be kept")
+	}
+}
+
+func TestShippingCarrierRegistry_RegisterConfig(t *testing.T) {
+	config, err := magento2.ReadConfigShow(strings.NewReader(`carriers/flatrate/active - 1
+carriers/flatrate/title - Flat Rate
+carriers/flatrate/name - Fixed
+carriers/tablerate/active - 1
+carriers/tablerate/title - Best Way
+carriers/freeshipping/active - 0
+carriers/ups/active - 1
+carriers/ups/title - UPS
+carriers/ups/allowed_methods - GND,1DA
+`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	registry := magento2.NewShippingCarrierRegistry()
+	registry.RegisterConfig("default", config)
+
+	carriers := registry.Carriers("default")
+	if len(carriers) != 3 || carriers[0].Code != "flatrate" || carriers[1].Code != "tablerate" || carriers[2].Code != "ups" {
+		t.Fatalf("expected the active carriers, got %+v", carriers)
+	}
+	if method := carriers[0].Methods["flatrate"]; method == nil || method.Title != "Fixed" || carriers[0].Title != "Flat Rate" {
+		t.Errorf("unexpected flat rate carrier: %+v", carriers[0])
+	}
+	if carriers[1].Methods["bestway"] == nil {
+		t.Errorf("expected the table rate method bestway, got %+v", carriers[1].Methods)
+	}
+	if len(carriers[2].Methods) != 2 || carriers[2].Methods["1DA"] == nil {
+		t.Errorf("expected the allowed UPS methods, got %+v", carriers[2].Methods)
+	}
+}
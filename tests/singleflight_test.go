@@ -0,0 +1,80 @@
+package magento2
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	magento2 "github.com/florinel-chis/go-m2rest"
+)
+
+func TestSingleflight(t *testing.T) {
+	var productRequests, attributeRequests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(30 * time.Millisecond)
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/rest/default/V1/products/24-MB01":
+			productRequests.Add(1)
+			_, _ = w.Write([]byte(`{"sku":"24-MB01","name":"Joust Duffle Bag"}`))
+		case "/rest/default/V1/products/attributes/color":
+			attributeRequests.Add(1)
+			_, _ = w.Write([]byte(`{"attribute_code":"color"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := magento2.NewClient(context.Background(),
+		magento2.WithStoreURL(server.URL, "default"),
+		magento2.WithBearerToken("token"),
+		magento2.WithSingleflight("/products/*"),
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	clone, err := client.Clone()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, c := range []*magento2.Client{client, clone} {
+		productRequests.Store(0)
+		attributeRequests.Store(0)
+		var wg sync.WaitGroup
+		for i := 0; i < 8; i++ {
+			wg.Add(2)
+			go func() {
+				defer wg.Done()
+				product, err := magento2.GetProductBySKU("24-MB01", c)
+				if err != nil || product.Product.Name != "Joust Duffle Bag" {
+					t.Errorf("unexpected product %+v, error: %v", product, err)
+				}
+			}()
+			go func() {
+				defer wg.Done()
+				if _, err := magento2.NewAttributeCache(c).Get(context.Background(), "color"); err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+			}()
+		}
+		wg.Wait()
+
+		if got := productRequests.Load(); got != 1 {
+			t.Errorf("expected identical product GETs to be collapsed, got %d requests", got)
+		}
+		// "/products/*" doesn't match the nested attribute route
+		if got := attributeRequests.Load(); got != 8 {
+			t.Errorf("expected attribute GETs not to be collapsed, got %d requests", got)
+		}
+	}
+
+	if _, err := magento2.NewClient(context.Background(), magento2.WithStoreURL(server.URL, "default"), magento2.WithSingleflight("[")); err == nil {
+		t.Errorf("expected an error for an invalid pattern")
+	}
+}
@@ -0,0 +1,77 @@
+package magento2
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	magento2 "github.com/florinel-chis/go-m2rest"
+)
+
+func TestNormalizeSku(t *testing.T) {
+	cases := map[string]string{
+		"  24-MB01 ":            "24-MB01",
+		"Bag\t\tBlue  L":        "Bag Blue L",
+		"shirt/red?#1":          "shirt-red--1",
+		strings.Repeat("a", 70): strings.Repeat("a", 64),
+	}
+	for input, expected := range cases {
+		normalized := magento2.NormalizeSku(input)
+		if normalized != expected {
+			t.Errorf("NormalizeSku(%q) = %q, expected %q", input, normalized, expected)
+		}
+		if err := magento2.ValidateSku(normalized); err != nil {
+			t.Errorf("expected normalized SKU %q to be valid, got: %v", normalized, err)
+		}
+	}
+	if err := magento2.ValidateSku("a/b"); !errors.Is(err, magento2.ErrValidation) {
+		t.Errorf("expected ErrValidation, got: %v", err)
+	}
+	if magento2.SkuKey(" MB01 ") != magento2.SkuKey("mb01") {
+		t.Errorf("expected SKU keys to ignore case and surrounding whitespace")
+	}
+}
+
+func TestFindSkuCollisions(t *testing.T) {
+	var filters []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		filters = append(filters, r.URL.Query().Get("searchCriteria[filter_groups][0][filters][0][value]"))
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"items":[{"sku":"MB01"},{"sku":"wt09"}],"total_count":2}`))
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := magento2.NewClient(context.Background(),
+		magento2.WithStoreURL(server.URL, "default"),
+		magento2.WithBearerToken("token"),
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	collisions, err := magento2.FindSkuCollisions(context.Background(), []string{"mb01", "WT09", "wt09", "Wt09", "new-sku"}, client)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(filters) != 1 || filters[0] != "mb01,MB01,WT09,wt09,new-sku,NEW-SKU" {
+		t.Errorf("expected one search with all case variants, got %q", filters)
+	}
+
+	expected := []magento2.SkuCollision{
+		{Sku: "wt09", CollidesWith: "WT09", Kind: magento2.SkuCollisionBatch},
+		{Sku: "Wt09", CollidesWith: "WT09", Kind: magento2.SkuCollisionBatch},
+		{Sku: "mb01", CollidesWith: "MB01", Kind: magento2.SkuCollisionExisting},
+		{Sku: "WT09", CollidesWith: "wt09", Kind: magento2.SkuCollisionExisting},
+	}
+	if len(collisions) != len(expected) {
+		t.Fatalf("expected %d collisions, got %+v", len(expected), collisions)
+	}
+	for i := range expected {
+		if collisions[i] != expected[i] {
+			t.Errorf("collision %d: expected %+v, got %+v", i, expected[i], collisions[i])
+		}
+	}
+}
@@ -0,0 +1,44 @@
+package magento2
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	magento2 "github.com/florinel-chis/go-m2rest"
+)
+
+func TestStockItem_DecimalQuantities(t *testing.T) {
+	product := &magento2.Product{}
+	if err := json.Unmarshal([]byte(`{"sku":"rope","extension_attributes":{"stock_item":`+
+		`{"item_id":4,"qty":"12.75","is_in_stock":true,"is_qty_decimal":true,"min_sale_qty":0.5,"max_sale_qty":10000}}}`), product); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	stockItem, ok := product.StockItem()
+	if !ok {
+		t.Fatal("expected a stock item with fractional quantities to decode")
+	}
+	if stockItem.Qty != 12 || stockItem.MinSaleQty != 0 || stockItem.MaxSaleQty != 10000 {
+		t.Errorf("expected the int fields to hold the integer parts, got %+v", stockItem)
+	}
+	if stockItem.DecimalQty().String() != "12.75" || stockItem.DecimalMinSaleQty().String() != "0.5" || stockItem.DecimalMaxSaleQty().String() != "10000" {
+		t.Errorf("unexpected decimal quantities %s %s %s", stockItem.DecimalQty(), stockItem.DecimalMinSaleQty(), stockItem.DecimalMaxSaleQty())
+	}
+
+	data, err := json.Marshal(stockItem)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(string(data), `"qty":12.75`) || !strings.Contains(string(data), `"min_sale_qty":0.5`) || strings.Contains(string(data), "min_qty") {
+		t.Errorf("expected the exact quantities to be encoded, got %s", data)
+	}
+
+	stockItem.Qty = 3
+	if data, _ = json.Marshal(stockItem); !strings.Contains(string(data), `"qty":3,`) {
+		t.Errorf("expected an assigned int quantity to win, got %s", data)
+	}
+	stockItem.SetDecimalQty(magento2.NewDecimal(125, 2))
+	if data, _ = json.Marshal(stockItem); stockItem.Qty != 1 || !strings.Contains(string(data), `"qty":1.25,`) {
+		t.Errorf("expected the decimal quantity to be set, got %d %s", stockItem.Qty, data)
+	}
+}
@@ -0,0 +1,61 @@
+package magento2
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"sync"
+	"testing"
+
+	magento2 "github.com/florinel-chis/go-m2rest"
+)
+
+func TestStoreScopedCategoryAndProductRequests(t *testing.T) {
+	var (
+		mu       sync.Mutex
+		requests []string
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/rest/de/V1/products/shirt":
+			_, _ = w.Write([]byte(`{"sku":"shirt","name":"Hemd"}`))
+		default:
+			_, _ = w.Write([]byte(`{"id":12,"name":"Hemden"}`))
+		}
+	}))
+	t.Cleanup(server.Close)
+	client, err := magento2.NewClient(context.Background(), magento2.WithStoreURL(server.URL, "default"), magento2.WithBearerToken("token"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	mC, err := magento2.CreateCategory(&magento2.Category{Name: "Hemden", ParentID: 2}, client, magento2.WithStoreCode("de"))
+	if err != nil || mC.Category.ID != 12 {
+		t.Fatalf("expected category 12, got %+v, %v", mC.Category, err)
+	}
+	if err := mC.UpdateCategoryOnRemote(context.Background(), magento2.WithStoreCode("de")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	mProduct, err := magento2.GetProductBySKU("shirt", client, magento2.WithStoreCode("de"))
+	if err != nil || mProduct.Product.Name != "Hemd" {
+		t.Fatalf("expected the store view name, got %+v, %v", mProduct.Product, err)
+	}
+	if _, err := magento2.GetProductBySKU("shirt", client); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []string{
+		"POST /rest/de/V1/categories",
+		"PUT /rest/de/V1/categories/12",
+		"GET /rest/de/V1/products/shirt",
+		"GET /rest/default/V1/products/shirt",
+	}
+	if !slices.Equal(requests, want) {
+		t.Errorf("expected requests\n%q\ngot\n%q", want, requests)
+	}
+}
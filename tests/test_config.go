@@ -2,7 +2,6 @@ package magento2
 
 import (
 	"fmt"
-	"net/url"
 	"os"
 	"strconv"
 	"strings"
@@ -36,22 +35,24 @@ func DefaultTestConfig() *TestConfig {
 	}
 }
 
-// LoadTestConfigFromEnv loads test configuration from environment variables
+// LoadTestConfigFromEnv loads test configuration from environment variables. Host, token, store code
+// and timeout are parsed by magento2.LoadEnvConfigWithDefaults, with the defaults of DefaultTestConfig
+// instead of the library's
 func LoadTestConfigFromEnv() (*TestConfig, error) {
 	config := DefaultTestConfig()
 
-	// Load from environment variables
-	if host := os.Getenv("MAGENTO_HOST"); host != "" {
-		config.Host = host
-	}
-
-	if token := os.Getenv("MAGENTO_BEARER_TOKEN"); token != "" {
-		config.BearerToken = token
-	}
-
-	if storeCode := os.Getenv("MAGENTO_STORE_CODE"); storeCode != "" {
-		config.StoreCode = storeCode
+	envConfig, err := magento2.LoadEnvConfigWithDefaults(magento2.EnvConfig{
+		Host:      config.Host,
+		StoreCode: config.StoreCode,
+		Timeout:   config.Timeout,
+	})
+	if err != nil {
+		return nil, err
 	}
+	config.Host = envConfig.Host
+	config.BearerToken = envConfig.BearerToken
+	config.StoreCode = envConfig.StoreCode
+	config.Timeout = envConfig.Timeout
 
 	if apiVersion := os.Getenv("MAGENTO_API_VERSION"); apiVersion != "" {
 		config.APIVersion = apiVersion
@@ -73,31 +74,12 @@ func LoadTestConfigFromEnv() (*TestConfig, error) {
 		}
 	}
 
-	// Validate required fields
-	if config.BearerToken == "" {
-		return nil, fmt.Errorf("MAGENTO_BEARER_TOKEN is required")
-	}
-
-	// Validate and parse host URL
-	if _, err := url.Parse(config.Host); err != nil {
-		return nil, fmt.Errorf("invalid MAGENTO_HOST URL: %w", err)
-	}
-
 	return config, nil
 }
 
 // CreateStoreConfig creates a StoreConfig from TestConfig
 func (tc *TestConfig) CreateStoreConfig() (*magento2.StoreConfig, error) {
-	parsedURL, err := url.Parse(tc.Host)
-	if err != nil {
-		return nil, fmt.Errorf("failed to parse host URL: %w", err)
-	}
-
-	return &magento2.StoreConfig{
-		Scheme:    parsedURL.Scheme,
-		HostName:  parsedURL.Host,
-		StoreCode: tc.StoreCode,
-	}, nil
+	return magento2.StoreConfigFromURL(tc.Host, tc.StoreCode)
 }
 
 // SetupTestClient creates a configured API client for testing
@@ -0,0 +1,135 @@
+package magento2
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	magento2 "github.com/florinel-chis/go-m2rest"
+)
+
+func TestValidation_Product(t *testing.T) {
+	valid := magento2.Product{
+		Sku:            "test-validation-sku",
+		Name:           "Validation Product",
+		AttributeSetID: 4,
+		Price:          10,
+	}
+
+	if err := valid.Validate(); err != nil {
+		t.Fatalf("expected valid product, got: %v", err)
+	}
+
+	cases := map[string]func(p *magento2.Product){
+		"missing sku":        func(p *magento2.Product) { p.Sku = "" },
+		"sku too long":       func(p *magento2.Product) { p.Sku = strings.Repeat("a", 65) },
+		"sku with slash":     func(p *magento2.Product) { p.Sku = "a/b" },
+		"sku with spaces":    func(p *magento2.Product) { p.Sku = " abc" },
+		"missing name":       func(p *magento2.Product) { p.Name = " " },
+		"missing set":        func(p *magento2.Product) { p.AttributeSetID = 0 },
+		"negative price":     func(p *magento2.Product) { p.Price = -1 },
+		"zero tier quantity": func(p *magento2.Product) { p.TierPrices = []magento2.TierPrices{{Qty: 0, Value: 1}} },
+	}
+
+	for name, mutate := range cases {
+		t.Run(name, func(t *testing.T) {
+			p := valid
+			mutate(&p)
+			err := p.Validate()
+			if !errors.Is(err, magento2.ErrValidation) {
+				t.Fatalf("expected ErrValidation, got: %v", err)
+			}
+		})
+	}
+}
+
+func TestValidation_Attribute(t *testing.T) {
+	attr := magento2.Attribute{
+		AttributeCode:        "test_color",
+		FrontendInput:        "select",
+		DefaultFrontendLabel: "Color",
+	}
+	if err := attr.Validate(); err != nil {
+		t.Fatalf("expected valid attribute, got: %v", err)
+	}
+
+	for _, code := range []string{"", "1color", "test-color", strings.Repeat("a", 61)} {
+		attr.AttributeCode = code
+		if err := attr.Validate(); !errors.Is(err, magento2.ErrValidation) {
+			t.Errorf("expected ErrValidation for code %q, got: %v", code, err)
+		}
+	}
+}
+
+func TestValidation_CategoryAndCartItem(t *testing.T) {
+	if err := (&magento2.Category{}).Validate(); !errors.Is(err, magento2.ErrValidation) {
+		t.Errorf("expected ErrValidation for unnamed category, got: %v", err)
+	}
+	if err := (&magento2.CartItem{Sku: "abc", Qty: 0}).Validate(); !errors.Is(err, magento2.ErrValidation) {
+		t.Errorf("expected ErrValidation for zero qty, got: %v", err)
+	}
+}
+
+func TestValidation_CreateRejectedLocally(t *testing.T) {
+	client := magento2.NewAPIClientWithoutAuthentication(&magento2.StoreConfig{
+		Scheme:    "http",
+		HostName:  "127.0.0.1:1",
+		StoreCode: "default",
+	})
+	client.ValidatePayloads = true
+
+	_, err := magento2.CreateOrReplaceProduct(&magento2.Product{Sku: "no-name"}, true, client)
+	var validationErr *magento2.ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("expected ValidationError before any request, got: %v", err)
+	}
+	if validationErr.Field != "name" {
+		t.Errorf("expected name field to be reported, got %q", validationErr.Field)
+	}
+}
+
+func TestValidation_Address(t *testing.T) {
+	address := magento2.Address{
+		CountryID: "US",
+		RegionID:  12,
+		Street:    []string{"6146 Honey Bluff Parkway"},
+		Telephone: "(555) 229-3326",
+		Postcode:  "49628-7978",
+		City:      "Calder",
+		Firstname: "Veronica",
+		Lastname:  "Costello",
+	}
+	country := &magento2.Country{
+		ID:               "US",
+		AvailableRegions: []magento2.CountryRegion{{ID: "12", Code: "CA", Name: "California"}},
+	}
+
+	if err := address.ValidateForCountry(country); err != nil {
+		t.Fatalf("expected valid address, got: %v", err)
+	}
+
+	cases := map[string]func(a *magento2.Address){
+		"lower case country": func(a *magento2.Address) { a.CountryID = "us" },
+		"missing telephone":  func(a *magento2.Address) { a.Telephone = "" },
+		"missing postcode":   func(a *magento2.Address) { a.Postcode = " " },
+		"missing region":     func(a *magento2.Address) { a.RegionID = 0 },
+		"unknown region":     func(a *magento2.Address) { a.RegionID = 99 },
+	}
+
+	for name, mutate := range cases {
+		t.Run(name, func(t *testing.T) {
+			a := address
+			mutate(&a)
+			if err := a.ValidateForCountry(country); !errors.Is(err, magento2.ErrValidation) {
+				t.Fatalf("expected ErrValidation, got: %v", err)
+			}
+		})
+	}
+
+	noRegions := &magento2.Country{ID: "US"}
+	a := address
+	a.RegionID = 0
+	if err := a.ValidateForCountry(noRegions); err != nil {
+		t.Errorf("expected region to be optional without available regions, got: %v", err)
+	}
+}
@@ -0,0 +1,70 @@
+package magento2
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	magento2 "github.com/florinel-chis/go-m2rest"
+)
+
+func TestWaitUntil(t *testing.T) {
+	backoff := magento2.Backoff{Initial: time.Millisecond, Max: 2 * time.Millisecond, Multiplier: 2}
+
+	calls := 0
+	err := magento2.WaitUntil(context.Background(), func(ctx context.Context) (bool, error) {
+		calls++
+		return calls == 3, nil
+	}, backoff)
+	if err != nil || calls != 3 {
+		t.Errorf("expected the condition to be met on the third call, got %d calls (%v)", calls, err)
+	}
+
+	failure := errors.New("boom")
+	err = magento2.WaitUntil(context.Background(), func(ctx context.Context) (bool, error) {
+		return false, failure
+	}, backoff)
+	if !errors.Is(err, failure) {
+		t.Errorf("expected the error of fn, got %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	err = magento2.WaitUntil(ctx, func(ctx context.Context) (bool, error) {
+		return false, nil
+	}, backoff)
+	if !errors.Is(err, magento2.ErrWaitTimeout) || !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected ErrWaitTimeout wrapping the deadline, got %v", err)
+	}
+}
+
+func TestWaitForProductIndexed(t *testing.T) {
+	var searches atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/rest/default/V1/products" || r.URL.Query().Get("fields") != "items[sku]" {
+			t.Errorf("unexpected request: %s", r.URL)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if searches.Add(1) == 1 {
+			_, _ = w.Write([]byte(`{"items":[],"total_count":0}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"items":[{"sku":"24-MB01"}],"total_count":1}`))
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := magento2.NewClient(context.Background(), magento2.WithStoreURL(server.URL, "default"), magento2.WithBearerToken("token"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := magento2.WaitForProductIndexed(context.Background(), "24-MB01", client); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if n := searches.Load(); n != 2 {
+		t.Errorf("expected a search until the product is found, got %d", n)
+	}
+}
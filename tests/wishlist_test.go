@@ -0,0 +1,58 @@
+package magento2
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	magento2 "github.com/florinel-chis/go-m2rest"
+)
+
+func TestMWishlist(t *testing.T) {
+	var calls []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		raw, _ := io.ReadAll(r.Body)
+		calls = append(calls, r.Method+" "+r.URL.Path+" "+string(raw))
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/rest/de/V1/wishlist/items":
+			_, _ = w.Write([]byte(`[{"id":3,"product_id":1,"sku":"24-MB01","qty":1}]`))
+		case "/rest/default/V1/wishlist/add/24-MB/02":
+			_, _ = w.Write([]byte(`true`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := magento2.NewClient(context.Background(),
+		magento2.WithStoreURL(server.URL, "default"),
+		magento2.WithBearerToken("customer-token"),
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	wishlist := magento2.NewWishlist(client, nil)
+
+	items, err := wishlist.Items(context.Background(), magento2.WithStoreCode("de"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(items) != 1 || items[0].Sku != "24-MB01" {
+		t.Errorf("unexpected items: %+v", items)
+	}
+
+	if err := wishlist.Add(context.Background(), "24-MB/02", 2); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if calls[1] != `POST /rest/default/V1/wishlist/add/24-MB/02 {"qty":2}` {
+		t.Errorf("unexpected add call: %s", calls[1])
+	}
+
+	if err := wishlist.Remove(context.Background(), 99); !errors.Is(err, magento2.ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got: %v", err)
+	}
+}
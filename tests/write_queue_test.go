@@ -0,0 +1,157 @@
+package magento2
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	magento2 "github.com/florinel-chis/go-m2rest"
+)
+
+func TestWriteQueue_OrderedPerSkuAndRetried(t *testing.T) {
+	var (
+		mu     sync.Mutex
+		seen   = map[string][]float64{}
+		failed = false
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		if !failed {
+			failed = true
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		var payload struct {
+			StockItem struct {
+				Qty float64 `json:"qty"`
+			} `json:"stockItem"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&payload)
+		sku := strings.Split(strings.TrimPrefix(r.URL.Path, "/rest/default/V1/products/"), "/")[0]
+		seen[sku] = append(seen[sku], payload.StockItem.Qty)
+		_, _ = w.Write([]byte("1"))
+	}))
+	t.Cleanup(server.Close)
+
+	parsed, _ := url.Parse(server.URL)
+	client := magento2.NewAPIClientWithoutAuthentication(&magento2.StoreConfig{
+		Scheme:    parsed.Scheme,
+		HostName:  parsed.Host,
+		StoreCode: "default",
+	})
+	client.HTTPClient.SetRetryCount(0)
+
+	var results sync.WaitGroup
+	queue := magento2.NewWriteQueue(client, magento2.WriteQueueConfig{
+		Workers:     3,
+		MaxAttempts: 2,
+		Backoff:     magento2.Backoff{Initial: time.Millisecond, Max: time.Millisecond, Multiplier: 1},
+		OnResult: func(op *magento2.WriteOperation, err error) {
+			if err != nil {
+				t.Errorf("unexpected error for %s: %v", op.Sku, err)
+			}
+			results.Done()
+		},
+	})
+	if err := queue.Start(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for i := 1; i <= 5; i++ {
+		for _, sku := range []string{"sku-a", "sku-b"} {
+			results.Add(1)
+			err := queue.Enqueue(&magento2.WriteOperation{
+				Type:        magento2.WriteOperationStock,
+				Sku:         sku,
+				StockItemID: "1",
+				Qty:         magento2.NewDecimal(int64(i), 0),
+				IsInStock:   true,
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		}
+	}
+	results.Wait()
+	if err := queue.Close(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, sku := range []string{"sku-a", "sku-b"} {
+		if len(seen[sku]) != 5 {
+			t.Fatalf("expected 5 writes for %s, got %v", sku, seen[sku])
+		}
+		for i, qty := range seen[sku] {
+			if qty != float64(i+1) {
+				t.Errorf("expected writes of %s in enqueue order, got %v", sku, seen[sku])
+				break
+			}
+		}
+	}
+
+	if err := queue.Enqueue(&magento2.WriteOperation{Sku: "late"}); err == nil {
+		t.Errorf("expected error enqueueing on closed queue")
+	}
+}
+
+func TestWriteQueue_DisablesClientRetries(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := magento2.NewClient(context.Background(), magento2.WithStoreURL(server.URL, "default"), magento2.WithBearerToken("token"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	client.HTTPClient.SetRetryWaitTime(time.Millisecond).SetRetryMaxWaitTime(time.Millisecond)
+
+	done := make(chan error, 1)
+	queue := magento2.NewWriteQueue(client, magento2.WriteQueueConfig{
+		MaxAttempts: 2,
+		Backoff:     magento2.Backoff{Initial: time.Millisecond, Max: time.Millisecond, Multiplier: 1},
+		OnResult: func(op *magento2.WriteOperation, err error) {
+			done <- err
+		},
+	})
+	if err := queue.Start(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	err = queue.Enqueue(&magento2.WriteOperation{Type: magento2.WriteOperationProduct, Sku: "sku-a", ProductFields: map[string]any{"name": "A"}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := <-done; err == nil {
+		t.Error("expected the write to fail")
+	}
+	if err := queue.Close(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if n := requests.Load(); n != 2 {
+		t.Errorf("expected one request per queue attempt, got %d", n)
+	}
+}
+
+func TestWriteQueue_CloseWithoutStart(t *testing.T) {
+	client := magento2.NewAPIClientWithoutAuthentication(&magento2.StoreConfig{Scheme: "http", HostName: "localhost", StoreCode: "default"})
+	queue := magento2.NewWriteQueue(client, magento2.WriteQueueConfig{})
+
+	if err := queue.Close(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := queue.Enqueue(&magento2.WriteOperation{Sku: "late"}); !errors.Is(err, magento2.ErrWriteQueueClosed) {
+		t.Errorf("expected ErrWriteQueueClosed, got %v", err)
+	}
+}
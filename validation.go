@@ -0,0 +1,124 @@
+package magento2
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+const (
+	maxSkuLength           = 64
+	maxAttributeCodeLength = 60
+)
+
+var attributeCodePattern = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9_]*$`)
+
+// ValidationError describes a payload that would be rejected by Magento before it is sent
+type ValidationError struct {
+	Entity string
+	Field  string
+	Reason string
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("invalid %s: field '%s' %s", e.Entity, e.Field, e.Reason)
+}
+
+func (e *ValidationError) Unwrap() error {
+	return ErrValidation
+}
+
+func validateSku(entity, sku string) error {
+	if strings.TrimSpace(sku) == "" {
+		return &ValidationError{Entity: entity, Field: "sku", Reason: "is required"}
+	}
+	if len([]rune(sku)) > maxSkuLength {
+		return &ValidationError{Entity: entity, Field: "sku", Reason: fmt.Sprintf("must not exceed %d characters", maxSkuLength)}
+	}
+	if sku != strings.TrimSpace(sku) {
+		return &ValidationError{Entity: entity, Field: "sku", Reason: "must not have leading or trailing whitespace"}
+	}
+	for _, r := range sku {
+		if unicode.IsControl(r) || r == '/' || r == '?' || r == '#' {
+			return &ValidationError{Entity: entity, Field: "sku", Reason: fmt.Sprintf("contains unsupported character %q", r)}
+		}
+	}
+	return nil
+}
+
+// Validate checks the product for the mistakes Magento answers with an opaque 400
+func (p *Product) Validate() error {
+	if err := validateSku("product", p.Sku); err != nil {
+		return err
+	}
+	if strings.TrimSpace(p.Name) == "" {
+		return &ValidationError{Entity: "product", Field: "name", Reason: "is required"}
+	}
+	if p.AttributeSetID <= 0 {
+		return &ValidationError{Entity: "product", Field: "attribute_set_id", Reason: "must be a positive id"}
+	}
+	if p.Price < 0 {
+		return &ValidationError{Entity: "product", Field: "price", Reason: "must not be negative"}
+	}
+	if p.Weight < 0 {
+		return &ValidationError{Entity: "product", Field: "weight", Reason: "must not be negative"}
+	}
+	if p.Weight != 0 && (p.TypeID == ProductTypeVirtual || p.TypeID == ProductTypeDownloadable) {
+		return &ValidationError{Entity: "product", Field: "weight", Reason: "must not be set on " + p.TypeID + " products"}
+	}
+	for i := range p.TierPrices {
+		if p.TierPrices[i].Qty <= 0 {
+			return &ValidationError{Entity: "product", Field: fmt.Sprintf("tier_prices[%d].qty", i), Reason: "must be greater than zero"}
+		}
+		if p.TierPrices[i].Value < 0 {
+			return &ValidationError{Entity: "product", Field: fmt.Sprintf("tier_prices[%d].value", i), Reason: "must not be negative"}
+		}
+	}
+	return nil
+}
+
+// Validate checks the category for required fields before it is sent to Magento
+func (c *Category) Validate() error {
+	if strings.TrimSpace(c.Name) == "" {
+		return &ValidationError{Entity: "category", Field: "name", Reason: "is required"}
+	}
+	if c.ParentID < 0 {
+		return &ValidationError{Entity: "category", Field: "parent_id", Reason: "must not be negative"}
+	}
+	if c.Position < 0 {
+		return &ValidationError{Entity: "category", Field: "position", Reason: "must not be negative"}
+	}
+	return nil
+}
+
+// Validate checks the attribute code format and the fields Magento requires on creation
+func (a *Attribute) Validate() error {
+	if a.AttributeCode == "" {
+		return &ValidationError{Entity: "attribute", Field: "attribute_code", Reason: "is required"}
+	}
+	if len(a.AttributeCode) > maxAttributeCodeLength {
+		return &ValidationError{Entity: "attribute", Field: "attribute_code", Reason: fmt.Sprintf("must not exceed %d characters", maxAttributeCodeLength)}
+	}
+	if !attributeCodePattern.MatchString(a.AttributeCode) {
+		return &ValidationError{Entity: "attribute", Field: "attribute_code", Reason: "must start with a letter and contain only letters, digits or underscores"}
+	}
+	if a.FrontendInput == "" {
+		return &ValidationError{Entity: "attribute", Field: "frontend_input", Reason: "is required"}
+	}
+	if strings.TrimSpace(a.DefaultFrontendLabel) == "" {
+		return &ValidationError{Entity: "attribute", Field: "default_frontend_label", Reason: "is required"}
+	}
+	return nil
+}
+
+// Validate checks the cart item before it is added to a quote
+func (item *CartItem) Validate() error {
+	if err := validateSku("cart item", item.Sku); err != nil {
+		return err
+	}
+	if item.Qty <= 0 {
+		return &ValidationError{Entity: "cart item", Field: "qty", Reason: "must be greater than zero"}
+	}
+	return nil
+}
@@ -0,0 +1,88 @@
+package magento2
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+var ErrWaitTimeout = errors.New("condition not met before the deadline")
+
+// Backoff describes the polling intervals used by WaitUntil
+type Backoff struct {
+	Initial    time.Duration
+	Max        time.Duration
+	Multiplier float64
+}
+
+var DefaultBackoff = Backoff{
+	Initial:    500 * time.Millisecond,
+	Max:        10 * time.Second,
+	Multiplier: 2,
+}
+
+func (b Backoff) next(current time.Duration) time.Duration {
+	if current == 0 {
+		return b.Initial
+	}
+	next := time.Duration(float64(current) * b.Multiplier)
+	if b.Max > 0 && next > b.Max {
+		return b.Max
+	}
+	return next
+}
+
+// WaitUntil calls fn until it reports done, an error, or ctx expires. Use it for read-after-write
+// races where Magento applies a write before it becomes visible through indexes
+func WaitUntil(ctx context.Context, fn func(ctx context.Context) (bool, error), backoff Backoff) error {
+	var wait time.Duration
+	for attempt := 1; ; attempt++ {
+		done, err := fn(ctx)
+		if err != nil {
+			return err
+		}
+		if done {
+			log.Debug().Int("attempt", attempt).Msg("Wait condition met")
+			return nil
+		}
+
+		wait = backoff.next(wait)
+		log.Debug().Int("attempt", attempt).Dur("wait", wait).Msg("Wait condition not met yet")
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("%w: %w", ErrWaitTimeout, ctx.Err())
+		case <-time.After(wait):
+		}
+	}
+}
+
+// WaitForProductIndexed waits until the product shows up in product search results, which
+// only happens once Magento has indexed it
+func WaitForProductIndexed(ctx context.Context, sku string, apiClient *Client) error {
+	searchQuery := BuildSearchQuery("sku", sku, "eq")
+	endpoint := products + "?" + searchQuery + "&fields=items[sku]"
+
+	return WaitUntil(ctx, func(ctx context.Context) (bool, error) {
+		response := &productSearchQueryResponse{}
+		resp, err := apiClient.HTTPClient.R().SetContext(ctx).SetResult(response).Get(endpoint)
+		if err != nil {
+			return false, fmt.Errorf("error searching product while waiting for index: %w", err)
+		}
+
+		httpErr := mayReturnErrorForHTTPResponse(resp, "search product while waiting for index")
+		if httpErr != nil {
+			return false, httpErr
+		}
+
+		for i := range response.Products {
+			if response.Products[i].Sku == sku {
+				return true, nil
+			}
+		}
+		return false, nil
+	}, DefaultBackoff)
+}
@@ -0,0 +1,61 @@
+package magento2
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+)
+
+// DefaultWishlistRoutes match the routes most wishlist REST modules expose for the logged in customer
+var DefaultWishlistRoutes = WishlistRoutes{
+	Prefix: "/wishlist",
+	Items:  "/items",
+	Add:    "/add/%s",
+	Remove: "/delete/%d",
+}
+
+// MWishlist is a typed wrapper of a wishlist module's endpoints. Magento core has no wishlist REST API, so
+// this needs such a module and a customer-token client. It also serves as a reference for wrapping
+// other non-core endpoints with Extension
+type MWishlist struct {
+	Routes    WishlistRoutes
+	Extension *Extension
+}
+
+// NewWishlist wraps the wishlist of the customer the client is authenticated as. Pass nil routes to use
+// DefaultWishlistRoutes
+func NewWishlist(apiClient *Client, routes *WishlistRoutes) *MWishlist {
+	if routes == nil {
+		routes = &DefaultWishlistRoutes
+	}
+	return &MWishlist{
+		Routes:    *routes,
+		Extension: apiClient.Extension(routes.Prefix),
+	}
+}
+
+func (w *MWishlist) Items(ctx context.Context, opts ...RequestOption) ([]WishlistItem, error) {
+	items := []WishlistItem{}
+	err := w.Extension.Get(ctx, w.Routes.Items, &items, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("error getting wishlist items: %w", err)
+	}
+	return items, nil
+}
+
+func (w *MWishlist) Add(ctx context.Context, sku string, qty float64, opts ...RequestOption) error {
+	route := fmt.Sprintf(w.Routes.Add, url.PathEscape(sku))
+	err := w.Extension.Post(ctx, route, &addWishlistItemPayload{Qty: qty}, nil, opts...)
+	if err != nil {
+		return fmt.Errorf("error adding '%s' to wishlist: %w", sku, err)
+	}
+	return nil
+}
+
+func (w *MWishlist) Remove(ctx context.Context, itemID int, opts ...RequestOption) error {
+	err := w.Extension.Delete(ctx, fmt.Sprintf(w.Routes.Remove, itemID), nil, opts...)
+	if err != nil {
+		return fmt.Errorf("error removing item %d from wishlist: %w", itemID, err)
+	}
+	return nil
+}
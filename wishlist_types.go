@@ -0,0 +1,24 @@
+package magento2
+
+// WishlistRoutes are the routes of a wishlist REST module, relative to its prefix. Add receives the SKU,
+// Remove the wishlist item ID
+type WishlistRoutes struct {
+	Prefix string
+	Items  string
+	Add    string
+	Remove string
+}
+
+type WishlistItem struct {
+	ID          int      `json:"id"`
+	ProductID   int      `json:"product_id"`
+	Sku         string   `json:"sku,omitempty"`
+	Qty         float64  `json:"qty"`
+	Description string   `json:"description,omitempty"`
+	AddedAt     string   `json:"added_at,omitempty"`
+	Product     *Product `json:"product,omitempty"`
+}
+
+type addWishlistItemPayload struct {
+	Qty float64 `json:"qty,omitempty"`
+}
@@ -0,0 +1,277 @@
+package magento2
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+var ErrWriteQueueClosed = errors.New("write queue is closed")
+
+var DefaultWriteQueueConfig = WriteQueueConfig{
+	Workers:     4,
+	MaxAttempts: RetryAttempts,
+	Backoff:     DefaultBackoff,
+	QueueSize:   100,
+}
+
+// WriteQueue applies product, stock and price mutations in the background (write-behind), rate limited,
+// retried and ordered per SKU. Create it with NewWriteQueue, call Start, Enqueue and finally Close
+type WriteQueue struct {
+	APIClient *Client
+	config    WriteQueueConfig
+
+	mu      sync.RWMutex
+	closed  bool
+	workers []chan *WriteOperation
+	limiter *time.Ticker
+	wg      sync.WaitGroup
+	cancel  context.CancelFunc
+}
+
+func NewWriteQueue(apiClient *Client, config WriteQueueConfig) *WriteQueue {
+	if config.Workers <= 0 {
+		config.Workers = DefaultWriteQueueConfig.Workers
+	}
+	if config.MaxAttempts <= 0 {
+		config.MaxAttempts = DefaultWriteQueueConfig.MaxAttempts
+	}
+	if config.Backoff.Initial == 0 {
+		config.Backoff = DefaultWriteQueueConfig.Backoff
+	}
+	if config.QueueSize <= 0 {
+		config.QueueSize = DefaultWriteQueueConfig.QueueSize
+	}
+	return &WriteQueue{
+		APIClient: apiClient,
+		config:    config,
+	}
+}
+
+// Start launches the workers and re-enqueues the operations the store still reports as pending
+func (q *WriteQueue) Start(ctx context.Context) error {
+	ctx, q.cancel = context.WithCancel(ctx)
+	if q.config.RequestsPerSecond > 0 {
+		q.limiter = time.NewTicker(time.Duration(float64(time.Second) / q.config.RequestsPerSecond))
+	}
+
+	q.workers = make([]chan *WriteOperation, q.config.Workers)
+	for i := range q.workers {
+		q.workers[i] = make(chan *WriteOperation, q.config.QueueSize)
+		q.wg.Add(1)
+		go q.work(ctx, q.workers[i])
+	}
+
+	if q.config.Store == nil {
+		return nil
+	}
+	pending, err := q.config.Store.Pending()
+	if err != nil {
+		return fmt.Errorf("error loading pending write operations: %w", err)
+	}
+	log.Info().Int("pending", len(pending)).Msg("Resuming pending write operations")
+	for _, op := range pending {
+		q.dispatch(op)
+	}
+	return nil
+}
+
+// Enqueue persists the operation (when a store is configured) and hands it to its SKU's worker
+func (q *WriteQueue) Enqueue(op *WriteOperation) error {
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+	if q.closed || q.workers == nil {
+		return ErrWriteQueueClosed
+	}
+
+	if op.ID == "" {
+		op.ID = newIdempotencyKey()
+	}
+	if op.EnqueuedAt.IsZero() {
+		op.EnqueuedAt = time.Now().UTC()
+	}
+	if q.config.Store != nil {
+		if err := q.config.Store.Save(op); err != nil {
+			return fmt.Errorf("error persisting write operation: %w", err)
+		}
+	}
+	q.dispatch(op)
+	return nil
+}
+
+func (q *WriteQueue) dispatch(op *WriteOperation) {
+	h := fnv.New32a()
+	h.Write([]byte(op.dispatchKey()))
+	q.workers[h.Sum32()%uint32(len(q.workers))] <- op
+}
+
+// Close stops accepting operations and waits until the queued ones are applied or ctx expires.
+// Operations left over on expiry stay pending in the store
+func (q *WriteQueue) Close(ctx context.Context) error {
+	q.mu.Lock()
+	if q.closed {
+		q.mu.Unlock()
+		return nil
+	}
+	q.closed = true
+	for _, worker := range q.workers {
+		close(worker)
+	}
+	q.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		q.wg.Wait()
+		close(done)
+	}()
+
+	defer func() {
+		if q.limiter != nil {
+			q.limiter.Stop()
+		}
+	}()
+
+	select {
+	case <-done:
+		q.stopWorkers()
+		return nil
+	case <-ctx.Done():
+		q.stopWorkers()
+		<-done
+		return ctx.Err()
+	}
+}
+
+// stopWorkers cancels the context of the workers, there is none when the queue was never started
+func (q *WriteQueue) stopWorkers() {
+	if q.cancel != nil {
+		q.cancel()
+	}
+}
+
+func (q *WriteQueue) work(ctx context.Context, operations <-chan *WriteOperation) {
+	defer q.wg.Done()
+	for op := range operations {
+		if ctx.Err() != nil {
+			// shutting down, the store keeps the operation pending
+			continue
+		}
+		err := q.applyWithRetries(ctx, op)
+		if ctx.Err() != nil && err != nil {
+			continue
+		}
+		if q.config.Store != nil {
+			if storeErr := q.config.Store.Done(op, err); storeErr != nil {
+				log.Error().Err(storeErr).Str("operationID", op.ID).Msg("Error marking write operation as done")
+			}
+		}
+		if q.config.OnResult != nil {
+			q.config.OnResult(op, err)
+		}
+	}
+}
+
+func (q *WriteQueue) applyWithRetries(ctx context.Context, op *WriteOperation) error {
+	var wait time.Duration
+	for {
+		if q.limiter != nil {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-q.limiter.C:
+			}
+		}
+
+		op.Attempts++
+		err := q.apply(ctx, op)
+		if err == nil {
+			log.Debug().Str("operationID", op.ID).Str("sku", op.Sku).Str("type", string(op.Type)).Msg("Write operation applied")
+			return nil
+		}
+		if !isTransientWriteError(err) || op.Attempts >= q.config.MaxAttempts {
+			log.Error().Err(err).Str("operationID", op.ID).Str("sku", op.Sku).Int("attempts", op.Attempts).Msg("Write operation failed")
+			return err
+		}
+
+		wait = q.config.Backoff.next(wait)
+		log.Warn().Err(err).Str("operationID", op.ID).Int("attempt", op.Attempts).Dur("wait", wait).Msg("Retrying write operation")
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(wait):
+		}
+	}
+}
+
+// dispatchKey is the entity the operation writes to, operations of one entity are applied in order
+func (op *WriteOperation) dispatchKey() string {
+	if op.Type == WriteOperationCategory {
+		return "category:" + strconv.Itoa(op.CategoryID)
+	}
+	return op.Sku
+}
+
+// isTransientWriteError tells network and server errors, worth a retry, from rejected payloads
+func isTransientWriteError(err error) bool {
+	var statusErr *HTTPStatusError
+	if errors.As(err, &statusErr) {
+		return statusErr.IsTransient()
+	}
+	return !errors.Is(err, ErrBadRequest) && !errors.Is(err, ErrNotFound) && !errors.Is(err, ErrValidation)
+}
+
+func (q *WriteQueue) apply(ctx context.Context, op *WriteOperation) error {
+	return applyWriteOperation(ctx, op, q.APIClient)
+}
+
+// applyWriteOperation sends the operation to apiClient once. The client's own retries are disabled,
+// the callers retry with their backoff and would multiply the attempts otherwise
+func applyWriteOperation(ctx context.Context, op *WriteOperation, apiClient *Client) error {
+	ctx = context.WithValue(ctx, retriesContextKey{}, 0)
+	mProduct := &MProduct{
+		Route:     products + "/" + url.PathEscape(op.Sku),
+		Product:   &Product{Sku: op.Sku},
+		APIClient: apiClient,
+	}
+
+	switch op.Type {
+	case WriteOperationProduct:
+		fields := map[string]any{}
+		for key, value := range op.ProductFields {
+			fields[key] = value
+		}
+		fields["sku"] = op.Sku
+		endpoint := newRequestOptions([]RequestOption{WithStoreCode(op.StoreCode)}).endpoint(apiClient, mProduct.Route)
+		resp, err := apiClient.HTTPClient.R().SetContext(ctx).SetBody(partialProductPayload{Product: fields}).Put(endpoint)
+		if err != nil {
+			return fmt.Errorf("error applying product write: %w", err)
+		}
+		return mayReturnErrorForHTTPResponse(resp, fmt.Sprintf("apply queued product write for '%s'", op.Sku))
+	case WriteOperationStock:
+		return mProduct.UpdateDecimalQuantityForStockItem(ctx, op.StockItemID, op.Qty, op.IsInStock)
+	case WriteOperationPrice:
+		_, err := UpdateBasePrices(ctx, []BasePrice{{Sku: op.Sku, Price: op.Price.Float64(), StoreID: op.StoreID}}, apiClient)
+		return err
+	case WriteOperationCategory:
+		fields := map[string]any{}
+		for key, value := range op.CategoryFields {
+			fields[key] = value
+		}
+		fields["id"] = op.CategoryID
+		route := fmt.Sprintf("%s/%d", categories, op.CategoryID)
+		endpoint := newRequestOptions([]RequestOption{WithStoreCode(op.StoreCode)}).endpoint(apiClient, route)
+		resp, err := apiClient.HTTPClient.R().SetContext(ctx).SetBody(partialCategoryPayload{Category: fields}).Put(endpoint)
+		if err != nil {
+			return fmt.Errorf("error applying category write: %w", err)
+		}
+		return mayReturnErrorForHTTPResponse(resp, fmt.Sprintf("apply queued category write for %d", op.CategoryID))
+	}
+	return fmt.Errorf("%w: unknown write operation type '%s'", ErrValidation, op.Type)
+}
@@ -0,0 +1,68 @@
+package magento2
+
+import (
+	"time"
+)
+
+type WriteOperationType string
+
+const (
+	WriteOperationProduct  WriteOperationType = "product"
+	WriteOperationStock    WriteOperationType = "stock"
+	WriteOperationPrice    WriteOperationType = "price"
+	WriteOperationCategory WriteOperationType = "category"
+)
+
+// WriteOperation is one queued mutation of a SKU. It only holds plain data, so a WriteQueueStore can
+// persist it as JSON and hand it back after a restart
+type WriteOperation struct {
+	ID         string             `json:"id"`
+	Type       WriteOperationType `json:"type"`
+	Sku        string             `json:"sku"`
+	EnqueuedAt time.Time          `json:"enqueued_at"`
+	Attempts   int                `json:"attempts"`
+
+	// ProductFields is the partial product sent with WriteOperationProduct, sku is added automatically
+	ProductFields map[string]any `json:"product_fields,omitempty"`
+
+	// CategoryID and CategoryFields are used by WriteOperationCategory, id is added automatically. Sku stays
+	// empty for category writes
+	CategoryID     int            `json:"category_id,omitempty"`
+	CategoryFields map[string]any `json:"category_fields,omitempty"`
+
+	// StoreCode is the store view product and category writes are sent to, empty means the client's store
+	StoreCode string `json:"store_code,omitempty"`
+
+	// StockItemID, Qty and IsInStock are used by WriteOperationStock
+	StockItemID string  `json:"stock_item_id,omitempty"`
+	Qty         Decimal `json:"qty"`
+	IsInStock   bool    `json:"is_in_stock,omitempty"`
+
+	// Price and StoreID are used by WriteOperationPrice
+	Price   Decimal `json:"price"`
+	StoreID int     `json:"store_id,omitempty"`
+}
+
+// WriteQueueStore persists queued operations, so nothing is lost when the process stops with a
+// non-empty queue. Done is called once per operation, with the final error or nil
+type WriteQueueStore interface {
+	Save(op *WriteOperation) error
+	Done(op *WriteOperation, err error) error
+	Pending() ([]*WriteOperation, error)
+}
+
+type WriteQueueConfig struct {
+	// Workers is the number of parallel dispatchers. Operations of one SKU always go to the same
+	// worker and are applied in enqueue order
+	Workers int
+	// RequestsPerSecond limits the dispatch rate over all workers, 0 means unlimited
+	RequestsPerSecond float64
+	// MaxAttempts per operation, transient errors are retried with Backoff
+	MaxAttempts int
+	Backoff     Backoff
+	// QueueSize is the buffer per worker, Enqueue blocks when it is full
+	QueueSize int
+	Store     WriteQueueStore
+	// OnResult is called after every operation finished, successfully or not
+	OnResult func(op *WriteOperation, err error)
+}